@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/backup"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const backupUsage = `Usage:
+  kyma-env-broker export FILE              writes the state of the broker to the encrypted backup file
+  kyma-env-broker import [-skip-check] FILE  restores the state of the broker from the backup file and checks it
+  kyma-env-broker check                    compares the instances with the Gardener shoots and the provisioner runtimes`
+
+// runBackupCommand executes the disaster recovery command given in the arguments instead of starting the broker
+func runBackupCommand(cfg Config, args []string, log logrus.FieldLogger) error {
+	if cfg.DbInMemory {
+		return errors.New("the backup commands require the database, the in-memory storage is not persisted")
+	}
+
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	skipCheck := flags.Bool("skip-check", false, "do not compare the imported instances with the Gardener shoots and the provisioner runtimes")
+	if err := flags.Parse(args[1:]); err != nil {
+		return errors.Wrap(err, backupUsage)
+	}
+	if args[0] != "check" && flags.NArg() != 1 {
+		return errors.New(backupUsage)
+	}
+
+	db, connection, err := storage.NewFromConfig(cfg.Database, log.WithField("service", "storage"))
+	if err != nil {
+		return errors.Wrap(err, "while creating storage")
+	}
+	defer connection.Close()
+
+	switch args[0] {
+	case "export":
+		return exportBackup(db.Backup(), cfg.Backup, flags.Arg(0), log)
+	case "import":
+		if err := importBackup(db.Backup(), cfg.Backup, flags.Arg(0), log); err != nil {
+			return err
+		}
+		if *skipCheck {
+			return nil
+		}
+		return checkBackup(db.Backup(), cfg, log)
+	case "check":
+		return checkBackup(db.Backup(), cfg, log)
+	default:
+		return errors.Errorf("unknown command %q\n%s", args[0], backupUsage)
+	}
+}
+
+func exportBackup(db storage.Backup, cfg backup.Config, path string, log logrus.FieldLogger) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Wrap(err, "while creating backup file")
+	}
+
+	_, err = backup.NewExporter(db, cfg, log.WithField("service", "backupExporter")).Export(file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = errors.Wrap(closeErr, "while closing backup file")
+	}
+	if err != nil {
+		// do not leave the incomplete backup which could be taken for a valid one
+		os.Remove(path)
+		return err
+	}
+
+	return nil
+}
+
+func importBackup(db storage.Backup, cfg backup.Config, path string, log logrus.FieldLogger) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "while opening backup file")
+	}
+	defer file.Close()
+
+	_, err = backup.NewImporter(db, cfg, log.WithField("service", "backupImporter")).Import(file)
+	return err
+}
+
+func checkBackup(db storage.Backup, cfg Config, log logrus.FieldLogger) error {
+	gardenerClusterConfig, err := gardener.NewGardenerClusterConfig(cfg.Gardener.KubeconfigPath)
+	if err != nil {
+		return errors.Wrap(err, "while creating Gardener cluster config")
+	}
+	shoots, err := gardener.NewGardenerShootInterface(gardenerClusterConfig, cfg.Gardener.Project)
+	if err != nil {
+		return errors.Wrap(err, "while creating Gardener shoots client")
+	}
+	provisionerClient := provisioner.NewProvisionerClient(cfg.Provisioning.URL, cfg.DumpProvisionerRequests)
+
+	report, err := backup.NewChecker(db, shoots, provisionerClient, cfg.Backup, log.WithField("service", "backupChecker")).Check()
+	if err != nil {
+		return errors.Wrap(err, "while checking instances")
+	}
+	for _, id := range report.MissingShoots {
+		log.Warnf("Instance %s has no Gardener shoot", id)
+	}
+	for _, id := range report.MissingInProvisioner {
+		log.Warnf("Instance %s has the runtime unknown to the provisioner", id)
+	}
+	for _, name := range report.UnknownShoots {
+		log.Warnf("Gardener shoot %s has no instance", name)
+	}
+	if !report.Consistent() {
+		return fmt.Errorf("the instances are not consistent with the runtimes: %d without the shoot, %d unknown to the provisioner, %d shoots without the instance",
+			len(report.MissingShoots), len(report.MissingInProvisioner), len(report.UnknownShoots))
+	}
+
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/appinfo"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/auditlog"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/avs"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/backup"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/edp"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
@@ -44,12 +45,15 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_kyma"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provider"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retention"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime/components"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtimeoverrides"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtimeversion"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/stream"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -88,7 +92,15 @@ type Config struct {
 	Provisioning input.Config
 	Director     director.Config
 	Database     storage.Config
+	Retention    retention.Config
+	Backup       backup.Config
 	Gardener     gardener.Config
+	Health       health.Config
+	Tracing      tracing.Config
+	AccountPool  hyperscaler.InventoryConfig
+
+	SharedAccountPool           hyperscaler.SharedPoolConfig
+	AccountAssignmentReconciler hyperscaler.AssignmentReconcilerConfig
 
 	ServiceManager servicemanager.Config
 
@@ -118,6 +130,12 @@ type Config struct {
 
 	TrialRegionMappingFilePath string
 	MaxPaginationPage          int `envconfig:"default=100"`
+
+	// OperationEventsKeepAliveInterval is the interval of the keep-alive messages sent on idle operation events streams
+	OperationEventsKeepAliveInterval time.Duration `envconfig:"default=15s"`
+
+	// ProvisionerStatusCacheTTL is the time for which the runtime statuses fetched from the provisioner for the runtimes API are reused
+	ProvisionerStatusCacheTTL time.Duration `envconfig:"default=1m"`
 }
 
 func main() {
@@ -134,13 +152,24 @@ func main() {
 	logger.RegisterSink(lager.NewWriterSink(os.Stdout, lager.DEBUG))
 	logger.RegisterSink(lager.NewWriterSink(os.Stderr, lager.ERROR))
 
-	logger.Info("Starting Kyma Environment Broker")
-
 	logs := logrus.New()
 	logs.SetFormatter(&logrus.JSONFormatter{})
 
-	logger.Info("Registering healthz endpoint for health probes")
-	health.NewServer(cfg.Host, cfg.StatusPort, logs).ServeAsync()
+	// the disaster recovery commands run instead of the broker
+	if len(os.Args) > 1 {
+		fatalOnError(runBackupCommand(cfg, os.Args[1:], logs))
+		return
+	}
+
+	logger.Info("Starting Kyma Environment Broker")
+
+	shutdownTracing, err := tracing.Init(ctx, cfg.Tracing)
+	fatalOnError(err)
+	defer shutdownTracing(context.Background())
+
+	logger.Info("Registering healthz and readyz endpoints for health probes")
+	healthServer := health.NewServer(cfg.Host, cfg.StatusPort, logs)
+	healthServer.ServeAsync()
 
 	// create provisioner client
 	provisionerClient := provisioner.NewProvisionerClient(cfg.Provisioning.URL, cfg.DumpProvisionerRequests)
@@ -156,6 +185,8 @@ func main() {
 
 	// create storage
 	var db storage.BrokerStorage
+	// dependencies checked by the readiness endpoint, the failures of the non-critical ones are only reported
+	var dependencies []health.Dependency
 	if cfg.DbInMemory {
 		db = storage.NewMemoryStorage()
 	} else {
@@ -164,6 +195,15 @@ func main() {
 		db = store
 		dbStatsCollector := sqlstats.NewStatsCollector("broker", conn)
 		prometheus.MustRegister(dbStatsCollector)
+		dependencies = append(dependencies, health.Dependency{Name: "database", Critical: true, Checker: health.NewDatabaseChecker(conn)})
+
+		// re-encrypt the secrets after the key rotation
+		storage.NewReEncryptionJob(db.RuntimeStates(), cfg.Database.ReEncryptionBatchSize, cfg.Database.ReEncryptionInterval, logs.WithField("service", "reEncryption")).Run(ctx.Done())
+	}
+
+	// archive the old records and purge the personal data of the deprovisioned instances
+	if cfg.Retention.Enabled {
+		retention.NewJob(db.Retention(), cfg.Retention, logs.WithField("service", "retention")).Run(ctx.Done())
 	}
 
 	// LMS
@@ -193,9 +233,13 @@ func main() {
 	gardenerShoots, err := gardener.NewGardenerShootInterface(gardenerClusterConfig, cfg.Gardener.Project)
 	fatalOnError(err)
 
-	gardenerAccountPool := hyperscaler.NewAccountPool(gardenerSecrets, gardenerShoots)
-	gardenerSharedPool := hyperscaler.NewSharedGardenerAccountPool(gardenerSecrets, gardenerShoots)
+	gardenerAccountPool := hyperscaler.NewAccountPool(gardenerSecrets, gardenerShoots, db.AccountAssignments())
+	gardenerSharedPool := hyperscaler.NewSharedGardenerAccountPool(gardenerSecrets, gardenerShoots, cfg.SharedAccountPool)
 	accountProvider := hyperscaler.NewAccountProvider(gardenerAccountPool, gardenerSharedPool)
+	accountPoolInventory := hyperscaler.NewInventory(gardenerSecrets, cfg.AccountPool, logs.WithField("service", "accountPoolInventory"))
+	// keep the recorded assignments consistent with the labels of the secrets
+	go hyperscaler.NewAssignmentReconciler(gardenerSecrets, db.AccountAssignments(), logs.WithField("service", "accountAssignmentReconciler")).
+		Run(cfg.AccountAssignmentReconciler, ctx.Done())
 
 	regions, err := provider.ReadPlatformRegionMappingFromFile(cfg.TrialRegionMappingFilePath)
 	fatalOnError(err)
@@ -223,7 +267,10 @@ func main() {
 	eventBroker := event.NewPubSub(logs)
 
 	// metrics collectors
-	metrics.RegisterAll(eventBroker, db.Operations(), db.Instances())
+	metrics.RegisterAll(eventBroker, db.Operations(), db.Instances(), db.Orchestrations(), db.Operations(), accountPoolInventory)
+
+	// operation events stream
+	operationEvents := stream.NewBroadcaster(eventBroker, logs.WithField("service", "operationEvents"))
 
 	//setup runtime overrides appender
 	runtimeOverrides := runtimeoverrides.NewRuntimeOverrides(ctx, cli)
@@ -254,7 +301,7 @@ func main() {
 		},
 		{
 			weight: 2,
-			step:   provisioning.NewResolveCredentialsStep(db.Operations(), accountProvider),
+			step:   provisioning.NewResolveCredentialsStep(db.Operations(), accountProvider, input.NewRegionResolver(regions)),
 		},
 		{
 			weight: 2,
@@ -390,7 +437,7 @@ func main() {
 
 	gardenerNamespace := fmt.Sprintf("garden-%s", cfg.Gardener.Project)
 	kymaQueue, err := NewOrchestrationProcessingQueue(ctx, db, runtimeOverrides, provisionerClient, gardenerClient,
-		gardenerNamespace, eventBroker, inputFactory, nil, time.Minute, runtimeVerConfigurator, cfg.DefaultRequestRegion, logs)
+		gardenerNamespace, eventBroker, inputFactory, nil, time.Minute, runtimeVerConfigurator, cfg.DefaultRequestRegion, cfg.Provisioning.KubernetesVersion, logs)
 	fatalOnError(err)
 
 	// TODO: in case of cluster upgrade the same Azure Zones must be send to the Provisioner
@@ -407,6 +454,9 @@ func main() {
 		logger.Info("Skipping processing operation in progress on start")
 	}
 
+	// trigger scheduled and recurring orchestrations when due
+	orchestration.NewScheduler(db.Orchestrations(), kymaQueue, time.Minute, logs.WithField("orchestration", "scheduler")).Run(ctx.Done())
+
 	// create OSB API endpoints
 	router.Use(middleware.AddRegionToContext(cfg.DefaultRequestRegion))
 	for _, prefix := range []string{
@@ -421,14 +471,45 @@ func main() {
 	orchestrationHandler.AttachRoutes(router)
 
 	// create list runtimes endpoint
-	runtimeHandler := runtime.NewHandler(db.Instances(), db.Operations(), cfg.MaxPaginationPage, cfg.DefaultRequestRegion)
+	runtimeHandler := runtime.NewHandler(db.Instances(), db.Operations(), db.RuntimeStates(), provisionerClient, cfg.ProvisionerStatusCacheTTL, cfg.MaxPaginationPage, cfg.DefaultRequestRegion)
 	runtimeHandler.AttachRoutes(router)
 
+	// create runtimes export endpoint
+	exportHandler := runtime.NewExportHandler(db.Instances(), cfg.MaxPaginationPage, cfg.DefaultRequestRegion, logs.WithField("service", "runtimesExport"))
+	exportHandler.AttachRoutes(router)
+
+	// create account pools endpoint
+	inventoryHandler := hyperscaler.NewInventoryHandler(accountPoolInventory)
+	inventoryHandler.AttachRoutes(router)
+
+	// create operation events stream endpoint
+	streamHandler := stream.NewHandler(operationEvents, cfg.OperationEventsKeepAliveInterval, logs)
+	streamHandler.AttachRoutes(router)
+
 	router.StrictSlash(true).PathPrefix("/").Handler(http.StripPrefix("/", http.FileServer(http.Dir("/swagger"))))
 	svr := handlers.CustomLoggingHandler(os.Stdout, router, func(writer io.Writer, params handlers.LogFormatterParams) {
 		logs.Infof("Call handled: method=%s url=%s statusCode=%d size=%d", params.Request.Method, params.URL.Path, params.StatusCode, params.Size)
 	})
 
+	// the broker is ready to serve the requests if its critical dependencies are available
+	checksHTTPClient := httputil.NewClient(30, false)
+	dependencies = append(dependencies,
+		health.Dependency{Name: "provisioner", Critical: true, Checker: health.NewHTTPChecker(checksHTTPClient, cfg.Provisioning.URL)},
+		health.Dependency{Name: "gardener", Critical: true, Checker: health.NewGardenerChecker(gardenerShoots)},
+		health.Dependency{Name: "director", Checker: health.NewHTTPChecker(checksHTTPClient, cfg.Director.URL)},
+		health.Dependency{Name: "lms", Checker: health.NewHTTPChecker(checksHTTPClient, cfg.LMS.URL)},
+	)
+	if !cfg.Avs.Disabled {
+		dependencies = append(dependencies, health.Dependency{Name: "avs", Checker: health.NewHTTPChecker(checksHTTPClient, cfg.Avs.ApiEndpoint)})
+	}
+	if !cfg.IAS.Disabled {
+		dependencies = append(dependencies, health.Dependency{Name: "ias", Checker: health.NewHTTPChecker(clientHTTPForIAS, cfg.IAS.URL)})
+	}
+	if !cfg.EDP.Disabled {
+		dependencies = append(dependencies, health.Dependency{Name: "edp", Checker: health.NewHTTPChecker(checksHTTPClient, cfg.EDP.AdminURL)})
+	}
+	healthServer.SetReadiness(health.NewReadiness(cfg.Health, dependencies...))
+
 	fatalOnError(http.ListenAndServe(cfg.Host+":"+cfg.Port, svr))
 }
 
@@ -503,7 +584,7 @@ func NewOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStora
 	gardenerClient gardenerclient.CoreV1beta1Interface, gardenerNamespace string, pub event.Publisher,
 	inputFactory input.CreatorForPlan, icfg *upgrade_kyma.TimeSchedule,
 	pollingInterval time.Duration, runtimeVerConfigurator *runtimeversion.RuntimeVersionConfigurator,
-	defaultRegion string, logs logrus.FieldLogger) (*process.Queue, error) {
+	defaultRegion, defaultKubernetesVersion string, logs logrus.FieldLogger) (*process.Queue, error) {
 
 	upgradeKymaManager := upgrade_kyma.NewManager(db.Operations(), pub, logs.WithField("upgradeKyma", "manager"))
 
@@ -514,6 +595,10 @@ func NewOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStora
 		weight   int
 		step     upgrade_kyma.Step
 	}{
+		{
+			weight: 1,
+			step:   upgrade_kyma.NewUpgradeClusterStep(db.Operations(), provisionerClient, defaultKubernetesVersion, icfg),
+		},
 		{
 			weight: 2,
 			step:   upgrade_kyma.NewOverridesFromSecretsAndConfigStep(db.Operations(), runtimeOverrides, runtimeVerConfigurator),
@@ -101,7 +101,7 @@ func NewOrchestrationSuite(t *testing.T) *OrchestrationSuite {
 			Retry:              10 * time.Millisecond,
 			StatusCheck:        100 * time.Millisecond,
 			UpgradeKymaTimeout: 2 * time.Second,
-		}, 250*time.Millisecond, runtimeVerConfigurator, defaultRegion, logs)
+		}, 250*time.Millisecond, runtimeVerConfigurator, defaultRegion, "1.18", logs)
 
 	return &OrchestrationSuite{
 		gardenerNamespace:  gardenerNamespace,
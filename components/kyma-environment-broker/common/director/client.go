@@ -8,6 +8,7 @@ import (
 
 	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	machineGraph "github.com/machinebox/graphql"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -54,6 +55,7 @@ func NewDirectorClient(ctx context.Context, config Config, log logrus.FieldLogge
 	}
 	httpClientOAuth := cfg.Client(ctx)
 	httpClientOAuth.Timeout = 30 * time.Second
+	httpClientOAuth.Transport = tracing.NewTransport(httpClientOAuth.Transport)
 
 	graphQLClient := machineGraph.NewClient(config.URL, machineGraph.WithHTTPClient(httpClientOAuth))
 
@@ -65,13 +67,13 @@ func NewDirectorClient(ctx context.Context, config Config, log logrus.FieldLogge
 }
 
 // GetConsoleURL fetches, validates and returns console URL from director component based on runtime ID
-func (dc *Client) GetConsoleURL(accountID, runtimeID string) (string, error) {
+func (dc *Client) GetConsoleURL(ctx context.Context, accountID, runtimeID string) (string, error) {
 	query := dc.queryProvider.Runtime(runtimeID)
 	req := machineGraph.NewRequest(query)
 	req.Header.Add(accountIDKey, accountID)
 
 	dc.log.Info("Send request to director")
-	response, err := dc.fetchURLFromDirector(req)
+	response, err := dc.fetchURLFromDirector(ctx, req)
 	if err != nil {
 		return "", errors.Wrap(err, "while making call to director")
 	}
@@ -81,13 +83,13 @@ func (dc *Client) GetConsoleURL(accountID, runtimeID string) (string, error) {
 }
 
 // SetLabel adds key-value label to a Runtime
-func (dc *Client) SetLabel(accountID, runtimeID, key, value string) error {
+func (dc *Client) SetLabel(ctx context.Context, accountID, runtimeID, key, value string) error {
 	query := dc.queryProvider.SetRuntimeLabel(runtimeID, key, value)
 	req := machineGraph.NewRequest(query)
 	req.Header.Add(accountIDKey, accountID)
 
 	dc.log.Info("Setup label in director")
-	response, err := dc.setLabelsInDirector(req)
+	response, err := dc.setLabelsInDirector(ctx, req)
 	if err != nil {
 		return errors.Wrapf(err, "while setting %s Runtime label to value %s", key, value)
 	}
@@ -101,13 +103,13 @@ func (dc *Client) SetLabel(accountID, runtimeID, key, value string) error {
 }
 
 // GetRuntimeID fetches runtime ID with given label name from director component
-func (dc *Client) GetRuntimeID(accountID, instanceID string) (string, error) {
+func (dc *Client) GetRuntimeID(ctx context.Context, accountID, instanceID string) (string, error) {
 	query := dc.queryProvider.RuntimeForInstanceId(instanceID)
 	req := machineGraph.NewRequest(query)
 	req.Header.Add(accountIDKey, accountID)
 
 	dc.log.Info("Send request to director")
-	response, err := dc.getRuntimeIdFromDirector(req)
+	response, err := dc.getRuntimeIdFromDirector(ctx, req)
 	if err != nil {
 		return "", err
 	}
@@ -116,10 +118,10 @@ func (dc *Client) GetRuntimeID(accountID, instanceID string) (string, error) {
 	return dc.getIDFromRuntime(&response.Result)
 }
 
-func (dc *Client) fetchURLFromDirector(req *machineGraph.Request) (*getURLResponse, error) {
+func (dc *Client) fetchURLFromDirector(ctx context.Context, req *machineGraph.Request) (*getURLResponse, error) {
 	var response getURLResponse
 
-	err := dc.graphQLClient.Run(context.Background(), req, &response)
+	err := dc.graphQLClient.Run(ctx, req, &response)
 	if err != nil {
 		dc.log.Errorf("call to director failed: %s", err)
 		return &getURLResponse{}, kebError.AsTemporaryError(err, "while requesting to director client")
@@ -128,10 +130,10 @@ func (dc *Client) fetchURLFromDirector(req *machineGraph.Request) (*getURLRespon
 	return &response, nil
 }
 
-func (dc *Client) setLabelsInDirector(req *machineGraph.Request) (*runtimeLabelResponse, error) {
+func (dc *Client) setLabelsInDirector(ctx context.Context, req *machineGraph.Request) (*runtimeLabelResponse, error) {
 	var response runtimeLabelResponse
 
-	err := dc.graphQLClient.Run(context.Background(), req, &response)
+	err := dc.graphQLClient.Run(ctx, req, &response)
 	if err != nil {
 		dc.log.Errorf("call to director failed: %s", err)
 		return &runtimeLabelResponse{}, kebError.AsTemporaryError(err, "while requesting to director client")
@@ -140,10 +142,10 @@ func (dc *Client) setLabelsInDirector(req *machineGraph.Request) (*runtimeLabelR
 	return &response, nil
 }
 
-func (dc *Client) getRuntimeIdFromDirector(req *machineGraph.Request) (*getRuntimeIdResponse, error) {
+func (dc *Client) getRuntimeIdFromDirector(ctx context.Context, req *machineGraph.Request) (*getRuntimeIdResponse, error) {
 	var response getRuntimeIdResponse
 
-	err := dc.graphQLClient.Run(context.Background(), req, &response)
+	err := dc.graphQLClient.Run(ctx, req, &response)
 	if err != nil {
 		dc.log.Errorf("call to director failed: %s", err)
 		return &getRuntimeIdResponse{}, kebError.AsTemporaryError(err, "while requesting to director client")
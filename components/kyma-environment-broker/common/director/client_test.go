@@ -52,7 +52,7 @@ func TestClient_GetConsoleURL(t *testing.T) {
 		defer qc.AssertExpectations(t)
 
 		// When
-		URL, tokenErr := client.GetConsoleURL(accountID, runtimeID)
+		URL, tokenErr := client.GetConsoleURL(context.Background(), accountID, runtimeID)
 
 		// Then
 		assert.NoError(t, tokenErr)
@@ -75,7 +75,7 @@ func TestClient_GetConsoleURL(t *testing.T) {
 		defer qc.AssertExpectations(t)
 
 		// When
-		URL, tokenErr := client.GetConsoleURL(accountID, runtimeID)
+		URL, tokenErr := client.GetConsoleURL(context.Background(), accountID, runtimeID)
 
 		// Then
 		assert.Error(t, tokenErr)
@@ -113,7 +113,7 @@ func TestClient_GetConsoleURL(t *testing.T) {
 		defer qc.AssertExpectations(t)
 
 		// When
-		URL, tokenErr := client.GetConsoleURL(accountID, runtimeID)
+		URL, tokenErr := client.GetConsoleURL(context.Background(), accountID, runtimeID)
 
 		// Then
 		assert.Error(t, tokenErr)
@@ -151,7 +151,7 @@ func TestClient_GetConsoleURL(t *testing.T) {
 		defer qc.AssertExpectations(t)
 
 		// When
-		URL, tokenErr := client.GetConsoleURL(accountID, runtimeID)
+		URL, tokenErr := client.GetConsoleURL(context.Background(), accountID, runtimeID)
 
 		// Then
 		assert.Error(t, tokenErr)
@@ -189,7 +189,7 @@ func TestClient_GetConsoleURL(t *testing.T) {
 		defer qc.AssertExpectations(t)
 
 		// When
-		URL, tokenErr := client.GetConsoleURL(accountID, runtimeID)
+		URL, tokenErr := client.GetConsoleURL(context.Background(), accountID, runtimeID)
 
 		// Then
 		assert.Error(t, tokenErr)
@@ -227,7 +227,7 @@ func TestClient_GetConsoleURL(t *testing.T) {
 		defer qc.AssertExpectations(t)
 
 		// When
-		URL, tokenErr := client.GetConsoleURL(accountID, runtimeID)
+		URL, tokenErr := client.GetConsoleURL(context.Background(), accountID, runtimeID)
 
 		// Then
 		assert.Error(t, tokenErr)
@@ -251,7 +251,7 @@ func TestClient_GetConsoleURL(t *testing.T) {
 		defer qc.AssertExpectations(t)
 
 		// When
-		URL, tokenErr := client.GetConsoleURL(accountID, runtimeID)
+		URL, tokenErr := client.GetConsoleURL(context.Background(), accountID, runtimeID)
 
 		// Then
 		assert.Error(t, tokenErr)
@@ -290,7 +290,7 @@ func TestClient_SetLabel(t *testing.T) {
 	defer qc.AssertExpectations(t)
 
 	// when
-	err := client.SetLabel(accountID, runtimeID, labelKey, labelValue)
+	err := client.SetLabel(context.Background(), accountID, runtimeID, labelKey, labelValue)
 
 	// then
 	assert.NoError(t, err)
@@ -2,13 +2,21 @@ package hyperscaler
 
 import (
 	"fmt"
-	"sync"
+	"math/rand"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 
 	gardener_apis "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
 )
 
 type Type string
@@ -33,17 +41,30 @@ type AccountPool interface {
 	IsSecretInternal(hyperscalerType Type, tenantName string) (bool, error)
 }
 
-func NewAccountPool(secretsClient corev1.SecretInterface, shootsClient gardener_apis.ShootInterface) AccountPool {
+// AccountAssignments stores the assignments of the secrets to the tenants for the audit
+// and the reconciliation with the labels of the secrets
+type AccountAssignments interface {
+	Insert(assignment internal.AccountAssignment) error
+	Release(secretName string, releasedAt time.Time) error
+	ListActive() ([]internal.AccountAssignment, error)
+}
+
+// NewAccountPool returns the account pool which assigns the secrets to the tenants using
+// the optimistic concurrency of the Kubernetes API, so it is safe to use by many broker replicas
+func NewAccountPool(secretsClient corev1.SecretInterface, shootsClient gardener_apis.ShootInterface, assignments AccountAssignments) AccountPool {
 	return &secretsAccountPool{
 		secretsClient: secretsClient,
 		shootsClient:  shootsClient,
+		assignments:   assignments,
+		log:           logrus.WithField("service", "accountPool"),
 	}
 }
 
 type secretsAccountPool struct {
 	secretsClient corev1.SecretInterface
 	shootsClient  gardener_apis.ShootInterface
-	mux           sync.Mutex
+	assignments   AccountAssignments
+	log           logrus.FieldLogger
 }
 
 func (p *secretsAccountPool) IsSecretInternal(hyperscalerType Type, tenantName string) (bool, error) {
@@ -79,10 +100,6 @@ func (p *secretsAccountPool) IsSecretDirty(hyperscalerType Type, tenantName stri
 }
 
 func (p *secretsAccountPool) MarkSecretAsDirty(hyperscalerType Type, tenantName string) error {
-
-	p.mux.Lock()
-	defer p.mux.Unlock()
-
 	labelSelector := fmt.Sprintf("shared!=true, tenantName=%s,hyperscalerType=%s", tenantName, hyperscalerType)
 
 	secret, err := getK8SSecret(p.secretsClient, labelSelector)
@@ -91,13 +108,29 @@ func (p *secretsAccountPool) MarkSecretAsDirty(hyperscalerType Type, tenantName
 		return errors.Wrapf(err, "error marking secret as dirty: failed to find secret used by the tenant %s and hyperscaler %s", tenantName, hyperscalerType)
 	}
 
-	secret.Labels["dirty"] = "true"
-
-	_, err = p.secretsClient.Update(secret)
+	// the secret could be modified in the meantime, so the label is applied on the latest version
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := p.secretsClient.Get(secret.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Labels["dirty"] = "true"
+		_, err = p.secretsClient.Update(current)
+		return err
+	})
 	if err != nil {
 		return errors.Wrapf(err, "error marking secret as dirty: failed to update secret for tenant: %s and hyperscaler: %s", tenantName, hyperscalerType)
 	}
 
+	err = p.assignments.Release(secret.Name, time.Now())
+	switch {
+	case dberr.IsNotFound(err):
+		p.log.Warnf("assignment of the secret %s to the tenant %s was not recorded", secret.Name, tenantName)
+	case err != nil:
+		// the assignment reconciler releases the assignment later
+		p.log.Errorf("while releasing assignment of the secret %s: %s", secret.Name, err)
+	}
+
 	return nil
 }
 
@@ -126,38 +159,71 @@ func (p *secretsAccountPool) IsSecretUsed(hyperscalerType Type, tenantName strin
 }
 
 func (p *secretsAccountPool) Credentials(hyperscalerType Type, tenantName string) (Credentials, error) {
+	var credentials Credentials
+	// the secrets are assigned concurrently by other broker replicas, every attempt picks a free secret again
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		// the secret could be assigned to the tenant by another broker replica in the meantime
+		labelSelector := fmt.Sprintf("tenantName=%s,hyperscalerType=%s", tenantName, hyperscalerType)
+		secret, err := getK8SSecret(p.secretsClient, labelSelector)
+
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			credentials = credentialsFromSecret(secret, hyperscalerType)
+			return nil
+		}
 
-	labelSelector := fmt.Sprintf("tenantName=%s,hyperscalerType=%s", tenantName, hyperscalerType)
-	secret, err := getK8SSecret(p.secretsClient, labelSelector)
+		labelSelector = fmt.Sprintf("shared!=true, !tenantName, !dirty, hyperscalerType=%s", hyperscalerType)
+		secret, err = getRandomK8SSecret(p.secretsClient, labelSelector)
 
-	if err != nil {
-		return Credentials{}, err
-	}
-	if secret != nil {
-		return credentialsFromSecret(secret, hyperscalerType), nil
-	}
+		if err != nil {
+			return err
+		}
 
-	labelSelector = fmt.Sprintf("shared!=true, !tenantName, !dirty, hyperscalerType=%s", hyperscalerType)
-	// lock so that only one thread can fetch an unassigned secret and assign it (update secret with tenantName)
-	p.mux.Lock()
-	defer p.mux.Unlock()
-	secret, err = getK8SSecret(p.secretsClient, labelSelector)
+		if secret == nil {
+			return errors.Errorf("failed to find unassigned secret for hyperscalerType: %s", hyperscalerType)
+		}
 
+		// the update contains the resource version of the listed secret, so it fails with the conflict
+		// if the secret was assigned to another tenant in the meantime
+		secret.Labels["tenantName"] = tenantName
+		updatedSecret, err := p.secretsClient.Update(secret)
+		if apierr.IsConflict(err) {
+			p.log.Infof("secret %s was modified concurrently, retrying to assign a secret to the tenant %s", secret.Name, tenantName)
+			return err
+		}
+		if err != nil {
+			return errors.Wrapf(err, "error updating secret with tenantName: %s", tenantName)
+		}
+
+		p.recordAssignment(updatedSecret.Name, hyperscalerType, tenantName)
+		credentials = credentialsFromSecret(updatedSecret, hyperscalerType)
+		return nil
+	})
+	if apierr.IsConflict(err) {
+		return Credentials{}, errors.Errorf("failed to assign secret for hyperscalerType: %s to the tenant: %s, the secrets were modified concurrently", hyperscalerType, tenantName)
+	}
 	if err != nil {
 		return Credentials{}, err
 	}
 
-	if secret == nil {
-		return Credentials{}, errors.Errorf("failed to find unassigned secret for hyperscalerType: %s", hyperscalerType)
-	}
+	return credentials, nil
+}
 
-	secret.Labels["tenantName"] = tenantName
-	updatedSecret, err := p.secretsClient.Update(secret)
+// recordAssignment does not fail the assignment, the secret labels are the source of truth
+// and the missing record is created by the assignment reconciler
+func (p *secretsAccountPool) recordAssignment(secretName string, hyperscalerType Type, tenantName string) {
+	err := p.assignments.Insert(internal.AccountAssignment{
+		ID:              uuid.New().String(),
+		SecretName:      secretName,
+		HyperscalerType: string(hyperscalerType),
+		TenantName:      tenantName,
+		CreatedAt:       time.Now(),
+	})
 	if err != nil {
-		return Credentials{}, errors.Wrapf(err, "error updating secret with tenantName: %s", tenantName)
+		p.log.Errorf("while recording assignment of the secret %s to the tenant %s: %s", secretName, tenantName, err)
 	}
-
-	return credentialsFromSecret(updatedSecret, hyperscalerType), nil
 }
 
 func getK8SSecret(secretsClient corev1.SecretInterface, labelSelector string) (*apiv1.Secret, error) {
@@ -177,6 +243,24 @@ func getK8SSecret(secretsClient corev1.SecretInterface, labelSelector string) (*
 	return nil, nil
 }
 
+// getRandomK8SSecret picks a random secret, so the concurrent requests do not compete for the same one
+func getRandomK8SSecret(secretsClient corev1.SecretInterface, labelSelector string) (*apiv1.Secret, error) {
+	secrets, err := secretsClient.List(metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+
+	if err != nil {
+		return nil,
+			errors.Wrapf(err, "error listing secrets for LabelSelector: %s", labelSelector)
+	}
+
+	if secrets == nil || len(secrets.Items) == 0 {
+		return nil, nil
+	}
+
+	return &secrets.Items[rand.Intn(len(secrets.Items))], nil
+}
+
 func credentialsFromSecret(secret *apiv1.Secret, hyperscalerType Type) Credentials {
 	return Credentials{
 		Name:            secret.Name,
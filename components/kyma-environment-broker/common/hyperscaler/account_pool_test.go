@@ -3,6 +3,8 @@ package hyperscaler
 import (
 	"testing"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/memory"
+
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
@@ -10,8 +12,12 @@ import (
 	gardener_fake "github.com/gardener/gardener/pkg/client/core/clientset/versioned/fake"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	machineryv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestCredentials(t *testing.T) {
@@ -173,6 +179,91 @@ func TestSecretsAccountPool_MarkSecretAsDirty(t *testing.T) {
 	})
 }
 
+func TestSecretsAccountPool_CredentialsConcurrentlyAssigned(t *testing.T) {
+	t.Run("should assign another secret when the picked one was assigned concurrently", func(t *testing.T) {
+		//given
+		mockClient := fake.NewSimpleClientset(
+			newTestPoolSecret("secret1", map[string]string{"hyperscalerType": "gcp"}),
+			newTestPoolSecret("secret2", map[string]string{"hyperscalerType": "gcp"}),
+		)
+		conflicted := ""
+		mockClient.PrependReactor("update", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			secret := action.(k8stesting.UpdateAction).GetObject().(*corev1.Secret)
+			if conflicted == "" {
+				conflicted = secret.Name
+				// simulate the assignment done by another broker replica
+				current, err := mockClient.Tracker().Get(schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, testNamespace, secret.Name)
+				require.NoError(t, err)
+				concurrent := current.(*corev1.Secret).DeepCopy()
+				concurrent.Labels["tenantName"] = "other-tenant"
+				require.NoError(t, mockClient.Tracker().Update(schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, concurrent, testNamespace))
+				return true, nil, apierr.NewConflict(schema.GroupResource{Resource: "secrets"}, secret.Name, nil)
+			}
+			return false, nil, nil
+		})
+		assignments := memory.NewAccountAssignments()
+		pool := NewAccountPool(mockClient.CoreV1().Secrets(testNamespace), nil, assignments)
+
+		//when
+		credentials, err := pool.Credentials(GCP, "tenant1")
+
+		//then
+		require.NoError(t, err)
+		assert.NotEqual(t, conflicted, credentials.Name)
+		active, err := assignments.ListActive()
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, credentials.Name, active[0].SecretName)
+		assert.Equal(t, "tenant1", active[0].TenantName)
+		assert.Equal(t, "gcp", active[0].HyperscalerType)
+	})
+
+	t.Run("should fail when all attempts conflict", func(t *testing.T) {
+		//given
+		mockClient := fake.NewSimpleClientset(newTestPoolSecret("secret1", map[string]string{"hyperscalerType": "gcp"}))
+		mockClient.PrependReactor("update", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierr.NewConflict(schema.GroupResource{Resource: "secrets"}, "secret1", nil)
+		})
+		pool := NewAccountPool(mockClient.CoreV1().Secrets(testNamespace), nil, memory.NewAccountAssignments())
+
+		//when
+		_, err := pool.Credentials(GCP, "tenant1")
+
+		//then
+		assert.EqualError(t, err, "failed to assign secret for hyperscalerType: gcp to the tenant: tenant1, the secrets were modified concurrently")
+	})
+}
+
+func TestSecretsAccountPool_MarkSecretAsDirtyReleasesAssignment(t *testing.T) {
+	//given
+	mockClient := fake.NewSimpleClientset(newTestPoolSecret("secret1", map[string]string{"hyperscalerType": "azure"}))
+	assignments := memory.NewAccountAssignments()
+	pool := NewAccountPool(mockClient.CoreV1().Secrets(testNamespace), nil, assignments)
+	_, err := pool.Credentials(Azure, "tenant1")
+	require.NoError(t, err)
+
+	//when
+	err = pool.MarkSecretAsDirty(Azure, "tenant1")
+
+	//then
+	require.NoError(t, err)
+	active, err := assignments.ListActive()
+	require.NoError(t, err)
+	assert.Empty(t, active)
+}
+
+func newTestPoolSecret(name string, labels map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: machineryv1.ObjectMeta{
+			Name: name, Namespace: testNamespace,
+			Labels: labels,
+		},
+		Data: map[string][]byte{
+			"credentials": []byte(name),
+		},
+	}
+}
+
 func newTestAccountPool() AccountPool {
 	secret1 := &corev1.Secret{
 		ObjectMeta: machineryv1.ObjectMeta{
@@ -247,7 +338,7 @@ func newTestAccountPool() AccountPool {
 
 	mockClient := fake.NewSimpleClientset(secret1, secret2, secret3, secret4, secret5, secret6)
 	mockSecrets := mockClient.CoreV1().Secrets(testNamespace)
-	pool := NewAccountPool(mockSecrets, nil)
+	pool := NewAccountPool(mockSecrets, nil, memory.NewAccountAssignments())
 	return pool
 }
 
@@ -287,7 +378,7 @@ func newTestAccountPoolWithSingleShoot() (AccountPool, v1.SecretInterface) {
 	gardenerFake := gardener_fake.NewSimpleClientset(shoot1)
 	mockShoots := gardenerFake.CoreV1beta1().Shoots(testNamespace)
 
-	pool := NewAccountPool(mockSecrets, mockShoots)
+	pool := NewAccountPool(mockSecrets, mockShoots, memory.NewAccountAssignments())
 
 	return pool, mockSecrets
 }
@@ -301,7 +392,7 @@ func newEmptyTestAccountPool() (AccountPool, v1.SecretInterface) {
 	gardenerFake := gardener_fake.NewSimpleClientset()
 	mockShoots := gardenerFake.CoreV1beta1().Shoots(testNamespace)
 
-	pool := NewAccountPool(mockSecrets, mockShoots)
+	pool := NewAccountPool(mockSecrets, mockShoots, memory.NewAccountAssignments())
 
 	return pool, mockSecrets
 }
@@ -327,7 +418,7 @@ func newTestAccountPoolWithSecretInternal() (AccountPool, v1.SecretInterface) {
 	gardenerFake := gardener_fake.NewSimpleClientset()
 	mockShoots := gardenerFake.CoreV1beta1().Shoots(testNamespace)
 
-	pool := NewAccountPool(mockSecrets, mockShoots)
+	pool := NewAccountPool(mockSecrets, mockShoots, memory.NewAccountAssignments())
 	return pool, mockSecrets
 }
 
@@ -368,7 +459,7 @@ func newTestAccountPoolWithSecretDirty() (AccountPool, v1.SecretInterface) {
 	gardenerFake := gardener_fake.NewSimpleClientset(shoot1)
 	mockShoots := gardenerFake.CoreV1beta1().Shoots(testNamespace)
 
-	pool := NewAccountPool(mockSecrets, mockShoots)
+	pool := NewAccountPool(mockSecrets, mockShoots, memory.NewAccountAssignments())
 	return pool, mockSecrets
 }
 
@@ -424,7 +515,7 @@ func newTestAccountPoolWithShootsUsingSecret() (AccountPool, v1.SecretInterface)
 	gardenerFake := gardener_fake.NewSimpleClientset(shoot1, shoot2)
 	mockShoots := gardenerFake.CoreV1beta1().Shoots(testNamespace)
 
-	pool := NewAccountPool(mockSecrets, mockShoots)
+	pool := NewAccountPool(mockSecrets, mockShoots, memory.NewAccountAssignments())
 	return pool, mockSecrets
 }
 
@@ -448,7 +539,7 @@ func newTestAccountPoolWithoutShoots() (AccountPool, v1.SecretInterface) {
 	gardenerFake := gardener_fake.NewSimpleClientset()
 	mockShoots := gardenerFake.CoreV1beta1().Shoots(testNamespace)
 
-	pool := NewAccountPool(mockSecrets, mockShoots)
+	pool := NewAccountPool(mockSecrets, mockShoots, memory.NewAccountAssignments())
 
 	return pool, mockSecrets
 }
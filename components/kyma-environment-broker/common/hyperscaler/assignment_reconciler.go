@@ -0,0 +1,122 @@
+package hyperscaler
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// AssignmentReconcilerConfig holds the interval of the reconciliation of the recorded assignments with the secret labels
+type AssignmentReconcilerConfig struct {
+	Interval time.Duration `envconfig:"default=10m"`
+}
+
+// AssignmentReconciler makes the recorded assignments consistent with the labels of the secrets,
+// which are the source of truth. It records the assignments missing in the storage and releases
+// the assignments of the secrets which were released or assigned to another tenant.
+//
+// The reconciliation is idempotent, so it runs on every broker replica without the leader election.
+// The storage allows only one active assignment per secret, the assignment recorded in the meantime
+// by another replica or by the account pool is left to the next reconciliation.
+type AssignmentReconciler struct {
+	secretsClient corev1.SecretInterface
+	assignments   AccountAssignments
+	log           logrus.FieldLogger
+}
+
+func NewAssignmentReconciler(secretsClient corev1.SecretInterface, assignments AccountAssignments, log logrus.FieldLogger) *AssignmentReconciler {
+	return &AssignmentReconciler{
+		secretsClient: secretsClient,
+		assignments:   assignments,
+		log:           log,
+	}
+}
+
+// Run reconciles the assignments periodically until the stop channel is closed
+func (r *AssignmentReconciler) Run(cfg AssignmentReconcilerConfig, stop <-chan struct{}) {
+	wait.Until(func() {
+		if err := r.Reconcile(); err != nil {
+			r.log.Errorf("while reconciling account assignments: %s", err)
+		}
+	}, cfg.Interval, stop)
+}
+
+func (r *AssignmentReconciler) Reconcile() error {
+	// the assignments are listed before the secrets, so the assignment recorded by the account pool
+	// between both calls is not listed as active and is not released because of the outdated secret labels
+	active, err := r.assignments.ListActive()
+	if err != nil {
+		return errors.Wrap(err, "while listing active account assignments")
+	}
+	secrets, err := r.secretsClient.List(metav1.ListOptions{LabelSelector: "shared!=true, tenantName, hyperscalerType"})
+	if err != nil {
+		return errors.Wrap(err, "while listing assigned secrets")
+	}
+
+	recorded := make(map[string]internal.AccountAssignment, len(active))
+	for _, assignment := range active {
+		recorded[assignment.SecretName] = assignment
+	}
+
+	assigned := map[string]struct{}{}
+	for _, secret := range secrets.Items {
+		if secret.Labels["dirty"] == "true" {
+			continue
+		}
+		tenantName := secret.Labels["tenantName"]
+		if assignment, found := recorded[secret.Name]; found && assignment.TenantName == tenantName {
+			assigned[secret.Name] = struct{}{}
+			continue
+		}
+		if _, found := recorded[secret.Name]; found {
+			// the record is stale, release it before recording the current tenant
+			if err := r.release(secret.Name); err != nil {
+				return err
+			}
+		}
+
+		r.log.Infof("recording missing assignment of the secret %s to the tenant %s", secret.Name, tenantName)
+		err := r.assignments.Insert(internal.AccountAssignment{
+			ID:              uuid.New().String(),
+			SecretName:      secret.Name,
+			HyperscalerType: secret.Labels["hyperscalerType"],
+			TenantName:      tenantName,
+			CreatedAt:       time.Now(),
+		})
+		switch {
+		case dberr.IsAlreadyExists(err):
+			r.log.Infof("assignment of the secret %s was recorded in the meantime", secret.Name)
+		case err != nil:
+			return errors.Wrapf(err, "while recording assignment of the secret %s", secret.Name)
+		}
+		assigned[secret.Name] = struct{}{}
+	}
+
+	for _, assignment := range active {
+		if _, found := assigned[assignment.SecretName]; found {
+			continue
+		}
+		r.log.Infof("releasing stale assignment of the secret %s to the tenant %s", assignment.SecretName, assignment.TenantName)
+		if err := r.release(assignment.SecretName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *AssignmentReconciler) release(secretName string) error {
+	err := r.assignments.Release(secretName, time.Now())
+	if err != nil && !dberr.IsNotFound(err) {
+		return errors.Wrapf(err, "while releasing assignment of the secret %s", secretName)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package hyperscaler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/memory"
+
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestAssignmentReconciler_Reconcile(t *testing.T) {
+	// given
+	mockClient := fake.NewSimpleClientset(
+		newTestPoolSecret("recorded", map[string]string{"hyperscalerType": "gcp", "tenantName": "tenant1"}),
+		newTestPoolSecret("not-recorded", map[string]string{"hyperscalerType": "gcp", "tenantName": "tenant2"}),
+		newTestPoolSecret("reassigned", map[string]string{"hyperscalerType": "azure", "tenantName": "tenant3"}),
+		newTestPoolSecret("dirty", map[string]string{"hyperscalerType": "azure", "tenantName": "tenant4", "dirty": "true"}),
+		newTestPoolSecret("shared", map[string]string{"hyperscalerType": "gcp", "tenantName": "tenant5", "shared": "true"}),
+		newTestPoolSecret("free", map[string]string{"hyperscalerType": "aws"}),
+	)
+	assignments := memory.NewAccountAssignments()
+	for _, a := range []internal.AccountAssignment{
+		{ID: "a1", SecretName: "recorded", HyperscalerType: "gcp", TenantName: "tenant1"},
+		{ID: "a2", SecretName: "reassigned", HyperscalerType: "azure", TenantName: "old-tenant"},
+		{ID: "a3", SecretName: "dirty", HyperscalerType: "azure", TenantName: "tenant4"},
+		{ID: "a4", SecretName: "free", HyperscalerType: "aws", TenantName: "tenant6"},
+	} {
+		a.CreatedAt = time.Now()
+		require.NoError(t, assignments.Insert(a))
+	}
+	logger, _ := logTest.NewNullLogger()
+	reconciler := NewAssignmentReconciler(mockClient.CoreV1().Secrets(testNamespace), assignments, logger)
+
+	// when
+	err := reconciler.Reconcile()
+
+	// then
+	require.NoError(t, err)
+	active, err := assignments.ListActive()
+	require.NoError(t, err)
+	tenants := map[string]string{}
+	for _, a := range active {
+		tenants[a.SecretName] = a.TenantName
+	}
+	assert.Equal(t, map[string]string{
+		"recorded":     "tenant1",
+		"not-recorded": "tenant2",
+		"reassigned":   "tenant3",
+	}, tenants)
+}
+
+func TestAssignmentReconciler_ReconcileWithConcurrentAssignment(t *testing.T) {
+	// given
+	mockClient := fake.NewSimpleClientset(newTestPoolSecret("secret1", map[string]string{"hyperscalerType": "gcp"}))
+	assignments := memory.NewAccountAssignments()
+	secretsResource := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	assigned := false
+	mockClient.PrependReactor("list", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		// return the secrets listed before the account pool assigns the secret to the tenant
+		list, err := mockClient.Tracker().List(secretsResource, schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, testNamespace)
+		if err != nil || assigned {
+			return true, list, err
+		}
+		assigned = true
+		current, err := mockClient.Tracker().Get(secretsResource, testNamespace, "secret1")
+		require.NoError(t, err)
+		secret := current.(*corev1.Secret).DeepCopy()
+		secret.Labels["tenantName"] = "tenant1"
+		require.NoError(t, mockClient.Tracker().Update(secretsResource, secret, testNamespace))
+		require.NoError(t, assignments.Insert(internal.AccountAssignment{
+			ID:              "a1",
+			SecretName:      "secret1",
+			HyperscalerType: "gcp",
+			TenantName:      "tenant1",
+			CreatedAt:       time.Now(),
+		}))
+		return true, list, nil
+	})
+	logger, _ := logTest.NewNullLogger()
+	reconciler := NewAssignmentReconciler(mockClient.CoreV1().Secrets(testNamespace), assignments, logger)
+
+	// when
+	err := reconciler.Reconcile()
+
+	// then
+	require.NoError(t, err)
+	require.True(t, assigned)
+	active, err := assignments.ListActive()
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "secret1", active[0].SecretName)
+	assert.Equal(t, "tenant1", active[0].TenantName)
+}
@@ -31,20 +31,20 @@ func (_m *AccountProvider) GardenerCredentials(hyperscalerType hyperscaler.Type,
 	return r0, r1
 }
 
-// GardenerSharedCredentials provides a mock function with given fields: hyperscalerType
-func (_m *AccountProvider) GardenerSharedCredentials(hyperscalerType hyperscaler.Type) (hyperscaler.Credentials, error) {
-	ret := _m.Called(hyperscalerType)
+// GardenerSharedCredentials provides a mock function with given fields: hyperscalerType, region
+func (_m *AccountProvider) GardenerSharedCredentials(hyperscalerType hyperscaler.Type, region string) (hyperscaler.Credentials, error) {
+	ret := _m.Called(hyperscalerType, region)
 
 	var r0 hyperscaler.Credentials
-	if rf, ok := ret.Get(0).(func(hyperscaler.Type) hyperscaler.Credentials); ok {
-		r0 = rf(hyperscalerType)
+	if rf, ok := ret.Get(0).(func(hyperscaler.Type, string) hyperscaler.Credentials); ok {
+		r0 = rf(hyperscalerType, region)
 	} else {
 		r0 = ret.Get(0).(hyperscaler.Credentials)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(hyperscaler.Type) error); ok {
-		r1 = rf(hyperscalerType)
+	if rf, ok := ret.Get(1).(func(hyperscaler.Type, string) error); ok {
+		r1 = rf(hyperscalerType, region)
 	} else {
 		r1 = ret.Error(1)
 	}
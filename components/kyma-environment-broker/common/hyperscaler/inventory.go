@@ -0,0 +1,141 @@
+package hyperscaler
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// InventoryConfig holds the minimal numbers of free secrets per hyperscaler type, below which the pool is reported as low.
+// Zero disables the warning for the given hyperscaler type.
+type InventoryConfig struct {
+	GCPLowWatermark   int `envconfig:"default=0"`
+	AzureLowWatermark int `envconfig:"default=0"`
+	AWSLowWatermark   int `envconfig:"default=0"`
+}
+
+func (c InventoryConfig) lowWatermarks() map[Type]int {
+	return map[Type]int{
+		GCP:   c.GCPLowWatermark,
+		Azure: c.AzureLowWatermark,
+		AWS:   c.AWSLowWatermark,
+	}
+}
+
+// AccountPoolStats holds the number of the secrets of the hyperscaler type by state:
+// - Free - the secrets which can be assigned to a new tenant
+// - Assigned - the secrets assigned to a tenant and not released yet
+// - Dirty - the secrets released by the tenants and waiting for the cleanup of the hyperscaler resources
+// - Shared - the secrets shared by the tenants, not counted in the other states
+// - Internal - the secrets of the internal tenants, counted also as assigned or dirty
+type AccountPoolStats struct {
+	HyperscalerType   Type `json:"hyperscalerType"`
+	Free              int  `json:"free"`
+	Assigned          int  `json:"assigned"`
+	Dirty             int  `json:"dirty"`
+	Shared            int  `json:"shared"`
+	Internal          int  `json:"internal"`
+	LowWatermark      int  `json:"lowWatermark"`
+	BelowLowWatermark bool `json:"belowLowWatermark"`
+}
+
+type AccountPoolStatsList struct {
+	Data []AccountPoolStats `json:"data"`
+}
+
+// Inventory counts the secrets of the account pool, the state of the secret is read from the labels
+// in the same way as the account pool does
+type Inventory struct {
+	secretsClient corev1.SecretInterface
+	lowWatermarks map[Type]int
+	log           logrus.FieldLogger
+
+	mu  sync.Mutex
+	low map[Type]bool
+}
+
+func NewInventory(secretsClient corev1.SecretInterface, cfg InventoryConfig, log logrus.FieldLogger) *Inventory {
+	return &Inventory{
+		secretsClient: secretsClient,
+		lowWatermarks: cfg.lowWatermarks(),
+		log:           log,
+		low:           map[Type]bool{},
+	}
+}
+
+// Stats returns the statistics of the account pool of every hyperscaler type which has any secret or the low watermark set
+func (i *Inventory) Stats() ([]AccountPoolStats, error) {
+	secrets, err := i.secretsClient.List(metav1.ListOptions{LabelSelector: "hyperscalerType"})
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing account pool secrets")
+	}
+
+	stats := map[Type]*AccountPoolStats{}
+	statsFor := func(hyperscalerType Type) *AccountPoolStats {
+		if _, found := stats[hyperscalerType]; !found {
+			stats[hyperscalerType] = &AccountPoolStats{HyperscalerType: hyperscalerType}
+		}
+		return stats[hyperscalerType]
+	}
+	for hyperscalerType, watermark := range i.lowWatermarks {
+		if watermark > 0 {
+			statsFor(hyperscalerType)
+		}
+	}
+
+	for _, secret := range secrets.Items {
+		s := statsFor(Type(secret.Labels["hyperscalerType"]))
+		_, assigned := secret.Labels["tenantName"]
+		_, dirtyLabel := secret.Labels["dirty"]
+
+		switch {
+		case secret.Labels["shared"] == "true":
+			s.Shared++
+			continue
+		case secret.Labels["dirty"] == "true":
+			s.Dirty++
+		case assigned:
+			s.Assigned++
+		case !dirtyLabel:
+			s.Free++
+		}
+		if secret.Labels["internal"] == "true" {
+			s.Internal++
+		}
+	}
+
+	result := make([]AccountPoolStats, 0, len(stats))
+	for hyperscalerType, s := range stats {
+		s.LowWatermark = i.lowWatermarks[hyperscalerType]
+		s.BelowLowWatermark = s.Free < s.LowWatermark
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].HyperscalerType < result[b].HyperscalerType
+	})
+	i.warnAboutLowPools(result)
+
+	return result, nil
+}
+
+// warnAboutLowPools logs the warning when the pool gets below the low watermark and when it is refilled,
+// the state is not logged on every check to not flood the logs
+func (i *Inventory) warnAboutLowPools(stats []AccountPoolStats) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, s := range stats {
+		wasLow := i.low[s.HyperscalerType]
+		switch {
+		case s.BelowLowWatermark && !wasLow:
+			i.log.Warnf("account pool for hyperscaler %s is running low: %d free secrets left, the low watermark is %d", s.HyperscalerType, s.Free, s.LowWatermark)
+		case !s.BelowLowWatermark && wasLow:
+			i.log.Infof("account pool for hyperscaler %s is refilled: %d free secrets", s.HyperscalerType, s.Free)
+		}
+		i.low[s.HyperscalerType] = s.BelowLowWatermark
+	}
+}
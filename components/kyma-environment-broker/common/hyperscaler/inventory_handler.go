@@ -0,0 +1,34 @@
+package hyperscaler
+
+import (
+	"net/http"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+type InventoryHandler struct {
+	inventory *Inventory
+}
+
+func NewInventoryHandler(inventory *Inventory) *InventoryHandler {
+	return &InventoryHandler{
+		inventory: inventory,
+	}
+}
+
+func (h *InventoryHandler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/account-pools", h.getAccountPools).Methods(http.MethodGet)
+}
+
+func (h *InventoryHandler) getAccountPools(w http.ResponseWriter, req *http.Request) {
+	stats, err := h.inventory.Stats()
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while getting account pool statistics"))
+		return
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, AccountPoolStatsList{Data: stats})
+}
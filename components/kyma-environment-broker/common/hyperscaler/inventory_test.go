@@ -0,0 +1,113 @@
+package hyperscaler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	machineryv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+func TestInventory_Stats(t *testing.T) {
+	// given
+	secrets := newTestInventorySecrets(
+		map[string]string{"hyperscalerType": "gcp"},
+		map[string]string{"hyperscalerType": "gcp"},
+		map[string]string{"hyperscalerType": "gcp", "tenantName": "tenant1"},
+		map[string]string{"hyperscalerType": "gcp", "tenantName": "tenant2", "internal": "true"},
+		map[string]string{"hyperscalerType": "gcp", "tenantName": "tenant3", "dirty": "true"},
+		map[string]string{"hyperscalerType": "gcp", "shared": "true"},
+		map[string]string{"hyperscalerType": "azure", "tenantName": "tenant1"},
+		map[string]string{"tenantName": "not-a-pool-secret"},
+	)
+	logger, _ := logTest.NewNullLogger()
+	inventory := NewInventory(secrets, InventoryConfig{GCPLowWatermark: 3, AWSLowWatermark: 1}, logger)
+
+	// when
+	stats, err := inventory.Stats()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []AccountPoolStats{
+		{HyperscalerType: AWS, LowWatermark: 1, BelowLowWatermark: true},
+		{HyperscalerType: Azure, Assigned: 1},
+		{HyperscalerType: GCP, Free: 2, Assigned: 2, Dirty: 1, Shared: 1, Internal: 1, LowWatermark: 3, BelowLowWatermark: true},
+	}, stats)
+}
+
+func TestInventory_LowWatermarkWarning(t *testing.T) {
+	// given
+	secrets := newTestInventorySecrets(map[string]string{"hyperscalerType": "azure"})
+	logger, hook := logTest.NewNullLogger()
+	inventory := NewInventory(secrets, InventoryConfig{AzureLowWatermark: 2}, logger)
+
+	// when
+	_, err := inventory.Stats()
+	require.NoError(t, err)
+	_, err = inventory.Stats()
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+
+	// when
+	_, err = secrets.Create(newTestInventorySecret("refilled", map[string]string{"hyperscalerType": "azure"}))
+	require.NoError(t, err)
+	_, err = inventory.Stats()
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, hook.AllEntries(), 2)
+	assert.Equal(t, logrus.InfoLevel, hook.LastEntry().Level)
+}
+
+func TestInventoryHandler(t *testing.T) {
+	// given
+	secrets := newTestInventorySecrets(map[string]string{"hyperscalerType": "gcp"})
+	logger, _ := logTest.NewNullLogger()
+	router := mux.NewRouter()
+	NewInventoryHandler(NewInventory(secrets, InventoryConfig{}, logger)).AttachRoutes(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/account-pools", nil)
+	rr := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(rr, req)
+
+	// then
+	require.Equal(t, http.StatusOK, rr.Code)
+	var out AccountPoolStatsList
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+	assert.Equal(t, []AccountPoolStats{{HyperscalerType: GCP, Free: 1}}, out.Data)
+}
+
+func newTestInventorySecrets(labels ...map[string]string) v1.SecretInterface {
+	secrets := fake.NewSimpleClientset().CoreV1().Secrets(testNamespace)
+	for i, l := range labels {
+		_, err := secrets.Create(newTestInventorySecret(fmt.Sprintf("secret%d", i), l))
+		if err != nil {
+			panic(err)
+		}
+	}
+	return secrets
+}
+
+func newTestInventorySecret(name string, labels map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: machineryv1.ObjectMeta{
+			Name: name, Namespace: testNamespace,
+			Labels: labels,
+		},
+	}
+}
@@ -2,6 +2,7 @@ package hyperscaler
 
 import (
 	"fmt"
+	"strings"
 
 	gardener_apis "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
 	"github.com/pkg/errors"
@@ -10,35 +11,56 @@ import (
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
+// regionLabelPrefix is the prefix of the labels of the shared Secrets which list the regions served by the Secret,
+// for example: region.westeurope=true. The Secret without any region label serves all regions.
+const regionLabelPrefix = "region."
+
 type SharedPool interface {
-	SharedCredentials(hyperscalerType Type) (Credentials, error)
+	SharedCredentials(hyperscalerType Type, region string) (Credentials, error)
+}
+
+// SharedPoolConfig holds the maximal number of the shoots using the same shared Secret, zero means no limit
+type SharedPoolConfig struct {
+	MaxShootsPerSecret int `envconfig:"default=0"`
 }
 
-func NewSharedGardenerAccountPool(secretsClient corev1.SecretInterface, shootsClient gardener_apis.ShootInterface) *SharedAccountPool {
+func NewSharedGardenerAccountPool(secretsClient corev1.SecretInterface, shootsClient gardener_apis.ShootInterface, cfg SharedPoolConfig) *SharedAccountPool {
 	return &SharedAccountPool{
-		secretsClient: secretsClient,
-		shootsClient:  shootsClient,
+		secretsClient:      secretsClient,
+		shootsClient:       shootsClient,
+		maxShootsPerSecret: cfg.MaxShootsPerSecret,
 	}
 }
 
 type SharedAccountPool struct {
-	secretsClient corev1.SecretInterface
-	shootsClient  gardener_apis.ShootInterface
+	secretsClient      corev1.SecretInterface
+	shootsClient       gardener_apis.ShootInterface
+	maxShootsPerSecret int
 }
 
-func (sp *SharedAccountPool) SharedCredentials(hyperscalerType Type) (Credentials, error) {
+// SharedCredentials returns the credentials of the least used shared Secret which serves the given region.
+// The empty region matches every Secret.
+func (sp *SharedAccountPool) SharedCredentials(hyperscalerType Type, region string) (Credentials, error) {
 	labelSelector := fmt.Sprintf("shared=true,hyperscalerType=%s", hyperscalerType)
 	secrets, err := getK8sSecrets(sp.secretsClient, labelSelector)
 	if err != nil {
 		return Credentials{}, err
 	}
 
+	secrets = filterByRegion(secrets, region)
+	if len(secrets) == 0 {
+		return Credentials{}, errors.Errorf("sharedAccountPool error: no shared Secret for hyperscalerType %s serves region %s", hyperscalerType, region)
+	}
+
 	secret, err := sp.getLeastUsed(secrets)
 	if err != nil {
 		return Credentials{}, err
 	}
+	if secret == nil {
+		return Credentials{}, errors.Errorf("sharedAccountPool error: all %d shared Secrets for hyperscalerType %s serving region %s are used by the maximum of %d shoots", len(secrets), hyperscalerType, region, sp.maxShootsPerSecret)
+	}
 
-	return credentialsFromSecret(&secret, hyperscalerType), nil
+	return credentialsFromSecret(secret, hyperscalerType), nil
 }
 
 func getK8sSecrets(secretsClient corev1.SecretInterface, labelSelector string) ([]apiv1.Secret, error) {
@@ -56,7 +78,38 @@ func getK8sSecrets(secretsClient corev1.SecretInterface, labelSelector string) (
 	return secrets.Items, nil
 }
 
-func (sp *SharedAccountPool) getLeastUsed(secrets []apiv1.Secret) (apiv1.Secret, error) {
+func filterByRegion(secrets []apiv1.Secret, region string) []apiv1.Secret {
+	if region == "" {
+		return secrets
+	}
+
+	var eligible []apiv1.Secret
+	for _, s := range secrets {
+		if servesRegion(s, region) {
+			eligible = append(eligible, s)
+		}
+	}
+
+	return eligible
+}
+
+func servesRegion(secret apiv1.Secret, region string) bool {
+	restricted := false
+	for label, value := range secret.Labels {
+		if !strings.HasPrefix(label, regionLabelPrefix) {
+			continue
+		}
+		if strings.TrimPrefix(label, regionLabelPrefix) == region && value == "true" {
+			return true
+		}
+		restricted = true
+	}
+
+	return !restricted
+}
+
+// getLeastUsed returns nil if every Secret is used by the maximum number of shoots
+func (sp *SharedAccountPool) getLeastUsed(secrets []apiv1.Secret) (*apiv1.Secret, error) {
 	usageCount := make(map[string]int, len(secrets))
 	for _, s := range secrets {
 		usageCount[s.Name] = 0
@@ -64,31 +117,29 @@ func (sp *SharedAccountPool) getLeastUsed(secrets []apiv1.Secret) (apiv1.Secret,
 
 	shoots, err := sp.shootsClient.List(metav1.ListOptions{})
 	if err != nil {
-		return apiv1.Secret{}, errors.Wrap(err, "error while listing Shoots")
+		return nil, errors.Wrap(err, "error while listing Shoots")
 	}
 
-	if shoots == nil || len(shoots.Items) == 0 {
-		return secrets[0], nil
-	}
+	if shoots != nil {
+		for _, s := range shoots.Items {
+			count, found := usageCount[s.Spec.SecretBindingName]
+			if !found {
+				continue
+			}
 
-	for _, s := range shoots.Items {
-		count, found := usageCount[s.Spec.SecretBindingName]
-		if !found {
-			continue
+			usageCount[s.Spec.SecretBindingName] = count + 1
 		}
-
-		usageCount[s.Spec.SecretBindingName] = count + 1
 	}
 
-	min := usageCount[secrets[0].Name]
-	minIndex := 0
-
+	var leastUsed *apiv1.Secret
 	for i, s := range secrets {
-		if usageCount[s.Name] < min {
-			min = usageCount[s.Name]
-			minIndex = i
+		if sp.maxShootsPerSecret > 0 && usageCount[s.Name] >= sp.maxShootsPerSecret {
+			continue
+		}
+		if leastUsed == nil || usageCount[s.Name] < usageCount[leastUsed.Name] {
+			leastUsed = &secrets[i]
 		}
 	}
 
-	return secrets[minIndex], nil
+	return leastUsed, nil
 }
@@ -24,6 +24,8 @@ func TestSharedPool_SharedCredentials(t *testing.T) {
 		secrets        []runtime.Object
 		shoots         []runtime.Object
 		hyperscaler    Type
+		region         string
+		maxShoots      int
 		expectedSecret string
 	}{
 		{
@@ -103,6 +105,32 @@ func TestSharedPool_SharedCredentials(t *testing.T) {
 			hyperscaler:    "aws",
 			expectedSecret: "s1",
 		},
+		{
+			description: "should get least used Secret serving the region",
+			secrets: []runtime.Object{
+				newSecret("s1", "azure", true, "westeurope"),
+				newSecret("s2", "azure", true, "eastus"),
+				newSecret("s3", "azure", true, "westeurope", "eastus"),
+			},
+			shoots: []runtime.Object{
+				newShoot("sh1", "s1"),
+				newShoot("sh2", "s3"),
+				newShoot("sh3", "s3"),
+			},
+			hyperscaler:    "azure",
+			region:         "westeurope",
+			expectedSecret: "s1",
+		},
+		{
+			description: "should treat Secret without region labels as serving all regions",
+			secrets: []runtime.Object{
+				newSecret("s1", "gcp", true, "us-east1"),
+				newSecret("s2", "gcp", true),
+			},
+			hyperscaler:    "gcp",
+			region:         "europe-west4",
+			expectedSecret: "s2",
+		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			// given
@@ -113,10 +141,10 @@ func TestSharedPool_SharedCredentials(t *testing.T) {
 			gardenerFake := gardener_fake.NewSimpleClientset(testCase.shoots...)
 			mockShoots := gardenerFake.CoreV1beta1().Shoots(testNamespace)
 
-			pool := NewSharedGardenerAccountPool(mockSecrets, mockShoots)
+			pool := NewSharedGardenerAccountPool(mockSecrets, mockShoots, SharedPoolConfig{MaxShootsPerSecret: testCase.maxShoots})
 
 			// when
-			credentials, err := pool.SharedCredentials(testCase.hyperscaler)
+			credentials, err := pool.SharedCredentials(testCase.hyperscaler, testCase.region)
 			require.NoError(t, err)
 
 			// then
@@ -134,18 +162,58 @@ func TestSharedPool_SharedCredentials_Errors(t *testing.T) {
 		)
 		mockSecrets := mockClient.CoreV1().Secrets(testNamespace)
 
-		pool := NewSharedGardenerAccountPool(mockSecrets, nil)
+		pool := NewSharedGardenerAccountPool(mockSecrets, nil, SharedPoolConfig{})
 
 		// when
-		_, err := pool.SharedCredentials("gcp")
+		_, err := pool.SharedCredentials("gcp", "")
 
 		// then
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "no shared Secret found")
 	})
+
+	t.Run("should return error when no Secret serves the region", func(t *testing.T) {
+		mockClient := fake.NewSimpleClientset(
+			newSecret("s1", "azure", true, "westeurope"),
+		)
+		mockSecrets := mockClient.CoreV1().Secrets(testNamespace)
+
+		pool := NewSharedGardenerAccountPool(mockSecrets, nil, SharedPoolConfig{})
+
+		// when
+		_, err := pool.SharedCredentials("azure", "eastus")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no shared Secret for hyperscalerType azure serves region eastus")
+	})
+
+	t.Run("should return error when all Secrets are used by the maximum number of shoots", func(t *testing.T) {
+		mockClient := fake.NewSimpleClientset(
+			newSecret("s1", "gcp", true),
+			newSecret("s2", "gcp", true),
+		)
+		mockSecrets := mockClient.CoreV1().Secrets(testNamespace)
+
+		gardenerFake := gardener_fake.NewSimpleClientset(
+			newShoot("sh1", "s1"),
+			newShoot("sh2", "s2"),
+			newShoot("sh3", "s2"),
+		)
+		mockShoots := gardenerFake.CoreV1beta1().Shoots(testNamespace)
+
+		pool := NewSharedGardenerAccountPool(mockSecrets, mockShoots, SharedPoolConfig{MaxShootsPerSecret: 1})
+
+		// when
+		_, err := pool.SharedCredentials("gcp", "europe-west4")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "all 2 shared Secrets for hyperscalerType gcp serving region europe-west4 are used by the maximum of 1 shoots")
+	})
 }
 
-func newSecret(name, hyperscaler string, shared bool) *corev1.Secret {
+func newSecret(name, hyperscaler string, shared bool, regions ...string) *corev1.Secret {
 	secret := &corev1.Secret{
 		ObjectMeta: machineryv1.ObjectMeta{
 			Name: name, Namespace: testNamespace,
@@ -161,6 +229,9 @@ func newSecret(name, hyperscaler string, shared bool) *corev1.Secret {
 	if shared {
 		secret.Labels["shared"] = "true"
 	}
+	for _, region := range regions {
+		secret.Labels[regionLabelPrefix+region] = "true"
+	}
 
 	return secret
 }
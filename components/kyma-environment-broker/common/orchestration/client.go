@@ -20,12 +20,14 @@ const defaultPageSize = 100
 
 // Client is the interface to interact with the KEB /orchestrations and /upgrade API
 // as an HTTP client using OIDC ID token in JWT format.
+// The CLI keeps a copy of the client in tools/cli/pkg/keb/orchestration, change both together.
 type Client interface {
 	ListOrchestrations(params ListParameters) (StatusResponseList, error)
 	GetOrchestration(orchestrationID string) (StatusResponse, error)
 	ListOperations(orchestrationID string, params ListParameters) (OperationResponseList, error)
 	GetOperation(orchestrationID, operationID string) (OperationDetailResponse, error)
 	UpgradeKyma(params Parameters) (UpgradeResponse, error)
+	RetryOrchestration(orchestrationID string) (UpgradeResponse, error)
 }
 
 type client struct {
@@ -51,6 +53,7 @@ func (c client) ListOrchestrations(params ListParameters) (StatusResponseList, e
 	orchestrations := StatusResponseList{}
 	getAll := false
 	fetchedAll := false
+	cursor := ""
 	if params.Page == 0 || params.PageSize == 0 {
 		getAll = true
 		params.Page = 1
@@ -64,7 +67,7 @@ func (c client) ListOrchestrations(params ListParameters) (StatusResponseList, e
 		if err != nil {
 			return orchestrations, errors.Wrap(err, "while creating request")
 		}
-		setQuery(req.URL, params)
+		setQuery(req.URL, params, cursor)
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
@@ -99,7 +102,7 @@ func (c client) ListOrchestrations(params ListParameters) (StatusResponseList, e
 		orchestrations.Data = append(orchestrations.Data, srl.Data...)
 		if getAll {
 			params.Page++
-			fetchedAll = orchestrations.Count >= orchestrations.TotalCount
+			fetchedAll = nextPageDone(&cursor, srl.NextCursor, orchestrations.Count, orchestrations.TotalCount)
 		} else {
 			fetchedAll = true
 		}
@@ -149,6 +152,7 @@ func (c client) ListOperations(orchestrationID string, params ListParameters) (O
 	url := fmt.Sprintf("%s/orchestrations/%s/operations", c.url, orchestrationID)
 	getAll := false
 	fetchedAll := false
+	cursor := ""
 	if params.Page == 0 || params.PageSize == 0 {
 		getAll = true
 		params.Page = 1
@@ -162,7 +166,7 @@ func (c client) ListOperations(orchestrationID string, params ListParameters) (O
 		if err != nil {
 			return operations, errors.Wrap(err, "while creating request")
 		}
-		setQuery(req.URL, params)
+		setQuery(req.URL, params, cursor)
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
@@ -197,7 +201,7 @@ func (c client) ListOperations(orchestrationID string, params ListParameters) (O
 		operations.Data = append(operations.Data, orl.Data...)
 		if getAll {
 			params.Page++
-			fetchedAll = operations.Count >= operations.TotalCount
+			fetchedAll = nextPageDone(&cursor, orl.NextCursor, operations.Count, operations.TotalCount)
 		} else {
 			fetchedAll = true
 		}
@@ -279,9 +283,58 @@ func (c client) UpgradeKyma(params Parameters) (UpgradeResponse, error) {
 	return ur, nil
 }
 
-func setQuery(url *url.URL, params ListParameters) {
+// RetryOrchestration sends request to KEB to retry the failed operations of the given finished orchestration.
+// The retried operations are processed by a new orchestration, whose ID is returned in the response.
+func (c client) RetryOrchestration(orchestrationID string) (UpgradeResponse, error) {
+	ur := UpgradeResponse{}
+	url := fmt.Sprintf("%s/orchestrations/%s/retry", c.url, orchestrationID)
+	resp, err := c.httpClient.Post(url, "application/json", nil)
+	if err != nil {
+		return ur, errors.Wrapf(err, "while calling %s", url)
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return ur, fmt.Errorf("calling %s returned %s status", url, resp.Status)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&ur)
+	if err != nil {
+		return ur, errors.Wrap(err, "while decoding response body")
+	}
+
+	return ur, nil
+}
+
+// nextPageDone stores the cursor of the next page and returns true if all items were fetched.
+// Brokers which do not return the cursor are paged by the page number.
+func nextPageDone(cursor *string, nextCursor string, count, totalCount int) bool {
+	if nextCursor != "" {
+		*cursor = nextCursor
+		return false
+	}
+	return *cursor != "" || count >= totalCount
+}
+
+func setQuery(url *url.URL, params ListParameters, cursor string) {
 	query := url.Query()
-	query.Add(pagination.PageParam, strconv.Itoa(params.Page))
+	if cursor != "" {
+		query.Add(pagination.CursorParam, cursor)
+	} else {
+		query.Add(pagination.PageParam, strconv.Itoa(params.Page))
+	}
 	query.Add(pagination.PageSizeParam, strconv.Itoa(params.PageSize))
 	setParamList(query, StateParam, params.States)
 	url.RawQuery = query.Encode()
@@ -226,6 +226,33 @@ func TestClient_UpgradeKyma(t *testing.T) {
 	})
 }
 
+func TestClient_RetryOrchestration(t *testing.T) {
+	t.Run("test_URL_NoError_path", func(t *testing.T) {
+		// given
+		called := 0
+		retryID := orch2.OrchestrationID
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, fmt.Sprintf("/orchestrations/%s/retry", orch1.OrchestrationID), r.URL.Path)
+			assert.Equal(t, fmt.Sprintf("Bearer %s", fixToken), r.Header.Get("Authorization"))
+
+			err := respondUpgrade(w, retryID)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		// when
+		ur, err := client.RetryOrchestration(orch1.OrchestrationID)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, called)
+		assert.Equal(t, retryID, ur.OrchestrationID)
+	})
+}
+
 func fixStatusResponse(id string) StatusResponse {
 	return StatusResponse{
 		OrchestrationID: id,
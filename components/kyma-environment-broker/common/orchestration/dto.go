@@ -11,6 +11,31 @@ type Parameters struct {
 	Targets  TargetSpec   `json:"targets"`
 	Strategy StrategySpec `json:"strategy,omitempty"`
 	DryRun   bool         `json:"dryRun,omitempty"`
+	// StartTime delays the orchestration until the given time, the orchestration starts immediately if not set
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// Recurrence is a cron expression, e.g. "0 3 * * 0", evaluated in UTC. When the orchestration finishes,
+	// its next run is scheduled as a new orchestration with the same parameters and the targets resolved again.
+	Recurrence string `json:"recurrence,omitempty"`
+	// Actions is the ordered list of actions performed on each runtime by a composite orchestration,
+	// empty for the Kyma upgrade orchestration
+	Actions []ActionType `json:"actions,omitempty"`
+	// KubernetesVersion is the target version of the cluster upgrade action, the default version is used if not set
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+}
+
+// ActionType is the type of the action performed on a runtime by a composite orchestration
+type ActionType string
+
+const (
+	UpgradeClusterAction ActionType = "upgradeCluster"
+	UpgradeKymaAction    ActionType = "upgradeKyma"
+)
+
+// ActionStatus is the progress of a single action of the runtime operation
+type ActionStatus struct {
+	Type        ActionType `json:"type"`
+	State       string     `json:"state"`
+	Description string     `json:"description,omitempty"`
 }
 
 const (
@@ -26,6 +51,9 @@ const (
 	Failed     = "failed"
 )
 
+// Canceled is the state of the composite orchestration action skipped due to a failure of the preceding action
+const Canceled = "canceled"
+
 // ListParameters hold attributes of list orchestrations / operations queries.
 type ListParameters struct {
 	Page     int
@@ -51,6 +79,8 @@ type RuntimeTarget struct {
 	RuntimeID string `json:"runtimeID,omitempty"`
 	// PlanName is used to match runtimes with the same plan
 	PlanName string `json:"planName,omitempty"`
+	// Labels is used to match runtimes having all of the given labels set in KEB, e.g. {"customer-pilot": "true"}
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 type StrategyType string
@@ -66,9 +96,26 @@ const (
 	MaintenanceWindow ScheduleType = "maintenanceWindow"
 )
 
+// LimitKey is the runtime attribute by which the operations are grouped for a concurrency limit
+type LimitKey string
+
+const (
+	LimitByRegion        LimitKey = "region"
+	LimitByGlobalAccount LimitKey = "globalAccount"
+	LimitBySeed          LimitKey = "seed"
+)
+
+// ConcurrencyLimit defines the maximum number of operations executed at the same time for runtimes sharing the same value of the given key,
+// e.g. at most 2 operations for each region
+type ConcurrencyLimit struct {
+	By  LimitKey `json:"by"`
+	Max int      `json:"max"`
+}
+
 // ParallelStrategySpec defines parameters for the parallel orchestration strategy
 type ParallelStrategySpec struct {
-	Workers int `json:"workers"`
+	Workers int                `json:"workers"`
+	Limits  []ConcurrencyLimit `json:"limits,omitempty"`
 }
 
 // StrategySpec is the strategy part common for all orchestration trigger/status API
@@ -85,12 +132,13 @@ type TargetSpec struct {
 }
 
 type StatusResponse struct {
-	OrchestrationID string     `json:"orchestrationID"`
-	State           string     `json:"state"`
-	Description     string     `json:"description"`
-	CreatedAt       time.Time  `json:"createdAt"`
-	UpdatedAt       time.Time  `json:"updatedAt"`
-	Parameters      Parameters `json:"parameters"`
+	OrchestrationID       string     `json:"orchestrationID"`
+	State                 string     `json:"state"`
+	Description           string     `json:"description"`
+	CreatedAt             time.Time  `json:"createdAt"`
+	UpdatedAt             time.Time  `json:"updatedAt"`
+	Parameters            Parameters `json:"parameters"`
+	ParentOrchestrationID string     `json:"parentOrchestrationID,omitempty"`
 }
 
 type OperationResponse struct {
@@ -107,12 +155,16 @@ type OperationResponse struct {
 	MaintenanceWindowEnd   time.Time `json:"maintenanceWindowEnd"`
 	State                  string    `json:"state"`
 	Description            string    `json:"description"`
+	// Actions is the progress of each action of the composite orchestration
+	Actions []ActionStatus `json:"actions,omitempty"`
 }
 
 type OperationResponseList struct {
 	Data       []OperationResponse `json:"data"`
 	Count      int                 `json:"count"`
 	TotalCount int                 `json:"totalCount"`
+	// NextCursor fetches the following page, empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type OperationDetailResponse struct {
@@ -126,6 +178,8 @@ type StatusResponseList struct {
 	Data       []StatusResponse `json:"data"`
 	Count      int              `json:"count"`
 	TotalCount int              `json:"totalCount"`
+	// NextCursor fetches the following page, empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type UpgradeResponse struct {
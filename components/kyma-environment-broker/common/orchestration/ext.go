@@ -12,6 +12,10 @@ type Runtime struct {
 	SubAccountID    string `json:"subaccountId"`
 	// The corresponding shoot cluster's .metadata.name value
 	ShootName string `json:"shootName"`
+	// The corresponding shoot cluster's .spec.region value
+	Region string `json:"region,omitempty"`
+	// The corresponding shoot cluster's .spec.seedName value
+	Seed string `json:"seed,omitempty"`
 	// The corresponding shoot cluster's .spec.maintenance.timeWindow.Begin value, which is in in "HHMMSS+[HHMM TZ]" format, e.g. "040000+0000"
 	MaintenanceWindowBegin time.Time `json:"maintenanceWindowBegin"`
 	// The corresponding shoot cluster's .spec.maintenance.timeWindow.End value, which is in "HHMMSS+[HHMM TZ]" format, e.g. "040000+0000"
@@ -23,6 +27,8 @@ type RuntimeOperation struct {
 	Runtime `json:""`
 	ID      string `json:"-"`
 	DryRun  bool   `json:"dryRun"`
+	// Dispatched is set when the strategy handed over the operation to the executor for the first time
+	Dispatched bool `json:"dispatched,omitempty"`
 }
 
 //go:generate mockery --name=RuntimeResolver --output=automock --outpkg=automock --case=underscore
@@ -40,3 +46,10 @@ type Strategy interface {
 	// Wait blocks and waits until the execution with the given ID is finished.
 	Wait(executionID string)
 }
+
+// DispatchRecorder persists the scheduling progress of the runtime operations executed by a strategy,
+// so that an interrupted orchestration can be continued without dispatching the operations from scratch.
+type DispatchRecorder interface {
+	// MarkDispatched stores that the operation with the given ID was handed over to the executor
+	MarkDispatched(operationID string) error
+}
@@ -172,7 +172,7 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 		// Match exact shoot by runtimeID
 		if rt.RuntimeID != "" {
 			if rt.RuntimeID == runtimeID {
-				runtimes = append(runtimes, resolver.runtimeFromDTO(runtime, shoot.Name, maintenanceWindowBegin, maintenanceWindowEnd))
+				runtimes = append(runtimes, resolver.runtimeFromDTO(runtime, shoot, maintenanceWindowBegin, maintenanceWindowEnd))
 			}
 			continue
 		}
@@ -184,6 +184,11 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 			}
 		}
 
+		// Perform match against the runtime labels
+		if !hasLabels(runtime.Labels, rt.Labels) {
+			continue
+		}
+
 		// Perform match against GlobalAccount regexp
 		if rt.GlobalAccount != "" {
 			matched, err := regexp.MatchString(rt.GlobalAccount, shoot.Labels[globalAccountLabel])
@@ -213,19 +218,36 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 			continue
 		}
 
-		runtimes = append(runtimes, resolver.runtimeFromDTO(runtime, shoot.Name, maintenanceWindowBegin, maintenanceWindowEnd))
+		runtimes = append(runtimes, resolver.runtimeFromDTO(runtime, shoot, maintenanceWindowBegin, maintenanceWindowEnd))
 	}
 
 	return runtimes, nil
 }
 
-func (*GardenerRuntimeResolver) runtimeFromDTO(runtime runtime.RuntimeDTO, shootName string, windowBegin, windowEnd time.Time) Runtime {
+// hasLabels returns true if the labels contain all of the selector labels
+func hasLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (*GardenerRuntimeResolver) runtimeFromDTO(runtime runtime.RuntimeDTO, shoot gardenerapi.Shoot, windowBegin, windowEnd time.Time) Runtime {
+	seed := ""
+	if shoot.Spec.SeedName != nil {
+		seed = *shoot.Spec.SeedName
+	}
+
 	return Runtime{
 		InstanceID:             runtime.InstanceID,
 		RuntimeID:              runtime.RuntimeID,
 		GlobalAccountID:        runtime.GlobalAccountID,
 		SubAccountID:           runtime.SubAccountID,
-		ShootName:              shootName,
+		ShootName:              shoot.Name,
+		Region:                 shoot.Spec.Region,
+		Seed:                   seed,
 		MaintenanceWindowBegin: windowBegin,
 		MaintenanceWindowEnd:   windowEnd,
 	}
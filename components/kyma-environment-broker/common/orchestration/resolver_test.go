@@ -36,6 +36,8 @@ const (
 	plan2 = "gcp"
 )
 
+var seedName = "az-eu1"
+
 func TestResolver_Resolve(t *testing.T) {
 	client := newFakeGardenerClient()
 	lister := newRuntimeListerMock()
@@ -158,6 +160,32 @@ func TestResolver_Resolve(t *testing.T) {
 			},
 			ExpectedRuntimes: []expectedRuntime{expectedRuntime2, expectedRuntime3},
 		},
+		"IncludeLabels": {
+			Target: TargetSpec{
+				Include: []RuntimeTarget{
+					{
+						Labels: map[string]string{"customer-pilot": "true"},
+					},
+				},
+				Exclude: nil,
+			},
+			ExpectedRuntimes: []expectedRuntime{expectedRuntime3},
+		},
+		"IncludeAllExcludeLabels": {
+			Target: TargetSpec{
+				Include: []RuntimeTarget{
+					{
+						Target: TargetAll,
+					},
+				},
+				Exclude: []RuntimeTarget{
+					{
+						Labels: map[string]string{"customer-pilot": "true"},
+					},
+				},
+			},
+			ExpectedRuntimes: []expectedRuntime{expectedRuntime1, expectedRuntime2},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			// when
@@ -238,7 +266,7 @@ var (
 	shoot4               = fixShoot(4, globalAccountID3, region1)
 	runtime1             = fixRuntimeDTO(1, globalAccountID1, string(brokerapi.Succeeded), "", plan2)
 	runtime2             = fixRuntimeDTO(2, globalAccountID1, string(brokerapi.Succeeded), "", plan1)
-	runtime3             = fixRuntimeDTO(3, globalAccountID2, string(brokerapi.Succeeded), "", plan1)
+	runtime3             = withLabels(fixRuntimeDTO(3, globalAccountID2, string(brokerapi.Succeeded), "", plan1), map[string]string{"customer-pilot": "true"})
 	runtime4             = fixRuntimeDTO(4, globalAccountID3, string(brokerapi.Succeeded), string(brokerapi.InProgress), plan1)
 	runtime5Failed       = fixRuntimeDTO(5, globalAccountID3, string(brokerapi.Failed), "", plan1)
 	runtime6Provisioning = fixRuntimeDTO(6, globalAccountID3, string(brokerapi.InProgress), "", plan2)
@@ -259,7 +287,8 @@ func fixShoot(id int, globalAccountID, region string) gardenerapi.Shoot {
 			},
 		},
 		Spec: gardenerapi.ShootSpec{
-			Region: region,
+			Region:   region,
+			SeedName: &seedName,
 			Maintenance: &gardenerapi.Maintenance{
 				TimeWindow: &gardenerapi.MaintenanceTimeWindow{
 					Begin: "030000+0000",
@@ -290,6 +319,11 @@ func fixRuntimeDTO(id int, globalAccountID, provState, deprovState, planName str
 	return rt
 }
 
+func withLabels(rt runtime.RuntimeDTO, labels map[string]string) runtime.RuntimeDTO {
+	rt.Labels = labels
+	return rt
+}
+
 type expectedRuntime struct {
 	shoot   *gardenerapi.Shoot
 	runtime *runtime.RuntimeDTO
@@ -360,6 +394,8 @@ func assertRuntimeTargets(t *testing.T, expectedRuntimes []expectedRuntime, runt
 		assert.Equal(t, e.runtime.GlobalAccountID, r.GlobalAccountID)
 		assert.Equal(t, e.runtime.SubAccountID, r.SubAccountID)
 		assert.Equal(t, e.shoot.Name, r.ShootName)
+		assert.Equal(t, e.shoot.Spec.Region, r.Region)
+		assert.Equal(t, *e.shoot.Spec.SeedName, r.Seed)
 		assert.Equal(t, e.shoot.Spec.Maintenance.TimeWindow.Begin, r.MaintenanceWindowBegin.Format(maintenanceWindowFormat))
 		assert.Equal(t, e.shoot.Spec.Maintenance.TimeWindow.End, r.MaintenanceWindowEnd.Format(maintenanceWindowFormat))
 	}
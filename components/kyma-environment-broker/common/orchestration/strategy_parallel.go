@@ -1,6 +1,7 @@
 package orchestration
 
 import (
+	"fmt"
 	"sort"
 	"sync"
 	"time"
@@ -14,20 +15,31 @@ type Executor interface {
 	Execute(operationID string) (time.Duration, error)
 }
 
+const defaultRetryBackoff = 30 * time.Second
+
 type ParallelOrchestrationStrategy struct {
 	executor Executor
+	recorder DispatchRecorder
 	log      logrus.FieldLogger
 	wg       map[string]*sync.WaitGroup
 	mux      sync.RWMutex
+
+	// retryBackoff is the time after which an operation deferred by a concurrency limit
+	// or by a failure of recording its dispatch is retried
+	retryBackoff time.Duration
 }
 
 // NewParallelOrchestrationStrategy returns a new parallel orchestration strategy, which
 // executes operations in parallel using a pool of workers and a delaying queue to support time-based scheduling.
-func NewParallelOrchestrationStrategy(executor Executor, log logrus.FieldLogger) Strategy {
+// Each operation is recorded as dispatched before its first execution. Operations already dispatched
+// before, e.g. by an execution interrupted by a restart, are resumed immediately regardless of the schedule.
+func NewParallelOrchestrationStrategy(executor Executor, recorder DispatchRecorder, log logrus.FieldLogger) Strategy {
 	return &ParallelOrchestrationStrategy{
-		executor: executor,
-		log:      log,
-		wg:       map[string]*sync.WaitGroup{},
+		executor:     executor,
+		recorder:     recorder,
+		log:          log,
+		wg:           map[string]*sync.WaitGroup{},
+		retryBackoff: defaultRetryBackoff,
 	}
 }
 
@@ -49,9 +61,12 @@ func (p *ParallelOrchestrationStrategy) Execute(operations []RuntimeOperation, s
 		})
 	}
 
+	limiter := newBucketLimiter(operations, strategySpec.Parallel.Limits)
+	dispatcher := newDispatcher(operations, p.recorder)
+
 	// Create workers
 	for i := 0; i < strategySpec.Parallel.Workers; i++ {
-		p.createWorker(execID, ops, dq, strategySpec)
+		p.createWorker(execID, ops, dq, limiter, dispatcher, strategySpec)
 	}
 
 	// Send operations to workers
@@ -72,11 +87,11 @@ func (p *ParallelOrchestrationStrategy) Wait(executionID string) {
 	}
 }
 
-func (p *ParallelOrchestrationStrategy) createWorker(execID string, ops <-chan RuntimeOperation, dq workqueue.DelayingInterface, strategy StrategySpec) {
+func (p *ParallelOrchestrationStrategy) createWorker(execID string, ops <-chan RuntimeOperation, dq workqueue.DelayingInterface, limiter *bucketLimiter, dispatcher *dispatcher, strategy StrategySpec) {
 	p.wg[execID].Add(1)
 	go func() {
 		for op := range ops {
-			p.processOperation(op, dq, strategy)
+			p.processOperation(op, dq, limiter, dispatcher, strategy)
 		}
 		p.mux.RLock()
 		p.wg[execID].Done()
@@ -84,17 +99,20 @@ func (p *ParallelOrchestrationStrategy) createWorker(execID string, ops <-chan R
 	}()
 }
 
-func (p *ParallelOrchestrationStrategy) processOperation(op RuntimeOperation, dq workqueue.DelayingInterface, strategy StrategySpec) {
+func (p *ParallelOrchestrationStrategy) processOperation(op RuntimeOperation, dq workqueue.DelayingInterface, limiter *bucketLimiter, dispatcher *dispatcher, strategy StrategySpec) {
 	exit := false
 	id := op.ID
 	log := p.log.WithField("operationID", id)
 
-	switch strategy.Schedule {
-	case MaintenanceWindow:
+	switch {
+	case op.Dispatched:
+		log.Infof("Upgrade operation was dispatched before, resuming it now")
+		dq.Add(id)
+	case strategy.Schedule == MaintenanceWindow:
 		until := time.Until(op.MaintenanceWindowBegin)
 		log.Infof("Upgrade operation will be scheduled in %v", until)
 		dq.AddAfter(id, until)
-	case Immediate:
+	case strategy.Schedule == Immediate:
 		log.Infof("Upgrade operation is scheduled now")
 		dq.Add(id)
 	}
@@ -114,12 +132,25 @@ func (p *ParallelOrchestrationStrategy) processOperation(op RuntimeOperation, dq
 				dq.Done(key)
 			}()
 
+			if !limiter.acquire(id) {
+				log.Infof("Concurrency limit reached, deferring %q item by %s", id, p.retryBackoff)
+				dq.AddAfter(key, p.retryBackoff)
+				return false
+			}
+			if err := dispatcher.dispatch(id); err != nil {
+				log.Errorf("while recording dispatch of %q item, deferring it by %s: %v", id, p.retryBackoff, err)
+				limiter.release(id)
+				dq.AddAfter(key, p.retryBackoff)
+				return false
+			}
+
 			when, err := p.executor.Execute(id)
 			if err == nil && when != 0 {
 				log.Infof("Adding %q item after %s", id, when)
 				dq.AddAfter(key, when)
 				return false
 			}
+			limiter.release(id)
 			if err != nil {
 				log.Errorf("Error from process: %v", err)
 			}
@@ -128,3 +159,122 @@ func (p *ParallelOrchestrationStrategy) processOperation(op RuntimeOperation, dq
 		}()
 	}
 }
+
+// dispatcher records the operations handed over to the executor for the first time
+type dispatcher struct {
+	recorder   DispatchRecorder
+	dispatched map[string]bool
+	mux        sync.Mutex
+}
+
+func newDispatcher(operations []RuntimeOperation, recorder DispatchRecorder) *dispatcher {
+	d := &dispatcher{
+		recorder:   recorder,
+		dispatched: map[string]bool{},
+	}
+	for _, op := range operations {
+		d.dispatched[op.ID] = op.Dispatched
+	}
+
+	return d
+}
+
+func (d *dispatcher) dispatch(id string) error {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	if d.dispatched[id] || d.recorder == nil {
+		return nil
+	}
+	if err := d.recorder.MarkDispatched(id); err != nil {
+		return err
+	}
+	d.dispatched[id] = true
+
+	return nil
+}
+
+// bucketLimiter limits the number of operations executed at the same time for runtimes which share
+// the same region, global account or seed. An operation occupies a slot in each of its buckets
+// from its first execution until it is finished.
+type bucketLimiter struct {
+	limits  []ConcurrencyLimit
+	buckets map[string][]string
+	running map[string]int
+	holding map[string]bool
+	mux     sync.Mutex
+}
+
+func newBucketLimiter(operations []RuntimeOperation, limits []ConcurrencyLimit) *bucketLimiter {
+	l := &bucketLimiter{
+		limits:  limits,
+		buckets: map[string][]string{},
+		running: map[string]int{},
+		holding: map[string]bool{},
+	}
+	for _, op := range operations {
+		l.buckets[op.ID] = l.bucketsFor(op.Runtime)
+	}
+
+	return l
+}
+
+// acquire returns true if the operation with the given ID may be executed
+func (l *bucketLimiter) acquire(id string) bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if l.holding[id] {
+		return true
+	}
+	for i, bucket := range l.buckets[id] {
+		if bucket != "" && l.running[bucket] >= l.limits[i].Max {
+			return false
+		}
+	}
+	for _, bucket := range l.buckets[id] {
+		if bucket != "" {
+			l.running[bucket]++
+		}
+	}
+	l.holding[id] = true
+
+	return true
+}
+
+// release frees the slots held by the finished operation with the given ID
+func (l *bucketLimiter) release(id string) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if !l.holding[id] {
+		return
+	}
+	for _, bucket := range l.buckets[id] {
+		if bucket != "" {
+			l.running[bucket]--
+		}
+	}
+	delete(l.holding, id)
+}
+
+// bucketsFor returns the bucket of the runtime for each limit, empty if the limit does not apply to the runtime
+func (l *bucketLimiter) bucketsFor(runtime Runtime) []string {
+	buckets := make([]string, len(l.limits))
+	for i, limit := range l.limits {
+		value := ""
+		switch limit.By {
+		case LimitByRegion:
+			value = runtime.Region
+		case LimitByGlobalAccount:
+			value = runtime.GlobalAccountID
+		case LimitBySeed:
+			value = runtime.Seed
+		}
+		if value != "" && limit.Max > 0 {
+			buckets[i] = fmt.Sprintf("%s/%s", limit.By, value)
+		}
+	}
+
+	return buckets
+}
@@ -32,7 +32,7 @@ func (t *testExecutor) Execute(opID string) (time.Duration, error) {
 func TestNewParallelOrchestrationStrategy_Immediate(t *testing.T) {
 	// given
 	executor := &testExecutor{opCalled: map[string]bool{}}
-	s := NewParallelOrchestrationStrategy(executor, logrus.New())
+	s := NewParallelOrchestrationStrategy(executor, nil, logrus.New())
 
 	ops := make([]RuntimeOperation, 3)
 	for i := range ops {
@@ -52,7 +52,7 @@ func TestNewParallelOrchestrationStrategy_Immediate(t *testing.T) {
 func TestNewParallelOrchestrationStrategy_MaintenanceWindow(t *testing.T) {
 	// given
 	executor := &testExecutor{opCalled: map[string]bool{}}
-	s := NewParallelOrchestrationStrategy(executor, logrus.New())
+	s := NewParallelOrchestrationStrategy(executor, nil, logrus.New())
 
 	start := time.Now().Add(5 * time.Second)
 
@@ -71,3 +71,106 @@ func TestNewParallelOrchestrationStrategy_MaintenanceWindow(t *testing.T) {
 	assert.NoError(t, err)
 	s.Wait(id)
 }
+
+type limitTestExecutor struct {
+	mux        sync.Mutex
+	opRegion   map[string]string
+	opCalled   map[string]bool
+	running    map[string]int
+	maxRunning map[string]int
+}
+
+func (t *limitTestExecutor) Execute(opID string) (time.Duration, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	region := t.opRegion[opID]
+	if t.opCalled[opID] {
+		t.running[region]--
+		return 0, nil
+	}
+	t.opCalled[opID] = true
+	t.running[region]++
+	if t.running[region] > t.maxRunning[region] {
+		t.maxRunning[region] = t.running[region]
+	}
+
+	return 500 * time.Millisecond, nil
+}
+
+func TestNewParallelOrchestrationStrategy_ConcurrencyLimits(t *testing.T) {
+	// given
+	executor := &limitTestExecutor{
+		opRegion:   map[string]string{},
+		opCalled:   map[string]bool{},
+		running:    map[string]int{},
+		maxRunning: map[string]int{},
+	}
+	s := NewParallelOrchestrationStrategy(executor, nil, logrus.New())
+	s.(*ParallelOrchestrationStrategy).retryBackoff = 100 * time.Millisecond
+
+	regions := []string{"westeurope", "westeurope", "westeurope", "centralus"}
+	ops := make([]RuntimeOperation, len(regions))
+	for i, region := range regions {
+		ops[i] = RuntimeOperation{
+			ID:      rand.String(5),
+			Runtime: Runtime{Region: region},
+		}
+		executor.opRegion[ops[i].ID] = region
+	}
+
+	// when
+	id, err := s.Execute(ops, StrategySpec{
+		Schedule: Immediate,
+		Parallel: ParallelStrategySpec{
+			Workers: 4,
+			Limits:  []ConcurrencyLimit{{By: LimitByRegion, Max: 1}},
+		},
+	})
+
+	// then
+	assert.NoError(t, err)
+	s.Wait(id)
+	assert.Len(t, executor.opCalled, len(ops))
+	assert.Equal(t, 1, executor.maxRunning["westeurope"])
+	assert.Equal(t, 1, executor.maxRunning["centralus"])
+}
+
+type testRecorder struct {
+	mux        sync.Mutex
+	dispatched map[string]int
+}
+
+func (r *testRecorder) MarkDispatched(operationID string) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.dispatched[operationID]++
+	return nil
+}
+
+func TestNewParallelOrchestrationStrategy_ResumeDispatched(t *testing.T) {
+	// given
+	executor := &testExecutor{opCalled: map[string]bool{}}
+	recorder := &testRecorder{dispatched: map[string]int{}}
+	s := NewParallelOrchestrationStrategy(executor, recorder, logrus.New())
+
+	dispatchedOp := RuntimeOperation{
+		ID:         rand.String(5),
+		Runtime:    Runtime{MaintenanceWindowBegin: time.Now().Add(time.Hour)},
+		Dispatched: true,
+	}
+	newOp := RuntimeOperation{
+		ID:      rand.String(5),
+		Runtime: Runtime{MaintenanceWindowBegin: time.Now()},
+	}
+
+	// when
+	id, err := s.Execute([]RuntimeOperation{dispatchedOp, newOp}, StrategySpec{Schedule: MaintenanceWindow, Parallel: ParallelStrategySpec{Workers: 2}})
+
+	// then
+	assert.NoError(t, err)
+	s.Wait(id)
+	assert.True(t, executor.opCalled[dispatchedOp.ID])
+	assert.True(t, executor.opCalled[newOp.ID])
+	assert.Equal(t, map[string]int{newOp.ID: 1}, recorder.dispatched)
+}
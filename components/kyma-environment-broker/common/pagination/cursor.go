@@ -0,0 +1,89 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const CursorParam = "cursor"
+
+// Cursor points at the last item of a page in the keyset pagination. The next page starts right after it,
+// so the items inserted in the meantime do not make the following pages skip or repeat items.
+type Cursor struct {
+	// Time is the value of the column the items are ordered by, the creation time by default
+	Time time.Time `json:"t"`
+	// ID breaks the ties between the items with the same Time
+	ID string `json:"id"`
+}
+
+// NewCursor returns the cursor pointing at the item with the given ordering values
+func NewCursor(t time.Time, id string) *Cursor {
+	return &Cursor{Time: t, ID: id}
+}
+
+// Encode returns the opaque string representation of the cursor used in the API
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses the cursor returned by Cursor.Encode
+func DecodeCursor(encoded string) (Cursor, error) {
+	var c Cursor
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return c, errors.New("cursor is malformed")
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return c, errors.New("cursor is malformed")
+	}
+
+	return c, nil
+}
+
+// Precedes returns true if the cursor precedes the item with the given ordering values, so the item belongs to the next pages
+func (c Cursor) Precedes(t time.Time, id string, desc bool) bool {
+	if !t.Equal(c.Time) {
+		return t.After(c.Time) != desc
+	}
+	if id == c.ID {
+		return false
+	}
+	return (id > c.ID) != desc
+}
+
+// ExtractCursorFromRequest returns the cursor given in the request, or nil if the request does not use the cursor.
+// The cursor cannot be used together with the page number.
+func ExtractCursorFromRequest(req *http.Request) (*Cursor, error) {
+	params := req.URL.Query()
+	cursorArr, ok := params[CursorParam]
+	if !ok {
+		return nil, nil
+	}
+	if len(cursorArr) > 1 {
+		return nil, errors.New("cursor has to be one parameter")
+	}
+	if _, ok := params[PageParam]; ok {
+		return nil, errors.New("cursor cannot be used together with page")
+	}
+
+	c, err := DecodeCursor(cursorArr[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// HasNextPage returns true if any items follow the fetched page. When the cursor is used, the page is fetched
+// with one additional item, whose presence means that the next page exists.
+func HasNextPage(cursor *Cursor, pageSize, page, count, totalCount int) bool {
+	if cursor != nil {
+		return count > pageSize
+	}
+	return count > 0 && page*pageSize < totalCount
+}
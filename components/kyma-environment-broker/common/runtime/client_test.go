@@ -1,6 +1,7 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -44,6 +45,12 @@ func TestClient_ListRuntimes(t *testing.T) {
 			RuntimeIDs:       []string{"rid1", "rid2"},
 			Regions:          []string{"region1", "region2"},
 			Shoots:           []string{"shoot1", "shoot2"},
+			Plans:            []string{"azure_lite"},
+			States:           []string{"failed"},
+			OperationTypes:   []string{OperationTypeProvision},
+			KymaVersions:     []string{"1.17.0"},
+			Sort:             SortByModifiedAtDesc,
+			Expand:           []string{ExpandProvisioner},
 		}
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			called++
@@ -59,6 +66,12 @@ func TestClient_ListRuntimes(t *testing.T) {
 			assert.ElementsMatch(t, params.RuntimeIDs, query[RuntimeIDParam])
 			assert.ElementsMatch(t, params.Regions, query[RegionParam])
 			assert.ElementsMatch(t, params.Shoots, query[ShootParam])
+			assert.ElementsMatch(t, params.Plans, query[PlanParam])
+			assert.ElementsMatch(t, params.States, query[StateParam])
+			assert.ElementsMatch(t, params.OperationTypes, query[OperationTypeParam])
+			assert.ElementsMatch(t, params.KymaVersions, query[KymaVersionParam])
+			assert.Equal(t, string(params.Sort), query.Get(SortParam))
+			assert.ElementsMatch(t, params.Expand, query[ExpandParam])
 
 			err := respondRuntimes(w, []RuntimeDTO{runtime1, runtime2}, 2)
 			require.NoError(t, err)
@@ -103,6 +116,194 @@ func TestClient_ListRuntimes(t *testing.T) {
 		assert.Equal(t, 4, rp.TotalCount)
 		assert.Len(t, rp.Data, 4)
 	})
+
+	t.Run("test cursor pagination", func(t *testing.T) {
+		called := 0
+		params := ListParameters{
+			PageSize: 2,
+		}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			query := r.URL.Query()
+
+			rp := RuntimesPage{TotalCount: 3}
+			switch called {
+			case 1:
+				assert.Equal(t, "1", query.Get(pagination.PageParam))
+				rp.Data = []RuntimeDTO{runtime1, runtime2}
+				rp.NextCursor = "next"
+			default:
+				assert.Empty(t, query[pagination.PageParam])
+				assert.Equal(t, "next", query.Get(pagination.CursorParam))
+				rp.Data = []RuntimeDTO{runtime1}
+			}
+			rp.Count = len(rp.Data)
+			err := json.NewEncoder(w).Encode(rp)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		//when
+		rp, err := client.ListRuntimes(params)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 2, called)
+		assert.Equal(t, 3, rp.Count)
+		assert.Len(t, rp.Data, 3)
+	})
+}
+
+func TestClient_ExportRuntimes(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/runtimes/export", r.URL.Path)
+		assert.Equal(t, string(ExportFormatCSV), r.URL.Query().Get(FormatParam))
+		assert.Equal(t, []string{"azure"}, r.URL.Query()[PlanParam])
+		assert.Empty(t, r.URL.Query()[pagination.PageParam])
+
+		w.Header().Set("Content-Type", "text/csv")
+		fmt.Fprint(w, "instanceID,runtimeID\ninstance1,runtime1\n")
+	}))
+	defer ts.Close()
+	client := NewClient(context.TODO(), ts.URL, fixToken)
+
+	// when
+	var out bytes.Buffer
+	err := client.ExportRuntimes(ListParameters{Plans: []string{"azure"}}, ExportFormatCSV, &out)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "instanceID,runtimeID\ninstance1,runtime1\n", out.String())
+}
+
+func TestClient_ListRuntimeStates(t *testing.T) {
+	// given
+	called := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called++
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/runtimes/runtime1/states", r.URL.Path)
+		assert.Equal(t, strconv.Itoa(called), r.URL.Query().Get(pagination.PageParam))
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(RuntimeStatesPage{
+			Data:       []RuntimeStateDTO{{ID: fmt.Sprintf("state%d", called), RuntimeID: "runtime1"}},
+			Count:      1,
+			TotalCount: 2,
+		})
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+	client := NewClient(context.TODO(), ts.URL, fixToken)
+
+	// when
+	sp, err := client.ListRuntimeStates("runtime1")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, called)
+	assert.Equal(t, 2, sp.Count)
+	require.Len(t, sp.Data, 2)
+	assert.Equal(t, "state1", sp.Data[0].ID)
+	assert.Equal(t, "state2", sp.Data[1].ID)
+}
+
+func TestClient_DiffRuntimeStates(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/runtimes/runtime1/states/diff", r.URL.Path)
+		assert.Equal(t, "state1", r.URL.Query().Get(FromStateParam))
+		assert.Empty(t, r.URL.Query()[ToStateParam])
+
+		err := json.NewEncoder(w).Encode(RuntimeStateDiff{
+			From: "state1",
+			To:   "state2",
+			Kyma: []ValueChange{{Name: "version", Change: Modified, From: "1.16.0", To: "1.17.0"}},
+		})
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+	client := NewClient(context.TODO(), ts.URL, fixToken)
+
+	// when
+	diff, err := client.DiffRuntimeStates("runtime1", "state1", "")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "state2", diff.To)
+	assert.Equal(t, []ValueChange{{Name: "version", Change: Modified, From: "1.16.0", To: "1.17.0"}}, diff.Kyma)
+}
+
+func TestClient_WatchOperations(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/events/operations", r.URL.Path)
+		assert.Equal(t, []string{"orchestration1"}, r.URL.Query()[OrchestrationIDParam])
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		for _, id := range []string{"operation1", "operation2"} {
+			data, err := json.Marshal(OperationEvent{OperationID: id, State: "succeeded"})
+			require.NoError(t, err)
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", OperationEventType, data)
+		}
+	}))
+	defer ts.Close()
+	client := NewClient(context.TODO(), ts.URL, fixToken)
+
+	// when
+	var received []string
+	err := client.WatchOperations(context.TODO(), WatchParameters{OrchestrationIDs: []string{"orchestration1"}}, func(ev OperationEvent) error {
+		received = append(received, ev.OperationID)
+		return nil
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"operation1", "operation2"}, received)
+}
+
+func TestClient_Labels(t *testing.T) {
+	// given
+	labels := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/runtimes/runtime1/labels":
+			var set map[string]string
+			err := json.NewDecoder(r.Body).Decode(&set)
+			require.NoError(t, err)
+			for k, v := range set {
+				labels[k] = v
+			}
+		case r.Method == http.MethodDelete && r.URL.Path == "/runtimes/runtime1/labels/team":
+			delete(labels, "team")
+		case r.Method == http.MethodGet && r.URL.Path == "/runtimes/runtime1/labels":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		err := json.NewEncoder(w).Encode(labels)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+	client := NewClient(context.TODO(), ts.URL, fixToken)
+
+	// when
+	set, err := client.SetLabels("runtime1", map[string]string{"team": "a", "pilot": "true"})
+	require.NoError(t, err)
+	deleted, err := client.DeleteLabel("runtime1", "team")
+	require.NoError(t, err)
+	got, err := client.GetLabels("runtime1")
+	require.NoError(t, err)
+	_, err = client.GetLabels("runtime2")
+
+	// then
+	assert.Equal(t, map[string]string{"team": "a", "pilot": "true"}, set)
+	assert.Equal(t, map[string]string{"pilot": "true"}, deleted)
+	assert.Equal(t, map[string]string{"pilot": "true"}, got)
+	assert.Error(t, err)
 }
 
 func fixRuntimeDTO(id string) RuntimeDTO {
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -33,7 +34,7 @@ func NewClient(ctx context.Context, avsConfig Config, log logrus.FieldLogger) (*
 	}, nil
 }
 
-func (c *Client) CreateEvaluation(evaluationRequest *BasicEvaluationCreateRequest) (_ *BasicEvaluationCreateResponse, err error) {
+func (c *Client) CreateEvaluation(ctx context.Context, evaluationRequest *BasicEvaluationCreateRequest) (_ *BasicEvaluationCreateResponse, err error) {
 	var responseObject BasicEvaluationCreateResponse
 
 	objAsBytes, err := json.Marshal(evaluationRequest)
@@ -41,7 +42,7 @@ func (c *Client) CreateEvaluation(evaluationRequest *BasicEvaluationCreateReques
 		return &responseObject, errors.Wrap(err, "while marshaling evaluation request")
 	}
 
-	request, err := http.NewRequest(http.MethodPost, c.avsConfig.ApiEndpoint, bytes.NewReader(objAsBytes))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.avsConfig.ApiEndpoint, bytes.NewReader(objAsBytes))
 	if err != nil {
 		return &responseObject, errors.Wrap(err, "while creating request")
 	}
@@ -65,9 +66,9 @@ func (c *Client) CreateEvaluation(evaluationRequest *BasicEvaluationCreateReques
 	return &responseObject, nil
 }
 
-func (c *Client) RemoveReferenceFromParentEval(evaluationId int64) (err error) {
+func (c *Client) RemoveReferenceFromParentEval(ctx context.Context, evaluationId int64) (err error) {
 	absoluteURL := fmt.Sprintf("%s/child/%d", appendId(c.avsConfig.ApiEndpoint, c.avsConfig.ParentId), evaluationId)
-	response, err := c.deleteRequest(absoluteURL)
+	response, err := c.deleteRequest(ctx, absoluteURL)
 	if err == nil {
 		return nil
 	}
@@ -92,9 +93,9 @@ func (c *Client) RemoveReferenceFromParentEval(evaluationId int64) (err error) {
 	return fmt.Errorf("unexpected response for evaluationId: %d while deleting reference from parent evaluation, error: %s", evaluationId, err)
 }
 
-func (c *Client) DeleteEvaluation(evaluationId int64) (err error) {
+func (c *Client) DeleteEvaluation(ctx context.Context, evaluationId int64) (err error) {
 	absoluteURL := appendId(c.avsConfig.ApiEndpoint, evaluationId)
-	response, err := c.deleteRequest(absoluteURL)
+	response, err := c.deleteRequest(ctx, absoluteURL)
 	defer func() {
 		if closeErr := c.closeResponseBody(response); closeErr != nil {
 			err = kebError.AsTemporaryError(closeErr, "while closing DeleteEvaluation response body")
@@ -115,8 +116,8 @@ func appendId(baseUrl string, id int64) string {
 	}
 }
 
-func (c *Client) deleteRequest(absoluteURL string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodDelete, absoluteURL, nil)
+func (c *Client) deleteRequest(ctx context.Context, absoluteURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, absoluteURL, nil)
 	if err != nil {
 		return &http.Response{}, errors.Wrap(err, "while creating delete request")
 	}
@@ -198,5 +199,8 @@ func getHttpClient(ctx context.Context, cfg Config) (http.Client, error) {
 		return http.Client{}, kebError.AsTemporaryError(err, "while fetching initial token")
 	}
 
-	return *config.Client(ctx, initialToken), nil
+	httpClient := config.Client(ctx, initialToken)
+	httpClient.Transport = tracing.NewTransport(httpClient.Transport)
+
+	return *httpClient, nil
 }
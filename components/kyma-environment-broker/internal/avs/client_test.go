@@ -36,7 +36,7 @@ func TestClient_CreateEvaluation(t *testing.T) {
 		assert.NoError(t, err)
 
 		// When
-		response, err := client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		response, err := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name:     "test_evaluation",
 			ParentId: parentEvaluationID,
 		})
@@ -58,7 +58,7 @@ func TestClient_CreateEvaluation(t *testing.T) {
 		assert.NoError(t, err)
 
 		// When
-		response, err := client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		response, err := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name:     "test_evaluation",
 			ParentId: parentEvaluationID,
 		})
@@ -81,7 +81,7 @@ func TestClient_CreateEvaluation(t *testing.T) {
 		assert.NoError(t, err)
 
 		// When
-		_, err = client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		_, err = client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name: "test_evaluation",
 		})
 
@@ -103,13 +103,13 @@ func TestClient_DeleteEvaluation(t *testing.T) {
 		}, logrus.New())
 		assert.NoError(t, err)
 
-		_, err = client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		_, err = client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name: "test_evaluation",
 		})
 		assert.NoError(t, err)
 
 		// When
-		err = client.DeleteEvaluation(evaluationID)
+		err = client.DeleteEvaluation(context.Background(), evaluationID)
 
 		// Then
 		assert.NoError(t, err)
@@ -127,13 +127,13 @@ func TestClient_DeleteEvaluation(t *testing.T) {
 		}, logrus.New())
 		assert.NoError(t, err)
 
-		_, err = client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		_, err = client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name: "test_evaluation",
 		})
 		assert.NoError(t, err)
 
 		// When
-		err = client.DeleteEvaluation(123)
+		err = client.DeleteEvaluation(context.Background(), 123)
 
 		// Then
 		assert.NoError(t, err)
@@ -152,13 +152,13 @@ func TestClient_RemoveReferenceFromParentEval(t *testing.T) {
 	}, logrus.New())
 	assert.NoError(t, err)
 
-	_, err = client.CreateEvaluation(&BasicEvaluationCreateRequest{
+	_, err = client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 		Name: "test_evaluation",
 	})
 	assert.NoError(t, err)
 
 	// When
-	err = client.RemoveReferenceFromParentEval(evaluationID)
+	err = client.RemoveReferenceFromParentEval(context.Background(), evaluationID)
 
 	// Then
 	assert.NoError(t, err)
@@ -177,7 +177,7 @@ func TestClient_RemoveReferenceFromParentEval_WrongApiURLError(t *testing.T) {
 	assert.NoError(t, err)
 
 	// When
-	err = client.RemoveReferenceFromParentEval(evaluationID)
+	err = client.RemoveReferenceFromParentEval(context.Background(), evaluationID)
 	assert.Error(t, err)
 }
 
@@ -1,6 +1,7 @@
 package avs
 
 import (
+	"context"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
@@ -42,7 +43,7 @@ func NewDelegator(client *Client, avsConfig Config, operationsStorage storage.Op
 	}
 }
 
-func (del *Delegator) CreateEvaluation(logger logrus.FieldLogger, operation internal.ProvisioningOperation, evalAssistant EvalAssistant, url string) (internal.ProvisioningOperation, time.Duration, error) {
+func (del *Delegator) CreateEvaluation(ctx context.Context, logger logrus.FieldLogger, operation internal.ProvisioningOperation, evalAssistant EvalAssistant, url string) (internal.ProvisioningOperation, time.Duration, error) {
 	logger.Infof("starting the step avs internal id [%d] and avs external id [%d]", operation.Avs.AvsEvaluationInternalId, operation.Avs.AVSEvaluationExternalId)
 
 	var updatedOperation internal.ProvisioningOperation
@@ -59,7 +60,7 @@ func (del *Delegator) CreateEvaluation(logger logrus.FieldLogger, operation inte
 			return operation, 5 * time.Second, nil
 		}
 
-		evalResp, err := del.client.CreateEvaluation(evaluationObject)
+		evalResp, err := del.client.CreateEvaluation(ctx, evaluationObject)
 		switch {
 		case err == nil:
 		case kebError.IsTemporaryError(err):
@@ -83,13 +84,13 @@ func (del *Delegator) CreateEvaluation(logger logrus.FieldLogger, operation inte
 	return updatedOperation, d, nil
 }
 
-func (del *Delegator) DeleteAvsEvaluation(deProvisioningOperation internal.DeprovisioningOperation, logger logrus.FieldLogger, assistant EvalAssistant) (internal.DeprovisioningOperation, error) {
+func (del *Delegator) DeleteAvsEvaluation(ctx context.Context, deProvisioningOperation internal.DeprovisioningOperation, logger logrus.FieldLogger, assistant EvalAssistant) (internal.DeprovisioningOperation, error) {
 	if assistant.IsAlreadyDeleted(deProvisioningOperation.Avs) {
 		logger.Infof("Evaluations have been deleted previously")
 		return deProvisioningOperation, nil
 	}
 
-	if err := del.tryDeleting(assistant, deProvisioningOperation.Avs, logger); err != nil {
+	if err := del.tryDeleting(ctx, assistant, deProvisioningOperation.Avs, logger); err != nil {
 		return deProvisioningOperation, err
 	}
 
@@ -102,15 +103,15 @@ func (del *Delegator) DeleteAvsEvaluation(deProvisioningOperation internal.Depro
 	return *updatedDeProvisioningOp, nil
 }
 
-func (del *Delegator) tryDeleting(assistant EvalAssistant, lifecycleData internal.AvsLifecycleData, logger logrus.FieldLogger) error {
+func (del *Delegator) tryDeleting(ctx context.Context, assistant EvalAssistant, lifecycleData internal.AvsLifecycleData, logger logrus.FieldLogger) error {
 	evaluationId := assistant.GetEvaluationId(lifecycleData)
-	err := del.client.RemoveReferenceFromParentEval(evaluationId)
+	err := del.client.RemoveReferenceFromParentEval(ctx, evaluationId)
 	if err != nil {
 		logger.Errorf("error while deleting reference for evaluation %v", err)
 		return err
 	}
 
-	err = del.client.DeleteEvaluation(evaluationId)
+	err = del.client.DeleteEvaluation(ctx, evaluationId)
 	if err != nil {
 		logger.Errorf("error while deleting evaluation %v", err)
 	}
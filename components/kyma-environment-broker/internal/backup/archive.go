@@ -0,0 +1,165 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// FormatName identifies the backup archives of the broker
+	FormatName = "kyma-environment-broker-backup"
+	// FormatVersion is the version of the archives written by the broker. It is increased on every change of the content
+	// which the older brokers cannot import, the archives of the newer versions are rejected.
+	FormatVersion = 1
+
+	archiveKeyID = "backup"
+)
+
+type Config struct {
+	// SecretKey encrypts the archive with AES-GCM, it must have 16, 24 or 32 bytes
+	SecretKey string `envconfig:"optional"`
+	// BatchSize is the number of records read from the database, or checked in the provisioner, in one query
+	BatchSize int `envconfig:"default=100"`
+}
+
+func (c Config) Validate() error {
+	if c.BatchSize < 1 {
+		return errors.New("the backup batch size must be positive")
+	}
+	return nil
+}
+
+// Header is the plain text first line of the archive, which describes the encrypted content.
+// The counts are also verified against the content on import.
+type Header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	Counts    Counts    `json:"counts"`
+}
+
+// Counts holds the number of the records of each kind
+type Counts struct {
+	Instances             int `json:"instances"`
+	Operations            int `json:"operations"`
+	Orchestrations        int `json:"orchestrations"`
+	RuntimeStates         int `json:"runtimeStates"`
+	LMSTenants            int `json:"lmsTenants"`
+	AccountAssignments    int `json:"accountAssignments"`
+	ArchivedOperations    int `json:"archivedOperations"`
+	ArchivedRuntimeStates int `json:"archivedRuntimeStates"`
+}
+
+func (c Counts) total() int {
+	return c.Instances + c.Operations + c.Orchestrations + c.RuntimeStates + c.LMSTenants + c.AccountAssignments +
+		c.ArchivedOperations + c.ArchivedRuntimeStates
+}
+
+// content is the encrypted part of the archive, the JSON compressed with gzip
+type content struct {
+	Version               int                             `json:"version"`
+	Instances             []internal.Instance             `json:"instances"`
+	Operations            []dbmodel.OperationDTO          `json:"operations"`
+	Orchestrations        []internal.Orchestration        `json:"orchestrations"`
+	RuntimeStates         []internal.RuntimeState         `json:"runtimeStates"`
+	LMSTenants            []internal.LMSTenant            `json:"lmsTenants"`
+	AccountAssignments    []internal.AccountAssignment    `json:"accountAssignments"`
+	ArchivedOperations    []dbmodel.ArchivedOperationDTO  `json:"archivedOperations"`
+	ArchivedRuntimeStates []internal.ArchivedRuntimeState `json:"archivedRuntimeStates"`
+}
+
+func (c content) counts() Counts {
+	return Counts{
+		Instances:             len(c.Instances),
+		Operations:            len(c.Operations),
+		Orchestrations:        len(c.Orchestrations),
+		RuntimeStates:         len(c.RuntimeStates),
+		LMSTenants:            len(c.LMSTenants),
+		AccountAssignments:    len(c.AccountAssignments),
+		ArchivedOperations:    len(c.ArchivedOperations),
+		ArchivedRuntimeStates: len(c.ArchivedRuntimeStates),
+	}
+}
+
+func newEncrypter(cfg Config) (*storage.Encrypter, error) {
+	switch len(cfg.SecretKey) {
+	case 16, 24, 32:
+	default:
+		return nil, errors.New("the backup secret key must have 16, 24 or 32 bytes")
+	}
+	return storage.NewEncrypter(storage.Keyring{
+		ActiveKeyID: archiveKeyID,
+		Keys:        map[string]string{archiveKeyID: cfg.SecretKey},
+	}), nil
+}
+
+func writeArchive(w io.Writer, enc *storage.Encrypter, header Header, c content) error {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if err := json.NewEncoder(gz).Encode(c); err != nil {
+		return errors.Wrap(err, "while encoding content")
+	}
+	if err := gz.Close(); err != nil {
+		return errors.Wrap(err, "while compressing content")
+	}
+	encrypted, err := enc.Encrypt(compressed.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "while encrypting content")
+	}
+
+	if err := json.NewEncoder(w).Encode(header); err != nil {
+		return errors.Wrap(err, "while writing header")
+	}
+	if _, err := w.Write(encrypted); err != nil {
+		return errors.Wrap(err, "while writing content")
+	}
+	return nil
+}
+
+func readArchive(r io.Reader, enc *storage.Encrypter) (Header, content, error) {
+	reader := bufio.NewReader(r)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return Header{}, content{}, errors.Wrap(err, "while reading header")
+	}
+	var header Header
+	if err := json.Unmarshal(line, &header); err != nil || header.Format != FormatName {
+		return Header{}, content{}, errors.New("the file is not a backup of the broker")
+	}
+	if header.Version > FormatVersion {
+		return Header{}, content{}, errors.Errorf("the backup version %d is not supported, the latest supported version is %d", header.Version, FormatVersion)
+	}
+
+	encrypted, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return Header{}, content{}, errors.Wrap(err, "while reading content")
+	}
+	compressed, err := enc.Decrypt(bytes.TrimSpace(encrypted))
+	if err != nil {
+		return Header{}, content{}, errors.Wrap(err, "while decrypting content")
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return Header{}, content{}, errors.Wrap(err, "while decompressing content")
+	}
+	var c content
+	if err := json.NewDecoder(gz).Decode(&c); err != nil {
+		return Header{}, content{}, errors.Wrap(err, "while decoding content")
+	}
+	if c.Version != header.Version || c.counts() != header.Counts {
+		return Header{}, content{}, errors.New("the backup header does not match the content")
+	}
+
+	return header, c, nil
+}
@@ -0,0 +1,393 @@
+package backup
+
+import (
+	"bytes"
+	"database/sql"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secretKey = "################################"
+
+func TestExportImport(t *testing.T) {
+	cfg := Config{SecretKey: secretKey, BatchSize: 2}
+
+	t.Run("should restore all records and skip the existing ones on repeated import", func(t *testing.T) {
+		// given
+		source := fixStorage()
+		var archive bytes.Buffer
+		header, err := NewExporter(source, cfg, logrus.New()).Export(&archive)
+		require.NoError(t, err)
+		assert.Equal(t, Counts{Instances: 3, Operations: 4, Orchestrations: 1, RuntimeStates: 1, LMSTenants: 1, AccountAssignments: 2,
+			ArchivedOperations: 1, ArchivedRuntimeStates: 1}, header.Counts)
+
+		target := newFakeStorage()
+		importer := NewImporter(target, cfg, logrus.New())
+
+		// when
+		report, err := importer.Import(bytes.NewReader(archive.Bytes()))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, header.Counts, report.Imported)
+		assert.Zero(t, report.Skipped)
+		assert.Equal(t, source, target)
+
+		// when
+		report, err = importer.Import(bytes.NewReader(archive.Bytes()))
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, report.Imported)
+		assert.Equal(t, header.Counts, report.Skipped)
+		assert.Equal(t, source, target)
+	})
+
+	t.Run("should not write the records in plain text", func(t *testing.T) {
+		// given
+		var archive bytes.Buffer
+
+		// when
+		_, err := NewExporter(fixStorage(), cfg, logrus.New()).Export(&archive)
+
+		// then
+		require.NoError(t, err)
+		assert.NotContains(t, archive.String(), "inst-1")
+		assert.True(t, strings.HasPrefix(archive.String(), `{"format":"kyma-environment-broker-backup","version":1,`))
+	})
+
+	t.Run("should reject the archive encrypted with another key", func(t *testing.T) {
+		// given
+		var archive bytes.Buffer
+		_, err := NewExporter(fixStorage(), cfg, logrus.New()).Export(&archive)
+		require.NoError(t, err)
+		otherCfg := Config{SecretKey: strings.Repeat("*", 32), BatchSize: 2}
+
+		// when
+		_, err = NewImporter(newFakeStorage(), otherCfg, logrus.New()).Import(&archive)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while decrypting content")
+	})
+
+	t.Run("should reject the archive of the newer version", func(t *testing.T) {
+		// given
+		var archive bytes.Buffer
+		_, err := NewExporter(fixStorage(), cfg, logrus.New()).Export(&archive)
+		require.NoError(t, err)
+		newer := strings.Replace(archive.String(), `"version":1`, `"version":2`, 1)
+
+		// when
+		_, err = NewImporter(newFakeStorage(), cfg, logrus.New()).Import(strings.NewReader(newer))
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the backup version 2 is not supported")
+	})
+
+	t.Run("should reject the header which does not match the content", func(t *testing.T) {
+		// given
+		var archive bytes.Buffer
+		_, err := NewExporter(fixStorage(), cfg, logrus.New()).Export(&archive)
+		require.NoError(t, err)
+		modified := strings.Replace(archive.String(), `"instances":3`, `"instances":2`, 1)
+
+		// when
+		_, err = NewImporter(newFakeStorage(), cfg, logrus.New()).Import(strings.NewReader(modified))
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the backup header does not match the content")
+	})
+
+	t.Run("should require the secret key", func(t *testing.T) {
+		// when
+		_, err := NewExporter(fixStorage(), Config{BatchSize: 2}, logrus.New()).Export(&bytes.Buffer{})
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestExportCoversAllTables(t *testing.T) {
+	// given
+	migrations, err := filepath.Glob("../../../schema-migrator/migrations/kyma-environment-broker/*.up.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	createTable := regexp.MustCompile(`(?i)CREATE TABLE IF NOT EXISTS\s+(\w+)`)
+
+	exported := map[string]bool{}
+	for _, step := range NewExporter(newFakeStorage(), Config{}, logrus.New()).steps(&content{}) {
+		for _, table := range step.tables {
+			exported[table] = true
+		}
+	}
+
+	// then
+	for _, migration := range migrations {
+		script, err := ioutil.ReadFile(migration)
+		require.NoError(t, err)
+		for _, match := range createTable.FindAllStringSubmatch(string(script), -1) {
+			assert.True(t, exported[match[1]], "the table %s created in %s is not exported", match[1], filepath.Base(migration))
+		}
+	}
+}
+
+func fixStorage() *fakeStorage {
+	now := time.Date(2020, 12, 14, 10, 0, 0, 0, time.UTC)
+	st := newFakeStorage()
+	for _, id := range []string{"inst-1", "inst-2", "inst-3"} {
+		st.instances[id] = internal.Instance{
+			InstanceID:      id,
+			RuntimeID:       "runtime-" + id,
+			GlobalAccountID: "ga",
+			Labels:          map[string]string{"team": id},
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+	}
+	for _, op := range []dbmodel.OperationDTO{
+		{ID: "op-1", InstanceID: "inst-1", Type: dbmodel.OperationTypeProvision, Data: `{"provisioning_parameters":"{}"}`},
+		{ID: "op-2", InstanceID: "inst-2", Type: dbmodel.OperationTypeProvision},
+		{ID: "op-3", InstanceID: "inst-3", Type: dbmodel.OperationTypeDeprovision},
+		{ID: "op-4", InstanceID: "inst-1", Type: dbmodel.OperationTypeUpgradeKyma, OrchestrationID: sql.NullString{String: "orch-1", Valid: true}},
+	} {
+		op.CreatedAt = now
+		op.UpdatedAt = now
+		st.operations[op.ID] = op
+	}
+	st.orchestrations["orch-1"] = internal.Orchestration{OrchestrationID: "orch-1", State: "succeeded", CreatedAt: now, UpdatedAt: now}
+	st.runtimeStates["state-1"] = internal.RuntimeState{
+		ID:          "state-1",
+		RuntimeID:   "runtime-inst-1",
+		OperationID: "op-1",
+		CreatedAt:   now,
+		KymaConfig:  gqlschema.KymaConfigInput{Version: "1.17.0"},
+	}
+	st.lmsTenants["tenant-1"] = internal.LMSTenant{ID: "tenant-1", Name: "tenant", Region: "eu", CreatedAt: now}
+	st.accountAssignments["assignment-1"] = internal.AccountAssignment{ID: "assignment-1", SecretName: "secret-1", HyperscalerType: "gcp", TenantName: "tenant", CreatedAt: now, ReleasedAt: &now}
+	st.accountAssignments["assignment-2"] = internal.AccountAssignment{ID: "assignment-2", SecretName: "secret-1", HyperscalerType: "gcp", TenantName: "other-tenant", CreatedAt: now}
+	archivedAt := now.Add(time.Hour)
+	st.archivedOperations["op-0"] = dbmodel.ArchivedOperationDTO{
+		OperationDTO: dbmodel.OperationDTO{ID: "op-0", InstanceID: "inst-3", Type: dbmodel.OperationTypeProvision, CreatedAt: now, UpdatedAt: now},
+		ArchivedAt:   archivedAt,
+	}
+	st.archivedRuntimeStates["state-0"] = internal.ArchivedRuntimeState{
+		RuntimeState: internal.RuntimeState{ID: "state-0", RuntimeID: "runtime-inst-3", OperationID: "op-0", CreatedAt: now},
+		ArchivedAt:   archivedAt,
+	}
+
+	return st
+}
+
+type fakeStorage struct {
+	instances      map[string]internal.Instance
+	operations     map[string]dbmodel.OperationDTO
+	orchestrations map[string]internal.Orchestration
+	runtimeStates  map[string]internal.RuntimeState
+	lmsTenants     map[string]internal.LMSTenant
+
+	accountAssignments    map[string]internal.AccountAssignment
+	archivedOperations    map[string]dbmodel.ArchivedOperationDTO
+	archivedRuntimeStates map[string]internal.ArchivedRuntimeState
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{
+		instances:      map[string]internal.Instance{},
+		operations:     map[string]dbmodel.OperationDTO{},
+		orchestrations: map[string]internal.Orchestration{},
+		runtimeStates:  map[string]internal.RuntimeState{},
+		lmsTenants:     map[string]internal.LMSTenant{},
+
+		accountAssignments:    map[string]internal.AccountAssignment{},
+		archivedOperations:    map[string]dbmodel.ArchivedOperationDTO{},
+		archivedRuntimeStates: map[string]internal.ArchivedRuntimeState{},
+	}
+}
+
+// idsAfter returns up to the limit of the sorted keys following the given ID
+func idsAfter(keys []string, afterID string, limit int) []string {
+	sort.Strings(keys)
+	var ids []string
+	for _, key := range keys {
+		if key > afterID && len(ids) < limit {
+			ids = append(ids, key)
+		}
+	}
+	return ids
+}
+
+func (s *fakeStorage) ListInstances(afterID string, limit int) ([]internal.Instance, error) {
+	var keys []string
+	for key := range s.instances {
+		keys = append(keys, key)
+	}
+	var result []internal.Instance
+	for _, id := range idsAfter(keys, afterID, limit) {
+		result = append(result, s.instances[id])
+	}
+	return result, nil
+}
+
+func (s *fakeStorage) ListOperations(afterID string, limit int) ([]dbmodel.OperationDTO, error) {
+	var keys []string
+	for key := range s.operations {
+		keys = append(keys, key)
+	}
+	var result []dbmodel.OperationDTO
+	for _, id := range idsAfter(keys, afterID, limit) {
+		result = append(result, s.operations[id])
+	}
+	return result, nil
+}
+
+func (s *fakeStorage) ListOrchestrations(afterID string, limit int) ([]internal.Orchestration, error) {
+	var keys []string
+	for key := range s.orchestrations {
+		keys = append(keys, key)
+	}
+	var result []internal.Orchestration
+	for _, id := range idsAfter(keys, afterID, limit) {
+		result = append(result, s.orchestrations[id])
+	}
+	return result, nil
+}
+
+func (s *fakeStorage) ListRuntimeStates(afterID string, limit int) ([]internal.RuntimeState, error) {
+	var keys []string
+	for key := range s.runtimeStates {
+		keys = append(keys, key)
+	}
+	var result []internal.RuntimeState
+	for _, id := range idsAfter(keys, afterID, limit) {
+		result = append(result, s.runtimeStates[id])
+	}
+	return result, nil
+}
+
+func (s *fakeStorage) ListLMSTenants(afterID string, limit int) ([]internal.LMSTenant, error) {
+	var keys []string
+	for key := range s.lmsTenants {
+		keys = append(keys, key)
+	}
+	var result []internal.LMSTenant
+	for _, id := range idsAfter(keys, afterID, limit) {
+		result = append(result, s.lmsTenants[id])
+	}
+	return result, nil
+}
+
+func (s *fakeStorage) ListAccountAssignments(afterID string, limit int) ([]internal.AccountAssignment, error) {
+	var keys []string
+	for key := range s.accountAssignments {
+		keys = append(keys, key)
+	}
+	var result []internal.AccountAssignment
+	for _, id := range idsAfter(keys, afterID, limit) {
+		result = append(result, s.accountAssignments[id])
+	}
+	return result, nil
+}
+
+func (s *fakeStorage) ListArchivedOperations(afterID string, limit int) ([]dbmodel.ArchivedOperationDTO, error) {
+	var keys []string
+	for key := range s.archivedOperations {
+		keys = append(keys, key)
+	}
+	var result []dbmodel.ArchivedOperationDTO
+	for _, id := range idsAfter(keys, afterID, limit) {
+		result = append(result, s.archivedOperations[id])
+	}
+	return result, nil
+}
+
+func (s *fakeStorage) ListArchivedRuntimeStates(afterID string, limit int) ([]internal.ArchivedRuntimeState, error) {
+	var keys []string
+	for key := range s.archivedRuntimeStates {
+		keys = append(keys, key)
+	}
+	var result []internal.ArchivedRuntimeState
+	for _, id := range idsAfter(keys, afterID, limit) {
+		result = append(result, s.archivedRuntimeStates[id])
+	}
+	return result, nil
+}
+
+func (s *fakeStorage) RestoreInstance(instance internal.Instance) (bool, error) {
+	if _, exists := s.instances[instance.InstanceID]; exists {
+		return false, nil
+	}
+	s.instances[instance.InstanceID] = instance
+	return true, nil
+}
+
+func (s *fakeStorage) RestoreOperation(operation dbmodel.OperationDTO) (bool, error) {
+	if _, exists := s.operations[operation.ID]; exists {
+		return false, nil
+	}
+	s.operations[operation.ID] = operation
+	return true, nil
+}
+
+func (s *fakeStorage) RestoreOrchestration(orchestration internal.Orchestration) (bool, error) {
+	if _, exists := s.orchestrations[orchestration.OrchestrationID]; exists {
+		return false, nil
+	}
+	s.orchestrations[orchestration.OrchestrationID] = orchestration
+	return true, nil
+}
+
+func (s *fakeStorage) RestoreRuntimeState(state internal.RuntimeState) (bool, error) {
+	if _, exists := s.runtimeStates[state.ID]; exists {
+		return false, nil
+	}
+	s.runtimeStates[state.ID] = state
+	return true, nil
+}
+
+func (s *fakeStorage) RestoreLMSTenant(tenant internal.LMSTenant) (bool, error) {
+	if _, exists := s.lmsTenants[tenant.ID]; exists {
+		return false, nil
+	}
+	s.lmsTenants[tenant.ID] = tenant
+	return true, nil
+}
+
+func (s *fakeStorage) RestoreAccountAssignment(assignment internal.AccountAssignment) (bool, error) {
+	if _, exists := s.accountAssignments[assignment.ID]; exists {
+		return false, nil
+	}
+	s.accountAssignments[assignment.ID] = assignment
+	return true, nil
+}
+
+func (s *fakeStorage) RestoreArchivedOperation(operation dbmodel.ArchivedOperationDTO) (bool, error) {
+	if _, exists := s.archivedOperations[operation.ID]; exists {
+		return false, nil
+	}
+	s.archivedOperations[operation.ID] = operation
+	return true, nil
+}
+
+func (s *fakeStorage) RestoreArchivedRuntimeState(state internal.ArchivedRuntimeState) (bool, error) {
+	if _, exists := s.archivedRuntimeStates[state.ID]; exists {
+		return false, nil
+	}
+	s.archivedRuntimeStates[state.ID] = state
+	return true, nil
+}
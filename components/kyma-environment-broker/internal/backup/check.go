@@ -0,0 +1,144 @@
+package backup
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	gardenerclient "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const runtimeIDAnnotation = "kcp.provisioner.kyma-project.io/runtime-id"
+
+// CheckReport holds the differences between the instances and the runtimes managed by Gardener and the provisioner
+type CheckReport struct {
+	// Instances is the number of the checked instances, the instances without the runtime ID are not checked
+	Instances int
+	// MissingShoots are the IDs of the instances with no Gardener shoot of their runtime
+	MissingShoots []string
+	// MissingInProvisioner are the IDs of the instances with the runtime unknown to the provisioner
+	MissingInProvisioner []string
+	// UnknownShoots are the names of the Gardener shoots of the runtimes with no instance,
+	// e.g. provisioned after the backup was created
+	UnknownShoots []string
+}
+
+// Consistent returns true if every instance has the runtime and every runtime has the instance
+func (r CheckReport) Consistent() bool {
+	return len(r.MissingShoots) == 0 && len(r.MissingInProvisioner) == 0 && len(r.UnknownShoots) == 0
+}
+
+// Checker compares the instances stored in the database with the Gardener shoots and the provisioner runtimes
+type Checker struct {
+	storage     storage.Backup
+	shoots      gardenerclient.ShootInterface
+	provisioner provisioner.Client
+	cfg         Config
+	log         logrus.FieldLogger
+}
+
+func NewChecker(storage storage.Backup, shoots gardenerclient.ShootInterface, provisioner provisioner.Client, cfg Config, log logrus.FieldLogger) *Checker {
+	return &Checker{
+		storage:     storage,
+		shoots:      shoots,
+		provisioner: provisioner,
+		cfg:         cfg,
+		log:         log,
+	}
+}
+
+// Check reports the instances whose runtimes do not exist and the shoots without the instance
+func (c *Checker) Check() (CheckReport, error) {
+	if err := c.cfg.Validate(); err != nil {
+		return CheckReport{}, err
+	}
+
+	var instances []internal.Instance
+	err := inBatches(c.cfg.BatchSize, func(afterID string) (int, string, error) {
+		batch, err := c.storage.ListInstances(afterID, c.cfg.BatchSize)
+		if err != nil || len(batch) == 0 {
+			return 0, "", err
+		}
+		instances = append(instances, batch...)
+		return len(batch), batch[len(batch)-1].InstanceID, nil
+	})
+	if err != nil {
+		return CheckReport{}, errors.Wrap(err, "while listing instances")
+	}
+
+	shootList, err := c.shoots.List(v1.ListOptions{})
+	if err != nil {
+		return CheckReport{}, errors.Wrap(err, "while listing Gardener shoots")
+	}
+	shoots := make(map[string]string, len(shootList.Items))
+	for _, shoot := range shootList.Items {
+		if runtimeID, found := shoot.Annotations[runtimeIDAnnotation]; found {
+			shoots[runtimeID] = shoot.Name
+		}
+	}
+
+	report := CheckReport{}
+	runtimes := make(map[string]struct{})
+	byGlobalAccount := make(map[string][]internal.Instance)
+	for _, instance := range instances {
+		if instance.RuntimeID == "" {
+			continue
+		}
+		report.Instances++
+		runtimes[instance.RuntimeID] = struct{}{}
+		byGlobalAccount[instance.GlobalAccountID] = append(byGlobalAccount[instance.GlobalAccountID], instance)
+		if _, found := shoots[instance.RuntimeID]; !found {
+			report.MissingShoots = append(report.MissingShoots, instance.InstanceID)
+		}
+	}
+	for runtimeID, name := range shoots {
+		if _, found := runtimes[runtimeID]; !found {
+			report.UnknownShoots = append(report.UnknownShoots, name)
+		}
+	}
+	sort.Strings(report.UnknownShoots)
+
+	for globalAccountID, accountInstances := range byGlobalAccount {
+		missing, err := c.missingInProvisioner(globalAccountID, accountInstances)
+		if err != nil {
+			return CheckReport{}, errors.Wrapf(err, "while fetching runtime statuses of global account %s", globalAccountID)
+		}
+		report.MissingInProvisioner = append(report.MissingInProvisioner, missing...)
+	}
+	sort.Strings(report.MissingInProvisioner)
+
+	c.log.Infof("Checked %d instances: %d without the shoot, %d unknown to the provisioner, %d shoots without the instance",
+		report.Instances, len(report.MissingShoots), len(report.MissingInProvisioner), len(report.UnknownShoots))
+
+	return report, nil
+}
+
+func (c *Checker) missingInProvisioner(globalAccountID string, instances []internal.Instance) ([]string, error) {
+	var missing []string
+	for start := 0; start < len(instances); start += c.cfg.BatchSize {
+		end := start + c.cfg.BatchSize
+		if end > len(instances) {
+			end = len(instances)
+		}
+		runtimeIDs := make([]string, 0, end-start)
+		for _, instance := range instances[start:end] {
+			runtimeIDs = append(runtimeIDs, instance.RuntimeID)
+		}
+		statuses, err := c.provisioner.RuntimeStatuses(context.Background(), globalAccountID, runtimeIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range instances[start:end] {
+			if _, found := statuses[instance.RuntimeID]; !found {
+				missing = append(missing, instance.InstanceID)
+			}
+		}
+	}
+	return missing, nil
+}
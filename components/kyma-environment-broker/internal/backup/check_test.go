@@ -0,0 +1,66 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardener_fake "github.com/gardener/gardener/pkg/client/core/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const gardenerNamespace = "garden-kyma"
+
+func TestChecker_Check(t *testing.T) {
+	// given
+	st := newFakeStorage()
+	for _, instance := range []internal.Instance{
+		{InstanceID: "consistent", RuntimeID: "runtime-consistent", GlobalAccountID: "ga-1"},
+		{InstanceID: "without-shoot", RuntimeID: "runtime-without-shoot", GlobalAccountID: "ga-1"},
+		{InstanceID: "unknown-to-provisioner", RuntimeID: "runtime-unknown-to-provisioner", GlobalAccountID: "ga-2"},
+		{InstanceID: "not-provisioned", GlobalAccountID: "ga-2"},
+	} {
+		st.instances[instance.InstanceID] = instance
+	}
+
+	gardenerClient := gardener_fake.NewSimpleClientset(
+		fixShoot("shoot-1", "runtime-consistent"),
+		fixShoot("shoot-2", "runtime-unknown-to-provisioner"),
+		fixShoot("shoot-3", "runtime-created-after-backup"),
+	)
+	provisionerClient := provisioner.NewFakeClient()
+	provisionerClient.SetRuntimeStatus("runtime-consistent", gqlschema.RuntimeStatus{})
+	provisionerClient.SetRuntimeStatus("runtime-without-shoot", gqlschema.RuntimeStatus{})
+
+	checker := NewChecker(st, gardenerClient.CoreV1beta1().Shoots(gardenerNamespace), provisionerClient,
+		Config{BatchSize: 1}, logrus.New())
+
+	// when
+	report, err := checker.Check()
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, report.Consistent())
+	assert.Equal(t, CheckReport{
+		Instances:            3,
+		MissingShoots:        []string{"without-shoot"},
+		MissingInProvisioner: []string{"unknown-to-provisioner"},
+		UnknownShoots:        []string{"shoot-3"},
+	}, report)
+}
+
+func fixShoot(name, runtimeID string) *gardener_types.Shoot {
+	return &gardener_types.Shoot{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        name,
+			Namespace:   gardenerNamespace,
+			Annotations: map[string]string{runtimeIDAnnotation: runtimeID},
+		},
+	}
+}
@@ -0,0 +1,188 @@
+package backup
+
+import (
+	"io"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Exporter writes the complete state of the broker to the encrypted archive
+type Exporter struct {
+	storage storage.Backup
+	cfg     Config
+	log     logrus.FieldLogger
+	now     func() time.Time
+}
+
+func NewExporter(storage storage.Backup, cfg Config, log logrus.FieldLogger) *Exporter {
+	return &Exporter{
+		storage: storage,
+		cfg:     cfg,
+		log:     log,
+		now:     time.Now,
+	}
+}
+
+// Export reads all records in batches and writes them to the archive. The records are not read in a single transaction,
+// so the broker should not process any operations during the export.
+func (e *Exporter) Export(w io.Writer) (Header, error) {
+	if err := e.cfg.Validate(); err != nil {
+		return Header{}, err
+	}
+	enc, err := newEncrypter(e.cfg)
+	if err != nil {
+		return Header{}, err
+	}
+
+	c := content{Version: FormatVersion}
+	for _, step := range e.steps(&c) {
+		if err := inBatches(e.cfg.BatchSize, step.list); err != nil {
+			return Header{}, errors.Wrapf(err, "while exporting %s", step.name)
+		}
+	}
+
+	header := Header{
+		Format:    FormatName,
+		Version:   FormatVersion,
+		CreatedAt: e.now().UTC(),
+		Counts:    c.counts(),
+	}
+	if err := writeArchive(w, enc, header, c); err != nil {
+		return Header{}, err
+	}
+	e.log.Infof("Exported %d instances, %d operations, %d orchestrations, %d runtime states, %d lms tenants, %d account assignments, "+
+		"%d archived operations and %d archived runtime states",
+		header.Counts.Instances, header.Counts.Operations, header.Counts.Orchestrations, header.Counts.RuntimeStates, header.Counts.LMSTenants,
+		header.Counts.AccountAssignments, header.Counts.ArchivedOperations, header.Counts.ArchivedRuntimeStates)
+
+	return header, nil
+}
+
+// exportStep lists the records of one kind to the content. The tables are all database tables holding the records,
+// every table created by the schema migrations must be exported by one of the steps.
+type exportStep struct {
+	name   string
+	tables []string
+	list   func(afterID string) (int, string, error)
+}
+
+func (e *Exporter) steps(c *content) []exportStep {
+	return []exportStep{
+		{
+			name:   "instances",
+			tables: []string{postsql.InstancesTableName, postsql.InstanceLabelsTableName},
+			list: func(afterID string) (int, string, error) {
+				instances, err := e.storage.ListInstances(afterID, e.cfg.BatchSize)
+				if err != nil || len(instances) == 0 {
+					return 0, "", err
+				}
+				c.Instances = append(c.Instances, instances...)
+				return len(instances), instances[len(instances)-1].InstanceID, nil
+			},
+		},
+		{
+			name:   "operations",
+			tables: []string{postsql.OperationTableName},
+			list: func(afterID string) (int, string, error) {
+				operations, err := e.storage.ListOperations(afterID, e.cfg.BatchSize)
+				if err != nil || len(operations) == 0 {
+					return 0, "", err
+				}
+				c.Operations = append(c.Operations, operations...)
+				return len(operations), operations[len(operations)-1].ID, nil
+			},
+		},
+		{
+			name:   "orchestrations",
+			tables: []string{postsql.OrchestrationTableName},
+			list: func(afterID string) (int, string, error) {
+				orchestrations, err := e.storage.ListOrchestrations(afterID, e.cfg.BatchSize)
+				if err != nil || len(orchestrations) == 0 {
+					return 0, "", err
+				}
+				c.Orchestrations = append(c.Orchestrations, orchestrations...)
+				return len(orchestrations), orchestrations[len(orchestrations)-1].OrchestrationID, nil
+			},
+		},
+		{
+			name:   "runtime states",
+			tables: []string{postsql.RuntimeStateTableName},
+			list: func(afterID string) (int, string, error) {
+				states, err := e.storage.ListRuntimeStates(afterID, e.cfg.BatchSize)
+				if err != nil || len(states) == 0 {
+					return 0, "", err
+				}
+				c.RuntimeStates = append(c.RuntimeStates, states...)
+				return len(states), states[len(states)-1].ID, nil
+			},
+		},
+		{
+			name:   "lms tenants",
+			tables: []string{postsql.LMSTenantTableName},
+			list: func(afterID string) (int, string, error) {
+				tenants, err := e.storage.ListLMSTenants(afterID, e.cfg.BatchSize)
+				if err != nil || len(tenants) == 0 {
+					return 0, "", err
+				}
+				c.LMSTenants = append(c.LMSTenants, tenants...)
+				return len(tenants), tenants[len(tenants)-1].ID, nil
+			},
+		},
+		{
+			name:   "account assignments",
+			tables: []string{postsql.AccountAssignmentTableName},
+			list: func(afterID string) (int, string, error) {
+				assignments, err := e.storage.ListAccountAssignments(afterID, e.cfg.BatchSize)
+				if err != nil || len(assignments) == 0 {
+					return 0, "", err
+				}
+				c.AccountAssignments = append(c.AccountAssignments, assignments...)
+				return len(assignments), assignments[len(assignments)-1].ID, nil
+			},
+		},
+		{
+			name:   "archived operations",
+			tables: []string{postsql.OperationArchiveTableName},
+			list: func(afterID string) (int, string, error) {
+				operations, err := e.storage.ListArchivedOperations(afterID, e.cfg.BatchSize)
+				if err != nil || len(operations) == 0 {
+					return 0, "", err
+				}
+				c.ArchivedOperations = append(c.ArchivedOperations, operations...)
+				return len(operations), operations[len(operations)-1].ID, nil
+			},
+		},
+		{
+			name:   "archived runtime states",
+			tables: []string{postsql.RuntimeStateArchiveTableName},
+			list: func(afterID string) (int, string, error) {
+				states, err := e.storage.ListArchivedRuntimeStates(afterID, e.cfg.BatchSize)
+				if err != nil || len(states) == 0 {
+					return 0, "", err
+				}
+				c.ArchivedRuntimeStates = append(c.ArchivedRuntimeStates, states...)
+				return len(states), states[len(states)-1].ID, nil
+			},
+		},
+	}
+}
+
+// inBatches lists the records following the last listed ID until the last batch is not full
+func inBatches(batchSize int, list func(afterID string) (int, string, error)) error {
+	afterID := ""
+	for {
+		count, lastID, err := list(afterID)
+		if err != nil {
+			return err
+		}
+		if count < batchSize {
+			return nil
+		}
+		afterID = lastID
+	}
+}
@@ -0,0 +1,139 @@
+package backup
+
+import (
+	"io"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ImportReport holds the number of the imported records and of the skipped ones, which already existed in the database
+type ImportReport struct {
+	Header   Header
+	Imported Counts
+	Skipped  Counts
+}
+
+// Importer restores the state of the broker from the archive written by the Exporter
+type Importer struct {
+	storage storage.Backup
+	cfg     Config
+	log     logrus.FieldLogger
+}
+
+func NewImporter(storage storage.Backup, cfg Config, log logrus.FieldLogger) *Importer {
+	return &Importer{
+		storage: storage,
+		cfg:     cfg,
+		log:     log,
+	}
+}
+
+// Import inserts the records of the archive which do not exist in the database. The existing records are skipped
+// without comparing them, so the import can be repeated after a failure. On error it returns the report
+// of the records processed so far.
+func (i *Importer) Import(r io.Reader) (ImportReport, error) {
+	if err := i.cfg.Validate(); err != nil {
+		return ImportReport{}, err
+	}
+	enc, err := newEncrypter(i.cfg)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	header, c, err := readArchive(r, enc)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	i.log.Infof("Importing backup version %d created at %s", header.Version, header.CreatedAt)
+
+	report := ImportReport{Header: header}
+	// the records are restored in the order of the references between them
+	steps := []struct {
+		name     string
+		count    int
+		restore  func(idx int) (bool, error)
+		imported *int
+		skipped  *int
+	}{
+		{
+			name:     "orchestrations",
+			count:    len(c.Orchestrations),
+			restore:  func(idx int) (bool, error) { return i.storage.RestoreOrchestration(c.Orchestrations[idx]) },
+			imported: &report.Imported.Orchestrations,
+			skipped:  &report.Skipped.Orchestrations,
+		},
+		{
+			name:     "instances",
+			count:    len(c.Instances),
+			restore:  func(idx int) (bool, error) { return i.storage.RestoreInstance(c.Instances[idx]) },
+			imported: &report.Imported.Instances,
+			skipped:  &report.Skipped.Instances,
+		},
+		{
+			name:     "operations",
+			count:    len(c.Operations),
+			restore:  func(idx int) (bool, error) { return i.storage.RestoreOperation(c.Operations[idx]) },
+			imported: &report.Imported.Operations,
+			skipped:  &report.Skipped.Operations,
+		},
+		{
+			name:     "runtime states",
+			count:    len(c.RuntimeStates),
+			restore:  func(idx int) (bool, error) { return i.storage.RestoreRuntimeState(c.RuntimeStates[idx]) },
+			imported: &report.Imported.RuntimeStates,
+			skipped:  &report.Skipped.RuntimeStates,
+		},
+		{
+			name:     "lms tenants",
+			count:    len(c.LMSTenants),
+			restore:  func(idx int) (bool, error) { return i.storage.RestoreLMSTenant(c.LMSTenants[idx]) },
+			imported: &report.Imported.LMSTenants,
+			skipped:  &report.Skipped.LMSTenants,
+		},
+		{
+			name:     "account assignments",
+			count:    len(c.AccountAssignments),
+			restore:  func(idx int) (bool, error) { return i.storage.RestoreAccountAssignment(c.AccountAssignments[idx]) },
+			imported: &report.Imported.AccountAssignments,
+			skipped:  &report.Skipped.AccountAssignments,
+		},
+		{
+			name:     "archived operations",
+			count:    len(c.ArchivedOperations),
+			restore:  func(idx int) (bool, error) { return i.storage.RestoreArchivedOperation(c.ArchivedOperations[idx]) },
+			imported: &report.Imported.ArchivedOperations,
+			skipped:  &report.Skipped.ArchivedOperations,
+		},
+		{
+			name:  "archived runtime states",
+			count: len(c.ArchivedRuntimeStates),
+			restore: func(idx int) (bool, error) {
+				return i.storage.RestoreArchivedRuntimeState(c.ArchivedRuntimeStates[idx])
+			},
+			imported: &report.Imported.ArchivedRuntimeStates,
+			skipped:  &report.Skipped.ArchivedRuntimeStates,
+		},
+	}
+
+	for _, step := range steps {
+		for idx := 0; idx < step.count; idx++ {
+			inserted, err := step.restore(idx)
+			if err != nil {
+				return report, errors.Wrapf(err, "while importing %s", step.name)
+			}
+			if inserted {
+				*step.imported++
+			} else {
+				*step.skipped++
+			}
+		}
+	}
+	i.log.Infof("Imported %d instances, %d operations, %d orchestrations, %d runtime states, %d lms tenants, %d account assignments, "+
+		"%d archived operations and %d archived runtime states, skipped %d existing records",
+		report.Imported.Instances, report.Imported.Operations, report.Imported.Orchestrations, report.Imported.RuntimeStates, report.Imported.LMSTenants,
+		report.Imported.AccountAssignments, report.Imported.ArchivedOperations, report.Imported.ArchivedRuntimeStates, report.Skipped.total())
+
+	return report, nil
+}
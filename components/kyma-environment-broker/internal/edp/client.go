@@ -10,6 +10,7 @@ import (
 	"time"
 
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -52,6 +53,7 @@ func NewClient(config Config, log logrus.FieldLogger) *Client {
 	}
 	httpClientOAuth := cfg.Client(context.Background())
 	httpClientOAuth.Timeout = 30 * time.Second
+	httpClientOAuth.Transport = tracing.NewTransport(httpClientOAuth.Transport)
 
 	return &Client{
 		config:     config,
@@ -68,18 +70,18 @@ func (c *Client) metadataTenantURL(name, env string) string {
 	return fmt.Sprintf(metadataTenantTmpl, c.config.AdminURL, c.config.Namespace, name, env)
 }
 
-func (c *Client) CreateDataTenant(data DataTenantPayload) error {
+func (c *Client) CreateDataTenant(ctx context.Context, data DataTenantPayload) error {
 	rawData, err := json.Marshal(data)
 	if err != nil {
 		return errors.Wrap(err, "while marshaling dataTenant payload")
 	}
 
-	return c.post(c.dataTenantURL(), rawData)
+	return c.post(ctx, c.dataTenantURL(), rawData)
 }
 
-func (c *Client) DeleteDataTenant(name, env string) (err error) {
+func (c *Client) DeleteDataTenant(ctx context.Context, name, env string) (err error) {
 	URL := fmt.Sprintf("%s/%s/%s", c.dataTenantURL(), name, env)
-	request, err := http.NewRequest(http.MethodDelete, URL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, URL, nil)
 	if err != nil {
 		return errors.Wrap(err, "while creating delete dataTenant request")
 	}
@@ -97,18 +99,18 @@ func (c *Client) DeleteDataTenant(name, env string) (err error) {
 	return c.processResponse(response, true)
 }
 
-func (c *Client) CreateMetadataTenant(name, env string, data MetadataTenantPayload) error {
+func (c *Client) CreateMetadataTenant(ctx context.Context, name, env string, data MetadataTenantPayload) error {
 	rawData, err := json.Marshal(data)
 	if err != nil {
 		return errors.Wrap(err, "while marshaling tenant metadata payload")
 	}
 
-	return c.post(c.metadataTenantURL(name, env), rawData)
+	return c.post(ctx, c.metadataTenantURL(name, env), rawData)
 }
 
-func (c *Client) DeleteMetadataTenant(name, env, key string) (err error) {
+func (c *Client) DeleteMetadataTenant(ctx context.Context, name, env, key string) (err error) {
 	URL := fmt.Sprintf("%s/%s", c.metadataTenantURL(name, env), key)
-	request, err := http.NewRequest(http.MethodDelete, URL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, URL, nil)
 	if err != nil {
 		return errors.Wrap(err, "while creating delete metadata request")
 	}
@@ -126,9 +128,9 @@ func (c *Client) DeleteMetadataTenant(name, env, key string) (err error) {
 	return c.processResponse(response, true)
 }
 
-func (c *Client) GetMetadataTenant(name, env string) (_ []MetadataItem, err error) {
+func (c *Client) GetMetadataTenant(ctx context.Context, name, env string) (_ []MetadataItem, err error) {
 	var metadata []MetadataItem
-	request, err := http.NewRequest(http.MethodGet, c.metadataTenantURL(name, env), nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.metadataTenantURL(name, env), nil)
 	if err != nil {
 		return metadata, errors.Wrap(err, "while creating GET metadata tenant request")
 	}
@@ -151,8 +153,8 @@ func (c *Client) GetMetadataTenant(name, env string) (_ []MetadataItem, err erro
 	return metadata, nil
 }
 
-func (c *Client) post(URL string, data []byte) (err error) {
-	request, err := http.NewRequest(http.MethodPost, URL, bytes.NewBuffer(data))
+func (c *Client) post(ctx context.Context, URL string, data []byte) (err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, URL, bytes.NewBuffer(data))
 	if err != nil {
 		return errors.Wrapf(err, "while creating POST request for %s", URL)
 	}
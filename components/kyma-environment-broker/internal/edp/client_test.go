@@ -1,6 +1,7 @@
 package edp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -32,7 +33,7 @@ func TestClient_CreateDataTenant(t *testing.T) {
 	client.setHttpClient(testServer.Client())
 
 	// when
-	err := client.CreateDataTenant(DataTenantPayload{
+	err := client.CreateDataTenant(context.Background(), DataTenantPayload{
 		Name:        subAccountID,
 		Environment: environment,
 	})
@@ -63,14 +64,14 @@ func TestClient_DeleteDataTenant(t *testing.T) {
 	client := NewClient(config, logger.NewLogDummy())
 	client.setHttpClient(testServer.Client())
 
-	err := client.CreateDataTenant(DataTenantPayload{
+	err := client.CreateDataTenant(context.Background(), DataTenantPayload{
 		Name:        subAccountID,
 		Environment: environment,
 	})
 	assert.NoError(t, err)
 
 	// when
-	err = client.DeleteDataTenant(subAccountID, environment)
+	err = client.DeleteDataTenant(context.Background(), subAccountID, environment)
 
 	// then
 	assert.NoError(t, err)
@@ -93,16 +94,16 @@ func TestClient_CreateMetadataTenant(t *testing.T) {
 	client.setHttpClient(testServer.Client())
 
 	// when
-	err := client.CreateMetadataTenant(subAccountID, environment, MetadataTenantPayload{Key: "tK", Value: "tV"})
+	err := client.CreateMetadataTenant(context.Background(), subAccountID, environment, MetadataTenantPayload{Key: "tK", Value: "tV"})
 	assert.NoError(t, err)
 
-	err = client.CreateMetadataTenant(subAccountID, environment, MetadataTenantPayload{Key: "tK2", Value: "tV2"})
+	err = client.CreateMetadataTenant(context.Background(), subAccountID, environment, MetadataTenantPayload{Key: "tK2", Value: "tV2"})
 	assert.NoError(t, err)
 
 	// then
 	assert.NoError(t, err)
 
-	data, err := client.GetMetadataTenant(subAccountID, environment)
+	data, err := client.GetMetadataTenant(context.Background(), subAccountID, environment)
 	assert.NoError(t, err)
 	assert.Len(t, data, 2)
 }
@@ -120,16 +121,16 @@ func TestClient_DeleteMetadataTenant(t *testing.T) {
 	client := NewClient(config, logger.NewLogDummy())
 	client.setHttpClient(testServer.Client())
 
-	err := client.CreateMetadataTenant(subAccountID, environment, MetadataTenantPayload{Key: key, Value: "tV"})
+	err := client.CreateMetadataTenant(context.Background(), subAccountID, environment, MetadataTenantPayload{Key: key, Value: "tV"})
 	assert.NoError(t, err)
 
 	// when
-	err = client.DeleteMetadataTenant(subAccountID, environment, key)
+	err = client.DeleteMetadataTenant(context.Background(), subAccountID, environment, key)
 
 	// then
 	assert.NoError(t, err)
 
-	data, err := client.GetMetadataTenant(subAccountID, environment)
+	data, err := client.GetMetadataTenant(context.Background(), subAccountID, environment)
 	assert.NoError(t, err)
 	assert.Len(t, data, 0)
 }
@@ -0,0 +1,50 @@
+package health
+
+import (
+	"context"
+	"net/http"
+
+	gardenerclient "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Pinger is implemented by the database connection
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// NewDatabaseChecker verifies the database connection
+func NewDatabaseChecker(db Pinger) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	})
+}
+
+// NewHTTPChecker verifies that the service responds on the given URL. Any response other than the server error passes
+// the check, because the endpoints of the services require the credentials or the specific requests.
+func NewHTTPChecker(client *http.Client, url string) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return errors.Wrap(err, "while creating request")
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return errors.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return nil
+	})
+}
+
+// NewGardenerChecker verifies the access to the shoots in the Gardener project
+func NewGardenerChecker(shoots gardenerclient.ShootInterface) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		_, err := shoots.List(v1.ListOptions{Limit: 1})
+		return err
+	})
+}
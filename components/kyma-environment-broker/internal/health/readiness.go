@@ -0,0 +1,164 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+)
+
+type Config struct {
+	// CheckTimeout is the time after which the check of a dependency fails
+	CheckTimeout time.Duration `envconfig:"default=3s"`
+	// CheckCacheTTL is the time for which the result of the check is reused, so the probes do not load the dependencies
+	CheckCacheTTL time.Duration `envconfig:"default=30s"`
+}
+
+// Checker verifies that a dependency of the broker is available
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts the function to the Checker
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Dependency is checked by the readiness endpoint
+type Dependency struct {
+	Name string
+	// Critical dependencies fail the readiness, the failures of the other ones are only reported
+	Critical bool
+	Checker  Checker
+}
+
+// Report is the response of the readiness endpoint
+type Report struct {
+	Ready  bool          `json:"ready"`
+	Checks []CheckResult `json:"checks"`
+}
+
+type CheckResult struct {
+	Name      string    `json:"name"`
+	Critical  bool      `json:"critical"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// Readiness checks the dependencies and reports whether the broker is ready to serve the requests
+type Readiness struct {
+	checks []*cachedCheck
+}
+
+func NewReadiness(cfg Config, dependencies ...Dependency) *Readiness {
+	checks := make([]*cachedCheck, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		checks = append(checks, &cachedCheck{
+			dependency: dependency,
+			timeout:    cfg.CheckTimeout,
+			ttl:        cfg.CheckCacheTTL,
+			now:        time.Now,
+		})
+	}
+	return &Readiness{checks: checks}
+}
+
+// Report checks the dependencies concurrently, the results of the recent checks are reused
+func (r *Readiness) Report() Report {
+	results := make([]CheckResult, len(r.checks))
+	var wg sync.WaitGroup
+	for i, check := range r.checks {
+		wg.Add(1)
+		go func(i int, check *cachedCheck) {
+			defer wg.Done()
+			results[i] = check.result()
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := Report{Ready: true, Checks: results}
+	for _, result := range results {
+		if result.Critical && result.Status != StatusOK {
+			report.Ready = false
+		}
+	}
+	return report
+}
+
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	writeReport(w, r.Report())
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	if report.Ready {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// cachedCheck runs the check with the timeout and keeps the result for the TTL. The result is shared by the requests,
+// so the check does not depend on their contexts. The concurrent requests wait for the running check instead of starting another one.
+type cachedCheck struct {
+	dependency Dependency
+	timeout    time.Duration
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu   sync.Mutex
+	last *CheckResult
+}
+
+func (c *cachedCheck) result() CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.last != nil && c.now().Sub(c.last.CheckedAt) < c.ttl {
+		return *c.last
+	}
+
+	result := CheckResult{
+		Name:      c.dependency.Name,
+		Critical:  c.dependency.Critical,
+		Status:    StatusOK,
+		CheckedAt: c.now(),
+	}
+	if err := c.run(); err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	}
+	c.last = &result
+
+	return result
+}
+
+// run returns on the timeout also if the checker does not respect the context
+func (c *cachedCheck) run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.dependency.Checker.Check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return errors.Errorf("check did not finish within %s", c.timeout)
+	}
+}
@@ -0,0 +1,157 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadiness(t *testing.T) {
+	cfg := Config{CheckTimeout: 100 * time.Millisecond, CheckCacheTTL: time.Minute}
+
+	t.Run("should be ready if only the non-critical dependency fails", func(t *testing.T) {
+		// given
+		readiness := NewReadiness(cfg,
+			Dependency{Name: "database", Critical: true, Checker: fixChecker(nil)},
+			Dependency{Name: "avs", Checker: fixChecker(errors.New("connection refused"))},
+		)
+
+		// when
+		code, report := callReadiness(t, readiness)
+
+		// then
+		assert.Equal(t, http.StatusOK, code)
+		assert.True(t, report.Ready)
+		require.Len(t, report.Checks, 2)
+		assert.Equal(t, StatusOK, report.Checks[0].Status)
+		assert.Equal(t, StatusFailed, report.Checks[1].Status)
+		assert.Equal(t, "connection refused", report.Checks[1].Error)
+	})
+
+	t.Run("should not be ready if the critical dependency fails", func(t *testing.T) {
+		// given
+		readiness := NewReadiness(cfg,
+			Dependency{Name: "database", Critical: true, Checker: fixChecker(errors.New("connection refused"))},
+			Dependency{Name: "avs", Checker: fixChecker(nil)},
+		)
+
+		// when
+		code, report := callReadiness(t, readiness)
+
+		// then
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.False(t, report.Ready)
+	})
+
+	t.Run("should fail the check which does not finish within the timeout", func(t *testing.T) {
+		// given
+		blocked := make(chan struct{})
+		defer close(blocked)
+		readiness := NewReadiness(cfg, Dependency{Name: "gardener", Critical: true, Checker: CheckerFunc(func(ctx context.Context) error {
+			<-blocked
+			return nil
+		})})
+
+		// when
+		report := readiness.Report()
+
+		// then
+		assert.False(t, report.Ready)
+		assert.Contains(t, report.Checks[0].Error, "check did not finish within 100ms")
+	})
+
+	t.Run("should reuse the result until the TTL passes", func(t *testing.T) {
+		// given
+		var calls int32
+		readiness := NewReadiness(cfg, Dependency{Name: "provisioner", Critical: true, Checker: CheckerFunc(func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		})})
+		now := time.Now()
+		readiness.checks[0].now = func() time.Time { return now }
+
+		// when
+		readiness.Report()
+		readiness.Report()
+
+		// then
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		// when
+		now = now.Add(cfg.CheckCacheTTL)
+		readiness.Report()
+
+		// then
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestHTTPChecker(t *testing.T) {
+	for name, tc := range map[string]struct {
+		statusCode int
+		healthy    bool
+	}{
+		"ok":           {statusCode: http.StatusOK, healthy: true},
+		"unauthorized": {statusCode: http.StatusUnauthorized, healthy: true},
+		"server error": {statusCode: http.StatusBadGateway, healthy: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			// when
+			err := NewHTTPChecker(server.Client(), server.URL).Check(context.Background())
+
+			// then
+			assert.Equal(t, tc.healthy, err == nil)
+		})
+	}
+}
+
+func TestServer_Readiness(t *testing.T) {
+	// given
+	srv := &Server{}
+	handler := srv.readinessHandler()
+
+	// when
+	rr := httptest.NewRecorder()
+	handler(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	// then
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+	// when
+	srv.SetReadiness(NewReadiness(Config{CheckTimeout: time.Second}, Dependency{Name: "database", Critical: true, Checker: fixChecker(nil)}))
+	rr = httptest.NewRecorder()
+	handler(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	// then
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+}
+
+func fixChecker(err error) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		return err
+	})
+}
+
+func callReadiness(t *testing.T, readiness *Readiness) (int, Report) {
+	rr := httptest.NewRecorder()
+	readiness.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var report Report
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&report))
+	return rr.Code, report
+}
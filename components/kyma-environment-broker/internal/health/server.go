@@ -3,6 +3,7 @@ package health
 import (
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
@@ -11,6 +12,9 @@ import (
 type Server struct {
 	Address string
 	Log     log.FieldLogger
+
+	mu        sync.RWMutex
+	readiness *Readiness
 }
 
 func NewServer(host, port string, log *log.Logger) *Server {
@@ -20,9 +24,18 @@ func NewServer(host, port string, log *log.Logger) *Server {
 	}
 }
 
+// SetReadiness sets the checks of the readiness endpoint. The broker is not ready until they are set,
+// so they are set when the broker is started and its dependencies are created.
+func (srv *Server) SetReadiness(readiness *Readiness) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.readiness = readiness
+}
+
 func (srv *Server) ServeAsync() {
 	healthRouter := mux.NewRouter()
 	healthRouter.HandleFunc("/healthz", livenessHandler())
+	healthRouter.HandleFunc("/readyz", srv.readinessHandler())
 	go func() {
 		err := http.ListenAndServe(srv.Address, healthRouter)
 		if err != nil {
@@ -37,3 +50,17 @@ func livenessHandler() func(w http.ResponseWriter, _ *http.Request) {
 		return
 	}
 }
+
+func (srv *Server) readinessHandler() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		srv.mu.RLock()
+		readiness := srv.readiness
+		srv.mu.RUnlock()
+
+		if readiness == nil {
+			writeReport(w, Report{Ready: false, Checks: []CheckResult{}})
+			return
+		}
+		readiness.ServeHTTP(w, r)
+	}
+}
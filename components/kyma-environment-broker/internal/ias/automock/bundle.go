@@ -3,6 +3,8 @@
 package automock
 
 import (
+	context "context"
+
 	ias "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ias"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,13 +14,13 @@ type Bundle struct {
 	mock.Mock
 }
 
-// ConfigureServiceProvider provides a mock function with given fields:
-func (_m *Bundle) ConfigureServiceProvider() error {
-	ret := _m.Called()
+// ConfigureServiceProvider provides a mock function with given fields: ctx
+func (_m *Bundle) ConfigureServiceProvider(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -26,13 +28,13 @@ func (_m *Bundle) ConfigureServiceProvider() error {
 	return r0
 }
 
-// ConfigureServiceProviderType provides a mock function with given fields: path
-func (_m *Bundle) ConfigureServiceProviderType(path string) error {
-	ret := _m.Called(path)
+// ConfigureServiceProviderType provides a mock function with given fields: ctx, path
+func (_m *Bundle) ConfigureServiceProviderType(ctx context.Context, path string) error {
+	ret := _m.Called(ctx, path)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(path)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, path)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -40,13 +42,13 @@ func (_m *Bundle) ConfigureServiceProviderType(path string) error {
 	return r0
 }
 
-// CreateServiceProvider provides a mock function with given fields:
-func (_m *Bundle) CreateServiceProvider() error {
-	ret := _m.Called()
+// CreateServiceProvider provides a mock function with given fields: ctx
+func (_m *Bundle) CreateServiceProvider(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -54,13 +56,13 @@ func (_m *Bundle) CreateServiceProvider() error {
 	return r0
 }
 
-// DeleteServiceProvider provides a mock function with given fields:
-func (_m *Bundle) DeleteServiceProvider() error {
-	ret := _m.Called()
+// DeleteServiceProvider provides a mock function with given fields: ctx
+func (_m *Bundle) DeleteServiceProvider(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -68,13 +70,13 @@ func (_m *Bundle) DeleteServiceProvider() error {
 	return r0
 }
 
-// FetchServiceProviderData provides a mock function with given fields:
-func (_m *Bundle) FetchServiceProviderData() error {
-	ret := _m.Called()
+// FetchServiceProviderData provides a mock function with given fields: ctx
+func (_m *Bundle) FetchServiceProviderData(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -82,13 +84,13 @@ func (_m *Bundle) FetchServiceProviderData() error {
 	return r0
 }
 
-// GenerateSecret provides a mock function with given fields:
-func (_m *Bundle) GenerateSecret() (*ias.ServiceProviderSecret, error) {
-	ret := _m.Called()
+// GenerateSecret provides a mock function with given fields: ctx
+func (_m *Bundle) GenerateSecret(ctx context.Context) (*ias.ServiceProviderSecret, error) {
+	ret := _m.Called(ctx)
 
 	var r0 *ias.ServiceProviderSecret
-	if rf, ok := ret.Get(0).(func() *ias.ServiceProviderSecret); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *ias.ServiceProviderSecret); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*ias.ServiceProviderSecret)
@@ -96,8 +98,8 @@ func (_m *Bundle) GenerateSecret() (*ias.ServiceProviderSecret, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,6 +1,7 @@
 package ias
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -12,15 +13,15 @@ type (
 	}
 
 	Bundle interface {
-		FetchServiceProviderData() error
+		FetchServiceProviderData(ctx context.Context) error
 		ServiceProviderName() string
 		ServiceProviderType() string
 		ServiceProviderExist() bool
-		CreateServiceProvider() error
-		DeleteServiceProvider() error
-		ConfigureServiceProvider() error
-		ConfigureServiceProviderType(path string) error
-		GenerateSecret() (*ServiceProviderSecret, error)
+		CreateServiceProvider(ctx context.Context) error
+		DeleteServiceProvider(ctx context.Context) error
+		ConfigureServiceProvider(ctx context.Context) error
+		ConfigureServiceProviderType(ctx context.Context, path string) error
+		GenerateSecret(ctx context.Context) (*ServiceProviderSecret, error)
 	}
 )
 
@@ -1,6 +1,7 @@
 package ias
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -24,18 +25,18 @@ type (
 
 //go:generate mockery -name=IASCLient -output=automock -outpkg=automock -case=underscore
 type IASCLient interface {
-	GetCompany() (*Company, error)
-	CreateServiceProvider(string, string) error
-	DeleteServiceProvider(string) error
-	DeleteSecret(SecretsRef) error
-	GenerateServiceProviderSecret(SecretConfiguration) (*ServiceProviderSecret, error)
+	GetCompany(context.Context) (*Company, error)
+	CreateServiceProvider(context.Context, string, string) error
+	DeleteServiceProvider(context.Context, string) error
+	DeleteSecret(context.Context, SecretsRef) error
+	GenerateServiceProviderSecret(context.Context, SecretConfiguration) (*ServiceProviderSecret, error)
 	AuthenticationURL(ProviderID) string
-	SetOIDCConfiguration(string, OIDCType) error
-	SetSAMLConfiguration(string, SAMLType) error
-	SetAssertionAttribute(string, PostAssertionAttributes) error
-	SetSubjectNameIdentifier(string, SubjectNameIdentifier) error
-	SetAuthenticationAndAccess(string, AuthenticationAndAccess) error
-	SetDefaultAuthenticatingIDP(DefaultAuthIDPConfig) error
+	SetOIDCConfiguration(context.Context, string, OIDCType) error
+	SetSAMLConfiguration(context.Context, string, SAMLType) error
+	SetAssertionAttribute(context.Context, string, PostAssertionAttributes) error
+	SetSubjectNameIdentifier(context.Context, string, SubjectNameIdentifier) error
+	SetAuthenticationAndAccess(context.Context, string, AuthenticationAndAccess) error
+	SetDefaultAuthenticatingIDP(context.Context, DefaultAuthIDPConfig) error
 }
 
 type ServiceProviderBundle struct {
@@ -72,8 +73,8 @@ func (b *ServiceProviderBundle) ServiceProviderType() string {
 
 // FetchServiceProviderData fetches all ServiceProviders and IdentityProviders for company
 // saves specific elements based on the name
-func (b *ServiceProviderBundle) FetchServiceProviderData() error {
-	company, err := b.client.GetCompany()
+func (b *ServiceProviderBundle) FetchServiceProviderData(ctx context.Context) error {
+	company, err := b.client.GetCompany(ctx)
 	if err != nil {
 		return errors.Wrap(err, "while getting company")
 	}
@@ -106,12 +107,12 @@ func (b *ServiceProviderBundle) ServiceProviderExist() bool {
 
 // CreateServiceProvider creates new ServiceProvider on IAS based on name
 // it will be create in specific company/organization
-func (b *ServiceProviderBundle) CreateServiceProvider() error {
-	err := b.client.CreateServiceProvider(b.serviceProviderName, b.organization)
+func (b *ServiceProviderBundle) CreateServiceProvider(ctx context.Context) error {
+	err := b.client.CreateServiceProvider(ctx, b.serviceProviderName, b.organization)
 	if err != nil {
 		return errors.Wrap(err, "while creating ServiceProvider")
 	}
-	err = b.FetchServiceProviderData()
+	err = b.FetchServiceProviderData(ctx)
 	if err != nil {
 		return errors.Wrap(err, "while fetching ServiceProvider")
 	}
@@ -120,8 +121,8 @@ func (b *ServiceProviderBundle) CreateServiceProvider() error {
 }
 
 // DeleteServiceProvider removes ServiceProvider from IAS
-func (b *ServiceProviderBundle) DeleteServiceProvider() error {
-	err := b.FetchServiceProviderData()
+func (b *ServiceProviderBundle) DeleteServiceProvider(ctx context.Context) error {
+	err := b.FetchServiceProviderData(ctx)
 	if err != nil {
 		return errors.Wrap(err, "while fetching ServiceProvider before deleting")
 	}
@@ -129,7 +130,7 @@ func (b *ServiceProviderBundle) DeleteServiceProvider() error {
 		return nil
 	}
 
-	err = b.client.DeleteServiceProvider(b.serviceProvider.ID)
+	err = b.client.DeleteServiceProvider(ctx, b.serviceProvider.ID)
 	if err != nil {
 		return errors.Wrap(err, "while deleting ServiceProvider")
 	}
@@ -137,7 +138,7 @@ func (b *ServiceProviderBundle) DeleteServiceProvider() error {
 	return nil
 }
 
-func (b *ServiceProviderBundle) configureServiceProviderOIDCType(serviceProviderName string, redirectURI string) error {
+func (b *ServiceProviderBundle) configureServiceProviderOIDCType(ctx context.Context, serviceProviderName string, redirectURI string) error {
 	iasType := OIDCType{
 		ServiceProviderName: serviceProviderName,
 		SsoType:             b.serviceProviderParams.ssoType,
@@ -146,10 +147,10 @@ func (b *ServiceProviderBundle) configureServiceProviderOIDCType(serviceProvider
 		},
 	}
 
-	return b.client.SetOIDCConfiguration(b.serviceProvider.ID, iasType)
+	return b.client.SetOIDCConfiguration(ctx, b.serviceProvider.ID, iasType)
 }
 
-func (b *ServiceProviderBundle) configureServiceProviderSAMLType(serviceProviderName string, redirectURI string) error {
+func (b *ServiceProviderBundle) configureServiceProviderSAMLType(ctx context.Context, serviceProviderName string, redirectURI string) error {
 	iasType := SAMLType{
 		ServiceProviderName: serviceProviderName,
 		ACSEndpoints: []ACSEndpoint{
@@ -161,11 +162,11 @@ func (b *ServiceProviderBundle) configureServiceProviderSAMLType(serviceProvider
 		},
 	}
 
-	return b.client.SetSAMLConfiguration(b.serviceProvider.ID, iasType)
+	return b.client.SetSAMLConfiguration(ctx, b.serviceProvider.ID, iasType)
 }
 
 // ConfigureServiceProviderType sets SSO type, name and URLs based on provided URL for ServiceProvider
-func (b *ServiceProviderBundle) ConfigureServiceProviderType(consolePath string) error {
+func (b *ServiceProviderBundle) ConfigureServiceProviderType(ctx context.Context, consolePath string) error {
 	u, err := url.ParseRequestURI(consolePath)
 	if err != nil {
 		return errors.Wrap(err, "while parsing path for IAS Type")
@@ -175,9 +176,9 @@ func (b *ServiceProviderBundle) ConfigureServiceProviderType(consolePath string)
 
 	switch b.serviceProviderParams.ssoType {
 	case SAML:
-		err = b.configureServiceProviderSAMLType(serviceProviderDNS, redirectURI)
+		err = b.configureServiceProviderSAMLType(ctx, serviceProviderDNS, redirectURI)
 	case OIDC:
-		err = b.configureServiceProviderOIDCType(serviceProviderDNS, redirectURI)
+		err = b.configureServiceProviderOIDCType(ctx, serviceProviderDNS, redirectURI)
 	default:
 		err = errors.Errorf("Unrecognized ssoType: %s", b.serviceProviderParams.ssoType)
 	}
@@ -191,13 +192,13 @@ func (b *ServiceProviderBundle) ConfigureServiceProviderType(consolePath string)
 
 // ConfigureServiceProvider sets configuration such as assertion attributes, name identifier and
 // gropus allows to connect with specific ServiceProvider
-func (b *ServiceProviderBundle) ConfigureServiceProvider() error {
+func (b *ServiceProviderBundle) ConfigureServiceProvider(ctx context.Context) error {
 	// set "AssertionAttributes"
 	attributeDeliver := NewAssertionAttributeDeliver()
 	sciAttributes := PostAssertionAttributes{
 		AssertionAttributes: attributeDeliver.GenerateAssertionAttribute(b.serviceProvider),
 	}
-	err := b.client.SetAssertionAttribute(b.serviceProvider.ID, sciAttributes)
+	err := b.client.SetAssertionAttribute(ctx, b.serviceProvider.ID, sciAttributes)
 	if err != nil {
 		return errors.Wrap(err, "while configuring AssertionAttributes")
 	}
@@ -206,7 +207,7 @@ func (b *ServiceProviderBundle) ConfigureServiceProvider() error {
 	subjectNameIdentifier := SubjectNameIdentifier{
 		NameIDAttribute: "mail",
 	}
-	err = b.client.SetSubjectNameIdentifier(b.serviceProvider.ID, subjectNameIdentifier)
+	err = b.client.SetSubjectNameIdentifier(ctx, b.serviceProvider.ID, subjectNameIdentifier)
 	if err != nil {
 		return errors.Wrap(err, "while configuring SubjectNameIdentifier")
 	}
@@ -217,7 +218,7 @@ func (b *ServiceProviderBundle) ConfigureServiceProvider() error {
 		ID:             b.serviceProvider.ID,
 		DefaultAuthIDP: b.client.AuthenticationURL(b.providerID),
 	}
-	err = b.client.SetDefaultAuthenticatingIDP(defaultAuthIDP)
+	err = b.client.SetDefaultAuthenticatingIDP(ctx, defaultAuthIDP)
 	if err != nil {
 		return errors.Wrap(err, "while configuring DefaultAuthenticatingIDP")
 	}
@@ -239,7 +240,7 @@ func (b *ServiceProviderBundle) ConfigureServiceProvider() error {
 				GroupType: "Cloud",
 			}
 		}
-		err = b.client.SetAuthenticationAndAccess(b.serviceProvider.ID, authenticationAndAccess)
+		err = b.client.SetAuthenticationAndAccess(ctx, b.serviceProvider.ID, authenticationAndAccess)
 		if err != nil {
 			return errors.Wrap(err, "while configuring AuthenticationAndAccess")
 		}
@@ -249,8 +250,8 @@ func (b *ServiceProviderBundle) ConfigureServiceProvider() error {
 }
 
 // GenerateSecret generates new ID and Secret for ServiceProvider, removes already existing secrets
-func (b *ServiceProviderBundle) GenerateSecret() (*ServiceProviderSecret, error) {
-	err := b.removeSecrets()
+func (b *ServiceProviderBundle) GenerateSecret(ctx context.Context) (*ServiceProviderSecret, error) {
+	err := b.removeSecrets(ctx)
 	if err != nil {
 		return &ServiceProviderSecret{}, errors.Wrap(err, "while removing existing secrets")
 	}
@@ -264,7 +265,7 @@ func (b *ServiceProviderBundle) GenerateSecret() (*ServiceProviderSecret, error)
 		},
 	}
 
-	sps, err := b.client.GenerateServiceProviderSecret(secretCfg)
+	sps, err := b.client.GenerateServiceProviderSecret(ctx, secretCfg)
 	if err != nil {
 		return &ServiceProviderSecret{}, errors.Wrap(err, "while creating ServiceProviderSecret")
 	}
@@ -272,7 +273,7 @@ func (b *ServiceProviderBundle) GenerateSecret() (*ServiceProviderSecret, error)
 	return sps, nil
 }
 
-func (b *ServiceProviderBundle) removeSecrets() error {
+func (b *ServiceProviderBundle) removeSecrets(ctx context.Context) error {
 	if len(b.serviceProvider.Secret) == 0 {
 		return nil
 	}
@@ -286,5 +287,5 @@ func (b *ServiceProviderBundle) removeSecrets() error {
 		ClientID:         b.serviceProvider.UserForRest,
 		ClientSecretsIDs: secretsIDs,
 	}
-	return b.client.DeleteSecret(deleteSecrets)
+	return b.client.DeleteSecret(ctx, deleteSecrets)
 }
@@ -1,6 +1,7 @@
 package ias
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -25,7 +26,7 @@ func TestServiceProviderBundle_FetchServiceProviderData(t *testing.T) {
 	bundle := NewServiceProviderBundle(FakeGrafanaName, ServiceProviderInputs[SPGrafanaID], client, Config{IdentityProvider: FakeIdentityProviderName})
 
 	// when
-	err := bundle.FetchServiceProviderData()
+	err := bundle.FetchServiceProviderData(context.Background())
 
 	// then
 	assert.NoError(t, err)
@@ -39,12 +40,12 @@ func TestServiceProviderBundle_CreateServiceProvider(t *testing.T) {
 	bundle := NewServiceProviderBundle("sp", ServiceProviderInputs[SPGrafanaID], client, Config{IdentityProvider: FakeIdentityProviderName})
 
 	// when
-	err := bundle.CreateServiceProvider()
+	err := bundle.CreateServiceProvider(context.Background())
 
 	// then
 	assert.NoError(t, err)
 
-	err = bundle.FetchServiceProviderData()
+	err = bundle.FetchServiceProviderData(context.Background())
 	assert.NoError(t, err)
 	assert.True(t, bundle.ServiceProviderExist())
 }
@@ -54,11 +55,11 @@ func TestServiceProviderBundle_ConfigureServiceProviderType_OIDC(t *testing.T) {
 	client := NewFakeClient()
 	bundle := NewServiceProviderBundle(FakeGrafanaName, ServiceProviderInputs[SPGrafanaID], client, Config{IdentityProvider: FakeIdentityProviderName})
 
-	err := bundle.FetchServiceProviderData()
+	err := bundle.FetchServiceProviderData(context.Background())
 	assert.NoError(t, err)
 
 	// when
-	err = bundle.ConfigureServiceProviderType("https://console.example.com")
+	err = bundle.ConfigureServiceProviderType(context.Background(), "https://console.example.com")
 
 	// then
 	assert.NoError(t, err)
@@ -74,11 +75,11 @@ func TestServiceProviderBundle_ConfigureServiceProvider(t *testing.T) {
 	client := NewFakeClient()
 	bundle := NewServiceProviderBundle(FakeGrafanaName, ServiceProviderInputs[SPGrafanaID], client, Config{IdentityProvider: FakeIdentityProviderName})
 
-	err := bundle.FetchServiceProviderData()
+	err := bundle.FetchServiceProviderData(context.Background())
 	assert.NoError(t, err)
 
 	// when
-	err = bundle.ConfigureServiceProvider()
+	err = bundle.ConfigureServiceProvider(context.Background())
 
 	// then
 	assert.NoError(t, err)
@@ -111,11 +112,11 @@ func TestServiceProviderBundle_GenerateSecret(t *testing.T) {
 	client := NewFakeClient()
 	bundle := NewServiceProviderBundle(FakeGrafanaName, ServiceProviderInputs[SPGrafanaID], client, Config{IdentityProvider: FakeIdentityProviderName})
 
-	err := bundle.FetchServiceProviderData()
+	err := bundle.FetchServiceProviderData(context.Background())
 	assert.NoError(t, err)
 
 	// when
-	secret, err := bundle.GenerateSecret()
+	secret, err := bundle.GenerateSecret(context.Background())
 
 	// then
 	assert.NoError(t, err)
@@ -130,9 +131,9 @@ func TestServiceProviderBundle_GenerateSecret(t *testing.T) {
 	assert.ElementsMatch(t, []string{"ManageApp", "ManageUsers", "OAuth"}, provider.Secret[0].Scopes)
 
 	// when
-	err = bundle.FetchServiceProviderData()
+	err = bundle.FetchServiceProviderData(context.Background())
 	assert.NoError(t, err)
-	secret, err = bundle.GenerateSecret()
+	secret, err = bundle.GenerateSecret(context.Background())
 
 	// then
 	provider, err = client.GetServiceProvider(FakeGrafanaID)
@@ -146,7 +147,7 @@ func TestServiceProviderBundle_DeleteServiceProvider(t *testing.T) {
 	bundle := NewServiceProviderBundle(FakeGrafanaName, ServiceProviderInputs[SPGrafanaID], client, Config{IdentityProvider: FakeIdentityProviderName})
 
 	// when
-	err := bundle.DeleteServiceProvider()
+	err := bundle.DeleteServiceProvider(context.Background())
 
 	// then
 	assert.NoError(t, err)
@@ -2,6 +2,7 @@ package ias
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -52,37 +53,37 @@ func NewClient(cli *http.Client, cfg ClientConfig) *Client {
 	}
 }
 
-func (c *Client) SetOIDCConfiguration(spID string, payload OIDCType) error {
-	return c.call(c.serviceProviderPath(spID), payload)
+func (c *Client) SetOIDCConfiguration(ctx context.Context, spID string, payload OIDCType) error {
+	return c.call(ctx, c.serviceProviderPath(spID), payload)
 }
 
-func (c *Client) SetSAMLConfiguration(spID string, payload SAMLType) error {
-	return c.call(c.serviceProviderPath(spID), payload)
+func (c *Client) SetSAMLConfiguration(ctx context.Context, spID string, payload SAMLType) error {
+	return c.call(ctx, c.serviceProviderPath(spID), payload)
 }
 
-func (c *Client) SetAssertionAttribute(spID string, payload PostAssertionAttributes) error {
-	return c.call(c.serviceProviderPath(spID), payload)
+func (c *Client) SetAssertionAttribute(ctx context.Context, spID string, payload PostAssertionAttributes) error {
+	return c.call(ctx, c.serviceProviderPath(spID), payload)
 }
 
-func (c *Client) SetSubjectNameIdentifier(spID string, payload SubjectNameIdentifier) error {
-	return c.call(c.serviceProviderPath(spID), payload)
+func (c *Client) SetSubjectNameIdentifier(ctx context.Context, spID string, payload SubjectNameIdentifier) error {
+	return c.call(ctx, c.serviceProviderPath(spID), payload)
 }
 
-func (c *Client) SetAuthenticationAndAccess(spID string, payload AuthenticationAndAccess) error {
+func (c *Client) SetAuthenticationAndAccess(ctx context.Context, spID string, payload AuthenticationAndAccess) error {
 	pathAccess := fmt.Sprintf(PathAccess, spID)
 
-	return c.call(pathAccess, payload)
+	return c.call(ctx, pathAccess, payload)
 }
 
-func (c *Client) SetDefaultAuthenticatingIDP(payload DefaultAuthIDPConfig) error {
-	return c.call(PathServiceProviders, payload)
+func (c *Client) SetDefaultAuthenticatingIDP(ctx context.Context, payload DefaultAuthIDPConfig) error {
+	return c.call(ctx, PathServiceProviders, payload)
 }
 
-func (c *Client) GetCompany() (_ *Company, err error) {
+func (c *Client) GetCompany(ctx context.Context) (_ *Company, err error) {
 	company := &Company{}
 	request := &Request{Method: http.MethodGet, Path: PathCompanyGlobal}
 
-	response, err := c.do(request)
+	response, err := c.do(ctx, request)
 	defer func() {
 		if closeErr := c.closeResponseBody(response); closeErr != nil {
 			err = kebError.AsTemporaryError(closeErr, "while closing response body with company data")
@@ -100,7 +101,7 @@ func (c *Client) GetCompany() (_ *Company, err error) {
 	return company, nil
 }
 
-func (c *Client) CreateServiceProvider(serviceName, companyID string) (err error) {
+func (c *Client) CreateServiceProvider(ctx context.Context, serviceName, companyID string) (err error) {
 	payload := fmt.Sprintf("sp_name=%s&company_id=%s", serviceName, companyID)
 	request := &Request{
 		Method:  http.MethodPost,
@@ -109,7 +110,7 @@ func (c *Client) CreateServiceProvider(serviceName, companyID string) (err error
 		Headers: map[string]string{"content-type": "application/x-www-form-urlencoded"},
 	}
 
-	response, err := c.do(request)
+	response, err := c.do(ctx, request)
 	defer func() {
 		if closeErr := c.closeResponseBody(response); closeErr != nil {
 			err = kebError.AsTemporaryError(closeErr, "while closing response body for ServiceProvider creation")
@@ -122,13 +123,13 @@ func (c *Client) CreateServiceProvider(serviceName, companyID string) (err error
 	return nil
 }
 
-func (c *Client) DeleteServiceProvider(spID string) (err error) {
+func (c *Client) DeleteServiceProvider(ctx context.Context, spID string) (err error) {
 	request := &Request{
 		Method: http.MethodPut,
 		Path:   fmt.Sprintf("%s?sp_id=%s", PathDelete, spID),
 		Delete: true,
 	}
-	response, err := c.do(request)
+	response, err := c.do(ctx, request)
 	defer func() {
 		if closeErr := c.closeResponseBody(response); closeErr != nil {
 			err = kebError.AsTemporaryError(closeErr, "while closing response body for ServiceProvider deletion")
@@ -141,14 +142,14 @@ func (c *Client) DeleteServiceProvider(spID string) (err error) {
 	return nil
 }
 
-func (c *Client) DeleteSecret(payload SecretsRef) (err error) {
+func (c *Client) DeleteSecret(ctx context.Context, payload SecretsRef) (err error) {
 	request, err := c.jsonRequest(PathDeleteSecret, http.MethodDelete, payload)
 	if err != nil {
 		return errors.Wrapf(err, "while creating json request for path %s", PathDeleteSecret)
 	}
 	request.Delete = true
 
-	response, err := c.do(request)
+	response, err := c.do(ctx, request)
 	defer func() {
 		if closeErr := c.closeResponseBody(response); closeErr != nil {
 			err = kebError.AsTemporaryError(closeErr, "while closing response body for Secret deletion")
@@ -161,14 +162,14 @@ func (c *Client) DeleteSecret(payload SecretsRef) (err error) {
 	return nil
 }
 
-func (c *Client) GenerateServiceProviderSecret(secretCfg SecretConfiguration) (_ *ServiceProviderSecret, err error) {
+func (c *Client) GenerateServiceProviderSecret(ctx context.Context, secretCfg SecretConfiguration) (_ *ServiceProviderSecret, err error) {
 	secretResponse := &ServiceProviderSecret{}
 	request, err := c.jsonRequest(PathServiceProviders, http.MethodPut, secretCfg)
 	if err != nil {
 		return secretResponse, errors.Wrap(err, "while creating request for secret provider")
 	}
 
-	response, err := c.do(request)
+	response, err := c.do(ctx, request)
 	defer func() {
 		if closeErr := c.closeResponseBody(response); closeErr != nil {
 			err = kebError.AsTemporaryError(closeErr, "while closing response body for ServiceProviderSecret generating")
@@ -194,13 +195,13 @@ func (c *Client) serviceProviderPath(spID string) string {
 	return fmt.Sprintf("%s/%s", PathServiceProviders, spID)
 }
 
-func (c *Client) call(path string, payload interface{}) (err error) {
+func (c *Client) call(ctx context.Context, path string, payload interface{}) (err error) {
 	request, err := c.jsonRequest(path, http.MethodPut, payload)
 	if err != nil {
 		return errors.Wrapf(err, "while creating json request for path %s", path)
 	}
 
-	response, err := c.do(request)
+	response, err := c.do(ctx, request)
 	defer func() {
 		if closeErr := c.closeResponseBody(response); closeErr != nil {
 			err = kebError.AsTemporaryError(closeErr, "while closing response body for call method")
@@ -229,9 +230,9 @@ func (c *Client) jsonRequest(path string, method string, payload interface{}) (*
 	}, nil
 }
 
-func (c *Client) do(sciReq *Request) (*http.Response, error) {
+func (c *Client) do(ctx context.Context, sciReq *Request) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s", c.config.URL, sciReq.Path)
-	req, err := http.NewRequest(sciReq.Method, url, sciReq.Body)
+	req, err := http.NewRequestWithContext(ctx, sciReq.Method, url, sciReq.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package ias
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -30,7 +31,7 @@ func TestClient_GetCompany(t *testing.T) {
 	client := NewClient(server.Client(), ClientConfig{URL: server.URL, ID: "admin", Secret: "admin123"})
 
 	// when
-	company, err := client.GetCompany()
+	company, err := client.GetCompany(context.Background())
 
 	// then
 	assert.NoError(t, err)
@@ -46,7 +47,7 @@ func TestClient_CreateServiceProvider(t *testing.T) {
 	client := NewClient(server.Client(), ClientConfig{URL: server.URL, ID: "admin", Secret: "admin123"})
 
 	// when
-	err := client.CreateServiceProvider("someName", companyID)
+	err := client.CreateServiceProvider(context.Background(), "someName", companyID)
 
 	// then
 	assert.NoError(t, err)
@@ -75,7 +76,7 @@ func TestClient_SetOIDCConfiguration(t *testing.T) {
 			PostLogoutRedirectURIs: nil,
 		},
 	}
-	err := client.SetOIDCConfiguration(serviceProviderID, iasType)
+	err := client.SetOIDCConfiguration(context.Background(), serviceProviderID, iasType)
 
 	// then
 	assert.NoError(t, err)
@@ -110,7 +111,7 @@ func TestClient_SetSAMLConfiguration(t *testing.T) {
 			},
 		},
 	}
-	err := client.SetSAMLConfiguration(serviceProviderID, iasType)
+	err := client.SetSAMLConfiguration(context.Background(), serviceProviderID, iasType)
 
 	// then
 	assert.NoError(t, err)
@@ -144,7 +145,7 @@ func TestClient_SetAssertionAttribute(t *testing.T) {
 			},
 		},
 	}
-	err := client.SetAssertionAttribute(serviceProviderID, attributes)
+	err := client.SetAssertionAttribute(context.Background(), serviceProviderID, attributes)
 
 	// then
 	assert.NoError(t, err)
@@ -171,7 +172,7 @@ func TestClient_SetSubjectNameIdentifier(t *testing.T) {
 	sni := SubjectNameIdentifier{
 		NameIDAttribute: "email",
 	}
-	err := client.SetSubjectNameIdentifier(serviceProviderID, sni)
+	err := client.SetSubjectNameIdentifier(context.Background(), serviceProviderID, sni)
 
 	// then
 	assert.NoError(t, err)
@@ -208,7 +209,7 @@ func TestClient_SetAuthenticationAndAccess(t *testing.T) {
 			},
 		},
 	}
-	err := client.SetAuthenticationAndAccess(serviceProviderID, auth)
+	err := client.SetAuthenticationAndAccess(context.Background(), serviceProviderID, auth)
 
 	// then
 	assert.NoError(t, err)
@@ -239,7 +240,7 @@ func TestClient_SetDefaultAuthenticatingIDP(t *testing.T) {
 		ID:             serviceProviderID,
 		DefaultAuthIDP: "http://example.com",
 	}
-	err := client.SetDefaultAuthenticatingIDP(authIDP)
+	err := client.SetDefaultAuthenticatingIDP(context.Background(), authIDP)
 
 	// then
 	assert.NoError(t, err)
@@ -271,7 +272,7 @@ func TestClient_GenerateServiceProviderSecret(t *testing.T) {
 			Scopes:      []string{"OAuth"},
 		},
 	}
-	secret, err := client.GenerateServiceProviderSecret(sc)
+	secret, err := client.GenerateServiceProviderSecret(context.Background(), sc)
 
 	// then
 	assert.NoError(t, err)
@@ -286,11 +287,11 @@ func TestClient_DeleteServiceProvider(t *testing.T) {
 
 	client := NewClient(server.Client(), ClientConfig{URL: server.URL, ID: "admin", Secret: "admin123"})
 
-	err := client.CreateServiceProvider(serviceProviderID, companyID)
+	err := client.CreateServiceProvider(context.Background(), serviceProviderID, companyID)
 	assert.NoError(t, err)
 
 	// when
-	err = client.DeleteServiceProvider(serviceProviderID)
+	err = client.DeleteServiceProvider(context.Background(), serviceProviderID)
 
 	// then
 	assert.NoError(t, err)
@@ -303,7 +304,7 @@ func TestClient_DeleteServiceProvider(t *testing.T) {
 	assert.Equal(t, "", string(body))
 
 	// when
-	err = client.DeleteServiceProvider(serviceProviderID)
+	err = client.DeleteServiceProvider(context.Background(), serviceProviderID)
 
 	// then
 	assert.NoError(t, err)
@@ -325,12 +326,12 @@ func TestClient_DeleteSecret(t *testing.T) {
 				Scopes:      []string{"OAuth"},
 			},
 		}
-		_, err := client.GenerateServiceProviderSecret(sc)
+		_, err := client.GenerateServiceProviderSecret(context.Background(), sc)
 		assert.NoError(t, err)
 	}
 
 	// when
-	err := client.DeleteSecret(SecretsRef{
+	err := client.DeleteSecret(context.Background(), SecretsRef{
 		ClientID:         userForRest,
 		ClientSecretsIDs: []string{fmt.Sprintf("%s-next", clientID)},
 	})
@@ -1,6 +1,9 @@
 package ias
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 const (
 	FakeIdentityProviderName = "IdentityProviderName"
@@ -47,7 +50,7 @@ func NewFakeClient() *FakeClient {
 	}
 }
 
-func (f *FakeClient) GetCompany() (*Company, error) {
+func (f *FakeClient) GetCompany(_ context.Context) (*Company, error) {
 	var sp []ServiceProvider
 	for _, fsp := range f.serviceProviders {
 		sp = append(sp, *fsp)
@@ -64,7 +67,7 @@ func (f *FakeClient) GetCompany() (*Company, error) {
 	}, nil
 }
 
-func (f *FakeClient) CreateServiceProvider(name string, _ string) error {
+func (f *FakeClient) CreateServiceProvider(_ context.Context, name string, _ string) error {
 	f.serviceProviders = append(f.serviceProviders, &ServiceProvider{
 		DisplayName: name,
 	})
@@ -72,7 +75,7 @@ func (f *FakeClient) CreateServiceProvider(name string, _ string) error {
 	return nil
 }
 
-func (f *FakeClient) SetDefaultAuthenticatingIDP(config DefaultAuthIDPConfig) error {
+func (f *FakeClient) SetDefaultAuthenticatingIDP(_ context.Context, config DefaultAuthIDPConfig) error {
 	serviceProvider, err := f.GetServiceProvider(config.ID)
 	if err != nil {
 		return err
@@ -84,7 +87,7 @@ func (f *FakeClient) SetDefaultAuthenticatingIDP(config DefaultAuthIDPConfig) er
 	return nil
 }
 
-func (f FakeClient) GenerateServiceProviderSecret(ss SecretConfiguration) (*ServiceProviderSecret, error) {
+func (f FakeClient) GenerateServiceProviderSecret(_ context.Context, ss SecretConfiguration) (*ServiceProviderSecret, error) {
 	serviceProvider, err := f.GetServiceProvider(ss.ID)
 	if err != nil {
 		return &ServiceProviderSecret{}, err
@@ -106,7 +109,7 @@ func (f FakeClient) AuthenticationURL(id ProviderID) string {
 	return fmt.Sprintf("https://authentication.com/%s", id)
 }
 
-func (f *FakeClient) SetOIDCConfiguration(id string, iasType OIDCType) error {
+func (f *FakeClient) SetOIDCConfiguration(_ context.Context, id string, iasType OIDCType) error {
 	serviceProvider, err := f.GetServiceProvider(id)
 	if err != nil {
 		return err
@@ -118,7 +121,7 @@ func (f *FakeClient) SetOIDCConfiguration(id string, iasType OIDCType) error {
 	return nil
 }
 
-func (f *FakeClient) SetSAMLConfiguration(id string, iasType SAMLType) error {
+func (f *FakeClient) SetSAMLConfiguration(_ context.Context, id string, iasType SAMLType) error {
 	serviceProvider, err := f.GetServiceProvider(id)
 	if err != nil {
 		return err
@@ -130,7 +133,7 @@ func (f *FakeClient) SetSAMLConfiguration(id string, iasType SAMLType) error {
 	return nil
 }
 
-func (f FakeClient) SetAssertionAttribute(id string, paa PostAssertionAttributes) error {
+func (f FakeClient) SetAssertionAttribute(_ context.Context, id string, paa PostAssertionAttributes) error {
 	serviceProvider, err := f.GetServiceProvider(id)
 	if err != nil {
 		return err
@@ -141,7 +144,7 @@ func (f FakeClient) SetAssertionAttribute(id string, paa PostAssertionAttributes
 	return nil
 }
 
-func (f FakeClient) SetSubjectNameIdentifier(id string, sni SubjectNameIdentifier) error {
+func (f FakeClient) SetSubjectNameIdentifier(_ context.Context, id string, sni SubjectNameIdentifier) error {
 	serviceProvider, err := f.GetServiceProvider(id)
 	if err != nil {
 		return err
@@ -152,7 +155,7 @@ func (f FakeClient) SetSubjectNameIdentifier(id string, sni SubjectNameIdentifie
 	return nil
 }
 
-func (f FakeClient) SetAuthenticationAndAccess(id string, auth AuthenticationAndAccess) error {
+func (f FakeClient) SetAuthenticationAndAccess(_ context.Context, id string, auth AuthenticationAndAccess) error {
 	serviceProvider, err := f.GetServiceProvider(id)
 	if err != nil {
 		return err
@@ -163,7 +166,7 @@ func (f FakeClient) SetAuthenticationAndAccess(id string, auth AuthenticationAnd
 	return nil
 }
 
-func (f *FakeClient) DeleteServiceProvider(id string) error {
+func (f *FakeClient) DeleteServiceProvider(_ context.Context, id string) error {
 	for index, sp := range f.serviceProviders {
 		if sp.ID == id {
 			f.serviceProviders[index] = f.serviceProviders[len(f.serviceProviders)-1]
@@ -176,7 +179,7 @@ func (f *FakeClient) DeleteServiceProvider(id string) error {
 	return nil
 }
 
-func (f *FakeClient) DeleteSecret(payload SecretsRef) error {
+func (f *FakeClient) DeleteSecret(_ context.Context, payload SecretsRef) error {
 	for _, provider := range f.serviceProviders {
 		if provider.UserForRest != payload.ClientID {
 			continue
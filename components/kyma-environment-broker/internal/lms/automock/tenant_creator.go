@@ -2,6 +2,8 @@
 package automock
 
 import (
+	context "context"
+
 	lms "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/lms"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -11,20 +13,20 @@ type TenantCreator struct {
 	mock.Mock
 }
 
-// CreateTenant provides a mock function with given fields: input
-func (_m *TenantCreator) CreateTenant(input lms.CreateTenantInput) (lms.CreateTenantOutput, error) {
-	ret := _m.Called(input)
+// CreateTenant provides a mock function with given fields: ctx, input
+func (_m *TenantCreator) CreateTenant(ctx context.Context, input lms.CreateTenantInput) (lms.CreateTenantOutput, error) {
+	ret := _m.Called(ctx, input)
 
 	var r0 lms.CreateTenantOutput
-	if rf, ok := ret.Get(0).(func(lms.CreateTenantInput) lms.CreateTenantOutput); ok {
-		r0 = rf(input)
+	if rf, ok := ret.Get(0).(func(context.Context, lms.CreateTenantInput) lms.CreateTenantOutput); ok {
+		r0 = rf(ctx, input)
 	} else {
 		r0 = ret.Get(0).(lms.CreateTenantOutput)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(lms.CreateTenantInput) error); ok {
-		r1 = rf(input)
+	if rf, ok := ret.Get(1).(func(context.Context, lms.CreateTenantInput) error); ok {
+		r1 = rf(ctx, input)
 	} else {
 		r1 = ret.Error(1)
 	}
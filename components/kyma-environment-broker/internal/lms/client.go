@@ -2,6 +2,7 @@ package lms
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -14,19 +15,20 @@ import (
 
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/iosafety"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 type Client interface {
-	CreateTenant(input CreateTenantInput) (o CreateTenantOutput, err error)
-	GetTenantStatus(tenantID string) (status TenantStatus, err error)
-	GetTenantInfo(tenantID string) (status TenantInfo, err error)
+	CreateTenant(ctx context.Context, input CreateTenantInput) (o CreateTenantOutput, err error)
+	GetTenantStatus(ctx context.Context, tenantID string) (status TenantStatus, err error)
+	GetTenantInfo(ctx context.Context, tenantID string) (status TenantInfo, err error)
 
-	GetCACertificate(tenantID string) (cert string, found bool, err error)
-	GetCertificateByURL(url string) (cert string, found bool, err error)
-	RequestCertificate(tenantID string, subject pkix.Name) (string, []byte, error)
+	GetCACertificate(ctx context.Context, tenantID string) (cert string, found bool, err error)
+	GetCertificateByURL(ctx context.Context, url string) (cert string, found bool, err error)
+	RequestCertificate(ctx context.Context, tenantID string, subject pkix.Name) (string, []byte, error)
 }
 
 // ClusterType can be ha or single-node
@@ -42,7 +44,8 @@ type client struct {
 	token      string
 	samlTenant string
 
-	log logrus.FieldLogger
+	httpClient *http.Client
+	log        logrus.FieldLogger
 }
 
 const (
@@ -79,6 +82,7 @@ func NewClient(cfg Config, log logrus.FieldLogger) Client {
 		environment: cfg.Environment,
 		token:       cfg.Token,
 		samlTenant:  cfg.SamlTenant,
+		httpClient:  &http.Client{Transport: tracing.NewTransport(http.DefaultTransport)},
 		log:         log,
 	}
 }
@@ -119,7 +123,7 @@ type TenantInfo struct {
 
 // CreateTenant create the LMS tenant
 // Tenant creation means creation of a cluster, which must be reusable for the same tenant/region/project
-func (c *client) CreateTenant(input CreateTenantInput) (o CreateTenantOutput, err error) {
+func (c *client) CreateTenant(ctx context.Context, input CreateTenantInput) (o CreateTenantOutput, err error) {
 	payload := createTenantPayload{
 		Name:        input.Name,
 		Region:      input.Region,
@@ -139,14 +143,14 @@ func (c *client) CreateTenant(input CreateTenantInput) (o CreateTenantOutput, er
 
 	url := fmt.Sprintf("%s/tenants", c.url)
 	c.log.Debugf("url: %s", url)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return CreateTenantOutput{}, errors.Wrapf(err, "while creating request Create Tenant")
 	}
 	req.Header.Add("X-LMS-Token", c.token)
 	req.Header.Add("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return CreateTenantOutput{}, kebError.AsTemporaryError(err, "while calling Create Tenant endpoint")
 	}
@@ -181,14 +185,14 @@ func (c *client) CreateTenant(input CreateTenantInput) (o CreateTenantOutput, er
 	return CreateTenantOutput{ID: output.ID}, nil
 }
 
-func (c *client) GetTenantStatus(tenantID string) (status TenantStatus, err error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/tenants/%s/status", c.url, tenantID), nil)
+func (c *client) GetTenantStatus(ctx context.Context, tenantID string) (status TenantStatus, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/tenants/%s/status", c.url, tenantID), nil)
 	if err != nil {
 		return TenantStatus{}, errors.Wrap(err, "while creating Get Tenant Status request")
 	}
 	req.Header.Add("X-LMS-Token", c.token)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return TenantStatus{}, kebError.AsTemporaryError(err, "while calling Get Tenant Status endpoint")
 	}
@@ -219,14 +223,14 @@ func (c *client) GetTenantStatus(tenantID string) (status TenantStatus, err erro
 	return tenantStatus, nil
 }
 
-func (c *client) GetTenantInfo(tenantID string) (status TenantInfo, err error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/tenants/%s", c.url, tenantID), nil)
+func (c *client) GetTenantInfo(ctx context.Context, tenantID string) (status TenantInfo, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/tenants/%s", c.url, tenantID), nil)
 	if err != nil {
 		return TenantInfo{}, errors.Wrapf(err, "while creating Get Tenant request")
 	}
 	req.Header.Add("X-LMS-Token", c.token)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return TenantInfo{}, kebError.AsTemporaryError(err, "while calling Get Tenant endpoint")
 	}
@@ -257,14 +261,14 @@ func (c *client) GetTenantInfo(tenantID string) (status TenantInfo, err error) {
 	return response, nil
 }
 
-func (c *client) GetCertificateByURL(url string) (cert string, found bool, err error) {
-	req, err := http.NewRequest("GET", url, nil)
+func (c *client) GetCertificateByURL(ctx context.Context, url string) (cert string, found bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", false, errors.Wrapf(err, "while creating Get Certificate request (%s)", url)
 	}
 	req.Header.Add("X-LMS-Token", c.token)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return "", false, kebError.AsTemporaryError(err, "while calling Get Certificate endpoint (%s)", url)
 	}
@@ -301,15 +305,15 @@ func (c *client) GetCertificateByURL(url string) (cert string, found bool, err e
 	return certResponse.Cert, true, nil
 }
 
-func (c *client) getCertificate(tenantID string, certID string) (cert string, found bool, err error) {
-	return c.GetCertificateByURL(fmt.Sprintf("%s/tenants/%s/certs/%s", c.url, tenantID, certID))
+func (c *client) getCertificate(ctx context.Context, tenantID string, certID string) (cert string, found bool, err error) {
+	return c.GetCertificateByURL(ctx, fmt.Sprintf("%s/tenants/%s/certs/%s", c.url, tenantID, certID))
 }
 
-func (c *client) GetCACertificate(tenantID string) (cert string, found bool, err error) {
-	return c.GetCertificateByURL(fmt.Sprintf("%s/tenants/%s/certs/ca", c.url, tenantID))
+func (c *client) GetCACertificate(ctx context.Context, tenantID string) (cert string, found bool, err error) {
+	return c.GetCertificateByURL(ctx, fmt.Sprintf("%s/tenants/%s/certs/ca", c.url, tenantID))
 }
 
-func (c *client) RequestCertificate(tenantID string, subject pkix.Name) (string, []byte, error) {
+func (c *client) RequestCertificate(ctx context.Context, tenantID string, subject pkix.Name) (string, []byte, error) {
 	csr, privateKey, err := c.generateCSR(subject)
 	if err != nil {
 		return "", privateKey, errors.Wrap(err, "while generating CSR")
@@ -325,14 +329,14 @@ func (c *client) RequestCertificate(tenantID string, subject pkix.Name) (string,
 		return "", privateKey, errors.Wrap(err, "while encoding Create Request payload")
 	}
 
-	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/tenants/%s/certs", c.url, tenantID), bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/tenants/%s/certs", c.url, tenantID), bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return "", privateKey, errors.Wrap(err, "while creating request certificate")
 	}
 	req.Header.Add("X-LMS-Token", c.token)
 	req.Header.Add("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return "", privateKey, kebError.AsTemporaryError(err, "while calling Request Certificate endpoint")
 	}
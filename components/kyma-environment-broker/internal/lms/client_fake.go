@@ -1,6 +1,7 @@
 package lms
 
 import (
+	"context"
 	"time"
 
 	"sync"
@@ -41,7 +42,7 @@ type tenantInfo struct {
 	createdAt time.Time
 }
 
-func (f *FakeClient) CreateTenant(input CreateTenantInput) (o CreateTenantOutput, err error) {
+func (f *FakeClient) CreateTenant(_ context.Context, input CreateTenantInput) (o CreateTenantOutput, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	id, _ := uuid.NewRandom()
@@ -55,7 +56,7 @@ func (f *FakeClient) CreateTenant(input CreateTenantInput) (o CreateTenantOutput
 	}, nil
 }
 
-func (f *FakeClient) GetTenantStatus(tenantID string) (status TenantStatus, err error) {
+func (f *FakeClient) GetTenantStatus(_ context.Context, tenantID string) (status TenantStatus, err error) {
 	ti, found := f.data[tenantID]
 	if !found {
 		return TenantStatus{}, errors.New("tenant not exists")
@@ -68,7 +69,7 @@ func (f *FakeClient) GetTenantStatus(tenantID string) (status TenantStatus, err
 	}
 }
 
-func (f *FakeClient) GetTenantInfo(tenantID string) (status TenantInfo, err error) {
+func (f *FakeClient) GetTenantInfo(_ context.Context, tenantID string) (status TenantInfo, err error) {
 	_, found := f.data[tenantID]
 	if !found {
 		return TenantInfo{}, errors.New("tenant not exists")
@@ -76,18 +77,18 @@ func (f *FakeClient) GetTenantInfo(tenantID string) (status TenantInfo, err erro
 	return TenantInfo{DNS: FakeLmsHost}, nil
 }
 
-func (f *FakeClient) GetCACertificate(tenantID string) (cert string, found bool, err error) {
+func (f *FakeClient) GetCACertificate(_ context.Context, tenantID string) (cert string, found bool, err error) {
 	if !f.IsCertRequestedForTenant(tenantID) {
 		return "", false, errors.New("certificate not requested")
 	}
 	return FakeCaCertificate, true, nil
 }
 
-func (f *FakeClient) GetCertificateByURL(url string) (cert string, found bool, err error) {
+func (f *FakeClient) GetCertificateByURL(_ context.Context, url string) (cert string, found bool, err error) {
 	return FakeSignedCertificate, true, nil
 }
 
-func (f *FakeClient) RequestCertificate(tenantID string, subj pkix.Name) (id string, privateKey []byte, err error) {
+func (f *FakeClient) RequestCertificate(_ context.Context, tenantID string, subj pkix.Name) (id string, privateKey []byte, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	f.requestedCerts[tenantID] = struct{}{}
@@ -3,6 +3,7 @@
 package lms
 
 import (
+	"context"
 	"crypto/x509/pkix"
 	"os"
 	"testing"
@@ -31,7 +32,7 @@ func TestCreateTenant(t *testing.T) {
 		URL:         url,
 	}, logrus.StandardLogger())
 
-	output, err := c.CreateTenant(CreateTenantInput{
+	output, err := c.CreateTenant(context.Background(), CreateTenantInput{
 		Region: "eu",
 		Name:   "kymatest000",
 	})
@@ -39,7 +40,7 @@ func TestCreateTenant(t *testing.T) {
 	t.Log(err)
 	t.Logf("%+v", output)
 
-	s, err := c.GetTenantStatus(output.ID)
+	s, err := c.GetTenantStatus(context.Background(), output.ID)
 	t.Log(s)
 	t.Log(err)
 }
@@ -61,7 +62,7 @@ func TestTenantStatus(t *testing.T) {
 		URL:         url,
 	}, logrus.StandardLogger())
 
-	s, err := c.GetTenantStatus(tID)
+	s, err := c.GetTenantStatus(context.Background(), tID)
 
 	t.Logf("%+v\n%s", s, err)
 }
@@ -87,7 +88,7 @@ func TestGenerateCsr(t *testing.T) {
 		Organization:       []string{"global-account-id1"},
 		OrganizationalUnit: []string{"sub-account-id1"},
 	}
-	url, resp, err := c.RequestCertificate(tID, subj)
+	url, resp, err := c.RequestCertificate(context.Background(), tID, subj)
 	t.Logf("CERT URL: %s", url)
 	t.Log(string(resp))
 	t.Log(err)
@@ -110,7 +111,7 @@ func TestGetCert(t *testing.T) {
 		URL:         url,
 	}, logrus.StandardLogger())
 
-	signedCert, found, err := c.GetCertificateByURL(certUrl)
+	signedCert, found, err := c.GetCertificateByURL(context.Background(), certUrl)
 	t.Logf("Found: %v", found)
 	t.Logf(string(signedCert))
 	t.Log(certUrl)
@@ -134,7 +135,7 @@ func TestGetCACert(t *testing.T) {
 		URL:         url,
 	}, logrus.StandardLogger())
 
-	signedCert, found, err := c.GetCACertificate(tenant)
+	signedCert, found, err := c.GetCACertificate(context.Background(), tenant)
 	t.Logf("Found: %v", found)
 	t.Logf(string(signedCert))
 	t.Log(err)
@@ -1,6 +1,7 @@
 package lms
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -37,7 +38,7 @@ func TestClient_CreateTenant(t *testing.T) {
 	client := createClient(ts.URL)
 
 	// when
-	out, err := client.CreateTenant(CreateTenantInput{
+	out, err := client.CreateTenant(context.Background(), CreateTenantInput{
 		Name:   "testing-name",
 		Region: "us",
 	})
@@ -64,7 +65,7 @@ func TestClient_GetTenantInfo(t *testing.T) {
 	client := createClient(ts.URL)
 
 	// when
-	info, err := client.GetTenantInfo(tenantID)
+	info, err := client.GetTenantInfo(context.Background(), tenantID)
 
 	// then
 	require.NoError(t, err)
@@ -88,7 +89,7 @@ func TestClient_GetCACertificate(t *testing.T) {
 	client := createClient(ts.URL)
 
 	// when
-	cert, found, err := client.GetCACertificate(tenantID)
+	cert, found, err := client.GetCACertificate(context.Background(), tenantID)
 
 	// then
 	require.NoError(t, err)
@@ -113,7 +114,7 @@ func TestClient_GetSignedCertificate(t *testing.T) {
 	client := createClient(ts.URL)
 
 	// when
-	cert, found, err := client.GetCertificateByURL(ts.URL)
+	cert, found, err := client.GetCertificateByURL(context.Background(), ts.URL)
 
 	// then
 	require.NoError(t, err)
@@ -143,7 +144,7 @@ func TestClient_RequestCertificate(t *testing.T) {
 		Organization:       []string{"global-account-id"},
 		OrganizationalUnit: []string{"sub-account-id"},
 	}
-	certURL, pkey, err := client.RequestCertificate(tenantID, subj)
+	certURL, pkey, err := client.RequestCertificate(context.Background(), tenantID, subj)
 
 	// then
 	require.NoError(t, err)
@@ -172,7 +173,7 @@ func TestClient_GetTenantStatus(t *testing.T) {
 	client := createClient(ts.URL)
 
 	// when
-	status, err := client.GetTenantStatus(tenantID)
+	status, err := client.GetTenantStatus(context.Background(), tenantID)
 
 	// then
 	require.NoError(t, err)
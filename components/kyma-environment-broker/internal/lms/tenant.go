@@ -1,6 +1,7 @@
 package lms
 
 import (
+	"context"
 	"time"
 
 	"regexp"
@@ -19,7 +20,7 @@ type TenantStorage interface {
 //go:generate mockery -name=TenantCreator -output=automock -outpkg=automock -case=underscore
 
 type TenantCreator interface {
-	CreateTenant(input CreateTenantInput) (o CreateTenantOutput, err error)
+	CreateTenant(ctx context.Context, input CreateTenantInput) (o CreateTenantOutput, err error)
 }
 
 type manager struct {
@@ -39,7 +40,7 @@ func NewTenantManager(storage TenantStorage, lmsClient TenantCreator, log logrus
 var tenantNameNormalizationRegexp = regexp.MustCompile("[^a-zA-Z0-9]+")
 
 // ProvideLMSTenantID returns existing tenant ID or creates new one (if not exists)
-func (c *manager) ProvideLMSTenantID(globalAccountID, region string) (string, error) {
+func (c *manager) ProvideLMSTenantID(ctx context.Context, globalAccountID, region string) (string, error) {
 	name := tenantNameNormalizationRegexp.ReplaceAllString(globalAccountID, "")
 	if len(name) > 50 {
 		name = name[:50]
@@ -50,7 +51,7 @@ func (c *manager) ProvideLMSTenantID(globalAccountID, region string) (string, er
 	}
 
 	if !exists {
-		output, err := c.lmsClient.CreateTenant(CreateTenantInput{
+		output, err := c.lmsClient.CreateTenant(ctx, CreateTenantInput{
 			Name:            name,
 			Region:          region,
 			GlobalAccountID: globalAccountID,
@@ -1,6 +1,7 @@
 package lms_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
@@ -16,7 +17,7 @@ func TestManagerProvideLMSTenantIDIfNotExists(t *testing.T) {
 	// given
 	lmsStorage := storage.NewMemoryStorage().LMSTenants()
 	tCreator := &automock.TenantCreator{}
-	tCreator.On("CreateTenant", lms.CreateTenantInput{
+	tCreator.On("CreateTenant", context.Background(), lms.CreateTenantInput{
 		Name:            "newtenant",
 		Region:          "eu",
 		GlobalAccountID: "newtenant",
@@ -26,7 +27,7 @@ func TestManagerProvideLMSTenantIDIfNotExists(t *testing.T) {
 	svc := lms.NewTenantManager(lmsStorage, tCreator, logrus.StandardLogger())
 
 	// when
-	id, err := svc.ProvideLMSTenantID("newtenant", "eu")
+	id, err := svc.ProvideLMSTenantID(context.Background(), "newtenant", "eu")
 	require.NoError(t, err)
 
 	// then
@@ -45,7 +46,7 @@ func TestManagerProvideLMSTenantIDIfExists(t *testing.T) {
 	svc := lms.NewTenantManager(lmsStorage, tCreator, logrus.StandardLogger())
 
 	// when
-	id, err := svc.ProvideLMSTenantID("newtenant", "eu")
+	id, err := svc.ProvideLMSTenantID(context.Background(), "newtenant", "eu")
 	require.NoError(t, err)
 
 	// then
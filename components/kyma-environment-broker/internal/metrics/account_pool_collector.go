@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// AccountPoolStatsGetter provides the statistics of the hyperscaler account pools for the following metrics:
+// - compass_keb_account_pool_secrets{"hyperscaler_type", "state"} - number of secrets of the hyperscaler account pool in the given state
+// - compass_keb_account_pool_low_watermark{"hyperscaler_type"} - minimal number of free secrets of the hyperscaler account pool
+type AccountPoolStatsGetter interface {
+	Stats() ([]hyperscaler.AccountPoolStats, error)
+}
+
+type AccountPoolCollector struct {
+	statsGetter AccountPoolStatsGetter
+
+	secretsDesc      *prometheus.Desc
+	lowWatermarkDesc *prometheus.Desc
+}
+
+func NewAccountPoolCollector(statsGetter AccountPoolStatsGetter) *AccountPoolCollector {
+	return &AccountPoolCollector{
+		statsGetter: statsGetter,
+
+		secretsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "account_pool_secrets"),
+			"The number of secrets of the hyperscaler account pool by state",
+			[]string{"hyperscaler_type", "state"},
+			nil),
+		lowWatermarkDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "account_pool_low_watermark"),
+			"The minimal number of free secrets of the hyperscaler account pool, 0 if not set",
+			[]string{"hyperscaler_type"},
+			nil),
+	}
+}
+
+func (c *AccountPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.secretsDesc
+	ch <- c.lowWatermarkDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *AccountPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats, err := c.statsGetter.Stats()
+	if err != nil {
+		logrus.Errorf("while getting account pool stats: %s", err)
+		return
+	}
+
+	for _, s := range stats {
+		hyperscalerType := string(s.HyperscalerType)
+		collect(ch, c.secretsDesc, s.Free, hyperscalerType, "free")
+		collect(ch, c.secretsDesc, s.Assigned, hyperscalerType, "assigned")
+		collect(ch, c.secretsDesc, s.Dirty, hyperscalerType, "dirty")
+		collect(ch, c.secretsDesc, s.Shared, hyperscalerType, "shared")
+		collect(ch, c.secretsDesc, s.Internal, hyperscalerType, "internal")
+		collect(ch, c.lowWatermarkDesc, s.LowWatermark, hyperscalerType)
+	}
+}
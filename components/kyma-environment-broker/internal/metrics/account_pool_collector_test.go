@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountPoolCollector(t *testing.T) {
+	// given
+	stats := fakeAccountPoolStats{
+		{HyperscalerType: hyperscaler.GCP, Free: 2, Assigned: 5, Dirty: 1, Shared: 1, Internal: 2, LowWatermark: 3},
+		{HyperscalerType: hyperscaler.Azure, Free: 4},
+	}
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(NewAccountPoolCollector(stats)))
+
+	// when
+	families, err := registry.Gather()
+
+	// then
+	require.NoError(t, err)
+	secrets := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != "compass_keb_account_pool_secrets" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			l := labels(metric)
+			secrets[l["hyperscaler_type"]+"/"+l["state"]] = metric.GetGauge().GetValue()
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"gcp/free": 2, "gcp/assigned": 5, "gcp/dirty": 1, "gcp/shared": 1, "gcp/internal": 2,
+		"azure/free": 4, "azure/assigned": 0, "azure/dirty": 0, "azure/shared": 0, "azure/internal": 0,
+	}, secrets)
+	assert.Equal(t, map[string]float64{"gcp": 3, "azure": 0}, gaugeValues(families, "compass_keb_account_pool_low_watermark", "hyperscaler_type"))
+}
+
+type fakeAccountPoolStats []hyperscaler.AccountPoolStats
+
+func (f fakeAccountPoolStats) Stats() ([]hyperscaler.AccountPoolStats, error) {
+	return f, nil
+}
@@ -6,18 +6,23 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func RegisterAll(sub event.Subscriber, operationStatsGetter OperationsStatsGetter, instanceStatsGetter InstancesStatsGetter) {
+func RegisterAll(sub event.Subscriber, operationStatsGetter OperationsStatsGetter, instanceStatsGetter InstancesStatsGetter, orchestrationsGetter OrchestrationsGetter, orchestrationStatsGetter OrchestrationOperationsStatsGetter, accountPoolStatsGetter AccountPoolStatsGetter) {
 	opResultCollector := NewOperationResultCollector()
 	opDurationCollector := NewOperationDurationCollector()
 	stepResultCollector := NewStepResultCollector()
 	prometheus.MustRegister(opResultCollector, opDurationCollector, stepResultCollector)
 	prometheus.MustRegister(NewOperationsCollector(operationStatsGetter))
 	prometheus.MustRegister(NewInstancesCollector(instanceStatsGetter))
+	prometheus.MustRegister(NewOrchestrationsCollector(orchestrationsGetter, orchestrationStatsGetter))
+	prometheus.MustRegister(NewAccountPoolCollector(accountPoolStatsGetter))
 
 	sub.Subscribe(process.ProvisioningStepProcessed{}, opResultCollector.OnProvisioningStepProcessed)
 	sub.Subscribe(process.DeprovisioningStepProcessed{}, opResultCollector.OnDeprovisioningStepProcessed)
+	sub.Subscribe(process.UpgradeKymaStepProcessed{}, opResultCollector.OnUpgradeKymaStepProcessed)
 	sub.Subscribe(process.ProvisioningStepProcessed{}, opDurationCollector.OnProvisioningStepProcessed)
 	sub.Subscribe(process.DeprovisioningStepProcessed{}, opDurationCollector.OnDeprovisioningStepProcessed)
+	sub.Subscribe(process.UpgradeKymaStepProcessed{}, opDurationCollector.OnUpgradeKymaStepProcessed)
 	sub.Subscribe(process.ProvisioningStepProcessed{}, stepResultCollector.OnProvisioningStepProcessed)
 	sub.Subscribe(process.DeprovisioningStepProcessed{}, stepResultCollector.OnDeprovisioningStepProcessed)
+	sub.Subscribe(process.UpgradeKymaStepProcessed{}, stepResultCollector.OnUpgradeKymaStepProcessed)
 }
@@ -4,17 +4,23 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-// OperationDurationCollector provides histograms which describes the time of provisioning/deprovisioning operations:
+// OperationDurationCollector provides histograms which describes the time of provisioning/deprovisioning/upgrade operations:
 // - compass_keb_provisioning_duration_minutes
+// - compass_keb_provisioning_duration_per_plan_minutes{"plan_id", "region"}
 // - compass_keb_deprovisioning_duration_minutes
+// - compass_keb_upgrade_kyma_duration_minutes{"plan_id", "target_version"}
 type OperationDurationCollector struct {
-	provisioningHistogram   prometheus.Histogram
-	deprovisioningHistogram prometheus.Histogram
+	provisioningHistogram        prometheus.Histogram
+	provisioningPerPlanHistogram *prometheus.HistogramVec
+	deprovisioningHistogram      prometheus.Histogram
+	upgradeKymaHistogram         *prometheus.HistogramVec
 }
 
 func NewOperationDurationCollector() *OperationDurationCollector {
@@ -26,6 +32,13 @@ func NewOperationDurationCollector() *OperationDurationCollector {
 			Help:      "The time of the provisioning process",
 			Buckets:   prometheus.LinearBuckets(20, 2, 40),
 		}),
+		provisioningPerPlanHistogram: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: prometheusSubsystem,
+			Name:      "provisioning_duration_per_plan_minutes",
+			Help:      "The end-to-end time of the provisioning process by plan and region",
+			Buckets:   prometheus.LinearBuckets(20, 2, 40),
+		}, []string{"plan_id", "region"}),
 		deprovisioningHistogram: prometheus.NewHistogram(prometheus.HistogramOpts{
 			Namespace: prometheusNamespace,
 			Subsystem: prometheusSubsystem,
@@ -33,17 +46,28 @@ func NewOperationDurationCollector() *OperationDurationCollector {
 			Help:      "The time of the deprovisioning process",
 			Buckets:   prometheus.LinearBuckets(1, 1, 30),
 		}),
+		upgradeKymaHistogram: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: prometheusSubsystem,
+			Name:      "upgrade_kyma_duration_minutes",
+			Help:      "The time of the Kyma upgrade process",
+			Buckets:   prometheus.LinearBuckets(5, 5, 24),
+		}, []string{"plan_id", "target_version"}),
 	}
 }
 
 func (c *OperationDurationCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.provisioningHistogram.Describe(ch)
+	c.provisioningPerPlanHistogram.Describe(ch)
 	c.deprovisioningHistogram.Describe(ch)
+	c.upgradeKymaHistogram.Describe(ch)
 }
 
 func (c *OperationDurationCollector) Collect(ch chan<- prometheus.Metric) {
 	c.provisioningHistogram.Collect(ch)
+	c.provisioningPerPlanHistogram.Collect(ch)
 	c.deprovisioningHistogram.Collect(ch)
+	c.upgradeKymaHistogram.Collect(ch)
 }
 
 func (c *OperationDurationCollector) OnProvisioningStepProcessed(ctx context.Context, ev interface{}) error {
@@ -55,6 +79,12 @@ func (c *OperationDurationCollector) OnProvisioningStepProcessed(ctx context.Con
 	if stepProcessed.OldOperation.State == domain.InProgress && stepProcessed.Operation.State == domain.Succeeded {
 		minutes := stepProcessed.Operation.UpdatedAt.Sub(stepProcessed.Operation.CreatedAt).Minutes()
 		c.provisioningHistogram.Observe(minutes)
+
+		pp, err := stepProcessed.Operation.GetProvisioningParameters()
+		if err != nil {
+			return errors.Wrap(err, "while getting provisioning parameters")
+		}
+		c.provisioningPerPlanHistogram.WithLabelValues(pp.PlanID, region(pp)).Observe(minutes)
 	}
 
 	return nil
@@ -73,3 +103,26 @@ func (c *OperationDurationCollector) OnDeprovisioningStepProcessed(ctx context.C
 
 	return nil
 }
+
+func (c *OperationDurationCollector) OnUpgradeKymaStepProcessed(ctx context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.UpgradeKymaStepProcessed)
+	if !ok {
+		return fmt.Errorf("expected process.UpgradeKymaStepProcessed but got %+v", ev)
+	}
+
+	op := stepProcessed.Operation
+	if stepProcessed.OldOperation.State == domain.InProgress && op.State == domain.Succeeded {
+		minutes := op.UpdatedAt.Sub(op.CreatedAt).Minutes()
+		c.upgradeKymaHistogram.WithLabelValues(op.PlanID, op.RuntimeVersion.Version).Observe(minutes)
+	}
+
+	return nil
+}
+
+// region returns the region requested by the user, the default region of the provider is not known to the broker
+func region(pp internal.ProvisioningParameters) string {
+	if pp.Parameters.Region == nil || *pp.Parameters.Region == "" {
+		return "default"
+	}
+	return *pp.Parameters.Region
+}
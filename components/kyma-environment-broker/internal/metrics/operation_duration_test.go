@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationDurationCollector_OnProvisioningStepProcessed(t *testing.T) {
+	// given
+	collector := NewOperationDurationCollector()
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	withRegion := provisioningOperation(`{"plan_id": "plan", "parameters": {"region": "westeurope"}}`)
+	withoutRegion := provisioningOperation(`{"plan_id": "plan", "parameters": {}}`)
+
+	// when
+	for _, operation := range []internal.ProvisioningOperation{withRegion, withoutRegion} {
+		finished := operation
+		finished.State = domain.Succeeded
+		require.NoError(t, collector.OnProvisioningStepProcessed(context.TODO(), process.ProvisioningStepProcessed{OldOperation: operation, Operation: operation}))
+		require.NoError(t, collector.OnProvisioningStepProcessed(context.TODO(), process.ProvisioningStepProcessed{OldOperation: operation, Operation: finished}))
+		require.NoError(t, collector.OnProvisioningStepProcessed(context.TODO(), process.ProvisioningStepProcessed{OldOperation: finished, Operation: finished}))
+	}
+
+	// then
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint64{"": 2}, histogramCounts(families, "compass_keb_provisioning_duration_minutes"))
+	assert.Equal(t, map[string]uint64{
+		"plan/westeurope": 1,
+		"plan/default":    1,
+	}, histogramCounts(families, "compass_keb_provisioning_duration_per_plan_minutes", "plan_id", "region"))
+}
+
+func TestOperationDurationCollector_OnProvisioningStepProcessedWithInvalidParameters(t *testing.T) {
+	// given
+	collector := NewOperationDurationCollector()
+	operation := provisioningOperation(`{`)
+	finished := operation
+	finished.State = domain.Succeeded
+
+	// when
+	err := collector.OnProvisioningStepProcessed(context.TODO(), process.ProvisioningStepProcessed{OldOperation: operation, Operation: finished})
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestOperationDurationCollector_OnUpgradeKymaStepProcessed(t *testing.T) {
+	// given
+	collector := NewOperationDurationCollector()
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	operation := internal.UpgradeKymaOperation{PlanID: "plan", RuntimeVersion: internal.RuntimeVersionData{Version: "1.18.0"}}
+	operation.State = domain.InProgress
+	finished := operation
+	finished.State = domain.Succeeded
+	failed := operation
+	failed.State = domain.Failed
+
+	// when
+	require.NoError(t, collector.OnUpgradeKymaStepProcessed(context.TODO(), process.UpgradeKymaStepProcessed{OldOperation: operation, Operation: operation}))
+	require.NoError(t, collector.OnUpgradeKymaStepProcessed(context.TODO(), process.UpgradeKymaStepProcessed{OldOperation: operation, Operation: finished}))
+	require.NoError(t, collector.OnUpgradeKymaStepProcessed(context.TODO(), process.UpgradeKymaStepProcessed{OldOperation: finished, Operation: finished}))
+	require.NoError(t, collector.OnUpgradeKymaStepProcessed(context.TODO(), process.UpgradeKymaStepProcessed{OldOperation: operation, Operation: failed}))
+
+	// then
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint64{"plan/1.18.0": 1}, histogramCounts(families, "compass_keb_upgrade_kyma_duration_minutes", "plan_id", "target_version"))
+}
+
+func TestOperationDurationCollector_OnUpgradeKymaStepProcessedWithUnexpectedEvent(t *testing.T) {
+	// given
+	collector := NewOperationDurationCollector()
+
+	// when
+	err := collector.OnUpgradeKymaStepProcessed(context.TODO(), process.ProvisioningStepProcessed{})
+
+	// then
+	assert.Error(t, err)
+}
+
+func provisioningOperation(parameters string) internal.ProvisioningOperation {
+	now := time.Now()
+	operation := internal.ProvisioningOperation{ProvisioningParameters: parameters}
+	operation.State = domain.InProgress
+	operation.CreatedAt = now.Add(-30 * time.Minute)
+	operation.UpdatedAt = now
+	return operation
+}
+
+// histogramCounts returns the sample counts of the histogram by the values of the given labels joined with a slash
+func histogramCounts(families []*dto.MetricFamily, name string, labelNames ...string) map[string]uint64 {
+	counts := map[string]uint64{}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			values := make([]string, 0, len(labelNames))
+			for _, label := range labelNames {
+				values = append(values, labels(metric)[label])
+			}
+			counts[strings.Join(values, "/")] = metric.GetHistogram().GetSampleCount()
+		}
+	}
+	return counts
+}
@@ -23,6 +23,7 @@ const (
 // OperationResultCollector provides the following metrics:
 // - compass_keb_provisioning_result{"operation_id", "runtime_id", "instance_id", "global_account_id", "plan_id"}
 // - compass_keb_deprovisioning_result{"operation_id", "runtime_id", "instance_id", "global_account_id", "plan_id"}
+// - compass_keb_upgrade_kyma_result{"operation_id", "runtime_id", "instance_id", "global_account_id", "plan_id", "orchestration_id", "target_version"}
 // These gauges show the status of the operation.
 // The value of the gauge could be:
 // 0 - Failed
@@ -31,6 +32,7 @@ const (
 type OperationResultCollector struct {
 	provisioningResultGauge   *prometheus.GaugeVec
 	deprovisioningResultGauge *prometheus.GaugeVec
+	upgradeKymaResultGauge    *prometheus.GaugeVec
 }
 
 func NewOperationResultCollector() *OperationResultCollector {
@@ -47,17 +49,25 @@ func NewOperationResultCollector() *OperationResultCollector {
 			Name:      "deprovisioning_result",
 			Help:      "Result of the deprovisioning",
 		}, []string{"operation_id", "runtime_id", "instance_id", "global_account_id", "plan_id"}),
+		upgradeKymaResultGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: prometheusSubsystem,
+			Name:      "upgrade_kyma_result",
+			Help:      "Result of the Kyma upgrade",
+		}, []string{"operation_id", "runtime_id", "instance_id", "global_account_id", "plan_id", "orchestration_id", "target_version"}),
 	}
 }
 
 func (c *OperationResultCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.provisioningResultGauge.Describe(ch)
 	c.deprovisioningResultGauge.Describe(ch)
+	c.upgradeKymaResultGauge.Describe(ch)
 }
 
 func (c *OperationResultCollector) Collect(ch chan<- prometheus.Metric) {
 	c.provisioningResultGauge.Collect(ch)
 	c.deprovisioningResultGauge.Collect(ch)
+	c.upgradeKymaResultGauge.Collect(ch)
 }
 
 func (c *OperationResultCollector) OnProvisioningStepProcessed(ctx context.Context, ev interface{}) error {
@@ -114,3 +124,25 @@ func (c *OperationResultCollector) OnDeprovisioningStepProcessed(ctx context.Con
 		Set(resultValue)
 	return nil
 }
+
+func (c *OperationResultCollector) OnUpgradeKymaStepProcessed(ctx context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.UpgradeKymaStepProcessed)
+	if !ok {
+		return fmt.Errorf("expected UpgradeKymaStepProcessed but got %+v", ev)
+	}
+
+	var resultValue float64
+	switch stepProcessed.Operation.State {
+	case domain.InProgress:
+		resultValue = resultInProgress
+	case domain.Succeeded:
+		resultValue = resultSucceeded
+	case domain.Failed:
+		resultValue = resultFailed
+	}
+	op := stepProcessed.Operation
+	c.upgradeKymaResultGauge.
+		WithLabelValues(op.Operation.ID, op.RuntimeID, op.Operation.InstanceID, op.GlobalAccountID, op.PlanID, op.OrchestrationID, op.RuntimeVersion.Version).
+		Set(resultValue)
+	return nil
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// OrchestrationsGetter provides the orchestrations for the following metrics:
+// - compass_keb_orchestrations_total{"state"} - number of orchestrations in the given state
+// - compass_keb_orchestration_pending_operations{"orchestration_id"} - number of unfinished operations of the orchestration in progress
+// - compass_keb_orchestration_failure_ratio{"orchestration_id"} - ratio of the failed operations to all operations of the orchestration in progress
+type OrchestrationsGetter interface {
+	CountByState() (map[string]int, error)
+	ListByState(state string) ([]internal.Orchestration, error)
+}
+
+// OrchestrationOperationsStatsGetter provides the number of operations of the orchestration by state
+type OrchestrationOperationsStatsGetter interface {
+	GetOperationStatsForOrchestration(orchestrationID string) (map[domain.LastOperationState]int, error)
+}
+
+type OrchestrationsCollector struct {
+	orchestrations OrchestrationsGetter
+	statsGetter    OrchestrationOperationsStatsGetter
+
+	orchestrationsDesc    *prometheus.Desc
+	pendingOperationsDesc *prometheus.Desc
+	failureRatioDesc      *prometheus.Desc
+}
+
+func NewOrchestrationsCollector(orchestrations OrchestrationsGetter, statsGetter OrchestrationOperationsStatsGetter) *OrchestrationsCollector {
+	return &OrchestrationsCollector{
+		orchestrations: orchestrations,
+		statsGetter:    statsGetter,
+
+		orchestrationsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "orchestrations_total"),
+			"The number of orchestrations by state",
+			[]string{"state"},
+			nil),
+		pendingOperationsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "orchestration_pending_operations"),
+			"The number of unfinished operations of the orchestration in progress",
+			[]string{"orchestration_id"},
+			nil),
+		failureRatioDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "orchestration_failure_ratio"),
+			"The ratio of the failed operations to all operations of the orchestration in progress",
+			[]string{"orchestration_id"},
+			nil),
+	}
+}
+
+func (c *OrchestrationsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.orchestrationsDesc
+	ch <- c.pendingOperationsDesc
+	ch <- c.failureRatioDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *OrchestrationsCollector) Collect(ch chan<- prometheus.Metric) {
+	counts, err := c.orchestrations.CountByState()
+	if err != nil {
+		logrus.Errorf("while counting orchestrations by state: %s", err)
+	} else {
+		for _, state := range []string{orchestration.Pending, orchestration.InProgress, orchestration.Succeeded, orchestration.Failed} {
+			collect(ch, c.orchestrationsDesc, counts[state], state)
+		}
+	}
+
+	// the operations are reported only for the orchestrations in progress to keep the number of series bounded
+	orchestrations, err := c.orchestrations.ListByState(orchestration.InProgress)
+	if err != nil {
+		logrus.Errorf("while listing orchestrations in state %s: %s", orchestration.InProgress, err)
+		return
+	}
+	for _, o := range orchestrations {
+		stats, err := c.statsGetter.GetOperationStatsForOrchestration(o.OrchestrationID)
+		if err != nil {
+			logrus.Errorf("while getting operation stats for orchestration %s: %s", o.OrchestrationID, err)
+			continue
+		}
+		collect(ch, c.pendingOperationsDesc, stats[domain.InProgress], o.OrchestrationID)
+
+		var failureRatio float64
+		if all := stats[domain.InProgress] + stats[domain.Succeeded] + stats[domain.Failed]; all > 0 {
+			failureRatio = float64(stats[domain.Failed]) / float64(all)
+		}
+		m, err := prometheus.NewConstMetric(c.failureRatioDesc, prometheus.GaugeValue, failureRatio, o.OrchestrationID)
+		if err != nil {
+			logrus.Errorf("unable to register metric %s", err.Error())
+			continue
+		}
+		ch <- m
+	}
+}
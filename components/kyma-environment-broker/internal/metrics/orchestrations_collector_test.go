@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrchestrationsCollector(t *testing.T) {
+	// given
+	orchestrations := fakeOrchestrations{
+		orchestration.InProgress: {{OrchestrationID: "orch-1"}},
+		orchestration.Succeeded:  {{OrchestrationID: "orch-2"}, {OrchestrationID: "orch-3"}},
+	}
+	stats := fakeOrchestrationStats{
+		"orch-1": {domain.InProgress: 2, domain.Succeeded: 1, domain.Failed: 1},
+		"orch-2": {domain.Succeeded: 4},
+	}
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(NewOrchestrationsCollector(orchestrations, stats)))
+
+	// when
+	families, err := registry.Gather()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{
+		orchestration.Pending:    0,
+		orchestration.InProgress: 1,
+		orchestration.Succeeded:  2,
+		orchestration.Failed:     0,
+	}, gaugeValues(families, "compass_keb_orchestrations_total", "state"))
+	assert.Equal(t, map[string]float64{"orch-1": 2}, gaugeValues(families, "compass_keb_orchestration_pending_operations", "orchestration_id"))
+	assert.Equal(t, map[string]float64{"orch-1": 0.25}, gaugeValues(families, "compass_keb_orchestration_failure_ratio", "orchestration_id"))
+}
+
+type fakeOrchestrations map[string][]internal.Orchestration
+
+func (f fakeOrchestrations) CountByState() (map[string]int, error) {
+	counts := map[string]int{}
+	for state, orchestrations := range f {
+		counts[state] = len(orchestrations)
+	}
+	return counts, nil
+}
+
+func (f fakeOrchestrations) ListByState(state string) ([]internal.Orchestration, error) {
+	if state != orchestration.InProgress {
+		return nil, errors.New("only the orchestrations in progress are listed")
+	}
+	return f[state], nil
+}
+
+type fakeOrchestrationStats map[string]map[domain.LastOperationState]int
+
+func (f fakeOrchestrationStats) GetOperationStatsForOrchestration(orchestrationID string) (map[domain.LastOperationState]int, error) {
+	return f[orchestrationID], nil
+}
+
+// gaugeValues returns the values of the gauge by the value of the given label
+func gaugeValues(families []*dto.MetricFamily, name, label string) map[string]float64 {
+	values := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			values[labels(metric)[label]] = metric.GetGauge().GetValue()
+		}
+	}
+	return values
+}
+
+func labels(metric *dto.Metric) map[string]string {
+	result := map[string]string{}
+	for _, pair := range metric.GetLabel() {
+		result[pair.GetName()] = pair.GetValue()
+	}
+	return result
+}
@@ -16,6 +16,7 @@ import (
 // StepResultCollector provides the following metrics:
 // - compass_keb_provisioning_step_result{"operation_id", "runtime_id", "instance_id", "step_name", "global_account_id", "plan_id"}
 // - compass_keb_deprovisioning_step_result{"operation_id", "runtime_id", "instance_id", "step_name", "global_account_id", "plan_id"}
+// - compass_keb_upgrade_kyma_step_result{"operation_id", "runtime_id", "instance_id", "step_name", "global_account_id", "plan_id"}
 // These gauges show the status of the operation step.
 // The value of the gauge could be:
 // 0 - Failed
@@ -24,6 +25,7 @@ import (
 type StepResultCollector struct {
 	provisioningResultGauge   *prometheus.GaugeVec
 	deprovisioningResultGauge *prometheus.GaugeVec
+	upgradeKymaResultGauge    *prometheus.GaugeVec
 }
 
 func NewStepResultCollector() *StepResultCollector {
@@ -40,17 +42,25 @@ func NewStepResultCollector() *StepResultCollector {
 			Name:      "deprovisioning_step_result",
 			Help:      "Result of the deprovisioning step",
 		}, []string{"operation_id", "runtime_id", "instance_id", "step_name", "global_account_id", "plan_id"}),
+		upgradeKymaResultGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: prometheusSubsystem,
+			Name:      "upgrade_kyma_step_result",
+			Help:      "Result of the Kyma upgrade step",
+		}, []string{"operation_id", "runtime_id", "instance_id", "step_name", "global_account_id", "plan_id"}),
 	}
 }
 
 func (c *StepResultCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.provisioningResultGauge.Describe(ch)
 	c.deprovisioningResultGauge.Describe(ch)
+	c.upgradeKymaResultGauge.Describe(ch)
 }
 
 func (c *StepResultCollector) Collect(ch chan<- prometheus.Metric) {
 	c.provisioningResultGauge.Collect(ch)
 	c.deprovisioningResultGauge.Collect(ch)
+	c.upgradeKymaResultGauge.Collect(ch)
 }
 
 func (c *StepResultCollector) OnProvisioningStepProcessed(ctx context.Context, ev interface{}) error {
@@ -121,3 +131,31 @@ func (c *StepResultCollector) OnDeprovisioningStepProcessed(ctx context.Context,
 		pp.PlanID).Set(resultValue)
 	return nil
 }
+
+func (c *StepResultCollector) OnUpgradeKymaStepProcessed(ctx context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.UpgradeKymaStepProcessed)
+	if !ok {
+		return fmt.Errorf("expected UpgradeKymaStepProcessed but got %+v", ev)
+	}
+
+	var resultValue float64
+	switch {
+	case stepProcessed.Operation.State == domain.Succeeded:
+		resultValue = resultSucceeded
+	case stepProcessed.When > 0 && stepProcessed.Error == nil:
+		resultValue = resultInProgress
+	case stepProcessed.When == 0 && stepProcessed.Error == nil:
+		resultValue = resultSucceeded
+	case stepProcessed.Error != nil:
+		resultValue = resultFailed
+	}
+	op := stepProcessed.Operation
+	c.upgradeKymaResultGauge.WithLabelValues(
+		op.Operation.ID,
+		op.RuntimeID,
+		op.Operation.InstanceID,
+		stepProcessed.StepName,
+		op.GlobalAccountID,
+		op.PlanID).Set(resultValue)
+	return nil
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepResultCollector_OnUpgradeKymaStepProcessed(t *testing.T) {
+	for name, tc := range map[string]struct {
+		state    domain.LastOperationState
+		when     time.Duration
+		err      error
+		expected float64
+	}{
+		"operation succeeded": {state: domain.Succeeded, expected: resultSucceeded},
+		"step repeated":       {state: domain.InProgress, when: time.Minute, expected: resultInProgress},
+		"step succeeded":      {state: domain.InProgress, expected: resultSucceeded},
+		"step failed":         {state: domain.Failed, err: errors.New("upgrade failed"), expected: resultFailed},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			collector := NewStepResultCollector()
+			registry := prometheus.NewRegistry()
+			require.NoError(t, registry.Register(collector))
+
+			operation := internal.UpgradeKymaOperation{
+				RuntimeOperation: orchestration.RuntimeOperation{
+					Runtime: orchestration.Runtime{RuntimeID: "runtime-id", GlobalAccountID: "ga-id"},
+				},
+				PlanID: "plan",
+			}
+			operation.Operation.ID = "operation-id"
+			operation.Operation.InstanceID = "instance-id"
+			operation.State = tc.state
+
+			// when
+			err := collector.OnUpgradeKymaStepProcessed(context.TODO(), process.UpgradeKymaStepProcessed{
+				StepProcessed: process.StepProcessed{StepName: "Upgrade_Kyma", When: tc.when, Error: tc.err},
+				Operation:     operation,
+			})
+
+			// then
+			require.NoError(t, err)
+			families, err := registry.Gather()
+			require.NoError(t, err)
+			require.Len(t, families, 1)
+			require.Len(t, families[0].GetMetric(), 1)
+			metric := families[0].GetMetric()[0]
+			assert.Equal(t, "compass_keb_upgrade_kyma_step_result", families[0].GetName())
+			assert.Equal(t, tc.expected, metric.GetGauge().GetValue())
+			assert.Equal(t, map[string]string{
+				"operation_id":      "operation-id",
+				"runtime_id":        "runtime-id",
+				"instance_id":       "instance-id",
+				"step_name":         "Upgrade_Kyma",
+				"global_account_id": "ga-id",
+				"plan_id":           "plan",
+			}, labels(metric))
+		})
+	}
+}
+
+func TestStepResultCollector_OnUpgradeKymaStepProcessedWithUnexpectedEvent(t *testing.T) {
+	// given
+	collector := NewStepResultCollector()
+
+	// when
+	err := collector.OnUpgradeKymaStepProcessed(context.TODO(), process.DeprovisioningStepProcessed{})
+
+	// then
+	assert.Error(t, err)
+}
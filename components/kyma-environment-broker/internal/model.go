@@ -37,6 +37,17 @@ type LMSTenant struct {
 	CreatedAt time.Time
 }
 
+// AccountAssignment records the assignment of the hyperscaler account pool secret to the tenant,
+// ReleasedAt is set when the tenant releases the secret
+type AccountAssignment struct {
+	ID              string
+	SecretName      string
+	HyperscalerType string
+	TenantName      string
+	CreatedAt       time.Time
+	ReleasedAt      *time.Time
+}
+
 type LMS struct {
 	TenantID    string    `json:"tenant_id"`
 	Failed      bool      `json:"failed"`
@@ -101,6 +112,10 @@ type Instance struct {
 	ProvisioningParameters string
 	ProviderRegion         string
 
+	// Labels are the free-form metadata set by the operators. They are returned by the Instances List method
+	// and are not stored on Insert and Update, use the SetLabels and DeleteLabels methods instead.
+	Labels map[string]string
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt time.Time
@@ -135,9 +150,12 @@ type Operation struct {
 type InstanceWithOperation struct {
 	Instance
 
-	Type        sql.NullString
-	State       sql.NullString
-	Description sql.NullString
+	Type               sql.NullString
+	State              sql.NullString
+	Description        sql.NullString
+	OperationCreatedAt sql.NullTime
+	// KymaVersion is set only for the succeeded operations which installed or upgraded Kyma, skipping dry runs
+	KymaVersion sql.NullString
 }
 
 type SMClientFactory interface {
@@ -187,6 +205,50 @@ type UpgradeKymaOperation struct {
 	ProvisioningParameters string `json:"provisioning_parameters"`
 
 	RuntimeVersion RuntimeVersionData `json:"runtime_version"`
+
+	// Actions is the progress of the composite orchestration actions, empty for the Kyma upgrade orchestration
+	Actions []orchestration.ActionStatus `json:"actions,omitempty"`
+	// KubernetesVersion is the target version of the cluster upgrade action
+	KubernetesVersion string `json:"kubernetes_version,omitempty"`
+	// ClusterUpgradeOperationID is the ID of the Provisioner operation which upgrades the cluster
+	ClusterUpgradeOperationID string `json:"cluster_upgrade_operation_id,omitempty"`
+	// ClusterUpgradeStartedAt is the time when the cluster upgrade was triggered, the time limit of the upgrade is measured from it
+	ClusterUpgradeStartedAt time.Time `json:"cluster_upgrade_started_at,omitempty"`
+}
+
+// CurrentAction returns the first unfinished action of the composite orchestration, nil if there is none
+func (o *UpgradeKymaOperation) CurrentAction() *orchestration.ActionStatus {
+	for i := range o.Actions {
+		if o.Actions[i].State == orchestration.Pending || o.Actions[i].State == orchestration.InProgress {
+			return &o.Actions[i]
+		}
+	}
+	return nil
+}
+
+// FinishCurrentAction sets the state of the current action and starts the next one. On failure
+// the remaining actions are canceled. It returns true if there are actions left to perform.
+func (o *UpgradeKymaOperation) FinishCurrentAction(state, description string) bool {
+	current := o.CurrentAction()
+	if current == nil {
+		return false
+	}
+	current.State = state
+	current.Description = description
+
+	next := o.CurrentAction()
+	if next == nil {
+		return false
+	}
+	if state != orchestration.Succeeded {
+		for next != nil {
+			next.State = orchestration.Canceled
+			next = o.CurrentAction()
+		}
+		return false
+	}
+	next.State = orchestration.InProgress
+	return true
 }
 
 // Orchestration holds all information about an orchestration.
@@ -199,6 +261,9 @@ type Orchestration struct {
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	Parameters      orchestration.Parameters
+
+	// ParentOrchestrationID specifies the orchestration which was retried by this orchestration, empty for orchestrations created by the user
+	ParentOrchestrationID string
 }
 
 func (o *Orchestration) IsFinished() bool {
@@ -239,6 +304,13 @@ type RuntimeState struct {
 	ClusterConfig gqlschema.GardenerConfigInput `json:"clusterConfig"`
 }
 
+// ArchivedRuntimeState is the runtime state moved to the archive by the retention
+type ArchivedRuntimeState struct {
+	RuntimeState
+
+	ArchivedAt time.Time `json:"archivedAt"`
+}
+
 // OperationStats provide number of operations per type and state
 type OperationStats struct {
 	Provisioning   map[domain.LastOperationState]int
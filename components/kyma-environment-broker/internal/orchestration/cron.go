@@ -0,0 +1,151 @@
+package orchestration
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxCronLookahead limits the search for the next occurrence of schedules which never match, e.g. "0 0 30 2 *"
+const maxCronLookahead = 5 * 365 * 24 * time.Hour
+
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+// CronSchedule is a parsed standard cron expression with the minute, hour, day of month, month and day of week fields.
+// All occurrences are computed in UTC.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// restricted day of month and day of week fields match with OR, as in the standard cron
+	domStar, dowStar bool
+}
+
+// ParseCron parses the cron expression, e.g. "0 3 * * 0" for every Sunday at 03:00 UTC.
+// Each field supports "*", single values, ranges ("1-5"), steps ("*/15", "0-30/10") and comma separated lists.
+// The @hourly, @daily, @weekly, @monthly and @yearly macros are supported as well.
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	var err error
+	s := &CronSchedule{
+		domStar: fields[2] == "*" || fields[2] == "?",
+		dowStar: fields[4] == "*" || fields[4] == "?",
+	}
+	for _, f := range []struct {
+		bits     *uint64
+		value    string
+		min, max int
+		name     string
+	}{
+		{bits: &s.minute, value: fields[0], min: 0, max: 59, name: "minute"},
+		{bits: &s.hour, value: fields[1], min: 0, max: 23, name: "hour"},
+		{bits: &s.dom, value: fields[2], min: 1, max: 31, name: "day of month"},
+		{bits: &s.month, value: fields[3], min: 1, max: 12, name: "month"},
+		{bits: &s.dow, value: fields[4], min: 0, max: 7, name: "day of week"},
+	} {
+		*f.bits, err = parseCronField(f.value, f.min, f.max)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while parsing %s field", f.name)
+		}
+	}
+	// both 0 and 7 stand for Sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	return s, nil
+}
+
+// Next returns the first occurrence of the schedule after the given time, or zero time if there is none
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxCronLookahead)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return 0, errors.Errorf("invalid step in %q", part)
+			}
+		}
+
+		from, to := min, max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if from, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, errors.Errorf("invalid range in %q", part)
+			}
+			if to, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, errors.Errorf("invalid range in %q", part)
+			}
+		default:
+			v, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, errors.Errorf("invalid value %q", part)
+			}
+			from = v
+			if step == 1 {
+				to = v
+			}
+		}
+		if from < min || to > max || from > to {
+			return 0, errors.Errorf("%q is out of the %d-%d range", part, min, max)
+		}
+
+		for v := from; v <= to; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
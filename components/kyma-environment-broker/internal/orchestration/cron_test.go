@@ -0,0 +1,90 @@
+package orchestration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronSchedule_Next(t *testing.T) {
+	// Thursday
+	now := time.Date(2020, time.November, 26, 10, 30, 15, 0, time.UTC)
+
+	for tn, tc := range map[string]struct {
+		expr     string
+		expected time.Time
+	}{
+		"every minute": {
+			expr:     "* * * * *",
+			expected: time.Date(2020, time.November, 26, 10, 31, 0, 0, time.UTC),
+		},
+		"every 15 minutes": {
+			expr:     "*/15 * * * *",
+			expected: time.Date(2020, time.November, 26, 10, 45, 0, 0, time.UTC),
+		},
+		"every Sunday": {
+			expr:     "0 3 * * 0",
+			expected: time.Date(2020, time.November, 29, 3, 0, 0, 0, time.UTC),
+		},
+		"every Sunday as 7": {
+			expr:     "0 3 * * 7",
+			expected: time.Date(2020, time.November, 29, 3, 0, 0, 0, time.UTC),
+		},
+		"weekdays list": {
+			expr:     "0 8 * * 1,3,5",
+			expected: time.Date(2020, time.November, 27, 8, 0, 0, 0, time.UTC),
+		},
+		"hour range": {
+			expr:     "0 9-17/4 * * *",
+			expected: time.Date(2020, time.November, 26, 13, 0, 0, 0, time.UTC),
+		},
+		"next year": {
+			expr:     "0 0 1 1 *",
+			expected: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"day of month or day of week": {
+			expr:     "0 0 1 * 5",
+			expected: time.Date(2020, time.November, 27, 0, 0, 0, 0, time.UTC),
+		},
+		"macro": {
+			expr:     "@monthly",
+			expected: time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"never": {
+			expr:     "0 0 30 2 *",
+			expected: time.Time{},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			schedule, err := ParseCron(tc.expr)
+			require.NoError(t, err)
+
+			// when
+			next := schedule.Next(now)
+
+			// then
+			assert.Equal(t, tc.expected, next)
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
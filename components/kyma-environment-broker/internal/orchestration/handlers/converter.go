@@ -18,6 +18,8 @@ func (*Converter) OrchestrationToDTO(o *internal.Orchestration) (*orchestration.
 		CreatedAt:       o.CreatedAt,
 		UpdatedAt:       o.UpdatedAt,
 		Parameters:      o.Parameters,
+
+		ParentOrchestrationID: o.ParentOrchestrationID,
 	}, nil
 }
 
@@ -58,6 +60,7 @@ func (c *Converter) UpgradeKymaOperationToDTO(op internal.UpgradeKymaOperation)
 		MaintenanceWindowEnd:   op.MaintenanceWindowEnd,
 		State:                  string(op.Operation.State),
 		Description:            op.Operation.Description,
+		Actions:                op.Actions,
 	}, nil
 }
 
@@ -18,7 +18,7 @@ type handler struct {
 func NewOrchestrationHandler(db storage.BrokerStorage, kymaQueue *process.Queue, defaultMaxPage int, log logrus.FieldLogger) Handler {
 	return &handler{
 		handlers: []Handler{
-			NewKymaOrchestrationHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), defaultMaxPage, kymaQueue, log),
+			NewKymaOrchestrationHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), defaultMaxPage, kymaQueue, log),
 		},
 	}
 }
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -16,8 +17,10 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	orchestrationInt "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -25,6 +28,7 @@ import (
 type kymaHandler struct {
 	orchestrations storage.Orchestrations
 	operations     storage.Operations
+	instances      storage.Instances
 	runtimeStates  storage.RuntimeStates
 
 	queue *process.Queue
@@ -34,10 +38,11 @@ type kymaHandler struct {
 	defaultMaxPage int
 }
 
-func NewKymaOrchestrationHandler(operations storage.Operations, orchestrations storage.Orchestrations, runtimeStates storage.RuntimeStates, defaultMaxPage int, q *process.Queue, log logrus.FieldLogger) *kymaHandler {
+func NewKymaOrchestrationHandler(operations storage.Operations, orchestrations storage.Orchestrations, instances storage.Instances, runtimeStates storage.RuntimeStates, defaultMaxPage int, q *process.Queue, log logrus.FieldLogger) *kymaHandler {
 	return &kymaHandler{
 		operations:     operations,
 		orchestrations: orchestrations,
+		instances:      instances,
 		runtimeStates:  runtimeStates,
 		queue:          q,
 		log:            log,
@@ -48,9 +53,11 @@ func NewKymaOrchestrationHandler(operations storage.Operations, orchestrations s
 
 func (h *kymaHandler) AttachRoutes(router *mux.Router) {
 	router.HandleFunc("/upgrade/kyma", h.createOrchestration).Methods(http.MethodPost)
+	router.HandleFunc("/upgrade/composite", h.createCompositeOrchestration).Methods(http.MethodPost)
 
 	router.HandleFunc("/orchestrations", h.listOrchestration).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}", h.getOrchestration).Methods(http.MethodGet)
+	router.HandleFunc("/orchestrations/{orchestration_id}/retry", h.retryOrchestration).Methods(http.MethodPost)
 	router.HandleFunc("/orchestrations/{orchestration_id}/operations", h.listOperations).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}/operations/{operation_id}", h.getOperation).Methods(http.MethodGet)
 }
@@ -81,6 +88,11 @@ func (h *kymaHandler) listOrchestration(w http.ResponseWriter, r *http.Request)
 		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while getting query parameters"))
 		return
 	}
+	cursor, err := pagination.ExtractCursorFromRequest(r)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while getting query parameters"))
+		return
+	}
 	query := r.URL.Query()
 	filter := dbmodel.OrchestrationFilter{
 		Page:     page,
@@ -88,6 +100,11 @@ func (h *kymaHandler) listOrchestration(w http.ResponseWriter, r *http.Request)
 		// For optional filters, zero value (nil) is ok if not supplied
 		States: query[orchestration.StateParam],
 	}
+	if cursor != nil {
+		// fetch one more orchestration to find out if the next page exists
+		filter.Cursor = cursor
+		filter.PageSize = pageSize + 1
+	}
 
 	orchestrations, count, totalCount, err := h.orchestrations.List(filter)
 	if err != nil {
@@ -95,6 +112,15 @@ func (h *kymaHandler) listOrchestration(w http.ResponseWriter, r *http.Request)
 		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting orchestrations"))
 		return
 	}
+	var nextCursor string
+	if pagination.HasNextPage(cursor, pageSize, page, count, totalCount) {
+		if len(orchestrations) > pageSize {
+			orchestrations = orchestrations[:pageSize]
+			count = pageSize
+		}
+		last := orchestrations[count-1]
+		nextCursor = pagination.NewCursor(last.CreatedAt, last.OrchestrationID).Encode()
+	}
 
 	response, err := h.conv.OrchestrationListToDTO(orchestrations, count, totalCount)
 	if err != nil {
@@ -102,6 +128,7 @@ func (h *kymaHandler) listOrchestration(w http.ResponseWriter, r *http.Request)
 		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while converting orchestrations"))
 		return
 	}
+	response.NextCursor = nextCursor
 
 	httputil.WriteResponse(w, http.StatusOK, response)
 }
@@ -113,6 +140,11 @@ func (h *kymaHandler) listOperations(w http.ResponseWriter, r *http.Request) {
 		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while getting query parameters"))
 		return
 	}
+	cursor, err := pagination.ExtractCursorFromRequest(r)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while getting query parameters"))
+		return
+	}
 	query := r.URL.Query()
 	filter := dbmodel.OperationFilter{
 		Page:     page,
@@ -120,6 +152,11 @@ func (h *kymaHandler) listOperations(w http.ResponseWriter, r *http.Request) {
 		// For optional filters, zero value (nil) is ok if not supplied
 		States: query[orchestration.StateParam],
 	}
+	if cursor != nil {
+		// fetch one more operation to find out if the next page exists
+		filter.Cursor = cursor
+		filter.PageSize = pageSize + 1
+	}
 
 	operations, count, totalCount, err := h.operations.ListUpgradeKymaOperationsByOrchestrationID(orchestrationID, filter)
 	if err != nil {
@@ -127,6 +164,15 @@ func (h *kymaHandler) listOperations(w http.ResponseWriter, r *http.Request) {
 		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting operations"))
 		return
 	}
+	var nextCursor string
+	if pagination.HasNextPage(cursor, pageSize, page, count, totalCount) {
+		if len(operations) > pageSize {
+			operations = operations[:pageSize]
+			count = pageSize
+		}
+		last := operations[count-1]
+		nextCursor = pagination.NewCursor(last.CreatedAt, last.Operation.ID).Encode()
+	}
 
 	response, err := h.conv.UpgradeKymaOperationListToDTO(operations, count, totalCount)
 	if err != nil {
@@ -134,6 +180,7 @@ func (h *kymaHandler) listOperations(w http.ResponseWriter, r *http.Request) {
 		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while converting operations"))
 		return
 	}
+	response.NextCursor = nextCursor
 
 	httputil.WriteResponse(w, http.StatusOK, response)
 }
@@ -174,6 +221,15 @@ func (h *kymaHandler) getOperation(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *kymaHandler) createOrchestration(w http.ResponseWriter, r *http.Request) {
+	h.create(w, r, false)
+}
+
+// createCompositeOrchestration creates the orchestration which performs the given ordered list of actions on each runtime
+func (h *kymaHandler) createCompositeOrchestration(w http.ResponseWriter, r *http.Request) {
+	h.create(w, r, true)
+}
+
+func (h *kymaHandler) create(w http.ResponseWriter, r *http.Request, composite bool) {
 	params := orchestration.Parameters{}
 
 	if r.Body != nil {
@@ -190,6 +246,32 @@ func (h *kymaHandler) createOrchestration(w http.ResponseWriter, r *http.Request
 		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrapf(err, "while validating target"))
 		return
 	}
+	description := "started processing of Kyma upgrade"
+	if composite {
+		err = h.validateActions(params.Actions)
+		if err != nil {
+			h.log.Errorf("while validating actions: %v", err)
+			httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrapf(err, "while validating actions"))
+			return
+		}
+		description = "started processing of composite upgrade"
+	} else {
+		// the Kyma upgrade orchestration performs the Kyma upgrade only
+		params.Actions = nil
+		params.KubernetesVersion = ""
+	}
+	err = h.validateLimits(params.Strategy.Parallel.Limits)
+	if err != nil {
+		h.log.Errorf("while validating concurrency limits: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrapf(err, "while validating concurrency limits"))
+		return
+	}
+	err = h.resolveStartTime(&params)
+	if err != nil {
+		h.log.Errorf("while validating schedule: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrapf(err, "while validating schedule"))
+		return
+	}
 
 	// defaults strategy if not specified to Parallel with Immediate schedule
 	h.defaultOrchestrationStrategy(&params.Strategy)
@@ -198,7 +280,7 @@ func (h *kymaHandler) createOrchestration(w http.ResponseWriter, r *http.Request
 	o := internal.Orchestration{
 		OrchestrationID: uuid.New().String(),
 		State:           orchestration.Pending,
-		Description:     "started processing of Kyma upgrade",
+		Description:     description,
 		Parameters:      params,
 		CreatedAt:       now,
 		UpdatedAt:       now,
@@ -211,6 +293,72 @@ func (h *kymaHandler) createOrchestration(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// scheduled orchestrations are triggered by the scheduler when due
+	if params.StartTime == nil || !params.StartTime.After(now) {
+		h.queue.Add(o.OrchestrationID)
+	}
+
+	response := orchestration.UpgradeResponse{OrchestrationID: o.OrchestrationID}
+
+	httputil.WriteResponse(w, http.StatusAccepted, response)
+}
+
+func (h *kymaHandler) retryOrchestration(w http.ResponseWriter, r *http.Request) {
+	orchestrationID := mux.Vars(r)["orchestration_id"]
+
+	parent, err := h.orchestrations.GetByID(orchestrationID)
+	if err != nil {
+		h.log.Errorf("while getting orchestration %s: %v", orchestrationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while getting orchestration %s", orchestrationID))
+		return
+	}
+	if !parent.IsFinished() {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Errorf("orchestration %s is in %s state, only finished orchestrations can be retried", orchestrationID, parent.State))
+		return
+	}
+
+	failed, _, _, err := h.operations.ListUpgradeKymaOperationsByOrchestrationID(orchestrationID, dbmodel.OperationFilter{States: []string{orchestration.Failed}})
+	if err != nil {
+		h.log.Errorf("while getting failed operations of orchestration %s: %v", orchestrationID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting failed operations of orchestration %s", orchestrationID))
+		return
+	}
+
+	targets, err := h.retryTargets(failed)
+	if err != nil {
+		h.log.Errorf("while resolving runtimes to retry: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while resolving runtimes to retry"))
+		return
+	}
+	if len(targets) == 0 {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Errorf("orchestration %s has no failed operations for existing runtimes", orchestrationID))
+		return
+	}
+
+	// the retry reuses the stored parameters of the parent, narrowed to the failed runtimes
+	params := parent.Parameters
+	params.Targets = orchestration.TargetSpec{Include: targets}
+	params.StartTime = nil
+	params.Recurrence = ""
+
+	now := time.Now()
+	o := internal.Orchestration{
+		OrchestrationID:       uuid.New().String(),
+		State:                 orchestration.Pending,
+		Description:           fmt.Sprintf("started retry of Kyma upgrade for orchestration %s", orchestrationID),
+		Parameters:            params,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+		ParentOrchestrationID: orchestrationID,
+	}
+
+	err = h.orchestrations.Insert(o)
+	if err != nil {
+		h.log.Errorf("while inserting orchestration to storage: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while inserting orchestration to storage"))
+		return
+	}
+
 	h.queue.Add(o.OrchestrationID)
 
 	response := orchestration.UpgradeResponse{OrchestrationID: o.OrchestrationID}
@@ -218,6 +366,46 @@ func (h *kymaHandler) createOrchestration(w http.ResponseWriter, r *http.Request
 	httputil.WriteResponse(w, http.StatusAccepted, response)
 }
 
+// retryTargets returns unique runtime targets of the given operations, skipping runtimes which were deprovisioned in the meantime
+func (h *kymaHandler) retryTargets(operations []internal.UpgradeKymaOperation) ([]orchestration.RuntimeTarget, error) {
+	targets := make([]orchestration.RuntimeTarget, 0)
+	seen := make(map[string]struct{})
+
+	for _, op := range operations {
+		if _, found := seen[op.RuntimeOperation.RuntimeID]; found {
+			continue
+		}
+		seen[op.RuntimeOperation.RuntimeID] = struct{}{}
+
+		_, err := h.instances.GetByID(op.InstanceID)
+		switch {
+		case dberr.IsNotFound(err):
+			h.log.Infof("skipping runtime %s, instance %s does not exist", op.RuntimeOperation.RuntimeID, op.InstanceID)
+			continue
+		case err != nil:
+			return nil, errors.Wrapf(err, "while getting instance %s", op.InstanceID)
+		}
+
+		deprovisioning, err := h.operations.GetDeprovisioningOperationByInstanceID(op.InstanceID)
+		switch {
+		case err == nil && isDeprovisioned(deprovisioning.State):
+			h.log.Infof("skipping runtime %s, instance %s is deprovisioned", op.RuntimeOperation.RuntimeID, op.InstanceID)
+			continue
+		case err != nil && !dberr.IsNotFound(err):
+			return nil, errors.Wrapf(err, "while getting deprovisioning operation for instance %s", op.InstanceID)
+		}
+
+		targets = append(targets, orchestration.RuntimeTarget{RuntimeID: op.RuntimeOperation.RuntimeID})
+	}
+
+	return targets, nil
+}
+
+// isDeprovisioned checks if the runtime is removed or being removed, the runtime of a failed deprovisioning still exists
+func isDeprovisioned(state domain.LastOperationState) bool {
+	return state == domain.Succeeded || state == domain.InProgress
+}
+
 func (h *kymaHandler) resolveErrorStatus(err error) int {
 	switch {
 	case dberr.IsNotFound(err):
@@ -234,6 +422,64 @@ func (h *kymaHandler) validateTarget(spec orchestration.TargetSpec) error {
 	return nil
 }
 
+// validateActions checks that the actions are known, unique, and that the cluster is upgraded before Kyma
+func (h *kymaHandler) validateActions(actions []orchestration.ActionType) error {
+	if len(actions) == 0 {
+		return errors.New("actions array must be not empty")
+	}
+	seen := make(map[orchestration.ActionType]bool)
+	for _, a := range actions {
+		switch a {
+		case orchestration.UpgradeClusterAction:
+			if seen[orchestration.UpgradeKymaAction] {
+				return errors.Errorf("%s action must precede %s action", orchestration.UpgradeClusterAction, orchestration.UpgradeKymaAction)
+			}
+		case orchestration.UpgradeKymaAction:
+		default:
+			return errors.Errorf("unknown action %q", a)
+		}
+		if seen[a] {
+			return errors.Errorf("action %s is duplicated", a)
+		}
+		seen[a] = true
+	}
+	return nil
+}
+
+func (h *kymaHandler) validateLimits(limits []orchestration.ConcurrencyLimit) error {
+	for _, l := range limits {
+		switch l.By {
+		case orchestration.LimitByRegion, orchestration.LimitByGlobalAccount, orchestration.LimitBySeed:
+		default:
+			return errors.Errorf("unknown concurrency limit key %q", l.By)
+		}
+		if l.Max < 1 {
+			return errors.Errorf("concurrency limit by %s must be greater than 0", l.By)
+		}
+	}
+	return nil
+}
+
+// resolveStartTime validates the recurrence and sets the start time of a recurring orchestration
+// to the first occurrence, unless specified explicitly
+func (h *kymaHandler) resolveStartTime(params *orchestration.Parameters) error {
+	if params.Recurrence == "" {
+		return nil
+	}
+	schedule, err := orchestrationInt.ParseCron(params.Recurrence)
+	if err != nil {
+		return errors.Wrap(err, "while parsing recurrence")
+	}
+	next := schedule.Next(time.Now())
+	if next.IsZero() {
+		return errors.Errorf("recurrence %q has no next occurrence", params.Recurrence)
+	}
+	if params.StartTime == nil {
+		params.StartTime = &next
+	}
+	return nil
+}
+
 func (h *kymaHandler) defaultOrchestrationStrategy(spec *orchestration.StrategySpec) {
 	if spec.Parallel.Workers == 0 {
 		spec.Parallel.Workers = 1
@@ -14,6 +14,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/handlers"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/gorilla/mux"
@@ -29,7 +30,7 @@ func TestKymaOrchestrationHandler_(t *testing.T) {
 		db := storage.NewMemoryStorage()
 		logs := logrus.New()
 		q := process.NewQueue(&testExecutor{}, logs)
-		kymaHandler := handlers.NewKymaOrchestrationHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), 100, q, logs)
+		kymaHandler := handlers.NewKymaOrchestrationHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), 100, q, logs)
 
 		params := orchestration.Parameters{
 			Targets: orchestration.TargetSpec{
@@ -94,7 +95,7 @@ func TestKymaOrchestrationHandler_(t *testing.T) {
 
 		logs := logrus.New()
 		q := process.NewQueue(&testExecutor{}, logs)
-		kymaHandler := handlers.NewKymaOrchestrationHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), 100, q, logs)
+		kymaHandler := handlers.NewKymaOrchestrationHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), 100, q, logs)
 
 		req, err := http.NewRequest("GET", "/orchestrations?page_size=1", nil)
 		require.NoError(t, err)
@@ -129,10 +130,41 @@ func TestKymaOrchestrationHandler_(t *testing.T) {
 		// then
 		require.Equal(t, http.StatusOK, rr.Code)
 
+		out = orchestration.StatusResponseList{}
 		err = json.Unmarshal(rr.Body.Bytes(), &out)
 		require.NoError(t, err)
 		assert.Equal(t, 2, out.TotalCount)
 		assert.Equal(t, 1, out.Count)
+		assert.Empty(t, out.NextCursor)
+		lastID := out.Data[0].OrchestrationID
+
+		// given
+		req, err = http.NewRequest(http.MethodGet, "/orchestrations?page_size=1", nil)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		out = orchestration.StatusResponseList{}
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+		require.NotEmpty(t, out.NextCursor)
+
+		req, err = http.NewRequest(http.MethodGet, "/orchestrations?page_size=1&cursor="+out.NextCursor, nil)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		out = orchestration.StatusResponseList{}
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+		require.Len(t, out.Data, 1)
+		assert.Equal(t, lastID, out.Data[0].OrchestrationID)
+		assert.Empty(t, out.NextCursor)
 
 		// given
 		urlPath = fmt.Sprintf("/orchestrations/%s", fixID)
@@ -184,7 +216,7 @@ func TestKymaOrchestrationHandler_(t *testing.T) {
 
 		logs := logrus.New()
 		q := process.NewQueue(&testExecutor{}, logs)
-		kymaHandler := handlers.NewKymaOrchestrationHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), 100, q, logs)
+		kymaHandler := handlers.NewKymaOrchestrationHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), 100, q, logs)
 
 		urlPath := fmt.Sprintf("/orchestrations/%s/operations", fixID)
 		req, err := http.NewRequest("GET", urlPath, nil)
@@ -227,6 +259,170 @@ func TestKymaOrchestrationHandler_(t *testing.T) {
 		assert.Equal(t, dto.OrchestrationID, fixID)
 		assert.Equal(t, dto.OperationID, fixID)
 	})
+
+	t.Run("retry", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		parameters := orchestration.Parameters{
+			Strategy: orchestration.StrategySpec{
+				Type:     orchestration.ParallelStrategy,
+				Schedule: orchestration.Immediate,
+				Parallel: orchestration.ParallelStrategySpec{Workers: 2},
+			},
+		}
+
+		err := db.Orchestrations().Insert(internal.Orchestration{OrchestrationID: fixID, State: orchestration.Failed, Parameters: parameters})
+		require.NoError(t, err)
+		for _, op := range []struct {
+			id, instanceID, runtimeID string
+			state                     domain.LastOperationState
+		}{
+			{id: "op-1", instanceID: "inst-1", runtimeID: "runtime-1", state: orchestration.Failed},
+			{id: "op-2", instanceID: "inst-2", runtimeID: "runtime-2", state: orchestration.Succeeded},
+			{id: "op-3", instanceID: "inst-3", runtimeID: "runtime-3", state: orchestration.Failed},
+			{id: "op-4", instanceID: "inst-4", runtimeID: "runtime-4", state: orchestration.Failed},
+			{id: "op-5", instanceID: "inst-5", runtimeID: "runtime-5", state: orchestration.Failed},
+		} {
+			err = db.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+				Operation: internal.Operation{
+					ID:              op.id,
+					InstanceID:      op.instanceID,
+					OrchestrationID: fixID,
+					State:           op.state,
+				},
+				RuntimeOperation: orchestration.RuntimeOperation{
+					Runtime: orchestration.Runtime{RuntimeID: op.runtimeID, InstanceID: op.instanceID},
+				},
+			})
+			require.NoError(t, err)
+		}
+		// inst-3 is deprovisioning, inst-4 is already removed, deprovisioning of inst-5 failed
+		for _, id := range []string{"inst-1", "inst-2", "inst-3", "inst-5"} {
+			err = db.Instances().Insert(internal.Instance{InstanceID: id})
+			require.NoError(t, err)
+		}
+		err = db.Operations().InsertDeprovisioningOperation(internal.DeprovisioningOperation{
+			Operation: internal.Operation{ID: "deprovisioning-3", InstanceID: "inst-3", State: domain.InProgress},
+		})
+		require.NoError(t, err)
+		err = db.Operations().InsertDeprovisioningOperation(internal.DeprovisioningOperation{
+			Operation: internal.Operation{ID: "deprovisioning-5", InstanceID: "inst-5", State: domain.Failed},
+		})
+		require.NoError(t, err)
+
+		logs := logrus.New()
+		q := process.NewQueue(&testExecutor{}, logs)
+		kymaHandler := handlers.NewKymaOrchestrationHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), 100, q, logs)
+
+		urlPath := fmt.Sprintf("/orchestrations/%s/retry", fixID)
+		req, err := http.NewRequest(http.MethodPost, urlPath, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		kymaHandler.AttachRoutes(router)
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusAccepted, rr.Code)
+
+		var out orchestration.UpgradeResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		retried, err := db.Orchestrations().GetByID(out.OrchestrationID)
+		require.NoError(t, err)
+		assert.Equal(t, fixID, retried.ParentOrchestrationID)
+		assert.Equal(t, orchestration.Pending, retried.State)
+		assert.Equal(t, parameters.Strategy, retried.Parameters.Strategy)
+		assert.Equal(t, []orchestration.RuntimeTarget{{RuntimeID: "runtime-1"}, {RuntimeID: "runtime-5"}}, retried.Parameters.Targets.Include)
+
+		// given
+		err = db.Orchestrations().Insert(internal.Orchestration{OrchestrationID: "id-2", State: orchestration.InProgress})
+		require.NoError(t, err)
+		req, err = http.NewRequest(http.MethodPost, "/orchestrations/id-2/retry", nil)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("composite upgrade", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		logs := logrus.New()
+		q := process.NewQueue(&testExecutor{}, logs)
+		kymaHandler := handlers.NewKymaOrchestrationHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), 100, q, logs)
+		router := mux.NewRouter()
+		kymaHandler.AttachRoutes(router)
+
+		for name, tc := range map[string]struct {
+			actions      []orchestration.ActionType
+			expectedCode int
+		}{
+			"cluster and kyma": {
+				actions:      []orchestration.ActionType{orchestration.UpgradeClusterAction, orchestration.UpgradeKymaAction},
+				expectedCode: http.StatusAccepted,
+			},
+			"cluster only": {
+				actions:      []orchestration.ActionType{orchestration.UpgradeClusterAction},
+				expectedCode: http.StatusAccepted,
+			},
+			"no actions": {
+				expectedCode: http.StatusBadRequest,
+			},
+			"unknown action": {
+				actions:      []orchestration.ActionType{"restart"},
+				expectedCode: http.StatusBadRequest,
+			},
+			"duplicated action": {
+				actions:      []orchestration.ActionType{orchestration.UpgradeKymaAction, orchestration.UpgradeKymaAction},
+				expectedCode: http.StatusBadRequest,
+			},
+			"kyma before cluster": {
+				actions:      []orchestration.ActionType{orchestration.UpgradeKymaAction, orchestration.UpgradeClusterAction},
+				expectedCode: http.StatusBadRequest,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				params := orchestration.Parameters{
+					Targets: orchestration.TargetSpec{
+						Include: []orchestration.RuntimeTarget{{RuntimeID: "test"}},
+					},
+					Actions:           tc.actions,
+					KubernetesVersion: "1.18.10",
+				}
+				p, err := json.Marshal(&params)
+				require.NoError(t, err)
+				req, err := http.NewRequest(http.MethodPost, "/upgrade/composite", bytes.NewBuffer(p))
+				require.NoError(t, err)
+				rr := httptest.NewRecorder()
+
+				// when
+				router.ServeHTTP(rr, req)
+
+				// then
+				require.Equal(t, tc.expectedCode, rr.Code)
+				if tc.expectedCode != http.StatusAccepted {
+					return
+				}
+				var out orchestration.UpgradeResponse
+				err = json.Unmarshal(rr.Body.Bytes(), &out)
+				require.NoError(t, err)
+
+				o, err := db.Orchestrations().GetByID(out.OrchestrationID)
+				require.NoError(t, err)
+				assert.Equal(t, tc.actions, o.Parameters.Actions)
+				assert.Equal(t, "1.18.10", o.Parameters.KubernetesVersion)
+			})
+		}
+	})
 }
 
 type testExecutor struct{}
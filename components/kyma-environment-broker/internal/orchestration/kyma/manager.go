@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	orchestrationInt "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
@@ -49,11 +50,28 @@ func (u *upgradeKymaManager) Execute(orchestrationID string) (time.Duration, err
 		return u.failOrchestration(o, errors.Wrap(err, "while getting orchestration"))
 	}
 
+	// scheduled orchestrations are processed when due
+	if o.State == orchestration.Pending && o.Parameters.StartTime != nil {
+		if until := time.Until(*o.Parameters.StartTime); until > 0 {
+			logger.Infof("Orchestration is scheduled at %s", o.Parameters.StartTime)
+			return until, nil
+		}
+	}
+
+	pending := o.State == orchestration.Pending
 	operations, err := u.resolveOperations(o, o.Parameters)
 	if err != nil {
 		return u.failOrchestration(o, errors.Wrap(err, "while resolving operations"))
 	}
 
+	// the next run is scheduled before the started run is stored, so it is not lost if the processing is interrupted
+	if pending {
+		if err := u.scheduleNextRun(o); err != nil {
+			logger.Errorf("while scheduling next run of recurring orchestration: %v", err)
+			return u.pollingInterval, nil
+		}
+	}
+
 	err = u.orchestrationStorage.Update(*o)
 	if err != nil {
 		logger.Errorf("while updating orchestration: %v", err)
@@ -85,6 +103,56 @@ func (u *upgradeKymaManager) Execute(orchestrationID string) (time.Duration, err
 	return 0, nil
 }
 
+// scheduleNextRun inserts the next run of the started recurring orchestration as a new pending orchestration,
+// which is triggered by the scheduler when due. The ID of the next run is derived from the ID of the started one,
+// so the next run is inserted only once when the start of the orchestration is processed again.
+func (u *upgradeKymaManager) scheduleNextRun(o *internal.Orchestration) error {
+	if o.Parameters.Recurrence == "" {
+		return nil
+	}
+	logger := u.log.WithField("orchestrationID", o.OrchestrationID)
+
+	nextID := uuid.NewSHA1(uuid.NameSpaceOID, []byte(o.OrchestrationID)).String()
+	_, err := u.orchestrationStorage.GetByID(nextID)
+	switch {
+	case err == nil:
+		logger.Infof("Next run %s of recurring orchestration is already scheduled", nextID)
+		return nil
+	case !dberr.IsNotFound(err):
+		return errors.Wrapf(err, "while getting next run %s", nextID)
+	}
+
+	schedule, err := orchestrationInt.ParseCron(o.Parameters.Recurrence)
+	if err != nil {
+		// the recurrence is validated when the orchestration is created, the invalid one cannot be fixed by a retry
+		logger.Errorf("while parsing recurrence: %v", err)
+		return nil
+	}
+	startTime := schedule.Next(time.Now())
+	if startTime.IsZero() {
+		logger.Errorf("recurrence %q has no next occurrence", o.Parameters.Recurrence)
+		return nil
+	}
+
+	params := o.Parameters
+	params.StartTime = &startTime
+	now := time.Now()
+	next := internal.Orchestration{
+		OrchestrationID: nextID,
+		State:           orchestration.Pending,
+		Description:     fmt.Sprintf("scheduled next run of recurring orchestration %s", o.OrchestrationID),
+		Parameters:      params,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	err = u.orchestrationStorage.Insert(next)
+	if err != nil {
+		return errors.Wrapf(err, "while inserting next run %s", nextID)
+	}
+	logger.Infof("Next run of recurring orchestration %s is scheduled at %s", next.OrchestrationID, startTime)
+	return nil
+}
+
 func (u *upgradeKymaManager) resolveOperations(o *internal.Orchestration, params orchestration.Parameters) ([]internal.UpgradeKymaOperation, error) {
 	var result []internal.UpgradeKymaOperation
 	if o.State == orchestration.Pending {
@@ -93,7 +161,21 @@ func (u *upgradeKymaManager) resolveOperations(o *internal.Orchestration, params
 			return result, errors.Wrap(err, "while resolving targets")
 		}
 
+		// operations created before the processing was interrupted, e.g. by a restart, are not created again
+		existing, _, _, err := u.operationStorage.ListUpgradeKymaOperationsByOrchestrationID(o.OrchestrationID, dbmodel.OperationFilter{})
+		if err != nil {
+			return result, errors.Wrap(err, "while listing existing operations")
+		}
+		created := make(map[string]bool)
+		for _, op := range existing {
+			created[op.RuntimeOperation.RuntimeID] = true
+			result = append(result, op)
+		}
+
 		for _, r := range runtimes {
+			if created[r.RuntimeID] {
+				continue
+			}
 			// we set planID fetched from provisioning parameters
 			po, err := u.operationStorage.GetProvisioningOperationByInstanceID(r.InstanceID)
 			if err != nil {
@@ -125,6 +207,8 @@ func (u *upgradeKymaManager) resolveOperations(o *internal.Orchestration, params
 					ID: id,
 					Runtime: orchestration.Runtime{
 						ShootName:              r.ShootName,
+						Region:                 r.Region,
+						Seed:                   r.Seed,
 						MaintenanceWindowBegin: windowBegin,
 						MaintenanceWindowEnd:   windowEnd,
 						RuntimeID:              r.RuntimeID,
@@ -133,7 +217,9 @@ func (u *upgradeKymaManager) resolveOperations(o *internal.Orchestration, params
 					},
 					DryRun: params.DryRun,
 				},
-				PlanID: provisioningParams.PlanID,
+				PlanID:            provisioningParams.PlanID,
+				Actions:           newActions(params.Actions),
+				KubernetesVersion: params.KubernetesVersion,
 			}
 			result = append(result, op)
 			err = u.operationStorage.InsertUpgradeKymaOperation(op)
@@ -142,12 +228,12 @@ func (u *upgradeKymaManager) resolveOperations(o *internal.Orchestration, params
 			}
 		}
 
-		if len(runtimes) != 0 {
+		if len(result) != 0 {
 			o.State = orchestration.InProgress
 		} else {
 			o.State = orchestration.Succeeded
 		}
-		o.Description = fmt.Sprintf("Scheduled %d operations", len(runtimes))
+		o.Description = fmt.Sprintf("Scheduled %d operations", len(result))
 
 	} else {
 		// Resume processing of in progress upgrade operations after restart
@@ -165,7 +251,7 @@ func (u *upgradeKymaManager) resolveOperations(o *internal.Orchestration, params
 func (u *upgradeKymaManager) resolveStrategy(sType orchestration.StrategyType, executor process.Executor, log logrus.FieldLogger) orchestration.Strategy {
 	switch sType {
 	case orchestration.ParallelStrategy:
-		return orchestration.NewParallelOrchestrationStrategy(executor, log)
+		return orchestration.NewParallelOrchestrationStrategy(executor, &dispatchRecorder{operations: u.operationStorage}, log)
 	}
 	return nil
 }
@@ -184,6 +270,13 @@ func (u *upgradeKymaManager) filterOperationsInProgress(ops []internal.UpgradeKy
 
 func (u *upgradeKymaManager) failOrchestration(o *internal.Orchestration, err error) (time.Duration, error) {
 	u.log.Errorf("orchestration %s failed: %s", o.OrchestrationID, err)
+	// the recurring orchestration which failed before it started still schedules the next run
+	if o.State == orchestration.Pending {
+		if err := u.scheduleNextRun(o); err != nil {
+			u.log.Errorf("while scheduling next run of recurring orchestration %s: %s", o.OrchestrationID, err)
+			return u.pollingInterval, nil
+		}
+	}
 	return u.updateOrchestration(o, orchestration.Failed, err.Error()), nil
 }
 
@@ -253,3 +346,35 @@ func (u *upgradeKymaManager) resolveWindowTime(beginTime, endTime time.Time) (ti
 
 	return start, end
 }
+
+// dispatchRecorder stores the dispatch of the upgrade kyma operation by the strategy
+type dispatchRecorder struct {
+	operations storage.Operations
+}
+
+func (r *dispatchRecorder) MarkDispatched(operationID string) error {
+	op, err := r.operations.GetUpgradeKymaOperationByID(operationID)
+	if err != nil {
+		return errors.Wrapf(err, "while getting upgrade operation %s", operationID)
+	}
+	op.RuntimeOperation.Dispatched = true
+	_, err = r.operations.UpdateUpgradeKymaOperation(*op)
+	if err != nil {
+		return errors.Wrapf(err, "while updating upgrade operation %s", operationID)
+	}
+
+	return nil
+}
+
+// newActions returns the initial progress of the composite orchestration actions, with the first one in progress
+func newActions(types []orchestration.ActionType) []orchestration.ActionStatus {
+	if len(types) == 0 {
+		return nil
+	}
+	actions := make([]orchestration.ActionStatus, len(types))
+	for i, t := range types {
+		actions[i] = orchestration.ActionStatus{Type: t, State: orchestration.Pending}
+	}
+	actions[0].State = orchestration.InProgress
+	return actions
+}
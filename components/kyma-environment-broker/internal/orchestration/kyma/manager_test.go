@@ -12,6 +12,8 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/kyma"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 )
@@ -165,6 +167,259 @@ func TestUpgradeKymaManager_Execute(t *testing.T) {
 		assert.Equal(t, orchestration.Succeeded, o.State)
 
 	})
+
+	t.Run("Scheduled", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		id := "id"
+		startTime := time.Now().Add(time.Hour)
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.Pending,
+			Parameters:      orchestration.Parameters{StartTime: &startTime},
+		})
+		require.NoError(t, err)
+
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), nil, resolver, poolingInterval, logrus.New())
+
+		// when
+		when, err := svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		assert.True(t, when > 59*time.Minute)
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Pending, o.State)
+	})
+
+	t.Run("Recurring", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+		resolver.On("Resolve", orchestration.TargetSpec{}).Return([]orchestration.Runtime{}, nil).Once()
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.Pending,
+			Parameters:      orchestration.Parameters{Recurrence: "0 3 * * 0"},
+		})
+		require.NoError(t, err)
+
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), nil, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Succeeded, o.State)
+
+		pending, err := store.Orchestrations().ListByState(orchestration.Pending)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, "0 3 * * 0", pending[0].Parameters.Recurrence)
+		require.NotNil(t, pending[0].Parameters.StartTime)
+		assert.Equal(t, time.Sunday, pending[0].Parameters.StartTime.Weekday())
+		assert.Equal(t, 3, pending[0].Parameters.StartTime.Hour())
+		assert.True(t, pending[0].Parameters.StartTime.After(time.Now()))
+	})
+
+	t.Run("RecurringNextRunScheduledWhenStarted", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+		resolver.On("Resolve", orchestration.TargetSpec{}).Return([]orchestration.Runtime{
+			{RuntimeID: "runtime-1", InstanceID: "instance-1"},
+		}, nil).Once()
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.Pending,
+			Parameters: orchestration.Parameters{
+				Recurrence: "0 3 * * 0",
+				Strategy: orchestration.StrategySpec{
+					Type:     orchestration.ParallelStrategy,
+					Schedule: orchestration.Immediate,
+					Parallel: orchestration.ParallelStrategySpec{Workers: 1},
+				},
+			},
+		})
+		require.NoError(t, err)
+		err = store.Operations().InsertProvisioningOperation(internal.ProvisioningOperation{
+			Operation:              internal.Operation{ID: "provisioning-1", InstanceID: "instance-1"},
+			ProvisioningParameters: `{"plan_id": "4deee563-e5ec-4731-b9b1-53b42d855f0c"}`,
+		})
+		require.NoError(t, err)
+
+		executor := &pendingRecorderExecutor{
+			succeedingExecutor: succeedingExecutor{operations: store.Operations()},
+			orchestrations:     store.Orchestrations(),
+		}
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), executor, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		require.Len(t, executor.pending, 1)
+		pending, err := store.Orchestrations().ListByState(orchestration.Pending)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, executor.pending[0].OrchestrationID, pending[0].OrchestrationID)
+	})
+
+	t.Run("RecurringResumedAfterRestart", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.InProgress,
+			Parameters: orchestration.Parameters{
+				Recurrence: "0 3 * * 0",
+				Strategy: orchestration.StrategySpec{
+					Type:     orchestration.ParallelStrategy,
+					Schedule: orchestration.Immediate,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), &testExecutor{}, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Succeeded, o.State)
+
+		// the next run was scheduled when the resumed run started
+		pending, err := store.Orchestrations().ListByState(orchestration.Pending)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("RecurringFailedBeforeStart", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+		resolver.On("Resolve", orchestration.TargetSpec{}).Return(nil, errors.New("gardener unavailable")).Twice()
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.Pending,
+			Parameters:      orchestration.Parameters{Recurrence: "0 3 * * 0"},
+		})
+		require.NoError(t, err)
+
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), nil, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		o.State = orchestration.Pending
+		require.NoError(t, store.Orchestrations().Update(*o))
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err = store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Failed, o.State)
+
+		pending, err := store.Orchestrations().ListByState(orchestration.Pending)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, "0 3 * * 0", pending[0].Parameters.Recurrence)
+	})
+
+	t.Run("PendingWithOperationsCreatedBeforeRestart", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+		resolver.On("Resolve", orchestration.TargetSpec{}).Return([]orchestration.Runtime{
+			{RuntimeID: "runtime-1", InstanceID: "instance-1"},
+			{RuntimeID: "runtime-2", InstanceID: "instance-2"},
+		}, nil).Once()
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.Pending,
+			Parameters: orchestration.Parameters{Strategy: orchestration.StrategySpec{
+				Type:     orchestration.ParallelStrategy,
+				Schedule: orchestration.Immediate,
+				Parallel: orchestration.ParallelStrategySpec{Workers: 1},
+			}},
+		})
+		require.NoError(t, err)
+		err = store.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+			Operation: internal.Operation{
+				ID:              "upgrade-1",
+				InstanceID:      "instance-1",
+				OrchestrationID: id,
+				State:           domain.InProgress,
+			},
+			RuntimeOperation: orchestration.RuntimeOperation{
+				ID:         "upgrade-1",
+				Runtime:    orchestration.Runtime{RuntimeID: "runtime-1", InstanceID: "instance-1"},
+				Dispatched: true,
+			},
+		})
+		require.NoError(t, err)
+		err = store.Operations().InsertProvisioningOperation(internal.ProvisioningOperation{
+			Operation:              internal.Operation{ID: "provisioning-2", InstanceID: "instance-2"},
+			ProvisioningParameters: `{"plan_id": "4deee563-e5ec-4731-b9b1-53b42d855f0c"}`,
+		})
+		require.NoError(t, err)
+
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), &succeedingExecutor{operations: store.Operations()}, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Succeeded, o.State)
+
+		ops, _, _, err := store.Operations().ListUpgradeKymaOperationsByOrchestrationID(id, dbmodel.OperationFilter{})
+		require.NoError(t, err)
+		require.Len(t, ops, 2)
+		for _, op := range ops {
+			assert.True(t, op.RuntimeOperation.Dispatched)
+		}
+	})
 }
 
 type testExecutor struct{}
@@ -172,3 +427,33 @@ type testExecutor struct{}
 func (t *testExecutor) Execute(opID string) (time.Duration, error) {
 	return 0, nil
 }
+
+type succeedingExecutor struct {
+	operations storage.Operations
+}
+
+func (e *succeedingExecutor) Execute(opID string) (time.Duration, error) {
+	op, err := e.operations.GetUpgradeKymaOperationByID(opID)
+	if err != nil {
+		return 0, err
+	}
+	op.State = domain.Succeeded
+	_, err = e.operations.UpdateUpgradeKymaOperation(*op)
+	return 0, err
+}
+
+// pendingRecorderExecutor records the pending orchestrations while the operations of the current run are executed
+type pendingRecorderExecutor struct {
+	succeedingExecutor
+	orchestrations storage.Orchestrations
+	pending        []internal.Orchestration
+}
+
+func (e *pendingRecorderExecutor) Execute(opID string) (time.Duration, error) {
+	pending, err := e.orchestrations.ListByState(orchestration.Pending)
+	if err != nil {
+		return 0, err
+	}
+	e.pending = pending
+	return e.succeedingExecutor.Execute(opID)
+}
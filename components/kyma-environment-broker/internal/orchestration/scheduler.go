@@ -0,0 +1,55 @@
+package orchestration
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Scheduler triggers the pending orchestrations which are due, i.e. the scheduled ones
+// and the next runs of recurring orchestrations created when the previous run started.
+type Scheduler struct {
+	orchestrations storage.Orchestrations
+	queue          *process.Queue
+	interval       time.Duration
+	log            logrus.FieldLogger
+
+	now func() time.Time
+}
+
+func NewScheduler(orchestrations storage.Orchestrations, queue *process.Queue, interval time.Duration, log logrus.FieldLogger) *Scheduler {
+	return &Scheduler{
+		orchestrations: orchestrations,
+		queue:          queue,
+		interval:       interval,
+		log:            log,
+		now:            time.Now,
+	}
+}
+
+// Run checks the pending orchestrations every interval until the stop channel is closed
+func (s *Scheduler) Run(stop <-chan struct{}) {
+	go wait.Until(s.trigger, s.interval, stop)
+}
+
+func (s *Scheduler) trigger() {
+	orchestrations, err := s.orchestrations.ListByState(orchestration.Pending)
+	if err != nil {
+		s.log.Errorf("while getting pending orchestrations: %v", err)
+		return
+	}
+
+	now := s.now()
+	for _, o := range orchestrations {
+		if o.Parameters.StartTime != nil && o.Parameters.StartTime.After(now) {
+			continue
+		}
+		// the queue ignores orchestrations which are already queued
+		s.log.Infof("Triggering scheduled orchestration %s", o.OrchestrationID)
+		s.queue.Add(o.OrchestrationID)
+	}
+}
@@ -0,0 +1,92 @@
+package orchestration
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_Trigger(t *testing.T) {
+	// given
+	now := time.Date(2020, time.November, 26, 10, 30, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+
+	orchestrations := storage.NewMemoryStorage().Orchestrations()
+	for _, o := range []internal.Orchestration{
+		{OrchestrationID: "pending", State: orchestration.Pending},
+		{OrchestrationID: "scheduled-due", State: orchestration.Pending, Parameters: orchestration.Parameters{StartTime: &past}},
+		{OrchestrationID: "scheduled-now", State: orchestration.Pending, Parameters: orchestration.Parameters{StartTime: &now}},
+		{OrchestrationID: "scheduled-later", State: orchestration.Pending, Parameters: orchestration.Parameters{StartTime: &future}},
+		{OrchestrationID: "in-progress", State: orchestration.InProgress, Parameters: orchestration.Parameters{StartTime: &past}},
+		{OrchestrationID: "succeeded", State: orchestration.Succeeded},
+	} {
+		require.NoError(t, orchestrations.Insert(o))
+	}
+
+	executor := &recordingExecutor{}
+	queue := process.NewQueue(executor, logrus.New())
+	stop := make(chan struct{})
+	defer close(stop)
+	queue.Run(stop, 1)
+
+	scheduler := NewScheduler(orchestrations, queue, time.Minute, logrus.New())
+	scheduler.now = func() time.Time { return now }
+
+	// when
+	scheduler.trigger()
+
+	// then
+	expected := []string{"pending", "scheduled-due", "scheduled-now"}
+	assert.Eventually(t, func() bool {
+		return len(executor.executed()) == len(expected)
+	}, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, expected, executor.executed())
+
+	// when the clock reaches the start time of the scheduled orchestration
+	scheduler.now = func() time.Time { return future }
+	scheduler.trigger()
+
+	// then
+	assert.Eventually(t, func() bool {
+		return containsID(executor.executed(), "scheduled-later")
+	}, time.Second, 10*time.Millisecond)
+	assert.NotContains(t, executor.executed(), "in-progress")
+	assert.NotContains(t, executor.executed(), "succeeded")
+}
+
+type recordingExecutor struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (e *recordingExecutor) Execute(id string) (time.Duration, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.ids = append(e.ids, id)
+	return 0, nil
+}
+
+func (e *recordingExecutor) executed() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.ids...)
+}
+
+func containsID(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
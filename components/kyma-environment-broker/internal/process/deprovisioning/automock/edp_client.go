@@ -2,6 +2,7 @@
 
 package automock
 
+import context "context"
 import mock "github.com/stretchr/testify/mock"
 
 // EDPClient is an autogenerated mock type for the EDPClient type
@@ -9,13 +10,13 @@ type EDPClient struct {
 	mock.Mock
 }
 
-// DeleteDataTenant provides a mock function with given fields: name, env
-func (_m *EDPClient) DeleteDataTenant(name string, env string) error {
-	ret := _m.Called(name, env)
+// DeleteDataTenant provides a mock function with given fields: ctx, name, env
+func (_m *EDPClient) DeleteDataTenant(ctx context.Context, name string, env string) error {
+	ret := _m.Called(ctx, name, env)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(name, env)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, env)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -23,13 +24,13 @@ func (_m *EDPClient) DeleteDataTenant(name string, env string) error {
 	return r0
 }
 
-// DeleteMetadataTenant provides a mock function with given fields: name, env, key
-func (_m *EDPClient) DeleteMetadataTenant(name string, env string, key string) error {
-	ret := _m.Called(name, env, key)
+// DeleteMetadataTenant provides a mock function with given fields: ctx, name, env, key
+func (_m *EDPClient) DeleteMetadataTenant(ctx context.Context, name string, env string, key string) error {
+	ret := _m.Called(ctx, name, env, key)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(name, env, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, name, env, key)
 	} else {
 		r0 = ret.Error(0)
 	}
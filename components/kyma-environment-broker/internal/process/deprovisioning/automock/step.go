@@ -3,6 +3,8 @@
 package automock
 
 import (
+	context "context"
+
 	internal "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	logrus "github.com/sirupsen/logrus"
 
@@ -30,27 +32,27 @@ func (_m *Step) Name() string {
 	return r0
 }
 
-// Run provides a mock function with given fields: operation, logger
-func (_m *Step) Run(operation internal.DeprovisioningOperation, logger logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
-	ret := _m.Called(operation, logger)
+// Run provides a mock function with given fields: ctx, operation, logger
+func (_m *Step) Run(ctx context.Context, operation internal.DeprovisioningOperation, logger logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+	ret := _m.Called(ctx, operation, logger)
 
 	var r0 internal.DeprovisioningOperation
-	if rf, ok := ret.Get(0).(func(internal.DeprovisioningOperation, logrus.FieldLogger) internal.DeprovisioningOperation); ok {
-		r0 = rf(operation, logger)
+	if rf, ok := ret.Get(0).(func(context.Context, internal.DeprovisioningOperation, logrus.FieldLogger) internal.DeprovisioningOperation); ok {
+		r0 = rf(ctx, operation, logger)
 	} else {
 		r0 = ret.Get(0).(internal.DeprovisioningOperation)
 	}
 
 	var r1 time.Duration
-	if rf, ok := ret.Get(1).(func(internal.DeprovisioningOperation, logrus.FieldLogger) time.Duration); ok {
-		r1 = rf(operation, logger)
+	if rf, ok := ret.Get(1).(func(context.Context, internal.DeprovisioningOperation, logrus.FieldLogger) time.Duration); ok {
+		r1 = rf(ctx, operation, logger)
 	} else {
 		r1 = ret.Get(1).(time.Duration)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(internal.DeprovisioningOperation, logrus.FieldLogger) error); ok {
-		r2 = rf(operation, logger)
+	if rf, ok := ret.Get(2).(func(context.Context, internal.DeprovisioningOperation, logrus.FieldLogger) error); ok {
+		r2 = rf(ctx, operation, logger)
 	} else {
 		r2 = ret.Error(2)
 	}
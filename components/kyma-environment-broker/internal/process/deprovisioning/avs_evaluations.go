@@ -1,6 +1,7 @@
 package deprovisioning
 
 import (
+	"context"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
@@ -33,19 +34,19 @@ func (ars *AvsEvaluationRemovalStep) Name() string {
 	return "De-provision_AVS_Evaluations"
 }
 
-func (ars *AvsEvaluationRemovalStep) Run(deProvisioningOperation internal.DeprovisioningOperation, logger logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+func (ars *AvsEvaluationRemovalStep) Run(ctx context.Context, deProvisioningOperation internal.DeprovisioningOperation, logger logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
 	logger.Infof("Avs lifecycle %+v", deProvisioningOperation.Avs)
 	if deProvisioningOperation.Avs.AVSExternalEvaluationDeleted && deProvisioningOperation.Avs.AVSInternalEvaluationDeleted {
 		logger.Infof("Both internal and external evaluations have been deleted")
 		return deProvisioningOperation, 0, nil
 	}
 
-	deProvisioningOperation, err := ars.delegator.DeleteAvsEvaluation(deProvisioningOperation, logger, ars.internalEvalAssistant)
+	deProvisioningOperation, err := ars.delegator.DeleteAvsEvaluation(ctx, deProvisioningOperation, logger, ars.internalEvalAssistant)
 	if err != nil {
 		return ars.deProvisioningManager.RetryOperationWithoutFail(deProvisioningOperation, err.Error(), 10*time.Second, 10*time.Minute, logger)
 	}
 
-	deProvisioningOperation, err = ars.delegator.DeleteAvsEvaluation(deProvisioningOperation, logger, ars.externalEvalAssistant)
+	deProvisioningOperation, err = ars.delegator.DeleteAvsEvaluation(ctx, deProvisioningOperation, logger, ars.externalEvalAssistant)
 	if err != nil {
 		return ars.deProvisioningManager.RetryOperationWithoutFail(deProvisioningOperation, err.Error(), 10*time.Second, 10*time.Minute, logger)
 	}
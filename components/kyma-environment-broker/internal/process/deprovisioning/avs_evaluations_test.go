@@ -55,7 +55,7 @@ func TestAvsEvaluationsRemovalStep_Run(t *testing.T) {
 	assert.Equal(t, 0, len(evalIdsHolder))
 	assert.Equal(t, 0, len(parentEvalIdHolder))
 	// when
-	deProvisioningOperation, repeat, err := step.Run(deProvisioningOperation, logger)
+	deProvisioningOperation, repeat, err := step.Run(context.Background(), deProvisioningOperation, logger)
 
 	// then
 	assert.NoError(t, err)
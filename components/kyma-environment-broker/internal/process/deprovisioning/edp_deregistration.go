@@ -1,6 +1,7 @@
 package deprovisioning
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -13,8 +14,8 @@ import (
 
 //go:generate mockery -name=EDPClient -output=automock -outpkg=automock -case=underscore
 type EDPClient interface {
-	DeleteDataTenant(name, env string) error
-	DeleteMetadataTenant(name, env, key string) error
+	DeleteDataTenant(ctx context.Context, name, env string) error
+	DeleteMetadataTenant(ctx context.Context, name, env, key string) error
 }
 
 type EDPDeregistrationStep struct {
@@ -33,21 +34,21 @@ func (s *EDPDeregistrationStep) Name() string {
 	return "EDP_Deregistration"
 }
 
-func (s *EDPDeregistrationStep) Run(operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+func (s *EDPDeregistrationStep) Run(ctx context.Context, operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
 	log.Info("Delete DataTenant metadata")
 	for _, key := range []string{
 		edp.MaasConsumerEnvironmentKey,
 		edp.MaasConsumerRegionKey,
 		edp.MaasConsumerSubAccountKey,
 	} {
-		err := s.client.DeleteMetadataTenant(operation.SubAccountID, s.config.Environment, key)
+		err := s.client.DeleteMetadataTenant(ctx, operation.SubAccountID, s.config.Environment, key)
 		if err != nil {
 			return s.handleError(operation, err, log, fmt.Sprintf("cannot remove DataTenant metadata with key: %s", key))
 		}
 	}
 
 	log.Info("Delete DataTenant")
-	err := s.client.DeleteDataTenant(operation.SubAccountID, s.config.Environment)
+	err := s.client.DeleteDataTenant(ctx, operation.SubAccountID, s.config.Environment)
 	if err != nil {
 		return s.handleError(operation, err, log, "cannot remove DataTenant")
 	}
@@ -1,6 +1,7 @@
 package deprovisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -20,13 +21,13 @@ const (
 func TestEDPDeregistration_Run(t *testing.T) {
 	// given
 	client := &automock.EDPClient{}
-	client.On("DeleteMetadataTenant", edpName, edpEnvironment, edp.MaasConsumerSubAccountKey).
+	client.On("DeleteMetadataTenant", context.Background(), edpName, edpEnvironment, edp.MaasConsumerSubAccountKey).
 		Return(nil).Once()
-	client.On("DeleteMetadataTenant", edpName, edpEnvironment, edp.MaasConsumerRegionKey).
+	client.On("DeleteMetadataTenant", context.Background(), edpName, edpEnvironment, edp.MaasConsumerRegionKey).
 		Return(nil).Once()
-	client.On("DeleteMetadataTenant", edpName, edpEnvironment, edp.MaasConsumerEnvironmentKey).
+	client.On("DeleteMetadataTenant", context.Background(), edpName, edpEnvironment, edp.MaasConsumerEnvironmentKey).
 		Return(nil).Once()
-	client.On("DeleteDataTenant", edpName, edpEnvironment).
+	client.On("DeleteDataTenant", context.Background(), edpName, edpEnvironment).
 		Return(nil).Once()
 	defer client.AssertExpectations(t)
 
@@ -35,7 +36,7 @@ func TestEDPDeregistration_Run(t *testing.T) {
 	})
 
 	// when
-	_, repeat, err := step.Run(internal.DeprovisioningOperation{SubAccountID: edpName}, logrus.New())
+	_, repeat, err := step.Run(context.Background(), internal.DeprovisioningOperation{SubAccountID: edpName}, logrus.New())
 
 	// then
 	assert.Equal(t, 0*time.Second, repeat)
@@ -39,7 +39,7 @@ func (s DeprovisionAzureEventHubStep) Name() string {
 	return "Deprovision Azure Event Hubs"
 }
 
-func (s DeprovisionAzureEventHubStep) Run(operation internal.DeprovisioningOperation, log logrus.FieldLogger) (
+func (s DeprovisionAzureEventHubStep) Run(ctx context.Context, operation internal.DeprovisioningOperation, log logrus.FieldLogger) (
 	internal.DeprovisioningOperation, time.Duration, error) {
 	if operation.EventHub.Deleted {
 		log.Info("Event Hub is already deprovisioned")
@@ -161,7 +161,7 @@ func Test_StepsDeprovisionSucceeded(t *testing.T) {
 			for idx, step := range steps {
 				// when
 				op.UpdatedAt = time.Now()
-				op, when, err := step.Run(op, fixLogger())
+				op, when, err := step.Run(context.Background(), op, fixLogger())
 				require.NoError(t, err)
 
 				fakeHyperscalerProvider, ok := step.HyperscalerProvider.(*azuretesting.FakeHyperscalerProvider)
@@ -318,7 +318,7 @@ func Test_StepsUnhappyPath(t *testing.T) {
 
 			// when
 			op.UpdatedAt = time.Now()
-			op, when, err := step.Run(op, fixLogger())
+			op, when, err := step.Run(context.Background(), op, fixLogger())
 			require.NotNil(t, op)
 
 			// then
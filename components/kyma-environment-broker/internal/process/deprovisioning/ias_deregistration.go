@@ -1,6 +1,7 @@
 package deprovisioning
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -28,7 +29,7 @@ func (s *IASDeregistrationStep) Name() string {
 	return "IAS_Deregistration"
 }
 
-func (s *IASDeregistrationStep) Run(operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+func (s *IASDeregistrationStep) Run(ctx context.Context, operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
 	for spID := range ias.ServiceProviderInputs {
 		spb, err := s.bundleBuilder.NewBundle(operation.InstanceID, spID)
 		if err != nil {
@@ -37,7 +38,7 @@ func (s *IASDeregistrationStep) Run(operation internal.DeprovisioningOperation,
 		}
 
 		log.Infof("Removing ServiceProvider %q from IAS", spb.ServiceProviderName())
-		err = spb.DeleteServiceProvider()
+		err = spb.DeleteServiceProvider(ctx)
 		if err != nil {
 			msg := fmt.Sprintf("cannot delete ServiceProvider %s", spb.ServiceProviderName())
 			log.Errorf("%s: %s", msg, err)
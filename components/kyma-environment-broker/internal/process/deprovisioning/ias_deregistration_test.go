@@ -1,6 +1,7 @@
 package deprovisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -25,7 +26,7 @@ func TestIASDeregistration_Run(t *testing.T) {
 	for inputID := range ias.ServiceProviderInputs {
 		bundle := &automock.Bundle{}
 		defer bundle.AssertExpectations(t)
-		bundle.On("DeleteServiceProvider").Return(nil).Once()
+		bundle.On("DeleteServiceProvider", context.Background()).Return(nil).Once()
 		bundle.On("ServiceProviderName").Return("MockServiceProvider")
 		bundleBuilder.On("NewBundle", iasInstanceID, inputID).Return(bundle, nil).Once()
 	}
@@ -38,7 +39,7 @@ func TestIASDeregistration_Run(t *testing.T) {
 	step := NewIASDeregistrationStep(memoryStorage.Operations(), bundleBuilder)
 
 	// when
-	_, repeat, err := step.Run(operation, logger.NewLogDummy())
+	_, repeat, err := step.Run(context.Background(), operation, logger.NewLogDummy())
 
 	// then
 	assert.Equal(t, time.Duration(0), repeat)
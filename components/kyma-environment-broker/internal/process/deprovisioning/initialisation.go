@@ -1,6 +1,7 @@
 package deprovisioning
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -45,8 +46,8 @@ func (s *InitialisationStep) Name() string {
 	return "Deprovision_Initialization"
 }
 
-func (s *InitialisationStep) Run(operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
-	op, when, err := s.run(operation, log)
+func (s *InitialisationStep) Run(ctx context.Context, operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+	op, when, err := s.run(ctx, operation, log)
 
 	if op.State == domain.Succeeded {
 		repeat, err := s.removeInstance(operation.InstanceID)
@@ -57,7 +58,7 @@ func (s *InitialisationStep) Run(operation internal.DeprovisioningOperation, log
 	return op, when, err
 }
 
-func (s *InitialisationStep) run(operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+func (s *InitialisationStep) run(ctx context.Context, operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
 	// rewrite necessary data from ProvisioningOperation to operation internal.DeprovisioningOperation
 	op, err := s.operationStorage.GetProvisioningOperationByInstanceID(operation.InstanceID)
 	if err != nil {
@@ -97,7 +98,7 @@ func (s *InitialisationStep) run(operation internal.DeprovisioningOperation, log
 		}
 		log.Info("runtime being removed, check operation status")
 		operation.RuntimeID = instance.RuntimeID
-		return s.checkRuntimeStatus(operation, instance, log.WithField("runtimeID", instance.RuntimeID))
+		return s.checkRuntimeStatus(ctx, operation, instance, log.WithField("runtimeID", instance.RuntimeID))
 	case dberr.IsNotFound(err):
 		return s.operationManager.OperationSucceeded(operation, "instance already deprovisioned")
 	default:
@@ -116,13 +117,13 @@ func setAvsIds(deprovisioningOperation *internal.DeprovisioningOperation, provis
 	}
 }
 
-func (s *InitialisationStep) checkRuntimeStatus(operation internal.DeprovisioningOperation, instance *internal.Instance, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+func (s *InitialisationStep) checkRuntimeStatus(ctx context.Context, operation internal.DeprovisioningOperation, instance *internal.Instance, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
 	if time.Since(operation.UpdatedAt) > CheckStatusTimeout {
 		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
 		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation has reached the time limit: %s", CheckStatusTimeout))
 	}
 
-	status, err := s.provisionerClient.RuntimeOperationStatus(instance.GlobalAccountID, operation.ProvisionerOperationID)
+	status, err := s.provisionerClient.RuntimeOperationStatus(ctx, instance.GlobalAccountID, operation.ProvisionerOperationID)
 	if err != nil {
 		return operation, 1 * time.Minute, nil
 	}
@@ -1,6 +1,7 @@
 package deprovisioning
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -50,7 +51,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+		provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
 			ID:        ptr.String(fixProvisionerOperationID),
 			Operation: "",
 			State:     gqlschema.OperationStateSucceeded,
@@ -61,7 +62,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, accountProviderMock)
 
 		// when
-		operation, repeat, err := step.Run(operation, log)
+		operation, repeat, err := step.Run(context.Background(), operation, log)
 
 		// then
 		assert.NoError(t, err)
@@ -98,7 +99,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, accountProviderMock)
 
 		// when
-		operation, repeat, err := step.Run(operation, log)
+		operation, repeat, err := step.Run(context.Background(), operation, log)
 
 		// then
 		assert.NoError(t, err)
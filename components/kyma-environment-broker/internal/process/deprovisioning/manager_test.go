@@ -135,7 +135,7 @@ func (ts *testStep) Name() string {
 	return ts.name
 }
 
-func (ts *testStep) Run(operation internal.DeprovisioningOperation, logger logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+func (ts *testStep) Run(ctx context.Context, operation internal.DeprovisioningOperation, logger logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
 	logger.Infof("inside %s step", ts.name)
 
 	operation.Description = fmt.Sprintf("%s %s", operation.Description, ts.name)
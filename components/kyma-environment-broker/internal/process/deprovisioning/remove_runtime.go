@@ -1,6 +1,7 @@
 package deprovisioning
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -36,7 +37,7 @@ func (s *RemoveRuntimeStep) Name() string {
 	return "Remove_Runtime"
 }
 
-func (s *RemoveRuntimeStep) Run(operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+func (s *RemoveRuntimeStep) Run(ctx context.Context, operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
 	if time.Since(operation.UpdatedAt) > RemoveRuntimeTimeout {
 		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
 		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation has reached the time limit: %s", RemoveRuntimeTimeout))
@@ -65,7 +66,7 @@ func (s *RemoveRuntimeStep) Run(operation internal.DeprovisioningOperation, log
 	var provisionerResponse string
 	if operation.ProvisionerOperationID == "" {
 
-		provisionerResponse, err = s.provisionerClient.DeprovisionRuntime(instance.GlobalAccountID, instance.RuntimeID)
+		provisionerResponse, err = s.provisionerClient.DeprovisionRuntime(ctx, instance.GlobalAccountID, instance.RuntimeID)
 		if err != nil {
 			log.Errorf("unable to deprovision runtime: %s", err)
 			return operation, 10 * time.Second, nil
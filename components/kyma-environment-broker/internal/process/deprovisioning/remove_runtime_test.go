@@ -1,6 +1,7 @@
 package deprovisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestRemoveRuntimeStep_Run(t *testing.T) {
@@ -26,13 +28,13 @@ func TestRemoveRuntimeStep_Run(t *testing.T) {
 		assert.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("DeprovisionRuntime", fixGlobalAccountID, fixRuntimeID).Return(fixProvisionerOperationID, nil)
+		provisionerClient.On("DeprovisionRuntime", mock.Anything, fixGlobalAccountID, fixRuntimeID).Return(fixProvisionerOperationID, nil)
 
 		step := NewRemoveRuntimeStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient)
 
 		// when
 		entry := log.WithFields(logrus.Fields{"step": "TEST"})
-		result, repeat, err := step.Run(operation, entry)
+		result, repeat, err := step.Run(context.Background(), operation, entry)
 
 		// then
 		assert.NoError(t, err)
@@ -60,13 +62,13 @@ func TestRemoveRuntimeStep_Run(t *testing.T) {
 		assert.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("DeprovisionRuntime", fixGlobalAccountID, fixRuntimeID).Return(fixProvisionerOperationID, nil)
+		provisionerClient.On("DeprovisionRuntime", mock.Anything, fixGlobalAccountID, fixRuntimeID).Return(fixProvisionerOperationID, nil)
 
 		step := NewRemoveRuntimeStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient)
 
 		// when
 		entry := log.WithFields(logrus.Fields{"step": "TEST"})
-		result, repeat, err := step.Run(operation, entry)
+		result, repeat, err := step.Run(context.Background(), operation, entry)
 
 		// then
 		assert.NoError(t, err)
@@ -1,6 +1,7 @@
 package deprovisioning
 
 import (
+	"context"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -29,7 +30,7 @@ func (s SkipForTrialPlanStep) Name() string {
 	return s.step.Name()
 }
 
-func (s SkipForTrialPlanStep) Run(operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+func (s SkipForTrialPlanStep) Run(ctx context.Context, operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
 	pp, err := operation.GetProvisioningParameters()
 	if err != nil {
 		log.Errorf("cannot fetch provisioning parameters from operation: %s", err)
@@ -41,5 +42,5 @@ func (s SkipForTrialPlanStep) Run(operation internal.DeprovisioningOperation, lo
 		return operation, 0, nil
 	}
 
-	return s.step.Run(operation, log)
+	return s.step.Run(ctx, operation, log)
 }
@@ -1,6 +1,7 @@
 package deprovisioning
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 	"time"
@@ -38,7 +39,7 @@ func TestSkipForTrialPlanStepShouldSkip(t *testing.T) {
 	skipStep := NewSkipForTrialPlanStep(givenStorage.Operations(), mockStep)
 
 	// When
-	gotOperation, gotSkipTime, gotErr := skipStep.Run(wantOperation, log)
+	gotOperation, gotSkipTime, gotErr := skipStep.Run(context.Background(), wantOperation, log)
 
 	// Then
 	mockStep.AssertExpectations(t)
@@ -56,11 +57,11 @@ func TestSkipForTrialPlanStepShouldNotSkip(t *testing.T) {
 	wantOperation2 := fixOperationWithPlanID(t, "operation2")
 
 	mockStep := new(automock.Step)
-	mockStep.On("Run", givenOperation1, log).Return(wantOperation2, wantSkipTime, nil)
+	mockStep.On("Run", context.Background(), givenOperation1, log).Return(wantOperation2, wantSkipTime, nil)
 	skipStep := NewSkipForTrialPlanStep(givenStorage.Operations(), mockStep)
 
 	// When
-	gotOperation, gotSkipTime, gotErr := skipStep.Run(givenOperation1, log)
+	gotOperation, gotSkipTime, gotErr := skipStep.Run(context.Background(), givenOperation1, log)
 
 	// Then
 	mockStep.AssertExpectations(t)
@@ -91,19 +91,9 @@ func (f *InputBuilderFactory) CreateProvisionInput(pp internal.ProvisioningParam
 		return nil, errors.Errorf("plan %s in not supported", pp.PlanID)
 	}
 
-	var provider HyperscalerInputProvider
-	switch pp.PlanID {
-	case broker.GCPPlanID:
-		provider = &cloudProvider.GcpInput{}
-	case broker.AzurePlanID:
-		provider = &cloudProvider.AzureInput{}
-	case broker.AzureLitePlanID:
-		provider = &cloudProvider.AzureLiteInput{}
-	case broker.TrialPlanID:
-		provider = f.forTrialPlan(pp.Parameters.Provider)
-		// insert cases for other providers like AWS or GCP
-	default:
-		return nil, errors.Errorf("case with plan %s is not supported", pp.PlanID)
+	provider, err := hyperscalerInputProviderForPlan(pp, f.trialPlatformRegionMapping)
+	if err != nil {
+		return nil, err
 	}
 
 	initInput, err := f.initProvisionRuntimeInput(provider, version)
@@ -130,21 +120,40 @@ func (f *InputBuilderFactory) CreateProvisionInput(pp internal.ProvisioningParam
 	}, nil
 }
 
-func (f *InputBuilderFactory) forTrialPlan(provider *internal.TrialCloudProvider) HyperscalerInputProvider {
+func hyperscalerInputProviderForPlan(pp internal.ProvisioningParameters, trialPlatformRegionMapping map[string]string) (HyperscalerInputProvider, error) {
+	var provider HyperscalerInputProvider
+	switch pp.PlanID {
+	case broker.GCPPlanID:
+		provider = &cloudProvider.GcpInput{}
+	case broker.AzurePlanID:
+		provider = &cloudProvider.AzureInput{}
+	case broker.AzureLitePlanID:
+		provider = &cloudProvider.AzureLiteInput{}
+	case broker.TrialPlanID:
+		provider = forTrialPlan(pp.Parameters.Provider, trialPlatformRegionMapping)
+		// insert cases for other providers like AWS or GCP
+	default:
+		return nil, errors.Errorf("case with plan %s is not supported", pp.PlanID)
+	}
+
+	return provider, nil
+}
+
+func forTrialPlan(provider *internal.TrialCloudProvider, trialPlatformRegionMapping map[string]string) HyperscalerInputProvider {
 	if provider == nil {
 		return &cloudProvider.AzureTrialInput{
-			PlatformRegionMapping: f.trialPlatformRegionMapping,
+			PlatformRegionMapping: trialPlatformRegionMapping,
 		}
 	}
 
 	switch *provider {
 	case internal.Gcp:
 		return &cloudProvider.GcpTrialInput{
-			PlatformRegionMapping: f.trialPlatformRegionMapping,
+			PlatformRegionMapping: trialPlatformRegionMapping,
 		}
 	default:
 		return &cloudProvider.AzureTrialInput{
-			PlatformRegionMapping: f.trialPlatformRegionMapping,
+			PlatformRegionMapping: trialPlatformRegionMapping,
 		}
 	}
 
@@ -0,0 +1,30 @@
+package input
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+)
+
+// RegionResolver resolves the hyperscaler region of the cluster before the provisioning input is created,
+// the region is resolved in the same way as in the provisioning input
+type RegionResolver struct {
+	trialPlatformRegionMapping map[string]string
+}
+
+func NewRegionResolver(trialPlatformRegionMapping map[string]string) *RegionResolver {
+	return &RegionResolver{
+		trialPlatformRegionMapping: trialPlatformRegionMapping,
+	}
+}
+
+func (r *RegionResolver) ProviderRegion(pp internal.ProvisioningParameters) (string, error) {
+	provider, err := hyperscalerInputProviderForPlan(pp, r.trialPlatformRegionMapping)
+	if err != nil {
+		return "", err
+	}
+
+	clusterConfig := provider.Defaults()
+	updateString(&clusterConfig.GardenerConfig.Region, pp.Parameters.Region)
+	provider.ApplyParameters(clusterConfig, pp)
+
+	return clusterConfig.GardenerConfig.Region, nil
+}
@@ -0,0 +1,59 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionResolver_ProviderRegion(t *testing.T) {
+	gcp := internal.Gcp
+	for name, tc := range map[string]struct {
+		pp             internal.ProvisioningParameters
+		expectedRegion string
+	}{
+		"default region of the plan": {
+			pp:             internal.ProvisioningParameters{PlanID: broker.AzureLitePlanID},
+			expectedRegion: "westeurope",
+		},
+		"region given in the parameters": {
+			pp: internal.ProvisioningParameters{
+				PlanID:     broker.GCPPlanID,
+				Parameters: internal.ProvisioningParametersDTO{Region: ptr.String("us-central1")},
+			},
+			expectedRegion: "us-central1",
+		},
+		"trial region mapped from the platform region": {
+			pp: internal.ProvisioningParameters{
+				PlanID:         broker.TrialPlanID,
+				PlatformRegion: "cf-us10",
+			},
+			expectedRegion: "eastus",
+		},
+		"trial region given in the parameters": {
+			pp: internal.ProvisioningParameters{
+				PlanID:         broker.TrialPlanID,
+				PlatformRegion: "cf-us10",
+				Parameters:     internal.ProvisioningParametersDTO{Region: ptr.String("asia"), Provider: &gcp},
+			},
+			expectedRegion: "asia-southeast1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			resolver := NewRegionResolver(map[string]string{"cf-us10": "us"})
+
+			// when
+			region, err := resolver.ProviderRegion(tc.pp)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRegion, region)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -38,7 +39,7 @@ func NewAuditLogOverridesStep(os storage.Operations, cfg auditlog.Config) *Audit
 	}
 }
 
-func (alo *AuditLogOverrides) Run(operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (alo *AuditLogOverrides) Run(ctx context.Context, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 
 	// Fetch the region
 	pp, err := operation.GetProvisioningParameters()
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -35,7 +36,7 @@ func TestAuditLog_ScriptFileDoesNotExist(t *testing.T) {
 	repo.InsertProvisioningOperation(operation)
 
 	// when
-	_, _, err := svc.Run(operation, NewLogDummy())
+	_, _, err := svc.Run(context.Background(), operation, NewLogDummy())
 	//then
 	require.Error(t, err)
 	require.EqualError(t, err, "open /auditlog-script/script: file does not exist")
@@ -147,7 +148,7 @@ return "fooBar"
 	}
 	repo.InsertProvisioningOperation(operation)
 	// when
-	_, repeat, err := svc.Run(operation, NewLogDummy())
+	_, repeat, err := svc.Run(context.Background(), operation, NewLogDummy())
 	//then
 	assert.NoError(t, err)
 	assert.Equal(t, time.Duration(0), repeat)
@@ -2,27 +2,31 @@
 
 package automock
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // DirectorClient is an autogenerated mock type for the DirectorClient type
 type DirectorClient struct {
 	mock.Mock
 }
 
-// GetConsoleURL provides a mock function with given fields: accountID, runtimeID
-func (_m *DirectorClient) GetConsoleURL(accountID string, runtimeID string) (string, error) {
-	ret := _m.Called(accountID, runtimeID)
+// GetConsoleURL provides a mock function with given fields: ctx, accountID, runtimeID
+func (_m *DirectorClient) GetConsoleURL(ctx context.Context, accountID string, runtimeID string) (string, error) {
+	ret := _m.Called(ctx, accountID, runtimeID)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(accountID, runtimeID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, accountID, runtimeID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(accountID, runtimeID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accountID, runtimeID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -30,13 +34,13 @@ func (_m *DirectorClient) GetConsoleURL(accountID string, runtimeID string) (str
 	return r0, r1
 }
 
-// SetLabel provides a mock function with given fields: accountID, runtimeID, key, value
-func (_m *DirectorClient) SetLabel(accountID string, runtimeID string, key string, value string) error {
-	ret := _m.Called(accountID, runtimeID, key, value)
+// SetLabel provides a mock function with given fields: ctx, accountID, runtimeID, key, value
+func (_m *DirectorClient) SetLabel(ctx context.Context, accountID string, runtimeID string, key string, value string) error {
+	ret := _m.Called(ctx, accountID, runtimeID, key, value)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string) error); ok {
-		r0 = rf(accountID, runtimeID, key, value)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, accountID, runtimeID, key, value)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2,6 +2,7 @@
 
 package automock
 
+import context "context"
 import edp "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/edp"
 import mock "github.com/stretchr/testify/mock"
 
@@ -10,13 +11,13 @@ type EDPClient struct {
 	mock.Mock
 }
 
-// CreateDataTenant provides a mock function with given fields: data
-func (_m *EDPClient) CreateDataTenant(data edp.DataTenantPayload) error {
-	ret := _m.Called(data)
+// CreateDataTenant provides a mock function with given fields: ctx, data
+func (_m *EDPClient) CreateDataTenant(ctx context.Context, data edp.DataTenantPayload) error {
+	ret := _m.Called(ctx, data)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(edp.DataTenantPayload) error); ok {
-		r0 = rf(data)
+	if rf, ok := ret.Get(0).(func(context.Context, edp.DataTenantPayload) error); ok {
+		r0 = rf(ctx, data)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -24,13 +25,13 @@ func (_m *EDPClient) CreateDataTenant(data edp.DataTenantPayload) error {
 	return r0
 }
 
-// CreateMetadataTenant provides a mock function with given fields: name, env, data
-func (_m *EDPClient) CreateMetadataTenant(name string, env string, data edp.MetadataTenantPayload) error {
-	ret := _m.Called(name, env, data)
+// CreateMetadataTenant provides a mock function with given fields: ctx, name, env, data
+func (_m *EDPClient) CreateMetadataTenant(ctx context.Context, name string, env string, data edp.MetadataTenantPayload) error {
+	ret := _m.Called(ctx, name, env, data)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, edp.MetadataTenantPayload) error); ok {
-		r0 = rf(name, env, data)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, edp.MetadataTenantPayload) error); ok {
+		r0 = rf(ctx, name, env, data)
 	} else {
 		r0 = ret.Error(0)
 	}
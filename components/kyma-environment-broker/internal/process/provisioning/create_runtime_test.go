@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -106,7 +107,7 @@ func TestCreateRuntimeStep_Run(t *testing.T) {
 	}
 
 	provisionerClient := &provisionerAutomock.Client{}
-	provisionerClient.On("ProvisionRuntime", globalAccountID, subAccountID, mock.MatchedBy(
+	provisionerClient.On("ProvisionRuntime", mock.Anything, globalAccountID, subAccountID, mock.MatchedBy(
 		func(input gqlschema.ProvisionRuntimeInput) bool {
 			return reflect.DeepEqual(input.RuntimeInput.Labels, provisionerInput.RuntimeInput.Labels) &&
 				reflect.DeepEqual(input.KymaConfig, provisionerInput.KymaConfig) &&
@@ -120,7 +121,7 @@ func TestCreateRuntimeStep_Run(t *testing.T) {
 		RuntimeID: nil,
 	}, nil)
 
-	provisionerClient.On("RuntimeOperationStatus", globalAccountID, provisionerOperationID).Return(gqlschema.OperationStatus{
+	provisionerClient.On("RuntimeOperationStatus", mock.Anything, globalAccountID, provisionerOperationID).Return(gqlschema.OperationStatus{
 		ID:        ptr.String(provisionerOperationID),
 		Operation: "",
 		State:     "",
@@ -132,7 +133,7 @@ func TestCreateRuntimeStep_Run(t *testing.T) {
 
 	// when
 	entry := log.WithFields(logrus.Fields{"step": "TEST"})
-	operation, repeat, err := step.Run(operation, entry)
+	operation, repeat, err := step.Run(context.Background(), operation, entry)
 
 	// then
 	assert.NoError(t, err)
@@ -157,13 +158,13 @@ func TestCreateRuntimeStep_RunWithBadRequestError(t *testing.T) {
 	assert.NoError(t, err)
 
 	provisionerClient := &provisionerAutomock.Client{}
-	provisionerClient.On("ProvisionRuntime", globalAccountID, subAccountID, mock.Anything).Return(gqlschema.OperationStatus{}, fmt.Errorf("some permanent error"))
+	provisionerClient.On("ProvisionRuntime", mock.Anything, globalAccountID, subAccountID, mock.Anything).Return(gqlschema.OperationStatus{}, fmt.Errorf("some permanent error"))
 
 	step := NewCreateRuntimeStep(memoryStorage.Operations(), memoryStorage.RuntimeStates(), memoryStorage.Instances(), provisionerClient)
 
 	// when
 	entry := log.WithFields(logrus.Fields{"step": "TEST"})
-	operation, _, err = step.Run(operation, entry)
+	operation, _, err = step.Run(context.Background(), operation, entry)
 
 	// then
 	assert.Equal(t, domain.Failed, operation.State)
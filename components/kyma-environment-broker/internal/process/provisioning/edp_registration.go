@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"strings"
@@ -17,8 +18,8 @@ import (
 
 //go:generate mockery -name=EDPClient -output=automock -outpkg=automock -case=underscore
 type EDPClient interface {
-	CreateDataTenant(data edp.DataTenantPayload) error
-	CreateMetadataTenant(name, env string, data edp.MetadataTenantPayload) error
+	CreateDataTenant(ctx context.Context, data edp.DataTenantPayload) error
+	CreateMetadataTenant(ctx context.Context, name, env string, data edp.MetadataTenantPayload) error
 }
 
 type EDPRegistrationStep struct {
@@ -39,7 +40,7 @@ func (s *EDPRegistrationStep) Name() string {
 	return "EDP_Registration"
 }
 
-func (s *EDPRegistrationStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *EDPRegistrationStep) Run(ctx context.Context, operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	parameters, err := operation.GetProvisioningParameters()
 	if err != nil {
 		return s.handleError(operation, err, log, "invalid operation provisioning parameters")
@@ -47,7 +48,7 @@ func (s *EDPRegistrationStep) Run(operation internal.ProvisioningOperation, log
 	subAccountID := parameters.ErsContext.SubAccountID
 
 	log.Infof("Create DataTenant for %s subaccount", subAccountID)
-	err = s.client.CreateDataTenant(edp.DataTenantPayload{
+	err = s.client.CreateDataTenant(ctx, edp.DataTenantPayload{
 		Name:        subAccountID,
 		Environment: s.config.Environment,
 		Secret:      s.generateSecret(subAccountID, s.config.Environment),
@@ -62,7 +63,7 @@ func (s *EDPRegistrationStep) Run(operation internal.ProvisioningOperation, log
 		edp.MaasConsumerRegionKey:      parameters.PlatformRegion,
 		edp.MaasConsumerSubAccountKey:  subAccountID,
 	} {
-		err = s.client.CreateMetadataTenant(subAccountID, s.config.Environment, edp.MetadataTenantPayload{
+		err = s.client.CreateMetadataTenant(ctx, subAccountID, s.config.Environment, edp.MetadataTenantPayload{
 			Key:   key,
 			Value: value,
 		})
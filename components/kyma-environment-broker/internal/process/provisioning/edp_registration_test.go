@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"testing"
@@ -25,21 +26,21 @@ func TestEDPRegistration_Run(t *testing.T) {
 	// given
 	memoryStorage := storage.NewMemoryStorage()
 	client := &automock.EDPClient{}
-	client.On("CreateDataTenant", edp.DataTenantPayload{
+	client.On("CreateDataTenant", context.Background(), edp.DataTenantPayload{
 		Name:        edpName,
 		Environment: edpEnvironment,
 		// it is copy of body `generateSecret` method in `EDPRegistrationStep` step
 		Secret: base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s%s", edpName, edpEnvironment))),
 	}).Return(nil).Once()
-	client.On("CreateMetadataTenant", edpName, edpEnvironment, edp.MetadataTenantPayload{
+	client.On("CreateMetadataTenant", context.Background(), edpName, edpEnvironment, edp.MetadataTenantPayload{
 		Key:   edp.MaasConsumerEnvironmentKey,
 		Value: "CF",
 	}).Return(nil).Once()
-	client.On("CreateMetadataTenant", edpName, edpEnvironment, edp.MetadataTenantPayload{
+	client.On("CreateMetadataTenant", context.Background(), edpName, edpEnvironment, edp.MetadataTenantPayload{
 		Key:   edp.MaasConsumerRegionKey,
 		Value: edpRegion,
 	}).Return(nil).Once()
-	client.On("CreateMetadataTenant", edpName, edpEnvironment, edp.MetadataTenantPayload{
+	client.On("CreateMetadataTenant", context.Background(), edpName, edpEnvironment, edp.MetadataTenantPayload{
 		Key:   edp.MaasConsumerSubAccountKey,
 		Value: edpName,
 	}).Return(nil).Once()
@@ -51,7 +52,7 @@ func TestEDPRegistration_Run(t *testing.T) {
 	})
 
 	// when
-	_, repeat, err := step.Run(internal.ProvisioningOperation{
+	_, repeat, err := step.Run(context.Background(), internal.ProvisioningOperation{
 		ProvisioningParameters: `{"platform_region":"` + edpRegion + `", "ers_context":{"subaccount_id":"` + edpName + `"}}`,
 	}, logger.NewLogDummy())
 
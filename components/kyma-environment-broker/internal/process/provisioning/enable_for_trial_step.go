@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -30,7 +31,7 @@ func (s *EnableForTrialPlanStep) Name() string {
 	return s.step.Name()
 }
 
-func (s *EnableForTrialPlanStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *EnableForTrialPlanStep) Run(ctx context.Context, operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	pp, err := operation.GetProvisioningParameters()
 	if err != nil {
 		log.Errorf("cannot fetch provisioning parameters from operation: %s", err)
@@ -38,7 +39,7 @@ func (s *EnableForTrialPlanStep) Run(operation internal.ProvisioningOperation, l
 	}
 	if broker.IsTrialPlan(pp.PlanID) {
 		log.Infof("Running step %s", s.Name())
-		return s.step.Run(operation, log)
+		return s.step.Run(ctx, operation, log)
 	}
 
 	return operation, 0, nil
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -25,12 +26,12 @@ func TestEnableForTrialPlanStepShouldEnable(t *testing.T) {
 
 	mockStep := &automock.Step{}
 	mockStep.On("Name").Return("Test")
-	mockStep.On("Run", operation, log).Return(anotherOperation, runTime, nil)
+	mockStep.On("Run", context.Background(), operation, log).Return(anotherOperation, runTime, nil)
 
 	enableStep := NewEnableForTrialPlanStep(memoryStorage.Operations(), mockStep)
 
 	// When
-	returnedOperation, time, err := enableStep.Run(operation, log)
+	returnedOperation, time, err := enableStep.Run(context.Background(), operation, log)
 
 	// Then
 	mockStep.AssertExpectations(t)
@@ -51,12 +52,12 @@ func TestEnableForTrialPlanStepShouldNotEnable(t *testing.T) {
 
 	mockStep := &automock.Step{}
 	mockStep.On("Name").Return("Test")
-	mockStep.On("Run", operation, log).Return(anotherOperation, runTime, nil)
+	mockStep.On("Run", context.Background(), operation, log).Return(anotherOperation, runTime, nil)
 
 	enableStep := NewEnableForTrialPlanStep(memoryStorage.Operations(), mockStep)
 
 	// When
-	returnedOperation, time, err := enableStep.Run(operation, log)
+	returnedOperation, time, err := enableStep.Run(context.Background(), operation, log)
 
 	// Then
 	assert.Empty(t, simpleInputCreator.enabledComponents)
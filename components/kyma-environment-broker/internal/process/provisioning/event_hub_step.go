@@ -57,7 +57,7 @@ func (p *ProvisionAzureEventHubStep) Name() string {
 	return "Provision Azure Event Hubs"
 }
 
-func (p *ProvisionAzureEventHubStep) Run(operation internal.ProvisioningOperation,
+func (p *ProvisionAzureEventHubStep) Run(ctx context.Context, operation internal.ProvisioningOperation,
 	log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 
 	hypType := hyperscaler.Azure
@@ -56,7 +56,7 @@ func Test_HappyPath(t *testing.T) {
 
 	// when
 	op.UpdatedAt = time.Now()
-	op, when, err := step.Run(op, fixLogger())
+	op, when, err := step.Run(context.Background(), op, fixLogger())
 	require.NoError(t, err)
 	provisionRuntimeInput, err := op.InputCreator.CreateProvisionRuntimeInput()
 	require.NoError(t, err)
@@ -178,7 +178,7 @@ func Test_StepsUnhappyPath(t *testing.T) {
 
 			// when
 			op.UpdatedAt = time.Now()
-			op, when, err := step.Run(op, fixLogger())
+			op, when, err := step.Run(context.Background(), op, fixLogger())
 			require.NotNil(t, op)
 
 			// then
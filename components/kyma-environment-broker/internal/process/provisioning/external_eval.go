@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
@@ -22,10 +23,10 @@ func NewExternalEvalCreator(delegator *avs.Delegator, disabled bool, assistant *
 	}
 }
 
-func (eec *ExternalEvalCreator) createEval(operation internal.ProvisioningOperation, url string, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (eec *ExternalEvalCreator) createEval(ctx context.Context, operation internal.ProvisioningOperation, url string, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	if eec.disabled {
 		return operation, 0, nil
 	} else {
-		return eec.delegator.CreateEvaluation(logger, operation, eec.assistant, url)
+		return eec.delegator.CreateEvaluation(ctx, logger, operation, eec.assistant, url)
 	}
 }
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
@@ -30,7 +31,7 @@ func (s *IASRegistrationStep) Name() string {
 	return "IAS_Registration"
 }
 
-func (s *IASRegistrationStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *IASRegistrationStep) Run(ctx context.Context, operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	for spID := range ias.ServiceProviderInputs {
 		spb, err := s.bundleBuilder.NewBundle(operation.InstanceID, spID)
 		if err != nil {
@@ -40,14 +41,14 @@ func (s *IASRegistrationStep) Run(operation internal.ProvisioningOperation, log
 		}
 
 		log.Infof("Check if IAS ServiceProvider %q already exist", spb.ServiceProviderName())
-		err = spb.FetchServiceProviderData()
+		err = spb.FetchServiceProviderData(ctx)
 		if err != nil {
 			return s.handleError(operation, err, log, "fetching IAS ServiceProvider data failed")
 		}
 
 		if !spb.ServiceProviderExist() {
 			log.Infof("Create IAS ServiceProvider %q", spb.ServiceProviderName())
-			err = spb.CreateServiceProvider()
+			err = spb.CreateServiceProvider(ctx)
 			if err != nil {
 				return s.handleError(operation, err, log, "creating IAS ServiceProvider failed")
 			}
@@ -56,14 +57,14 @@ func (s *IASRegistrationStep) Run(operation internal.ProvisioningOperation, log
 		}
 
 		log.Infof("Configure IAS ServiceProvider %q", spb.ServiceProviderName())
-		err = spb.ConfigureServiceProvider()
+		err = spb.ConfigureServiceProvider(ctx)
 		if err != nil {
 			return s.handleError(operation, err, log, "configuring IAS ServiceProvider failed")
 		}
 
 		if spb.ServiceProviderType() == ias.OIDC {
 			log.Info("Generate IAS ServiceProvider Secret")
-			secret, err := spb.GenerateSecret()
+			secret, err := spb.GenerateSecret(ctx)
 			if err != nil {
 				return s.handleError(operation, err, log, "creating secret for IAS ServiceProvider failed")
 			}
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -33,14 +34,14 @@ func TestIASRegistration_Run(t *testing.T) {
 		bundle := &automock.Bundle{}
 		defer bundle.AssertExpectations(t)
 		bundle.On("ServiceProviderName").Return("MockServiceProvider")
-		bundle.On("FetchServiceProviderData").Return(nil).Once()
+		bundle.On("FetchServiceProviderData", context.Background()).Return(nil).Once()
 		bundle.On("ServiceProviderExist").Return(false).Once()
-		bundle.On("CreateServiceProvider").Return(nil).Once()
-		bundle.On("ConfigureServiceProvider").Return(nil).Once()
+		bundle.On("CreateServiceProvider", context.Background()).Return(nil).Once()
+		bundle.On("ConfigureServiceProvider", context.Background()).Return(nil).Once()
 		switch inputID {
 		case ias.SPGrafanaID:
 			bundle.On("ServiceProviderType").Return(ias.OIDC)
-			bundle.On("GenerateSecret").Return(&ias.ServiceProviderSecret{
+			bundle.On("GenerateSecret", context.Background()).Return(&ias.ServiceProviderSecret{
 				ClientID:     iasClentID,
 				ClientSecret: iasClientSecret,
 			}, nil).Once()
@@ -74,7 +75,7 @@ func TestIASRegistration_Run(t *testing.T) {
 	step := NewIASRegistrationStep(memoryStorage.Operations(), bundleBuilder)
 
 	// when
-	_, repeat, err := step.Run(operation, logger.NewLogDummy())
+	_, repeat, err := step.Run(context.Background(), operation, logger.NewLogDummy())
 
 	// then
 	assert.Equal(t, time.Duration(0), repeat)
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
@@ -30,7 +31,7 @@ func (s *IASType) Disabled() bool {
 	return s.disabled
 }
 
-func (s *IASType) ConfigureType(operation internal.ProvisioningOperation, runtimeURL string, log logrus.FieldLogger) (time.Duration, error) {
+func (s *IASType) ConfigureType(ctx context.Context, operation internal.ProvisioningOperation, runtimeURL string, log logrus.FieldLogger) (time.Duration, error) {
 	if s.disabled {
 		return 0, nil
 	}
@@ -41,13 +42,13 @@ func (s *IASType) ConfigureType(operation internal.ProvisioningOperation, runtim
 			log.Errorf("%s: %s", "Failed to create ServiceProvider Bundle", err)
 			return 0, nil
 		}
-		err = spb.FetchServiceProviderData()
+		err = spb.FetchServiceProviderData(ctx)
 		if err != nil {
 			return s.handleError(operation, err, log, "fetching ServiceProvider data failed")
 		}
 
 		log.Infof("Configure SSO Type for ServiceProvider %q with RuntimeURL: %s", spb.ServiceProviderName(), runtimeURL)
-		err = spb.ConfigureServiceProviderType(runtimeURL)
+		err = spb.ConfigureServiceProviderType(ctx, runtimeURL)
 		if err != nil {
 			return s.handleError(operation, err, log, "setting SSO Type failed")
 		}
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -25,16 +26,16 @@ func TestIASType_ConfigureType(t *testing.T) {
 	for inputID := range ias.ServiceProviderInputs {
 		bundle := &automock.Bundle{}
 		defer bundle.AssertExpectations(t)
-		bundle.On("FetchServiceProviderData").Return(nil).Once()
+		bundle.On("FetchServiceProviderData", context.Background()).Return(nil).Once()
 		bundle.On("ServiceProviderName").Return("MockProviderName")
-		bundle.On("ConfigureServiceProviderType", iasTypeURLDashboard).Return(nil).Once()
+		bundle.On("ConfigureServiceProviderType", context.Background(), iasTypeURLDashboard).Return(nil).Once()
 		bundleBuilder.On("NewBundle", iasTypeInstanceID, inputID).Return(bundle, nil).Once()
 	}
 
 	step := NewIASType(bundleBuilder, false)
 
 	// when
-	repeat, err := step.ConfigureType(internal.ProvisioningOperation{
+	repeat, err := step.ConfigureType(context.Background(), internal.ProvisioningOperation{
 		Operation: internal.Operation{
 			InstanceID: iasTypeInstanceID,
 		},
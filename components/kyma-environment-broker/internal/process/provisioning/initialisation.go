@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -30,8 +31,8 @@ const (
 //go:generate mockery -name=DirectorClient -output=automock -outpkg=automock -case=underscore
 
 type DirectorClient interface {
-	GetConsoleURL(accountID, runtimeID string) (string, error)
-	SetLabel(accountID, runtimeID, key, value string) error
+	GetConsoleURL(ctx context.Context, accountID, runtimeID string) (string, error)
+	SetLabel(ctx context.Context, accountID, runtimeID, key, value string) error
 }
 
 type KymaVersionConfigurator interface {
@@ -79,7 +80,7 @@ func (s *InitialisationStep) Name() string {
 	return "Provision_Initialization"
 }
 
-func (s *InitialisationStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *InitialisationStep) Run(ctx context.Context, operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	pp, err := operation.GetProvisioningParameters()
 	if err != nil {
 		log.Errorf("cannot fetch provisioning parameters from operation: %s", err)
@@ -98,7 +99,7 @@ func (s *InitialisationStep) Run(operation internal.ProvisioningOperation, log l
 			return s.initializeRuntimeInputRequest(operation, log)
 		}
 		log.Info("runtimeID exist, check instance status")
-		return s.checkRuntimeStatus(operation, log.WithField("runtimeID", inst.RuntimeID))
+		return s.checkRuntimeStatus(ctx, operation, log.WithField("runtimeID", inst.RuntimeID))
 	case dberr.IsNotFound(err):
 		log.Info("instance not exist")
 		return s.operationManager.OperationFailed(operation, "instance was not created")
@@ -153,7 +154,7 @@ func (s *InitialisationStep) configureKymaVersion(operation *internal.Provisioni
 	return nil
 }
 
-func (s *InitialisationStep) checkRuntimeStatus(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *InitialisationStep) checkRuntimeStatus(ctx context.Context, operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	if time.Since(operation.UpdatedAt) > s.provisioningTimeout {
 		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
 		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation has reached the time limit: %s", s.provisioningTimeout))
@@ -164,7 +165,7 @@ func (s *InitialisationStep) checkRuntimeStatus(operation internal.ProvisioningO
 		return operation, 10 * time.Second, nil
 	}
 
-	status, err := s.provisionerClient.RuntimeOperationStatus(instance.GlobalAccountID, operation.ProvisionerOperationID)
+	status, err := s.provisionerClient.RuntimeOperationStatus(ctx, instance.GlobalAccountID, operation.ProvisionerOperationID)
 	if err != nil {
 		return operation, 1 * time.Minute, nil
 	}
@@ -177,7 +178,7 @@ func (s *InitialisationStep) checkRuntimeStatus(operation internal.ProvisioningO
 
 	switch status.State {
 	case gqlschema.OperationStateSucceeded:
-		repeat, err := s.handleDashboardURL(instance, log)
+		repeat, err := s.handleDashboardURL(ctx, instance, log)
 		if repeat != 0 {
 			return operation, repeat, nil
 		}
@@ -185,7 +186,7 @@ func (s *InitialisationStep) checkRuntimeStatus(operation internal.ProvisioningO
 			log.Errorf("cannot handle dashboard URL: %s", err)
 			return s.operationManager.OperationFailed(operation, "cannot handle dashboard URL")
 		}
-		return s.launchPostActions(operation, instance, log, msg)
+		return s.launchPostActions(ctx, operation, instance, log, msg)
 	case gqlschema.OperationStateInProgress:
 		return operation, 2 * time.Minute, nil
 	case gqlschema.OperationStatePending:
@@ -197,8 +198,8 @@ func (s *InitialisationStep) checkRuntimeStatus(operation internal.ProvisioningO
 	return s.operationManager.OperationFailed(operation, fmt.Sprintf("unsupported provisioner client status: %s", status.State.String()))
 }
 
-func (s *InitialisationStep) handleDashboardURL(instance *internal.Instance, log logrus.FieldLogger) (time.Duration, error) {
-	dashboardURL, err := s.directorClient.GetConsoleURL(instance.GlobalAccountID, instance.RuntimeID)
+func (s *InitialisationStep) handleDashboardURL(ctx context.Context, instance *internal.Instance, log logrus.FieldLogger) (time.Duration, error) {
+	dashboardURL, err := s.directorClient.GetConsoleURL(ctx, instance.GlobalAccountID, instance.RuntimeID)
 	if kebError.IsTemporaryError(err) {
 		log.Errorf("cannot get console URL from director client: %s", err)
 		return 3 * time.Minute, nil
@@ -214,21 +215,21 @@ func (s *InitialisationStep) handleDashboardURL(instance *internal.Instance, log
 	return 0, nil
 }
 
-func (s *InitialisationStep) launchPostActions(operation internal.ProvisioningOperation, instance *internal.Instance, log logrus.FieldLogger, msg string) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *InitialisationStep) launchPostActions(ctx context.Context, operation internal.ProvisioningOperation, instance *internal.Instance, log logrus.FieldLogger, msg string) (internal.ProvisioningOperation, time.Duration, error) {
 	// action #1
-	operation, repeat, err := s.externalEvalCreator.createEval(operation, instance.DashboardURL, log)
+	operation, repeat, err := s.externalEvalCreator.createEval(ctx, operation, instance.DashboardURL, log)
 	if err != nil || repeat != 0 {
 		return operation, repeat, nil
 	}
 
 	// action #2
-	repeat, err = s.iasType.ConfigureType(operation, instance.DashboardURL, log)
+	repeat, err = s.iasType.ConfigureType(ctx, operation, instance.DashboardURL, log)
 	if err != nil || repeat != 0 {
 		return operation, repeat, nil
 	}
 	if !s.iasType.Disabled() {
 		grafanaPath := strings.Replace(instance.DashboardURL, "console.", "grafana.", 1)
-		err = s.directorClient.SetLabel(instance.GlobalAccountID, instance.RuntimeID, grafanaURLLabel, grafanaPath)
+		err = s.directorClient.SetLabel(ctx, instance.GlobalAccountID, instance.RuntimeID, grafanaURLLabel, grafanaPath)
 		if err != nil {
 			log.Errorf("Cannot set labels in director: %s", err)
 		} else {
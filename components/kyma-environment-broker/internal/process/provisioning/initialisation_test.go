@@ -21,6 +21,7 @@ import (
 
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 const (
@@ -46,7 +47,7 @@ func TestInitialisationStep_RunInitialized(t *testing.T) {
 	assert.NoError(t, err)
 
 	provisionerClient := &provisionerAutomock.Client{}
-	provisionerClient.On("RuntimeOperationStatus", statusGlobalAccountID, statusProvisionerOperationID).Return(gqlschema.OperationStatus{
+	provisionerClient.On("RuntimeOperationStatus", mock.Anything, statusGlobalAccountID, statusProvisionerOperationID).Return(gqlschema.OperationStatus{
 		ID:        ptr.String(statusProvisionerOperationID),
 		Operation: "",
 		State:     gqlschema.OperationStateSucceeded,
@@ -55,7 +56,7 @@ func TestInitialisationStep_RunInitialized(t *testing.T) {
 	}, nil)
 
 	directorClient := &automock.DirectorClient{}
-	directorClient.On("GetConsoleURL", statusGlobalAccountID, statusRuntimeID).Return(dashboardURL, nil)
+	directorClient.On("GetConsoleURL", context.Background(), statusGlobalAccountID, statusRuntimeID).Return(dashboardURL, nil)
 
 	idh := &idHolder{}
 	mockOauthServer := newMockAvsOauthServer()
@@ -77,7 +78,7 @@ func TestInitialisationStep_RunInitialized(t *testing.T) {
 		directorClient, nil, externalEvalCreator, iasType, time.Hour, rvc, nil)
 
 	// when
-	operation, repeat, err := step.Run(operation, logger.NewLogDummy())
+	operation, repeat, err := step.Run(context.Background(), operation, logger.NewLogDummy())
 
 	// then
 	assert.NoError(t, err)
@@ -107,7 +108,7 @@ func TestInitialisationStep_RunUninitialized(t *testing.T) {
 	assert.NoError(t, err)
 
 	provisionerClient := &provisionerAutomock.Client{}
-	provisionerClient.On("RuntimeOperationStatus", statusGlobalAccountID, statusProvisionerOperationID).Return(gqlschema.OperationStatus{
+	provisionerClient.On("RuntimeOperationStatus", mock.Anything, statusGlobalAccountID, statusProvisionerOperationID).Return(gqlschema.OperationStatus{
 		ID:        ptr.String(statusProvisionerOperationID),
 		Operation: "",
 		State:     gqlschema.OperationStateSucceeded,
@@ -116,7 +117,7 @@ func TestInitialisationStep_RunUninitialized(t *testing.T) {
 	}, nil)
 
 	directorClient := &automock.DirectorClient{}
-	directorClient.On("GetConsoleURL", statusGlobalAccountID, statusRuntimeID).Return(dashboardURL, nil)
+	directorClient.On("GetConsoleURL", context.Background(), statusGlobalAccountID, statusRuntimeID).Return(dashboardURL, nil)
 
 	idh := &idHolder{}
 	mockOauthServer := newMockAvsOauthServer()
@@ -138,7 +139,7 @@ func TestInitialisationStep_RunUninitialized(t *testing.T) {
 		directorClient, nil, externalEvalCreator, iasType, time.Hour, rvc, nil)
 
 	// when
-	operation, repeat, err := step.Run(operation, logger.NewLogDummy())
+	operation, repeat, err := step.Run(context.Background(), operation, logger.NewLogDummy())
 
 	// then
 	assert.NoError(t, err)
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/avs"
@@ -25,6 +26,6 @@ func (ies *InternalEvaluationStep) Name() string {
 	return "AVS_Create_Internal_Eval_Step"
 }
 
-func (ies *InternalEvaluationStep) Run(operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
-	return ies.delegator.CreateEvaluation(logger, operation, ies.iec, "")
+func (ies *InternalEvaluationStep) Run(ctx context.Context, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+	return ies.delegator.CreateEvaluation(ctx, logger, operation, ies.iec, "")
 }
@@ -50,7 +50,7 @@ func TestInternalEvaluationStep_Run(t *testing.T) {
 
 	// when
 	logger := log.WithFields(logrus.Fields{"step": "TEST"})
-	provisioningOperation, repeat, err := ies.Run(provisioningOperation, logger)
+	provisioningOperation, repeat, err := ies.Run(context.Background(), provisioningOperation, logger)
 
 	//then
 	assert.NoError(t, err)
@@ -95,7 +95,7 @@ func TestInternalEvaluationStep_WhenOperationIsRepeatedWithIdPresent(t *testing.
 
 	// when
 	logger := log.WithFields(logrus.Fields{"step": "TEST"})
-	provisioningOperation, repeat, err := ies.Run(provisioningOperation, logger)
+	provisioningOperation, repeat, err := ies.Run(context.Background(), provisioningOperation, logger)
 
 	//then
 	assert.NoError(t, err)
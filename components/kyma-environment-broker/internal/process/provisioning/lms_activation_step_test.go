@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -31,7 +32,7 @@ func TestLmsActivationStepShouldNotActivate(t *testing.T) {
 	activationStep := NewLmsActivationStep(memoryStorage.Operations(), cfg, mockStep)
 
 	// When
-	returnedOperation, time, err := activationStep.Run(operation, log)
+	returnedOperation, time, err := activationStep.Run(context.Background(), operation, log)
 
 	// Then
 	mockStep.AssertExpectations(t)
@@ -51,12 +52,12 @@ func TestLmsActivationStepShouldActivateForAll(t *testing.T) {
 	var activationTime time.Duration = 10
 
 	mockStep := &automock.Step{}
-	mockStep.On("Run", operation, log).Return(anotherOperation, activationTime, nil)
+	mockStep.On("Run", context.Background(), operation, log).Return(anotherOperation, activationTime, nil)
 
 	activationStep := NewLmsActivationStep(memoryStorage.Operations(), cfg, mockStep)
 
 	// When
-	returnedOperation, time, err := activationStep.Run(operation, log)
+	returnedOperation, time, err := activationStep.Run(context.Background(), operation, log)
 
 	// Then
 	mockStep.AssertExpectations(t)
@@ -76,12 +77,12 @@ func TestLmsActivationStepShouldActivateForOne(t *testing.T) {
 	var activationTime time.Duration = 10
 
 	mockStep := &automock.Step{}
-	mockStep.On("Run", operation, log).Return(anotherOperation, activationTime, nil)
+	mockStep.On("Run", context.Background(), operation, log).Return(anotherOperation, activationTime, nil)
 
 	activationStep := NewLmsActivationStep(memoryStorage.Operations(), cfg, mockStep)
 
 	// When
-	returnedOperation, time, err := activationStep.Run(operation, log)
+	returnedOperation, time, err := activationStep.Run(context.Background(), operation, log)
 
 	// Then
 	mockStep.AssertExpectations(t)
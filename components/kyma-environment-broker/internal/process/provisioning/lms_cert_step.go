@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -30,11 +31,11 @@ const (
 )
 
 type LmsClient interface {
-	RequestCertificate(tenantID string, subject pkix.Name) (id string, privateKey []byte, err error)
-	GetCertificateByURL(url string) (cert string, found bool, err error)
-	GetCACertificate(tenantID string) (cert string, found bool, err error)
-	GetTenantStatus(tenantID string) (status lms.TenantStatus, err error)
-	GetTenantInfo(tenantID string) (status lms.TenantInfo, err error)
+	RequestCertificate(ctx context.Context, tenantID string, subject pkix.Name) (id string, privateKey []byte, err error)
+	GetCertificateByURL(ctx context.Context, url string) (cert string, found bool, err error)
+	GetCACertificate(ctx context.Context, tenantID string) (cert string, found bool, err error)
+	GetTenantStatus(ctx context.Context, tenantID string) (status lms.TenantStatus, err error)
+	GetTenantInfo(ctx context.Context, tenantID string) (status lms.TenantInfo, err error)
 }
 
 type lmsCertStep struct {
@@ -63,7 +64,7 @@ func (s *lmsCertStep) Name() string {
 // 1. check if the tenant is ready
 // 2. request certificates
 // 3. poll CA and signed certificates
-func (s *lmsCertStep) Run(operation internal.ProvisioningOperation, l logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *lmsCertStep) Run(ctx context.Context, operation internal.ProvisioningOperation, l logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	if operation.Lms.Failed {
 		l.Info("LMS has failed, skipping")
 		return operation, 0, nil
@@ -82,7 +83,7 @@ func (s *lmsCertStep) Run(operation internal.ProvisioningOperation, l logrus.Fie
 	}
 
 	// check if LMS tenant is ready
-	status, err := s.provider.GetTenantStatus(operation.Lms.TenantID)
+	status, err := s.provider.GetTenantStatus(ctx, operation.Lms.TenantID)
 	if err != nil {
 		return s.handleError(
 			operation,
@@ -100,7 +101,7 @@ func (s *lmsCertStep) Run(operation internal.ProvisioningOperation, l logrus.Fie
 		return operation, tenantReadyRetryInterval, nil
 	}
 
-	tenantInfo, err := s.provider.GetTenantInfo(operation.Lms.TenantID)
+	tenantInfo, err := s.provider.GetTenantInfo(ctx, operation.Lms.TenantID)
 	if err != nil {
 		return s.handleError(
 			operation,
@@ -116,7 +117,7 @@ func (s *lmsCertStep) Run(operation internal.ProvisioningOperation, l logrus.Fie
 		Organization:       []string{pp.ErsContext.GlobalAccountID},
 		OrganizationalUnit: []string{uuid.New().String()},
 	}
-	certURL, pKey, err := s.provider.RequestCertificate(operation.Lms.TenantID, subj)
+	certURL, pKey, err := s.provider.RequestCertificate(ctx, operation.Lms.TenantID, subj)
 	if err != nil {
 		logger.Errorf("Unable to request LMS Certificates %s", err.Error())
 		return operation, 5 * time.Second, nil
@@ -129,7 +130,7 @@ func (s *lmsCertStep) Run(operation internal.ProvisioningOperation, l logrus.Fie
 	// certs cannot be stored so there is a need to poll until certs are ready
 	// get Signed Certificate
 	err = wait.PollImmediate(pollingInterval, certPollingTimeout, func() (done bool, err error) {
-		c, found, err := s.provider.GetCertificateByURL(certURL)
+		c, found, err := s.provider.GetCertificateByURL(ctx, certURL)
 		if err != nil {
 			logger.Warnf("Unable to get LMS Signed Certificate: %s, retrying", err.Error())
 			return false, nil
@@ -148,7 +149,7 @@ func (s *lmsCertStep) Run(operation internal.ProvisioningOperation, l logrus.Fie
 
 	// get CA cert
 	err = wait.PollImmediate(pollingInterval, certPollingTimeout, func() (done bool, err error) {
-		c, found, err := s.provider.GetCACertificate(operation.Lms.TenantID)
+		c, found, err := s.provider.GetCACertificate(ctx, operation.Lms.TenantID)
 		if err != nil {
 			logger.Warnf("Unable to get LMS CA Certificate: %s", err.Error())
 			return false, nil
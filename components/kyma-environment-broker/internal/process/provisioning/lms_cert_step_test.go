@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -23,7 +24,7 @@ func TestCertStep_RunFreshOperation(t *testing.T) {
 	}
 
 	// when
-	_, _, err := svc.Run(operation, fixLogger())
+	_, _, err := svc.Run(context.Background(), operation, fixLogger())
 
 	//then
 	require.Error(t, err)
@@ -44,7 +45,7 @@ func TestCertStep_Run(t *testing.T) {
 	repo.InsertProvisioningOperation(operation)
 
 	// when
-	op, duration, err := svc.Run(operation, fixLogger())
+	op, duration, err := svc.Run(context.Background(), operation, fixLogger())
 
 	// then
 	require.NoError(t, err)
@@ -70,7 +71,7 @@ func TestCertStep_TenantNotReady(t *testing.T) {
 		repo.InsertProvisioningOperation(operation)
 
 		// when
-		op, duration, err := svc.Run(operation, fixLogger())
+		op, duration, err := svc.Run(context.Background(), operation, fixLogger())
 
 		// then
 		require.NoError(t, err)
@@ -98,7 +99,7 @@ func TestCertStep_TenantNotReadyTimeout(t *testing.T) {
 		repo.InsertProvisioningOperation(operation)
 
 		// when
-		op, duration, err := svc.Run(operation, fixLogger())
+		op, duration, err := svc.Run(context.Background(), operation, fixLogger())
 
 		// then
 		a.AssertError(t, err)
@@ -128,7 +129,7 @@ func TestLmsStepsHappyPath(t *testing.T) {
 	opRepo.InsertProvisioningOperation(operation)
 
 	// when
-	op, when, err := tenantStep.Run(operation, fixLogger())
+	op, when, err := tenantStep.Run(context.Background(), operation, fixLogger())
 
 	// then
 	require.NoError(t, err)
@@ -136,7 +137,7 @@ func TestLmsStepsHappyPath(t *testing.T) {
 	assert.NotEmpty(t, op.Lms.TenantID)
 
 	// when
-	op, when, err = certStep.Run(op, fixLogger())
+	op, when, err = certStep.Run(context.Background(), op, fixLogger())
 
 	// then
 	require.NoError(t, err)
@@ -161,7 +162,7 @@ func TestLmsStepsHappyPath(t *testing.T) {
 
 func newFakeClientWithTenant(timeToReady time.Duration) (*lms.FakeClient, string) {
 	lmsClient := lms.NewFakeClient(timeToReady)
-	out, _ := lmsClient.CreateTenant(lms.CreateTenantInput{
+	out, _ := lmsClient.CreateTenant(context.Background(), lms.CreateTenantInput{
 		Name: "some-tenant",
 	})
 
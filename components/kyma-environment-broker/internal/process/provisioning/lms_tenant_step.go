@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -12,7 +13,7 @@ import (
 )
 
 type LmsTenantProvider interface {
-	ProvideLMSTenantID(name, region string) (string, error)
+	ProvideLMSTenantID(ctx context.Context, name, region string) (string, error)
 }
 
 // provideLmsTenantStep creates (if not exists) LMS tenant and provides its ID.
@@ -41,7 +42,7 @@ func (s *provideLmsTenantStep) Name() string {
 	return "Create_LMS_Tenant"
 }
 
-func (s *provideLmsTenantStep) Run(operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *provideLmsTenantStep) Run(ctx context.Context, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	if operation.Lms.TenantID != "" {
 		return operation, 0, nil
 	}
@@ -54,7 +55,7 @@ func (s *provideLmsTenantStep) Run(operation internal.ProvisioningOperation, log
 	}
 	region := s.provideRegion(pp.Parameters.Region)
 
-	lmsTenantID, err := s.tenantProvider.ProvideLMSTenantID(pp.ErsContext.GlobalAccountID, region)
+	lmsTenantID, err := s.tenantProvider.ProvideLMSTenantID(ctx, pp.ErsContext.GlobalAccountID, region)
 	if err != nil {
 		return s.handleError(
 			operation,
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -29,7 +30,7 @@ func TestProvideLmsTenantStep_TenantProviderWithRetry(t *testing.T) {
 	opRepo.InsertProvisioningOperation(operation)
 
 	// when
-	_, when, err := tenantStep.Run(operation, fixLogger())
+	_, when, err := tenantStep.Run(context.Background(), operation, fixLogger())
 
 	// then
 	require.NoError(t, err)
@@ -55,7 +56,7 @@ func TestProvideLmsTenantStep_TenantProviderWithError(t *testing.T) {
 		opRepo.InsertProvisioningOperation(operation)
 
 		// when
-		op, when, err := tenantStep.Run(operation, fixLogger())
+		op, when, err := tenantStep.Run(context.Background(), operation, fixLogger())
 
 		// then
 		a.AssertError(t, err)
@@ -67,6 +68,6 @@ func TestProvideLmsTenantStep_TenantProviderWithError(t *testing.T) {
 type fakeErrorTenantProvider struct {
 }
 
-func (fakeErrorTenantProvider) ProvideLMSTenantID(name, region string) (string, error) {
+func (fakeErrorTenantProvider) ProvideLMSTenantID(ctx context.Context, name, region string) (string, error) {
 	return "", errors.New("some error")
 }
@@ -9,13 +9,14 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/sirupsen/logrus"
 )
 
 type Step interface {
 	Name() string
-	Run(operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error)
+	Run(ctx context.Context, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error)
 }
 
 type Manager struct {
@@ -46,10 +47,14 @@ func (m *Manager) AddStep(weight int, step Step) {
 	m.steps[weight] = append(m.steps[weight], step)
 }
 
-func (m *Manager) runStep(step Step, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (m *Manager) runStep(ctx context.Context, step Step, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+	ctx, span := tracing.StartStep(ctx, step.Name())
+	defer span.End()
+
 	start := time.Now()
-	processedOperation, when, err := step.Run(operation, logger)
-	m.publisher.Publish(context.TODO(), process.ProvisioningStepProcessed{
+	processedOperation, when, err := step.Run(ctx, operation, logger)
+	tracing.SetError(span, err)
+	m.publisher.Publish(ctx, process.ProvisioningStepProcessed{
 		OldOperation: operation,
 		Operation:    processedOperation,
 		StepProcessed: process.StepProcessed{
@@ -80,6 +85,9 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 
 	logOperation := m.log.WithFields(logrus.Fields{"operation": operationID, "instanceID": operation.InstanceID, "planID": pp.PlanID})
 
+	ctx, span := tracing.StartOperation(context.Background(), "provisioning", operationID, operation.InstanceID)
+	defer span.End()
+
 	logOperation.Info("Start process operation steps")
 	for _, weightStep := range m.sortWeight() {
 		steps := m.steps[weightStep]
@@ -87,9 +95,10 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 			logStep := logOperation.WithField("step", step.Name())
 			logStep.Infof("Start step")
 
-			processedOperation, when, err = m.runStep(step, processedOperation, logStep)
+			processedOperation, when, err = m.runStep(ctx, step, processedOperation, logStep)
 			if err != nil {
 				logStep.Errorf("Process operation failed: %s", err)
+				tracing.SetError(span, err)
 				return 0, err
 			}
 			if processedOperation.State != domain.InProgress {
@@ -119,7 +119,7 @@ func (ts *testStep) Name() string {
 	return ts.name
 }
 
-func (ts *testStep) Run(operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (ts *testStep) Run(ctx context.Context, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	logger.Infof("inside %s step", ts.name)
 
 	operation.Description = fmt.Sprintf("%s %s", operation.Description, ts.name)
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -40,7 +41,7 @@ func TestNatssWithInitialOverrides(t *testing.T) {
 	step := NewNatsStreamingOverridesStep(memoryStorage.Operations())
 
 	// When
-	returnedOperation, time, err := step.Run(operation, log)
+	returnedOperation, time, err := step.Run(context.Background(), operation, log)
 
 	// Then
 	require.NoError(t, err)
@@ -64,7 +65,7 @@ func TestNatssWithEmptyOverrides(t *testing.T) {
 	step := NewNatsStreamingOverridesStep(memoryStorage.Operations())
 
 	// When
-	returnedOperation, time, err := step.Run(operation, log)
+	returnedOperation, time, err := step.Run(context.Background(), operation, log)
 
 	// Then
 	require.NoError(t, err)
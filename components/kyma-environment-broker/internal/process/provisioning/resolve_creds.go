@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -14,9 +15,15 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// RegionResolver resolves the hyperscaler region of the cluster, which is used to choose the shared credentials
+type RegionResolver interface {
+	ProviderRegion(pp internal.ProvisioningParameters) (string, error)
+}
+
 type ResolveCredentialsStep struct {
 	operationManager *process.ProvisionOperationManager
 	accountProvider  hyperscaler.AccountProvider
+	regionResolver   RegionResolver
 	opStorage        storage.Operations
 	tenant           string
 }
@@ -50,12 +57,13 @@ func forTrialProvider(provider *internal.TrialCloudProvider) (hyperscaler.Type,
 
 }
 
-func NewResolveCredentialsStep(os storage.Operations, accountProvider hyperscaler.AccountProvider) *ResolveCredentialsStep {
+func NewResolveCredentialsStep(os storage.Operations, accountProvider hyperscaler.AccountProvider, regionResolver RegionResolver) *ResolveCredentialsStep {
 
 	return &ResolveCredentialsStep{
 		operationManager: process.NewProvisionOperationManager(os),
 		opStorage:        os,
 		accountProvider:  accountProvider,
+		regionResolver:   regionResolver,
 	}
 }
 
@@ -63,7 +71,7 @@ func (s *ResolveCredentialsStep) Name() string {
 	return "Resolve_Target_Secret"
 }
 
-func (s *ResolveCredentialsStep) Run(operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *ResolveCredentialsStep) Run(ctx context.Context, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 
 	pp, err := operation.GetProvisioningParameters()
 	if err != nil {
@@ -87,8 +95,13 @@ func (s *ResolveCredentialsStep) Run(operation internal.ProvisioningOperation, l
 	if !broker.IsTrialPlan(pp.PlanID) {
 		credentials, err = s.accountProvider.GardenerCredentials(hypType, pp.ErsContext.GlobalAccountID)
 	} else {
-		logger.Infof("HAP lookup for shared credentials")
-		credentials, err = s.accountProvider.GardenerSharedCredentials(hypType)
+		region, regionErr := s.regionResolver.ProviderRegion(pp)
+		if regionErr != nil {
+			logger.Errorf("Aborting after failing to resolve the region of the cluster: %s", regionErr)
+			return s.operationManager.OperationFailed(operation, regionErr.Error())
+		}
+		logger.Infof("HAP lookup for shared credentials in region %s", region)
+		credentials, err = s.accountProvider.GardenerSharedCredentials(hypType, region)
 	}
 	if err != nil {
 		errMsg := fmt.Sprintf("HAP lookup for credentials to provision cluster for global account ID %s on Hyperscaler %s has failed: %s", pp.ErsContext.GlobalAccountID, hypType, err)
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
 
 	"github.com/stretchr/testify/require"
 
@@ -39,10 +41,10 @@ func TestResolveCredentialsStepHappyPath_Run(t *testing.T) {
 		CredentialData:  map[string][]byte{},
 	}, nil)
 
-	step := NewResolveCredentialsStep(memoryStorage.Operations(), accountProviderMock)
+	step := NewResolveCredentialsStep(memoryStorage.Operations(), accountProviderMock, input.NewRegionResolver(fixTrialRegionMapping()))
 
 	// when
-	operation, repeat, err := step.Run(operation, log)
+	operation, repeat, err := step.Run(context.Background(), operation, log)
 
 	assert.NoError(t, err)
 
@@ -71,16 +73,16 @@ func TestResolveCredentialsStepHappyPathTrialDefaultProvider_Run(t *testing.T) {
 
 	accountProviderMock := &hyperscalerMocks.AccountProvider{}
 
-	accountProviderMock.On("GardenerSharedCredentials", hyperscaler.Azure).Return(hyperscaler.Credentials{
+	accountProviderMock.On("GardenerSharedCredentials", hyperscaler.Azure, "westeurope").Return(hyperscaler.Credentials{
 		Name:            "gardener-secret-azure",
 		HyperscalerType: "azure",
 		CredentialData:  map[string][]byte{},
 	}, nil)
 
-	step := NewResolveCredentialsStep(memoryStorage.Operations(), accountProviderMock)
+	step := NewResolveCredentialsStep(memoryStorage.Operations(), accountProviderMock, input.NewRegionResolver(fixTrialRegionMapping()))
 
 	// when
-	operation, repeat, err := step.Run(operation, log)
+	operation, repeat, err := step.Run(context.Background(), operation, log)
 
 	assert.NoError(t, err)
 
@@ -110,16 +112,16 @@ func TestResolveCredentialsStepHappyPathTrialGivenProvider_Run(t *testing.T) {
 
 	accountProviderMock := &hyperscalerMocks.AccountProvider{}
 
-	accountProviderMock.On("GardenerSharedCredentials", hyperscaler.GCP).Return(hyperscaler.Credentials{
+	accountProviderMock.On("GardenerSharedCredentials", hyperscaler.GCP, "europe-west4").Return(hyperscaler.Credentials{
 		Name:            "gardener-secret-gcp",
 		HyperscalerType: "gcp",
 		CredentialData:  map[string][]byte{},
 	}, nil)
 
-	step := NewResolveCredentialsStep(memoryStorage.Operations(), accountProviderMock)
+	step := NewResolveCredentialsStep(memoryStorage.Operations(), accountProviderMock, input.NewRegionResolver(fixTrialRegionMapping()))
 
 	// when
-	operation, repeat, err := step.Run(operation, log)
+	operation, repeat, err := step.Run(context.Background(), operation, log)
 
 	assert.NoError(t, err)
 
@@ -150,12 +152,12 @@ func TestResolveCredentialsStepRetry_Run(t *testing.T) {
 
 	accountProviderMock.On("GardenerCredentials", hyperscaler.GCP, statusGlobalAccountID).Return(hyperscaler.Credentials{}, errors.New("Failed!"))
 
-	step := NewResolveCredentialsStep(memoryStorage.Operations(), accountProviderMock)
+	step := NewResolveCredentialsStep(memoryStorage.Operations(), accountProviderMock, input.NewRegionResolver(fixTrialRegionMapping()))
 
 	operation.UpdatedAt = time.Now()
 
 	// when
-	operation, repeat, err := step.Run(operation, log)
+	operation, repeat, err := step.Run(context.Background(), operation, log)
 
 	assert.NoError(t, err)
 
@@ -169,7 +171,7 @@ func TestResolveCredentialsStepRetry_Run(t *testing.T) {
 	assert.Empty(t, operation.State)
 
 	time.Sleep(repeat)
-	operation, repeat, err = step.Run(operation, log)
+	operation, repeat, err = step.Run(context.Background(), operation, log)
 
 	pp, err = operation.GetProvisioningParameters()
 	assert.NoError(t, err)
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -40,7 +41,7 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionComputed(t *testing.T)
 		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rcvMock)
 
 		// When
-		operation, repeat, err := step.Run(operation, logrus.New())
+		operation, repeat, err := step.Run(context.Background(), operation, logrus.New())
 
 		// Then
 		assert.NoError(t, err)
@@ -77,7 +78,7 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionFromOperation(t *testi
 		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rcvMock)
 
 		// When
-		operation, repeat, err := step.Run(operation, logrus.New())
+		operation, repeat, err := step.Run(context.Background(), operation, logrus.New())
 
 		// Then
 		assert.NoError(t, err)
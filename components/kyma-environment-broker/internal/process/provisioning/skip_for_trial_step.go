@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -27,7 +28,7 @@ func (s *SkipForTrialPlanStep) Name() string {
 	return s.step.Name()
 }
 
-func (s *SkipForTrialPlanStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *SkipForTrialPlanStep) Run(ctx context.Context, operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	pp, err := operation.GetProvisioningParameters()
 	if err != nil {
 		log.Errorf("cannot fetch provisioning parameters from operation: %s", err)
@@ -38,5 +39,5 @@ func (s *SkipForTrialPlanStep) Run(operation internal.ProvisioningOperation, log
 		return operation, 0, nil
 	}
 
-	return s.step.Run(operation, log)
+	return s.step.Run(ctx, operation, log)
 }
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -30,7 +31,7 @@ func TestSkipForTrialPlanStepShouldSkip(t *testing.T) {
 	skipStep := NewSkipForTrialPlanStep(memoryStorage.Operations(), mockStep)
 
 	// When
-	returnedOperation, time, err := skipStep.Run(operation, log)
+	returnedOperation, time, err := skipStep.Run(context.Background(), operation, log)
 
 	// Then
 	mockStep.AssertExpectations(t)
@@ -49,12 +50,12 @@ func TestSkipForTrialPlanStepShouldNotSkip(t *testing.T) {
 	var skipTime time.Duration = 10
 
 	mockStep := &automock.Step{}
-	mockStep.On("Run", operation, log).Return(anotherOperation, skipTime, nil)
+	mockStep.On("Run", context.Background(), operation, log).Return(anotherOperation, skipTime, nil)
 
 	skipStep := NewSkipForTrialPlanStep(memoryStorage.Operations(), mockStep)
 
 	// When
-	returnedOperation, time, err := skipStep.Run(operation, log)
+	returnedOperation, time, err := skipStep.Run(context.Background(), operation, log)
 
 	// Then
 	mockStep.AssertExpectations(t)
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
@@ -29,7 +30,7 @@ func (s *ServiceManagerOverridesStep) Name() string {
 	return "ServiceManagerOverrides"
 }
 
-func (s *ServiceManagerOverridesStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *ServiceManagerOverridesStep) Run(ctx context.Context, operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	creds, err := operation.ProvideServiceManagerCredentials(log)
 	if err != nil {
 		log.Errorf("unable to obtain SM credentials", err)
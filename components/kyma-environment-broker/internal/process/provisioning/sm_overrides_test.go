@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"testing"
@@ -88,7 +89,7 @@ func TestServiceManagerOverridesStepSuccess(t *testing.T) {
 			smStep := NewServiceManagerOverridesStep(memoryStorage.Operations())
 
 			// when
-			gotOperation, retryTime, err := smStep.Run(operation, NewLogDummy())
+			gotOperation, retryTime, err := smStep.Run(context.Background(), operation, NewLogDummy())
 
 			// then
 			require.NoError(t, err)
@@ -135,7 +136,7 @@ func TestServiceManagerOverridesStepError(t *testing.T) {
 			smStep := NewServiceManagerOverridesStep(memoryStorage.Operations())
 
 			// when
-			gotOperation, retryTime, err := smStep.Run(operation, NewLogDummy())
+			gotOperation, retryTime, err := smStep.Run(context.Background(), operation, NewLogDummy())
 
 			// then
 			require.EqualError(t, err, tC.expErr)
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/servicemanager"
@@ -29,7 +30,7 @@ func (s *UaaInstantiationStep) Name() string {
 	return "UAA_POC"
 }
 
-func (s *UaaInstantiationStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+func (s *UaaInstantiationStep) Run(ctx context.Context, operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	// This implementation only lists offerings and plans and it is a placeholder for full implementation
 
 	var cli servicemanager.Client
@@ -1,6 +1,7 @@
 package upgrade_kyma
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -58,7 +59,7 @@ func (s *InitialisationStep) Name() string {
 	return "Upgrade_Kyma_Initialisation"
 }
 
-func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (s *InitialisationStep) Run(ctx context.Context, operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
 	// rewrite necessary data from ProvisioningOperation to operation internal.UpgradeOperation
 	op, err := s.operationStorage.GetProvisioningOperationByInstanceID(operation.InstanceID)
 	if err != nil {
@@ -91,8 +92,9 @@ func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log lo
 	switch {
 	case err == nil:
 		if operation.ProvisionerOperationID == "" {
-			// if schedule is maintenanceWindow and time window for this operation has finished we reprocess on next time window
-			if !operation.MaintenanceWindowEnd.IsZero() && operation.MaintenanceWindowEnd.Before(time.Now()) {
+			// if schedule is maintenanceWindow and time window for this operation has finished we reprocess on next time window,
+			// unless the cluster upgrade of the composite orchestration has already started
+			if !operation.MaintenanceWindowEnd.IsZero() && operation.MaintenanceWindowEnd.Before(time.Now()) && operation.ClusterUpgradeOperationID == "" {
 				return s.rescheduleAtNextMaintenanceWindow(operation, log)
 			}
 			log.Info("provisioner operation ID is empty, initialize upgrade runtime input request")
@@ -100,7 +102,7 @@ func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log lo
 		}
 		log.Infof("runtime being upgraded, check operation status")
 		operation.RuntimeID = instance.RuntimeID
-		return s.checkRuntimeStatus(operation, instance, log.WithField("runtimeID", instance.RuntimeID))
+		return s.checkRuntimeStatus(ctx, operation, instance, log.WithField("runtimeID", instance.RuntimeID))
 	case dberr.IsNotFound(err):
 		log.Info("instance does not exist, it may have been deprovisioned")
 		return s.operationManager.OperationSucceeded(operation, "instance was not found")
@@ -175,13 +177,13 @@ func (s *InitialisationStep) configureKymaVersion(operation *internal.UpgradeKym
 	return nil
 }
 
-func (s *InitialisationStep) checkRuntimeStatus(operation internal.UpgradeKymaOperation, instance *internal.Instance, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (s *InitialisationStep) checkRuntimeStatus(ctx context.Context, operation internal.UpgradeKymaOperation, instance *internal.Instance, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
 	if time.Since(operation.UpdatedAt) > CheckStatusTimeout {
 		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
 		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation has reached the time limit: %s", CheckStatusTimeout))
 	}
 
-	status, err := s.provisionerClient.RuntimeOperationStatus(instance.GlobalAccountID, operation.ProvisionerOperationID)
+	status, err := s.provisionerClient.RuntimeOperationStatus(ctx, instance.GlobalAccountID, operation.ProvisionerOperationID)
 	if err != nil {
 		return operation, s.timeSchedule.StatusCheck, nil
 	}
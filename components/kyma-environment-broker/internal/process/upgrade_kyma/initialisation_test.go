@@ -1,6 +1,7 @@
 package upgrade_kyma
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 const (
@@ -48,7 +50,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+		provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
 			ID:        ptr.String(fixProvisionerOperationID),
 			Operation: "",
 			State:     gqlschema.OperationStateSucceeded,
@@ -59,7 +61,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, nil, nil, nil)
 
 		// when
-		upgradeOperation, repeat, err := step.Run(upgradeOperation, log)
+		upgradeOperation, repeat, err := step.Run(context.Background(), upgradeOperation, log)
 
 		// then
 		assert.NoError(t, err)
@@ -104,7 +106,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, inputBuilder, nil, rvc)
 
 		// when
-		op, repeat, err := step.Run(upgradeOperation, log)
+		op, repeat, err := step.Run(context.Background(), upgradeOperation, log)
 
 		// then
 		assert.NoError(t, err)
@@ -9,12 +9,13 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/sirupsen/logrus"
 )
 
 type Step interface {
 	Name() string
-	Run(operation internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error)
+	Run(ctx context.Context, operation internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error)
 }
 
 type Manager struct {
@@ -45,10 +46,14 @@ func (m *Manager) AddStep(weight int, step Step) {
 	m.steps[weight] = append(m.steps[weight], step)
 }
 
-func (m *Manager) runStep(step Step, operation internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (m *Manager) runStep(ctx context.Context, step Step, operation internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	ctx, span := tracing.StartStep(ctx, step.Name())
+	defer span.End()
+
 	start := time.Now()
-	processedOperation, when, err := step.Run(operation, logger)
-	m.publisher.Publish(context.TODO(), process.UpgradeKymaStepProcessed{
+	processedOperation, when, err := step.Run(ctx, operation, logger)
+	tracing.SetError(span, err)
+	m.publisher.Publish(ctx, process.UpgradeKymaStepProcessed{
 		OldOperation: operation,
 		Operation:    processedOperation,
 		StepProcessed: process.StepProcessed{
@@ -75,6 +80,9 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 	var when time.Duration
 	logOperation := m.log.WithFields(logrus.Fields{"operation": operationID, "instanceID": operation.InstanceID})
 
+	ctx, span := tracing.StartOperation(context.Background(), "upgrade_kyma", operationID, operation.InstanceID)
+	defer span.End()
+
 	logOperation.Info("Start process operation steps")
 	for _, weightStep := range m.sortWeight() {
 		steps := m.steps[weightStep]
@@ -82,9 +90,10 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 			logStep := logOperation.WithField("step", step.Name())
 			logStep.Infof("Start step")
 
-			operation, when, err = m.runStep(step, operation, logStep)
+			operation, when, err = m.runStep(ctx, step, operation, logStep)
 			if err != nil {
 				logStep.Errorf("Process operation failed: %s", err)
+				tracing.SetError(span, err)
 				return 0, err
 			}
 			if operation.IsFinished() {
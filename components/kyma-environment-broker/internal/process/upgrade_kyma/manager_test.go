@@ -18,6 +18,9 @@ import (
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
@@ -100,6 +103,34 @@ func TestManager_Execute(t *testing.T) {
 	}
 }
 
+func TestManager_Execute_SameTraceForRepeatedOperation(t *testing.T) {
+	// given
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previousProvider)
+
+	memoryStorage := storage.NewMemoryStorage()
+	operations := memoryStorage.Operations()
+	err := operations.InsertUpgradeKymaOperation(fixOperation(operationIDRepeat))
+	assert.NoError(t, err)
+
+	step := &traceStep{}
+	manager := NewManager(operations, event.NewPubSub(logrus.New()), logrus.New())
+	manager.InitStep(step)
+
+	// when
+	_, err = manager.Execute(operationIDRepeat)
+	assert.NoError(t, err)
+	_, err = manager.Execute(operationIDRepeat)
+	assert.NoError(t, err)
+
+	// then
+	assert.Len(t, step.traceIDs, 2)
+	assert.True(t, step.traceIDs[0].IsValid())
+	assert.Equal(t, step.traceIDs[0], step.traceIDs[1])
+}
+
 func fixOperation(ID string) internal.UpgradeKymaOperation {
 	return internal.UpgradeKymaOperation{
 		Operation: internal.Operation{
@@ -112,6 +143,19 @@ func fixOperation(ID string) internal.UpgradeKymaOperation {
 	}
 }
 
+type traceStep struct {
+	traceIDs []trace.TraceID
+}
+
+func (ts *traceStep) Name() string {
+	return "trace"
+}
+
+func (ts *traceStep) Run(ctx context.Context, operation internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	ts.traceIDs = append(ts.traceIDs, trace.SpanContextFromContext(ctx).TraceID())
+	return operation, time.Second, nil
+}
+
 type testStep struct {
 	t       *testing.T
 	name    string
@@ -122,7 +166,7 @@ func (ts *testStep) Name() string {
 	return ts.name
 }
 
-func (ts *testStep) Run(operation internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (ts *testStep) Run(ctx context.Context, operation internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
 	logger.Infof("inside %s step", ts.name)
 
 	operation.Description = fmt.Sprintf("%s %s", operation.Description, ts.name)
@@ -1,6 +1,7 @@
 package upgrade_kyma
 
 import (
+	"context"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
@@ -41,7 +42,7 @@ func (s *OverridesFromSecretsAndConfigStep) Name() string {
 	return "Overrides_From_Secrets_And_Config_Step"
 }
 
-func (s *OverridesFromSecretsAndConfigStep) Run(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+func (s *OverridesFromSecretsAndConfigStep) Run(ctx context.Context, operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
 	pp, err := operation.GetProvisioningParameters()
 	if err != nil {
 		log.Errorf("cannot fetch provisioning parameters from operation: %s", err)
@@ -1,6 +1,7 @@
 package upgrade_kyma
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -39,7 +40,7 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionComputed(t *testing.T)
 		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rvcMock)
 
 		// When
-		operation, repeat, err := step.Run(operation, logrus.New())
+		operation, repeat, err := step.Run(context.Background(), operation, logrus.New())
 
 		// Then
 		assert.NoError(t, err)
@@ -76,7 +77,7 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionFromOperation(t *testi
 		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rvcMock)
 
 		// When
-		operation, repeat, err := step.Run(operation, logrus.New())
+		operation, repeat, err := step.Run(context.Background(), operation, logrus.New())
 
 		// Then
 		assert.NoError(t, err)
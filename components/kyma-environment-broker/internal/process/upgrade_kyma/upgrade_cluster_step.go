@@ -0,0 +1,144 @@
+package upgrade_kyma
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/sirupsen/logrus"
+)
+
+// UpgradeClusterStep performs the upgradeCluster action of the composite orchestration.
+// It upgrades the Kubernetes version of the shoot cluster and waits until the Provisioner finishes,
+// so that the following upgradeKyma action is performed on the upgraded cluster.
+type UpgradeClusterStep struct {
+	operationManager         *process.UpgradeKymaOperationManager
+	provisionerClient        provisioner.Client
+	defaultKubernetesVersion string
+	timeSchedule             TimeSchedule
+}
+
+func NewUpgradeClusterStep(os storage.Operations, cli provisioner.Client, defaultKubernetesVersion string, timeSchedule *TimeSchedule) *UpgradeClusterStep {
+	ts := timeSchedule
+	if ts == nil {
+		ts = &TimeSchedule{
+			Retry:              5 * time.Second,
+			StatusCheck:        time.Minute,
+			UpgradeKymaTimeout: time.Hour,
+		}
+	}
+	return &UpgradeClusterStep{
+		operationManager:         process.NewUpgradeKymaOperationManager(os),
+		provisionerClient:        cli,
+		defaultKubernetesVersion: defaultKubernetesVersion,
+		timeSchedule:             *ts,
+	}
+}
+
+func (s *UpgradeClusterStep) Name() string {
+	return "Upgrade_Cluster"
+}
+
+func (s *UpgradeClusterStep) Run(ctx context.Context, operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	action := operation.CurrentAction()
+	if action == nil || action.Type != orchestration.UpgradeClusterAction {
+		return operation, 0, nil
+	}
+
+	pp, err := operation.GetProvisioningParameters()
+	if err != nil {
+		return s.operationManager.OperationFailed(operation, "invalid operation provisioning parameters")
+	}
+
+	version := operation.KubernetesVersion
+	if version == "" {
+		version = s.defaultKubernetesVersion
+	}
+
+	if operation.DryRun {
+		return s.finishAction(operation, fmt.Sprintf("dry run of the cluster upgrade to %s succeeded", version), log)
+	}
+
+	if operation.ClusterUpgradeOperationID == "" {
+		// trigger upgradeShoot mutation
+		status, err := s.provisionerClient.UpgradeShoot(ctx, pp.ErsContext.GlobalAccountID, operation.RuntimeID, gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion: &version,
+			},
+		})
+		if err != nil {
+			log.Errorf("call to provisioner failed: %s", err)
+			return operation, s.timeSchedule.Retry, nil
+		}
+		operation.ClusterUpgradeOperationID = *status.ID
+		operation.ClusterUpgradeStartedAt = time.Now()
+		operation.Description = fmt.Sprintf("cluster upgrade to %s in progress", version)
+
+		operation, repeat := s.operationManager.UpdateOperation(operation)
+		if repeat != 0 {
+			log.Errorf("cannot save cluster upgrade operation ID from provisioner")
+			return operation, s.timeSchedule.Retry, nil
+		}
+		log.Infof("cluster upgrade to %s initiated, got operation ID %q", version, operation.ClusterUpgradeOperationID)
+		return operation, s.timeSchedule.StatusCheck, nil
+	}
+
+	return s.checkClusterUpgradeStatus(ctx, operation, pp.ErsContext.GlobalAccountID, log)
+}
+
+func (s *UpgradeClusterStep) checkClusterUpgradeStatus(ctx context.Context, operation internal.UpgradeKymaOperation, globalAccountID string, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	// the operation is updated on every pass of the steps, so the time limit is measured from the start of the upgrade
+	startedAt := operation.ClusterUpgradeStartedAt
+	if startedAt.IsZero() {
+		startedAt = operation.CreatedAt
+	}
+	if time.Since(startedAt) > CheckStatusTimeout {
+		log.Infof("operation has reached the time limit: cluster upgrade started at: %s", startedAt)
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation has reached the time limit: %s", CheckStatusTimeout))
+	}
+
+	status, err := s.provisionerClient.RuntimeOperationStatus(ctx, globalAccountID, operation.ClusterUpgradeOperationID)
+	if err != nil {
+		log.Errorf("call to provisioner about operation status failed: %s", err)
+		return operation, s.timeSchedule.StatusCheck, nil
+	}
+	log.Infof("call to provisioner returned %s status for the cluster upgrade", status.State.String())
+
+	var msg string
+	if status.Message != nil {
+		msg = *status.Message
+	}
+
+	switch status.State {
+	case gqlschema.OperationStateSucceeded:
+		return s.finishAction(operation, msg, log)
+	case gqlschema.OperationStateInProgress, gqlschema.OperationStatePending:
+		return operation, s.timeSchedule.StatusCheck, nil
+	case gqlschema.OperationStateFailed:
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("cluster upgrade failed: %s", msg))
+	}
+
+	return s.operationManager.OperationFailed(operation, fmt.Sprintf("unsupported provisioner client status: %s", status.State.String()))
+}
+
+// finishAction marks the upgradeCluster action as succeeded and either finishes the operation
+// or hands it over to the next steps which perform the upgradeKyma action
+func (s *UpgradeClusterStep) finishAction(operation internal.UpgradeKymaOperation, description string, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	if !operation.FinishCurrentAction(orchestration.Succeeded, description) {
+		return s.operationManager.OperationSucceeded(operation, description)
+	}
+
+	operation.Description = "cluster upgraded, kyma upgrade in progress"
+	operation, repeat := s.operationManager.UpdateOperation(operation)
+	if repeat != 0 {
+		log.Errorf("cannot save the operation")
+		return operation, s.timeSchedule.Retry, nil
+	}
+	return operation, 0, nil
+}
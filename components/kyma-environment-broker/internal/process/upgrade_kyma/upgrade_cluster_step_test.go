@@ -0,0 +1,127 @@
+package upgrade_kyma
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	provisionerAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const fixClusterUpgradeOperationID = "cluster-upgrade-op-id"
+
+func TestUpgradeClusterStep_Run(t *testing.T) {
+	// given
+	log := logrus.New()
+	memoryStorage := storage.NewMemoryStorage()
+
+	operation := fixUpgradeKymaOperationWithInputCreator(t)
+	operation.State = domain.InProgress
+	operation.KubernetesVersion = "1.18.10"
+	operation.Actions = []orchestration.ActionStatus{
+		{Type: orchestration.UpgradeClusterAction, State: orchestration.InProgress},
+		{Type: orchestration.UpgradeKymaAction, State: orchestration.Pending},
+	}
+	err := memoryStorage.Operations().InsertUpgradeKymaOperation(operation)
+	require.NoError(t, err)
+
+	provisionerClient := &provisionerAutomock.Client{}
+	provisionerClient.On("UpgradeShoot", mock.Anything, fixGlobalAccountID, fixRuntimeID, gqlschema.UpgradeShootInput{
+		GardenerConfig: &gqlschema.GardenerUpgradeInput{
+			KubernetesVersion: ptr.String("1.18.10"),
+		},
+	}).Return(gqlschema.OperationStatus{
+		ID:        ptr.String(fixClusterUpgradeOperationID),
+		RuntimeID: ptr.String(fixRuntimeID),
+	}, nil).Once()
+	provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixClusterUpgradeOperationID).Return(gqlschema.OperationStatus{
+		ID:        ptr.String(fixClusterUpgradeOperationID),
+		State:     gqlschema.OperationStateSucceeded,
+		RuntimeID: ptr.String(fixRuntimeID),
+	}, nil).Once()
+	defer provisionerClient.AssertExpectations(t)
+
+	step := NewUpgradeClusterStep(memoryStorage.Operations(), provisionerClient, "1.17.0", nil)
+
+	// when
+	operation, repeat, err := step.Run(context.Background(), operation, log.WithField("step", "TEST"))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, repeat)
+	assert.Equal(t, fixClusterUpgradeOperationID, operation.ClusterUpgradeOperationID)
+	assert.False(t, operation.ClusterUpgradeStartedAt.IsZero())
+	assert.Equal(t, orchestration.InProgress, operation.Actions[0].State)
+
+	// when
+	operation, repeat, err = step.Run(context.Background(), operation, log.WithField("step", "TEST"))
+
+	// then
+	require.NoError(t, err)
+	assert.Zero(t, repeat)
+	assert.Equal(t, domain.InProgress, operation.State)
+	assert.Equal(t, orchestration.Succeeded, operation.Actions[0].State)
+	assert.Equal(t, orchestration.InProgress, operation.Actions[1].State)
+
+	// when the cluster upgrade is finished the step is skipped
+	operation, repeat, err = step.Run(context.Background(), operation, log.WithField("step", "TEST"))
+
+	// then
+	require.NoError(t, err)
+	assert.Zero(t, repeat)
+}
+
+func TestUpgradeClusterStep_RunTimeout(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+
+	operation := fixUpgradeKymaOperationWithInputCreator(t)
+	operation.State = domain.InProgress
+	operation.Actions = []orchestration.ActionStatus{
+		{Type: orchestration.UpgradeClusterAction, State: orchestration.InProgress},
+	}
+	operation.ClusterUpgradeOperationID = fixClusterUpgradeOperationID
+	operation.ClusterUpgradeStartedAt = time.Now().Add(-CheckStatusTimeout - time.Minute)
+	// the operation was updated recently by the previous steps
+	operation.UpdatedAt = time.Now()
+	err := memoryStorage.Operations().InsertUpgradeKymaOperation(operation)
+	require.NoError(t, err)
+
+	provisionerClient := &provisionerAutomock.Client{}
+	step := NewUpgradeClusterStep(memoryStorage.Operations(), provisionerClient, "1.17.0", nil)
+
+	// when
+	operation, repeat, err := step.Run(context.Background(), operation, logrus.New())
+
+	// then
+	require.Error(t, err)
+	assert.Zero(t, repeat)
+	assert.Equal(t, domain.Failed, operation.State)
+	provisionerClient.AssertNotCalled(t, "RuntimeOperationStatus")
+}
+
+func TestUpgradeClusterStep_RunSkipsKymaUpgrade(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+	operation := fixUpgradeKymaOperationWithInputCreator(t)
+	provisionerClient := &provisionerAutomock.Client{}
+
+	step := NewUpgradeClusterStep(memoryStorage.Operations(), provisionerClient, "1.17.0", nil)
+
+	// when
+	_, repeat, err := step.Run(context.Background(), operation, logrus.New())
+
+	// then
+	require.NoError(t, err)
+	assert.Zero(t, repeat)
+	provisionerClient.AssertNotCalled(t, "UpgradeShoot")
+}
@@ -1,6 +1,7 @@
 package upgrade_kyma
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/kyma-project/kyma/components/kyma-operator/pkg/apis/installer/v1alpha1"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 const (
@@ -39,7 +41,7 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 	assert.NoError(t, err)
 
 	provisionerClient := &provisionerAutomock.Client{}
-	provisionerClient.On("UpgradeRuntime", fixGlobalAccountID, fixRuntimeID, gqlschema.UpgradeRuntimeInput{
+	provisionerClient.On("UpgradeRuntime", mock.Anything, fixGlobalAccountID, fixRuntimeID, gqlschema.UpgradeRuntimeInput{
 		KymaConfig: &gqlschema.KymaConfigInput{
 			Version: kymaVersion,
 			Components: []*gqlschema.ComponentConfigurationInput{
@@ -58,7 +60,7 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 		Message:   nil,
 		RuntimeID: StringPtr(fixRuntimeID),
 	}, nil)
-	provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+	provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
 		ID:        ptr.String(fixProvisionerOperationID),
 		Operation: "",
 		State:     "",
@@ -70,7 +72,7 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 
 	// when
 
-	operation, repeat, err := step.Run(operation, log.WithFields(logrus.Fields{"step": "TEST"}))
+	operation, repeat, err := step.Run(context.Background(), operation, log.WithFields(logrus.Fields{"step": "TEST"}))
 
 	// then
 	assert.NoError(t, err)
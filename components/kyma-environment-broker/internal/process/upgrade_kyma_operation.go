@@ -65,6 +65,10 @@ func (om *UpgradeKymaOperationManager) UpdateOperation(operation internal.Upgrad
 func (om *UpgradeKymaOperationManager) update(operation internal.UpgradeKymaOperation, state domain.LastOperationState, description string) (internal.UpgradeKymaOperation, time.Duration) {
 	operation.State = state
 	operation.Description = description
+	// the finished operation finishes all its composite orchestration actions
+	for operation.CurrentAction() != nil {
+		operation.FinishCurrentAction(string(state), description)
+	}
 
 	return om.UpdateOperation(operation)
 }
@@ -109,3 +109,27 @@ func fixUpgradeKymaOperation() internal.UpgradeKymaOperation {
 		InputCreator:           nil,
 	}
 }
+
+func TestUpgradeKymaOperationManager_OperationFailedWithActions(t *testing.T) {
+	// given
+	memory := storage.NewMemoryStorage()
+	operations := memory.Operations()
+	opManager := NewUpgradeKymaOperationManager(operations)
+	op := fixUpgradeKymaOperation()
+	op.Actions = []orchestration.ActionStatus{
+		{Type: orchestration.UpgradeClusterAction, State: orchestration.InProgress},
+		{Type: orchestration.UpgradeKymaAction, State: orchestration.Pending},
+	}
+	err := operations.InsertUpgradeKymaOperation(op)
+	require.NoError(t, err)
+
+	// when
+	op, _, err = opManager.OperationFailed(op, "cluster upgrade failed")
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, []orchestration.ActionStatus{
+		{Type: orchestration.UpgradeClusterAction, State: orchestration.Failed, Description: "cluster upgrade failed"},
+		{Type: orchestration.UpgradeKymaAction, State: orchestration.Canceled},
+	}, op.Actions)
+}
@@ -3,6 +3,8 @@
 package automock
 
 import (
+	context "context"
+
 	gqlschema "github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,20 +14,20 @@ type Client struct {
 	mock.Mock
 }
 
-// DeprovisionRuntime provides a mock function with given fields: accountID, runtimeID
-func (_m *Client) DeprovisionRuntime(accountID string, runtimeID string) (string, error) {
-	ret := _m.Called(accountID, runtimeID)
+// DeprovisionRuntime provides a mock function with given fields: ctx, accountID, runtimeID
+func (_m *Client) DeprovisionRuntime(ctx context.Context, accountID string, runtimeID string) (string, error) {
+	ret := _m.Called(ctx, accountID, runtimeID)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(accountID, runtimeID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, accountID, runtimeID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(accountID, runtimeID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accountID, runtimeID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,20 +35,20 @@ func (_m *Client) DeprovisionRuntime(accountID string, runtimeID string) (string
 	return r0, r1
 }
 
-// ProvisionRuntime provides a mock function with given fields: accountID, subAccountID, config
-func (_m *Client) ProvisionRuntime(accountID string, subAccountID string, config gqlschema.ProvisionRuntimeInput) (gqlschema.OperationStatus, error) {
-	ret := _m.Called(accountID, subAccountID, config)
+// ProvisionRuntime provides a mock function with given fields: ctx, accountID, subAccountID, config
+func (_m *Client) ProvisionRuntime(ctx context.Context, accountID string, subAccountID string, config gqlschema.ProvisionRuntimeInput) (gqlschema.OperationStatus, error) {
+	ret := _m.Called(ctx, accountID, subAccountID, config)
 
 	var r0 gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(string, string, gqlschema.ProvisionRuntimeInput) gqlschema.OperationStatus); ok {
-		r0 = rf(accountID, subAccountID, config)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, gqlschema.ProvisionRuntimeInput) gqlschema.OperationStatus); ok {
+		r0 = rf(ctx, accountID, subAccountID, config)
 	} else {
 		r0 = ret.Get(0).(gqlschema.OperationStatus)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, gqlschema.ProvisionRuntimeInput) error); ok {
-		r1 = rf(accountID, subAccountID, config)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, gqlschema.ProvisionRuntimeInput) error); ok {
+		r1 = rf(ctx, accountID, subAccountID, config)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,20 +56,64 @@ func (_m *Client) ProvisionRuntime(accountID string, subAccountID string, config
 	return r0, r1
 }
 
-// ReconnectRuntimeAgent provides a mock function with given fields: accountID, runtimeID
-func (_m *Client) ReconnectRuntimeAgent(accountID string, runtimeID string) (string, error) {
-	ret := _m.Called(accountID, runtimeID)
+// ReconnectRuntimeAgent provides a mock function with given fields: ctx, accountID, runtimeID
+func (_m *Client) ReconnectRuntimeAgent(ctx context.Context, accountID string, runtimeID string) (string, error) {
+	ret := _m.Called(ctx, accountID, runtimeID)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(accountID, runtimeID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, accountID, runtimeID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(accountID, runtimeID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accountID, runtimeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RuntimeOperationStatus provides a mock function with given fields: ctx, accountID, operationID
+func (_m *Client) RuntimeOperationStatus(ctx context.Context, accountID string, operationID string) (gqlschema.OperationStatus, error) {
+	ret := _m.Called(ctx, accountID, operationID)
+
+	var r0 gqlschema.OperationStatus
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) gqlschema.OperationStatus); ok {
+		r0 = rf(ctx, accountID, operationID)
+	} else {
+		r0 = ret.Get(0).(gqlschema.OperationStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accountID, operationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RuntimeStatuses provides a mock function with given fields: ctx, accountID, runtimeIDs
+func (_m *Client) RuntimeStatuses(ctx context.Context, accountID string, runtimeIDs []string) (map[string]gqlschema.RuntimeStatus, error) {
+	ret := _m.Called(ctx, accountID, runtimeIDs)
+
+	var r0 map[string]gqlschema.RuntimeStatus
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) map[string]gqlschema.RuntimeStatus); ok {
+		r0 = rf(ctx, accountID, runtimeIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]gqlschema.RuntimeStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, accountID, runtimeIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,20 +121,20 @@ func (_m *Client) ReconnectRuntimeAgent(accountID string, runtimeID string) (str
 	return r0, r1
 }
 
-// RuntimeOperationStatus provides a mock function with given fields: accountID, operationID
-func (_m *Client) RuntimeOperationStatus(accountID string, operationID string) (gqlschema.OperationStatus, error) {
-	ret := _m.Called(accountID, operationID)
+// UpgradeRuntime provides a mock function with given fields: ctx, accountID, runtimeID, config
+func (_m *Client) UpgradeRuntime(ctx context.Context, accountID string, runtimeID string, config gqlschema.UpgradeRuntimeInput) (gqlschema.OperationStatus, error) {
+	ret := _m.Called(ctx, accountID, runtimeID, config)
 
 	var r0 gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(string, string) gqlschema.OperationStatus); ok {
-		r0 = rf(accountID, operationID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, gqlschema.UpgradeRuntimeInput) gqlschema.OperationStatus); ok {
+		r0 = rf(ctx, accountID, runtimeID, config)
 	} else {
 		r0 = ret.Get(0).(gqlschema.OperationStatus)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(accountID, operationID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, gqlschema.UpgradeRuntimeInput) error); ok {
+		r1 = rf(ctx, accountID, runtimeID, config)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -96,20 +142,20 @@ func (_m *Client) RuntimeOperationStatus(accountID string, operationID string) (
 	return r0, r1
 }
 
-// UpgradeRuntime provides a mock function with given fields: accountID, runtimeID, config
-func (_m *Client) UpgradeRuntime(accountID string, runtimeID string, config gqlschema.UpgradeRuntimeInput) (gqlschema.OperationStatus, error) {
-	ret := _m.Called(accountID, runtimeID, config)
+// UpgradeShoot provides a mock function with given fields: ctx, accountID, runtimeID, config
+func (_m *Client) UpgradeShoot(ctx context.Context, accountID string, runtimeID string, config gqlschema.UpgradeShootInput) (gqlschema.OperationStatus, error) {
+	ret := _m.Called(ctx, accountID, runtimeID, config)
 
 	var r0 gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(string, string, gqlschema.UpgradeRuntimeInput) gqlschema.OperationStatus); ok {
-		r0 = rf(accountID, runtimeID, config)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, gqlschema.UpgradeShootInput) gqlschema.OperationStatus); ok {
+		r0 = rf(ctx, accountID, runtimeID, config)
 	} else {
 		r0 = ret.Get(0).(gqlschema.OperationStatus)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, gqlschema.UpgradeRuntimeInput) error); ok {
-		r1 = rf(accountID, runtimeID, config)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, gqlschema.UpgradeShootInput) error); ok {
+		r1 = rf(ctx, accountID, runtimeID, config)
 	} else {
 		r1 = ret.Error(1)
 	}
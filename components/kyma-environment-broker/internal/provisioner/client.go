@@ -22,11 +22,13 @@ const (
 //go:generate mockery -name=Client -output=automock -outpkg=automock -case=underscore
 
 type Client interface {
-	ProvisionRuntime(accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error)
-	DeprovisionRuntime(accountID, runtimeID string) (string, error)
-	UpgradeRuntime(accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error)
-	ReconnectRuntimeAgent(accountID, runtimeID string) (string, error)
-	RuntimeOperationStatus(accountID, operationID string) (schema.OperationStatus, error)
+	ProvisionRuntime(ctx context.Context, accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error)
+	DeprovisionRuntime(ctx context.Context, accountID, runtimeID string) (string, error)
+	UpgradeRuntime(ctx context.Context, accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error)
+	UpgradeShoot(ctx context.Context, accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error)
+	ReconnectRuntimeAgent(ctx context.Context, accountID, runtimeID string) (string, error)
+	RuntimeOperationStatus(ctx context.Context, accountID, operationID string) (schema.OperationStatus, error)
+	RuntimeStatuses(ctx context.Context, accountID string, runtimeIDs []string) (map[string]schema.RuntimeStatus, error)
 }
 
 type client struct {
@@ -50,7 +52,7 @@ func NewProvisionerClient(endpoint string, queryDumping bool) Client {
 	}
 }
 
-func (c *client) ProvisionRuntime(accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error) {
+func (c *client) ProvisionRuntime(ctx context.Context, accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error) {
 	provisionRuntimeIptGQL, err := c.graphqlizer.ProvisionRuntimeInputToGraphQL(config)
 	if err != nil {
 		return schema.OperationStatus{}, errors.Wrap(err, "Failed to convert Provision Runtime Input to query")
@@ -62,7 +64,7 @@ func (c *client) ProvisionRuntime(accountID, subAccountID string, config schema.
 	req.Header.Add(subAccountIDKey, subAccountID)
 
 	var response schema.OperationStatus
-	err = c.executeRequest(req, &response)
+	err = c.executeRequest(ctx, req, &response)
 	if err != nil {
 		return schema.OperationStatus{}, errors.Wrap(err, "failed to provision a Runtime")
 	}
@@ -70,20 +72,20 @@ func (c *client) ProvisionRuntime(accountID, subAccountID string, config schema.
 	return response, nil
 }
 
-func (c *client) DeprovisionRuntime(accountID, runtimeID string) (string, error) {
+func (c *client) DeprovisionRuntime(ctx context.Context, accountID, runtimeID string) (string, error) {
 	query := c.queryProvider.deprovisionRuntime(runtimeID)
 	req := gcli.NewRequest(query)
 	req.Header.Add(accountIDKey, accountID)
 
 	var operationId string
-	err := c.executeRequest(req, &operationId)
+	err := c.executeRequest(ctx, req, &operationId)
 	if err != nil {
 		return "", errors.Wrap(err, "Failed to deprovision Runtime")
 	}
 	return operationId, nil
 }
 
-func (c *client) UpgradeRuntime(accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error) {
+func (c *client) UpgradeRuntime(ctx context.Context, accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error) {
 	upgradeRuntimeIptGQL, err := c.graphqlizer.UpgradeRuntimeInputToGraphQL(config)
 	if err != nil {
 		return schema.OperationStatus{}, errors.Wrap(err, "Failed to convert Upgrade Runtime Input to query")
@@ -94,40 +96,87 @@ func (c *client) UpgradeRuntime(accountID, runtimeID string, config schema.Upgra
 	req.Header.Add(accountIDKey, accountID)
 
 	var res schema.OperationStatus
-	err = c.executeRequest(req, &res)
+	err = c.executeRequest(ctx, req, &res)
 	if err != nil {
 		return schema.OperationStatus{}, errors.Wrap(err, "Failed to upgrade Runtime")
 	}
 	return res, nil
 }
 
-func (c *client) ReconnectRuntimeAgent(accountID, runtimeID string) (string, error) {
+func (c *client) UpgradeShoot(ctx context.Context, accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error) {
+	upgradeShootIptGQL, err := c.graphqlizer.UpgradeShootInputToGraphQL(config)
+	if err != nil {
+		return schema.OperationStatus{}, errors.Wrap(err, "Failed to convert Upgrade Shoot Input to query")
+	}
+
+	query := c.queryProvider.upgradeShoot(runtimeID, upgradeShootIptGQL)
+	req := gcli.NewRequest(query)
+	req.Header.Add(accountIDKey, accountID)
+
+	var res schema.OperationStatus
+	err = c.executeRequest(ctx, req, &res)
+	if err != nil {
+		return schema.OperationStatus{}, errors.Wrap(err, "Failed to upgrade Shoot")
+	}
+	return res, nil
+}
+
+func (c *client) ReconnectRuntimeAgent(ctx context.Context, accountID, runtimeID string) (string, error) {
 	query := c.queryProvider.reconnectRuntimeAgent(runtimeID)
 	req := gcli.NewRequest(query)
 	req.Header.Add(accountIDKey, accountID)
 
 	var operationId string
-	err := c.executeRequest(req, &operationId)
+	err := c.executeRequest(ctx, req, &operationId)
 	if err != nil {
 		return "", errors.Wrap(err, "Failed to reconnect Runtime agent")
 	}
 	return operationId, nil
 }
 
-func (c *client) RuntimeOperationStatus(accountID, operationID string) (schema.OperationStatus, error) {
+func (c *client) RuntimeOperationStatus(ctx context.Context, accountID, operationID string) (schema.OperationStatus, error) {
 	query := c.queryProvider.runtimeOperationStatus(operationID)
 	req := gcli.NewRequest(query)
 	req.Header.Add(accountIDKey, accountID)
 
 	var response schema.OperationStatus
-	err := c.executeRequest(req, &response)
+	err := c.executeRequest(ctx, req, &response)
 	if err != nil {
 		return schema.OperationStatus{}, errors.Wrap(err, "Failed to get Runtime operation status")
 	}
 	return response, nil
 }
 
-func (c *client) executeRequest(req *gcli.Request, respDestination interface{}) error {
+// RuntimeStatuses fetches the statuses of the runtimes of the global account in a single request.
+// The returned statuses do not contain the kubeconfigs.
+func (c *client) RuntimeStatuses(ctx context.Context, accountID string, runtimeIDs []string) (map[string]schema.RuntimeStatus, error) {
+	if len(runtimeIDs) == 0 {
+		return map[string]schema.RuntimeStatus{}, nil
+	}
+	query := c.queryProvider.runtimeStatuses(runtimeIDs)
+	req := gcli.NewRequest(query)
+	req.Header.Add(accountIDKey, accountID)
+
+	response := map[string]*schema.RuntimeStatus{}
+	err := c.graphQLClient.Run(ctx, req, &response)
+	switch {
+	case isClientError(err):
+		return nil, errors.Wrap(err, "Failed to get Runtime statuses")
+	case err != nil:
+		return nil, kebError.AsTemporaryError(err, "Failed to get Runtime statuses")
+	}
+
+	statuses := make(map[string]schema.RuntimeStatus, len(runtimeIDs))
+	for i, runtimeID := range runtimeIDs {
+		status := response[runtimeStatusAlias(i)]
+		if status != nil {
+			statuses[runtimeID] = *status
+		}
+	}
+	return statuses, nil
+}
+
+func (c *client) executeRequest(ctx context.Context, req *gcli.Request, respDestination interface{}) error {
 	if reflect.ValueOf(respDestination).Kind() != reflect.Ptr {
 		return errors.New("destination is not of pointer type")
 	}
@@ -137,7 +186,7 @@ func (c *client) executeRequest(req *gcli.Request, respDestination interface{})
 	}
 
 	wrapper := &graphQLResponseWrapper{Result: respDestination}
-	err := c.graphQLClient.Run(context.TODO(), req, wrapper)
+	err := c.graphQLClient.Run(ctx, req, wrapper)
 	switch {
 	case isClientError(err):
 		return err
@@ -23,6 +23,7 @@ const (
 	provisionRuntimeID            = "4e268c0f-d053-4ab7-b167-6dbc0a0e09a6"
 	provisionRuntimeOperationID   = "c89f7862-0ef9-4d4e-bc82-afbc5ac98b8d"
 	upgradeRuntimeOperationID     = "74f47e0a-9a76-4336-9974-70705500a981"
+	upgradeShootOperationID       = "1b7a2b5e-58c1-4d0c-8d0e-6b6f2f5d7a41"
 	deprovisionRuntimeOperationID = "f9f7b734-7538-419c-8ac1-37060c60531a"
 )
 
@@ -36,7 +37,7 @@ func TestClient_ProvisionRuntime(t *testing.T) {
 		client := NewProvisionerClient(testServer.URL, false)
 
 		// When
-		status, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		status, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 
 		// Then
 		assert.NoError(t, err)
@@ -56,7 +57,7 @@ func TestClient_ProvisionRuntime(t *testing.T) {
 		client := NewProvisionerClient(testServer.URL, false)
 
 		// When
-		status, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		status, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 
 		// Then
 		assert.Error(t, err)
@@ -74,11 +75,11 @@ func TestClient_DeprovisionRuntime(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		// When
-		operationId, err := client.DeprovisionRuntime(testAccountID, *operation.RuntimeID)
+		operationId, err := client.DeprovisionRuntime(context.Background(), testAccountID, *operation.RuntimeID)
 
 		// Then
 		assert.NoError(t, err)
@@ -94,13 +95,13 @@ func TestClient_DeprovisionRuntime(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		tr.failed = true
 
 		// When
-		operationId, err := client.DeprovisionRuntime(testAccountID, *operation.RuntimeID)
+		operationId, err := client.DeprovisionRuntime(context.Background(), testAccountID, *operation.RuntimeID)
 
 		// Then
 		assert.Error(t, err)
@@ -118,11 +119,11 @@ func TestClient_UpgradeRuntime(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		// when
-		status, err := client.UpgradeRuntime(testAccountID, *operation.RuntimeID, fixUpgradeRuntimeInput("1.14.0"))
+		status, err := client.UpgradeRuntime(context.Background(), testAccountID, *operation.RuntimeID, fixUpgradeRuntimeInput("1.14.0"))
 
 		// then
 		assert.NoError(t, err)
@@ -139,13 +140,13 @@ func TestClient_UpgradeRuntime(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		tr.failed = true
 
 		// when
-		status, err := client.UpgradeRuntime(testAccountID, *operation.RuntimeID, fixUpgradeRuntimeInput("1.14.0"))
+		status, err := client.UpgradeRuntime(context.Background(), testAccountID, *operation.RuntimeID, fixUpgradeRuntimeInput("1.14.0"))
 
 		// Then
 		assert.Error(t, err)
@@ -155,6 +156,58 @@ func TestClient_UpgradeRuntime(t *testing.T) {
 	})
 }
 
+func TestClient_UpgradeShoot(t *testing.T) {
+	t.Run("should trigger shoot upgrade", func(t *testing.T) {
+		// given
+		tr := &testResolver{t: t, runtime: &testRuntime{}}
+		testServer := fixHTTPServer(tr)
+		defer testServer.Close()
+
+		client := NewProvisionerClient(testServer.URL, false)
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		assert.NoError(t, err)
+
+		// when
+		status, err := client.UpgradeShoot(context.Background(), testAccountID, *operation.RuntimeID, schema.UpgradeShootInput{
+			GardenerConfig: &schema.GardenerUpgradeInput{
+				KubernetesVersion: ptr.String("1.18.10"),
+			},
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, ptr.String(upgradeShootOperationID), status.ID)
+		assert.Equal(t, schema.OperationStateInProgress, status.State)
+		assert.Equal(t, schema.OperationTypeUpgradeShoot, status.Operation)
+		assert.Equal(t, "1.18.10", tr.getRuntime().kubernetesVersion)
+	})
+
+	t.Run("provisioner should return error", func(t *testing.T) {
+		// given
+		tr := &testResolver{t: t, runtime: &testRuntime{}}
+		testServer := fixHTTPServer(tr)
+		defer testServer.Close()
+
+		client := NewProvisionerClient(testServer.URL, false)
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		assert.NoError(t, err)
+
+		tr.failed = true
+
+		// when
+		status, err := client.UpgradeShoot(context.Background(), testAccountID, *operation.RuntimeID, schema.UpgradeShootInput{
+			GardenerConfig: &schema.GardenerUpgradeInput{
+				KubernetesVersion: ptr.String("1.18.10"),
+			},
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Empty(t, status)
+		assert.Equal(t, "", tr.getRuntime().upgradeShootOperationID)
+	})
+}
+
 func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 	t.Run("should reconnect runtime agent", func(t *testing.T) {
 		// Given
@@ -163,11 +216,11 @@ func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		// When
-		operationId, err := client.ReconnectRuntimeAgent(testAccountID, *operation.RuntimeID)
+		operationId, err := client.ReconnectRuntimeAgent(context.Background(), testAccountID, *operation.RuntimeID)
 
 		// Then
 		assert.NoError(t, err)
@@ -181,13 +234,13 @@ func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		tr.failed = true
 
 		// When
-		operationId, err := client.ReconnectRuntimeAgent(testAccountID, *operation.RuntimeID)
+		operationId, err := client.ReconnectRuntimeAgent(context.Background(), testAccountID, *operation.RuntimeID)
 
 		// Then
 		assert.Error(t, err)
@@ -216,7 +269,7 @@ func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 		client := NewProvisionerClient(server.URL, false)
 
 		// when
-		_, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		_, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 
 		// Then
 		assert.Error(t, err)
@@ -245,7 +298,7 @@ func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 		client := NewProvisionerClient(server.URL, false)
 
 		// when
-		_, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		_, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 
 		// Then
 		assert.Error(t, err)
@@ -256,7 +309,7 @@ func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 		client := NewProvisionerClient("http://not-existing", false)
 
 		// when
-		_, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		_, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 
 		// Then
 		assert.Error(t, err)
@@ -272,11 +325,11 @@ func TestClient_RuntimeOperationStatus(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		_, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		_, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		// When
-		status, err := client.RuntimeOperationStatus(testAccountID, provisionRuntimeID)
+		status, err := client.RuntimeOperationStatus(context.Background(), testAccountID, provisionRuntimeID)
 
 		// Then
 		assert.NoError(t, err)
@@ -293,13 +346,13 @@ func TestClient_RuntimeOperationStatus(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		_, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		_, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		tr.failed = true
 
 		// When
-		status, err := client.RuntimeOperationStatus(testAccountID, provisionRuntimeID)
+		status, err := client.RuntimeOperationStatus(context.Background(), testAccountID, provisionRuntimeID)
 
 		// Then
 		assert.Error(t, err)
@@ -307,14 +360,59 @@ func TestClient_RuntimeOperationStatus(t *testing.T) {
 	})
 }
 
+func TestClient_RuntimeStatuses(t *testing.T) {
+	t.Run("should return runtime statuses", func(t *testing.T) {
+		// Given
+		tr := &testResolver{t: t, runtime: &testRuntime{}}
+		testServer := fixHTTPServer(tr)
+		defer testServer.Close()
+
+		client := NewProvisionerClient(testServer.URL, false)
+		_, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		assert.NoError(t, err)
+
+		// When
+		statuses, err := client.RuntimeStatuses(context.Background(), testAccountID, []string{"unknown", provisionRuntimeID})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Len(t, statuses, 1)
+		status := statuses[provisionRuntimeID]
+		assert.Equal(t, schema.RuntimeAgentConnectionStatusConnected, status.RuntimeConnectionStatus.Status)
+		assert.Equal(t, ptr.String("test"), status.RuntimeConfiguration.ClusterConfig.Name)
+		assert.Equal(t, ptr.String("1.18.12"), status.RuntimeConfiguration.ClusterConfig.KubernetesVersion)
+		assert.Equal(t, ptr.String("1.17.0"), status.RuntimeConfiguration.KymaConfig.Version)
+		assert.Equal(t, &schema.GCPProviderConfig{Zones: []string{"europe-west3-b"}}, status.RuntimeConfiguration.ClusterConfig.ProviderSpecificConfig)
+		assert.Nil(t, status.RuntimeConfiguration.Kubeconfig)
+	})
+
+	t.Run("provisioner should return error", func(t *testing.T) {
+		// Given
+		tr := &testResolver{t: t, runtime: &testRuntime{}, failed: true}
+		testServer := fixHTTPServer(tr)
+		defer testServer.Close()
+
+		client := NewProvisionerClient(testServer.URL, false)
+
+		// When
+		statuses, err := client.RuntimeStatuses(context.Background(), testAccountID, []string{provisionRuntimeID})
+
+		// Then
+		assert.Error(t, err)
+		assert.Empty(t, statuses)
+	})
+}
+
 type testRuntime struct {
-	tenant                 string
-	clientID               string
-	name                   string
-	runtimeID              string
-	provisionOperationID   string
-	upgradeOperationID     string
-	deprovisionOperationID string
+	tenant                  string
+	clientID                string
+	name                    string
+	runtimeID               string
+	provisionOperationID    string
+	upgradeOperationID      string
+	upgradeShootOperationID string
+	kubernetesVersion       string
+	deprovisionOperationID  string
 }
 
 type testResolver struct {
@@ -454,8 +552,24 @@ func (tmr testMutationResolver) ReconnectRuntimeAgent(_ context.Context, id stri
 	return "", nil
 }
 
-func (tqr testMutationResolver) UpgradeShoot(ctx context.Context, id string, config schema.UpgradeShootInput) (*schema.OperationStatus, error) {
-	return nil, nil
+func (tmr testMutationResolver) UpgradeShoot(_ context.Context, id string, config schema.UpgradeShootInput) (*schema.OperationStatus, error) {
+	tmr.t.Log("UpgradeShoot testMutationResolver")
+
+	if tmr.failed {
+		return nil, fmt.Errorf("upgrade shoot failed for %s", id)
+	}
+
+	if tmr.runtime.runtimeID == id {
+		tmr.runtime.upgradeShootOperationID = upgradeShootOperationID
+		tmr.runtime.kubernetesVersion = *config.GardenerConfig.KubernetesVersion
+	}
+
+	return &schema.OperationStatus{
+		ID:        ptr.String(tmr.runtime.upgradeShootOperationID),
+		State:     schema.OperationStateInProgress,
+		Operation: schema.OperationTypeUpgradeShoot,
+		RuntimeID: ptr.String(tmr.runtime.runtimeID),
+	}, nil
 }
 
 type testQueryResolver struct {
@@ -465,6 +579,30 @@ type testQueryResolver struct {
 }
 
 func (tqr testQueryResolver) RuntimeStatus(_ context.Context, id string) (*schema.RuntimeStatus, error) {
+	tqr.t.Log("RuntimeStatus - testQueryResolver")
+
+	if tqr.failed {
+		return nil, fmt.Errorf("query about runtime status failed for %s", id)
+	}
+
+	if tqr.runtime.runtimeID == id {
+		return &schema.RuntimeStatus{
+			RuntimeConnectionStatus: &schema.RuntimeConnectionStatus{Status: schema.RuntimeAgentConnectionStatusConnected},
+			RuntimeConfiguration: &schema.RuntimeConfig{
+				ClusterConfig: &schema.GardenerConfig{
+					Name:              ptr.String(tqr.runtime.name),
+					KubernetesVersion: ptr.String("1.18.12"),
+					Provider:          ptr.String("gcp"),
+					ProviderSpecificConfig: &schema.GCPProviderConfig{
+						Zones: []string{"europe-west3-b"},
+					},
+				},
+				KymaConfig: &schema.KymaConfig{Version: ptr.String("1.17.0")},
+				Kubeconfig: ptr.String("kubeconfig"),
+			},
+		}, nil
+	}
+
 	return nil, nil
 }
 
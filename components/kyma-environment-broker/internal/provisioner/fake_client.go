@@ -1,6 +1,7 @@
 package provisioner
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -13,17 +14,21 @@ type runtime struct {
 }
 
 type FakeClient struct {
-	mu         sync.Mutex
-	runtimes   []runtime
-	upgrades   map[string]schema.UpgradeRuntimeInput
-	operations map[string]schema.OperationStatus
+	mu            sync.Mutex
+	runtimes      []runtime
+	upgrades      map[string]schema.UpgradeRuntimeInput
+	shootUpgrades map[string]schema.UpgradeShootInput
+	operations    map[string]schema.OperationStatus
+	statuses      map[string]schema.RuntimeStatus
 }
 
 func NewFakeClient() *FakeClient {
 	return &FakeClient{
-		runtimes:   []runtime{},
-		operations: make(map[string]schema.OperationStatus),
-		upgrades:   make(map[string]schema.UpgradeRuntimeInput),
+		runtimes:      []runtime{},
+		operations:    make(map[string]schema.OperationStatus),
+		upgrades:      make(map[string]schema.UpgradeRuntimeInput),
+		shootUpgrades: make(map[string]schema.UpgradeShootInput),
+		statuses:      make(map[string]schema.RuntimeStatus),
 	}
 }
 
@@ -63,9 +68,16 @@ func (c *FakeClient) SetOperation(id string, operation schema.OperationStatus) {
 	c.operations[id] = operation
 }
 
+func (c *FakeClient) SetRuntimeStatus(runtimeID string, status schema.RuntimeStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.statuses[runtimeID] = status
+}
+
 // Provisioner Client methods
 
-func (c *FakeClient) ProvisionRuntime(accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error) {
+func (c *FakeClient) ProvisionRuntime(ctx context.Context, accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -86,15 +98,15 @@ func (c *FakeClient) ProvisionRuntime(accountID, subAccountID string, config sch
 	}, nil
 }
 
-func (c *FakeClient) DeprovisionRuntime(accountID, runtimeID string) (string, error) {
+func (c *FakeClient) DeprovisionRuntime(ctx context.Context, accountID, runtimeID string) (string, error) {
 	return uuid.New().String(), nil
 }
 
-func (c *FakeClient) ReconnectRuntimeAgent(accountID, runtimeID string) (string, error) {
+func (c *FakeClient) ReconnectRuntimeAgent(ctx context.Context, accountID, runtimeID string) (string, error) {
 	return "", fmt.Errorf("not implemented")
 }
 
-func (c *FakeClient) RuntimeOperationStatus(accountID, operationID string) (schema.OperationStatus, error) {
+func (c *FakeClient) RuntimeOperationStatus(ctx context.Context, accountID, operationID string) (schema.OperationStatus, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -105,7 +117,20 @@ func (c *FakeClient) RuntimeOperationStatus(accountID, operationID string) (sche
 	return o, nil
 }
 
-func (c *FakeClient) UpgradeRuntime(accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error) {
+func (c *FakeClient) RuntimeStatuses(ctx context.Context, accountID string, runtimeIDs []string) (map[string]schema.RuntimeStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	statuses := make(map[string]schema.RuntimeStatus)
+	for _, runtimeID := range runtimeIDs {
+		if status, found := c.statuses[runtimeID]; found {
+			statuses[runtimeID] = status
+		}
+	}
+	return statuses, nil
+}
+
+func (c *FakeClient) UpgradeRuntime(ctx context.Context, accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -123,6 +148,29 @@ func (c *FakeClient) UpgradeRuntime(accountID, runtimeID string, config schema.U
 	}, nil
 }
 
+func (c *FakeClient) UpgradeShoot(ctx context.Context, accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	opId := uuid.New().String()
+	c.operations[opId] = schema.OperationStatus{
+		ID:        &opId,
+		RuntimeID: &runtimeID,
+		Operation: schema.OperationTypeUpgradeShoot,
+		State:     schema.OperationStateInProgress,
+	}
+	c.shootUpgrades[runtimeID] = config
+	return schema.OperationStatus{
+		RuntimeID: &runtimeID,
+		ID:        &opId,
+	}, nil
+}
+
+func (c *FakeClient) IsShootUpgraded(runtimeID string) bool {
+	_, found := c.shootUpgrades[runtimeID]
+	return found
+}
+
 func (c *FakeClient) IsRuntimeUpgraded(runtimeID string) bool {
 	_, found := c.upgrades[runtimeID]
 	return found
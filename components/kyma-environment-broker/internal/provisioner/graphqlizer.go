@@ -206,6 +206,24 @@ func (g *Graphqlizer) UpgradeRuntimeInputToGraphQL(in gqlschema.UpgradeRuntimeIn
 	}`)
 }
 
+func (g *Graphqlizer) UpgradeShootInputToGraphQL(in gqlschema.UpgradeShootInput) (string, error) {
+	return g.genericToGraphQL(in, `{
+		gardenerConfig: {
+			{{- with .GardenerConfig }}
+			{{- if .KubernetesVersion }}
+			kubernetesVersion: "{{ .KubernetesVersion }}",
+			{{- end }}
+			{{- if .MachineImage }}
+			machineImage: "{{ .MachineImage }}",
+			{{- end }}
+			{{- if .MachineImageVersion }}
+			machineImageVersion: "{{ .MachineImageVersion }}",
+			{{- end }}
+			{{- end }}
+		}
+	}`)
+}
+
 func (g *Graphqlizer) genericToGraphQL(obj interface{}, tmpl string) (string, error) {
 	fm := sprig.TxtFuncMap()
 	fm["marshal"] = g.marshal
@@ -1,6 +1,9 @@
 package provisioner
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type queryProvider struct{}
 
@@ -20,6 +23,14 @@ func (qp queryProvider) upgradeRuntime(runtimeID string, config string) string {
 }`, runtimeID, config, operationStatusData())
 }
 
+func (qp queryProvider) upgradeShoot(runtimeID string, config string) string {
+	return fmt.Sprintf(`mutation {
+	result: upgradeShoot(id: "%s", config: %s) {
+		%s
+}
+}`, runtimeID, config, operationStatusData())
+}
+
 func (qp queryProvider) deprovisionRuntime(runtimeID string) string {
 	return fmt.Sprintf(`mutation {
 	result: deprovisionRuntime(id: "%s")
@@ -40,6 +51,23 @@ func (qp queryProvider) runtimeStatus(operationID string) string {
 }`, operationID, runtimeStatusData())
 }
 
+// runtimeStatuses queries the statuses of many runtimes at once, each under the alias returned by runtimeStatusAlias
+func (qp queryProvider) runtimeStatuses(runtimeIDs []string) string {
+	var queries strings.Builder
+	for i, runtimeID := range runtimeIDs {
+		fmt.Fprintf(&queries, `
+	%s: runtimeStatus(id: "%s") {
+	%s
+	}`, runtimeStatusAlias(i), runtimeID, runtimeStatusSummaryData())
+	}
+	return fmt.Sprintf(`query {%s
+}`, queries.String())
+}
+
+func runtimeStatusAlias(index int) string {
+	return fmt.Sprintf("runtime%d", index)
+}
+
 func (qp queryProvider) runtimeOperationStatus(operationID string) string {
 	return fmt.Sprintf(`query {
 	result: runtimeOperationStatus(id: "%s") {
@@ -60,6 +88,17 @@ func runtimeStatusData() string {
 			}`, clusterConfig())
 }
 
+// runtimeStatusSummaryData skips the kubeconfig
+func runtimeStatusSummaryData() string {
+	return fmt.Sprintf(`runtimeConnectionStatus { status }
+			runtimeConfiguration {
+				clusterConfig {
+					%s
+				}
+				kymaConfig { version }
+			}`, clusterConfig())
+}
+
 func clusterConfig() string {
 	return fmt.Sprintf(`
 		name 
@@ -86,10 +125,11 @@ func clusterConfig() string {
 func providerSpecificConfig() string {
 	return fmt.Sprint(`
 		... on GCPProviderConfig { 
-			zone 
+			zones 
 		} 
 		... on AzureProviderConfig {
 			vnetCidr
+			zones
 		}
 		... on AWSProviderConfig {
 			zone 
@@ -0,0 +1,141 @@
+package retention
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type Config struct {
+	Enabled bool `envconfig:"default=false"`
+	// DryRun only reports the number of records which would be archived or purged, without changing them
+	DryRun   bool          `envconfig:"default=false"`
+	Interval time.Duration `envconfig:"default=24h"`
+	// BatchSize is the number of records archived or purged in one database query
+	BatchSize int `envconfig:"default=100"`
+
+	// OperationsMaxAge is the time after the last update after which the finished operations are archived
+	OperationsMaxAge time.Duration `envconfig:"default=2160h"`
+	// RuntimeStatesMaxAge is the age after which the runtime states are archived, the latest state of each runtime is always kept
+	RuntimeStatesMaxAge time.Duration `envconfig:"default=2160h"`
+	// PersonalDataGracePeriod is the time after the deprovisioning after which the personal data of the instance is purged
+	PersonalDataGracePeriod time.Duration `envconfig:"default=720h"`
+}
+
+// Report holds the number of the archived and purged records, or the number of the records to archive and purge in the dry run mode
+type Report struct {
+	DryRun          bool
+	Operations      int
+	RuntimeStates   int
+	PurgedInstances int
+}
+
+// Job archives the old operations and runtime states, and purges the personal data of the deprovisioned instances
+type Job struct {
+	storage storage.Retention
+	cfg     Config
+	log     logrus.FieldLogger
+	now     func() time.Time
+}
+
+func NewJob(storage storage.Retention, cfg Config, log logrus.FieldLogger) *Job {
+	return &Job{
+		storage: storage,
+		cfg:     cfg,
+		log:     log,
+		now:     time.Now,
+	}
+}
+
+// Run executes the job every interval until the stop channel is closed
+func (j *Job) Run(stop <-chan struct{}) {
+	go wait.Until(func() {
+		report, err := j.Execute()
+		if err != nil {
+			j.log.Errorf("while applying the retention policy: %v", err)
+		}
+		j.logReport(report)
+	}, j.cfg.Interval, stop)
+}
+
+// Execute archives and purges the records, or only counts them in the dry run mode. On error it returns the report
+// of the records processed so far.
+func (j *Job) Execute() (Report, error) {
+	now := j.now()
+	report := Report{DryRun: j.cfg.DryRun}
+
+	steps := []struct {
+		name    string
+		count   func() (int, error)
+		process func(limit int) (int, error)
+		result  *int
+	}{
+		{
+			name:  "archiving operations",
+			count: func() (int, error) { return j.storage.CountOperationsToArchive(now.Add(-j.cfg.OperationsMaxAge)) },
+			process: func(limit int) (int, error) {
+				return j.storage.ArchiveOperations(now.Add(-j.cfg.OperationsMaxAge), limit)
+			},
+			result: &report.Operations,
+		},
+		{
+			name:  "archiving runtime states",
+			count: func() (int, error) { return j.storage.CountRuntimeStatesToArchive(now.Add(-j.cfg.RuntimeStatesMaxAge)) },
+			process: func(limit int) (int, error) {
+				return j.storage.ArchiveRuntimeStates(now.Add(-j.cfg.RuntimeStatesMaxAge), limit)
+			},
+			result: &report.RuntimeStates,
+		},
+		{
+			name:  "purging instances",
+			count: func() (int, error) { return j.storage.CountInstancesToPurge(now.Add(-j.cfg.PersonalDataGracePeriod)) },
+			process: func(limit int) (int, error) {
+				return j.storage.PurgeInstances(now.Add(-j.cfg.PersonalDataGracePeriod), limit)
+			},
+			result: &report.PurgedInstances,
+		},
+	}
+
+	for _, step := range steps {
+		var err error
+		if j.cfg.DryRun {
+			*step.result, err = step.count()
+		} else {
+			*step.result, err = j.inBatches(step.process)
+		}
+		if err != nil {
+			return report, errors.Wrapf(err, "while %s", step.name)
+		}
+	}
+
+	return report, nil
+}
+
+// inBatches processes the records until the last batch is not full
+func (j *Job) inBatches(process func(limit int) (int, error)) (int, error) {
+	total := 0
+	for {
+		count, err := process(j.cfg.BatchSize)
+		total += count
+		if err != nil {
+			return total, err
+		}
+		if count == 0 || count < j.cfg.BatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (j *Job) logReport(report Report) {
+	if report.DryRun {
+		j.log.Infof("Retention dry run: %d operations and %d runtime states to archive, personal data of %d instances to purge",
+			report.Operations, report.RuntimeStates, report.PurgedInstances)
+		return
+	}
+	j.log.Infof("Retention: archived %d operations and %d runtime states, purged personal data of %d instances",
+		report.Operations, report.RuntimeStates, report.PurgedInstances)
+}
@@ -0,0 +1,129 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob_Execute(t *testing.T) {
+	now := time.Date(2020, 12, 8, 10, 0, 0, 0, time.UTC)
+	cfg := Config{
+		BatchSize:               2,
+		OperationsMaxAge:        90 * 24 * time.Hour,
+		RuntimeStatesMaxAge:     30 * 24 * time.Hour,
+		PersonalDataGracePeriod: 7 * 24 * time.Hour,
+	}
+
+	t.Run("should archive and purge records in batches", func(t *testing.T) {
+		// given
+		st := &fakeStorage{operations: 5, runtimeStates: 2, instances: 1}
+		job := fixJob(st, cfg, now)
+
+		// when
+		report, err := job.Execute()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Report{Operations: 5, RuntimeStates: 2, PurgedInstances: 1}, report)
+		assert.Zero(t, st.operations)
+		assert.Zero(t, st.runtimeStates)
+		assert.Zero(t, st.instances)
+		assert.Equal(t, now.Add(-cfg.OperationsMaxAge), st.operationsBefore)
+		assert.Equal(t, now.Add(-cfg.RuntimeStatesMaxAge), st.runtimeStatesBefore)
+		assert.Equal(t, now.Add(-cfg.PersonalDataGracePeriod), st.deprovisionedBefore)
+	})
+
+	t.Run("should only count records in dry run", func(t *testing.T) {
+		// given
+		st := &fakeStorage{operations: 5, runtimeStates: 2, instances: 1}
+		dryRunCfg := cfg
+		dryRunCfg.DryRun = true
+		job := fixJob(st, dryRunCfg, now)
+
+		// when
+		report, err := job.Execute()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Report{DryRun: true, Operations: 5, RuntimeStates: 2, PurgedInstances: 1}, report)
+		assert.Equal(t, 5, st.operations)
+		assert.Equal(t, 2, st.runtimeStates)
+		assert.Equal(t, 1, st.instances)
+	})
+
+	t.Run("should return report of processed records on error", func(t *testing.T) {
+		// given
+		st := &fakeStorage{operations: 3, runtimeStates: 2, instances: 1, runtimeStatesErr: errors.New("connection refused")}
+		job := fixJob(st, cfg, now)
+
+		// when
+		report, err := job.Execute()
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, Report{Operations: 3}, report)
+		assert.Equal(t, 1, st.instances)
+	})
+}
+
+func fixJob(st *fakeStorage, cfg Config, now time.Time) *Job {
+	job := NewJob(st, cfg, logrus.New())
+	job.now = func() time.Time { return now }
+	return job
+}
+
+// fakeStorage holds the number of records to archive or purge
+type fakeStorage struct {
+	operations    int
+	runtimeStates int
+	instances     int
+
+	operationsBefore    time.Time
+	runtimeStatesBefore time.Time
+	deprovisionedBefore time.Time
+
+	runtimeStatesErr error
+}
+
+func (s *fakeStorage) CountOperationsToArchive(before time.Time) (int, error) {
+	return s.operations, nil
+}
+
+func (s *fakeStorage) ArchiveOperations(before time.Time, limit int) (int, error) {
+	s.operationsBefore = before
+	return take(&s.operations, limit), nil
+}
+
+func (s *fakeStorage) CountRuntimeStatesToArchive(before time.Time) (int, error) {
+	return s.runtimeStates, nil
+}
+
+func (s *fakeStorage) ArchiveRuntimeStates(before time.Time, limit int) (int, error) {
+	if s.runtimeStatesErr != nil {
+		return 0, s.runtimeStatesErr
+	}
+	s.runtimeStatesBefore = before
+	return take(&s.runtimeStates, limit), nil
+}
+
+func (s *fakeStorage) CountInstancesToPurge(deprovisionedBefore time.Time) (int, error) {
+	return s.instances, nil
+}
+
+func (s *fakeStorage) PurgeInstances(deprovisionedBefore time.Time, limit int) (int, error) {
+	s.deprovisionedBefore = deprovisionedBefore
+	return take(&s.instances, limit), nil
+}
+
+func take(records *int, limit int) int {
+	if *records < limit {
+		limit = *records
+	}
+	*records -= limit
+	return limit
+}
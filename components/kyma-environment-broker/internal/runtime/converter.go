@@ -5,6 +5,7 @@ import (
 
 	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/pkg/errors"
 )
 
@@ -13,6 +14,8 @@ type Converter interface {
 	ApplyProvisioningOperation(dto *pkg.RuntimeDTO, pOpr *internal.ProvisioningOperation)
 	ApplyDeprovisioningOperation(dto *pkg.RuntimeDTO, dOpr *internal.DeprovisioningOperation)
 	ApplyUpgradingKymaOperations(dto *pkg.RuntimeDTO, oprs []internal.UpgradeKymaOperation, totalCount int)
+	NewRuntimeStateDTO(state internal.RuntimeState) pkg.RuntimeStateDTO
+	NewProvisionerStatus(status gqlschema.RuntimeStatus) pkg.ProvisionerStatus
 }
 
 type converter struct {
@@ -75,6 +78,7 @@ func (c *converter) NewDTO(instance internal.Instance) (pkg.RuntimeDTO, error) {
 		ServicePlanID:    instance.ServicePlanID,
 		ServicePlanName:  instance.ServicePlanName,
 		ProviderRegion:   instance.ProviderRegion,
+		Labels:           instance.Labels,
 		Status: pkg.RuntimeStatus{
 			CreatedAt:    instance.CreatedAt,
 			ModifiedAt:   instance.UpdatedAt,
@@ -105,3 +109,54 @@ func (c *converter) ApplyUpgradingKymaOperations(dto *pkg.RuntimeDTO, oprs []int
 		dto.Status.UpgradingKyma.Data = append(dto.Status.UpgradingKyma.Data, op)
 	}
 }
+
+// NewRuntimeStateDTO returns the runtime state with the values of secret overrides masked
+func (c *converter) NewRuntimeStateDTO(state internal.RuntimeState) pkg.RuntimeStateDTO {
+	kymaConfig := state.KymaConfig
+	kymaConfig.Configuration = maskSecrets(kymaConfig.Configuration)
+	kymaConfig.Components = make([]*gqlschema.ComponentConfigurationInput, 0, len(state.KymaConfig.Components))
+	for _, component := range state.KymaConfig.Components {
+		if component == nil {
+			continue
+		}
+		masked := *component
+		masked.Configuration = maskSecrets(component.Configuration)
+		kymaConfig.Components = append(kymaConfig.Components, &masked)
+	}
+
+	return pkg.RuntimeStateDTO{
+		ID:            state.ID,
+		RuntimeID:     state.RuntimeID,
+		OperationID:   state.OperationID,
+		CreatedAt:     state.CreatedAt,
+		KymaConfig:    kymaConfig,
+		ClusterConfig: state.ClusterConfig,
+	}
+}
+
+func maskSecrets(entries []*gqlschema.ConfigEntryInput) []*gqlschema.ConfigEntryInput {
+	result := make([]*gqlschema.ConfigEntryInput, 0, len(entries))
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		masked := *entry
+		masked.Value = overrideValue(entry)
+		result = append(result, &masked)
+	}
+	return result
+}
+
+func (c *converter) NewProvisionerStatus(status gqlschema.RuntimeStatus) pkg.ProvisionerStatus {
+	var result pkg.ProvisionerStatus
+	if status.RuntimeConnectionStatus != nil {
+		result.RuntimeConnectionStatus = string(status.RuntimeConnectionStatus.Status)
+	}
+	if cfg := status.RuntimeConfiguration; cfg != nil {
+		result.GardenerConfig = cfg.ClusterConfig
+		if cfg.KymaConfig != nil && cfg.KymaConfig.Version != nil {
+			result.KymaVersion = *cfg.KymaConfig.Version
+		}
+	}
+	return result
+}
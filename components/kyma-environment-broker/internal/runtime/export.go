@@ -0,0 +1,241 @@
+package runtime
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/predicate"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var csvExportHeader = []string{
+	"instanceID", "runtimeID", "globalAccountID", "subAccountID", "region", "subAccountRegion", "shootName", "servicePlanName", "createdAt",
+	"kymaVersion", "lastOperationType", "lastOperationState", "lastOperationDescription", "lastOperationCreatedAt",
+}
+
+// ExportHandler streams the runtimes matching the filters of the runtimes list as CSV or NDJSON.
+// The runtimes are fetched and written in batches, so the whole fleet is never loaded into memory.
+type ExportHandler struct {
+	instancesDb storage.Instances
+	converter   Converter
+	batchSize   int
+	log         logrus.FieldLogger
+}
+
+func NewExportHandler(instancesDb storage.Instances, batchSize int, defaultRequestRegion string, log logrus.FieldLogger) *ExportHandler {
+	return &ExportHandler{
+		instancesDb: instancesDb,
+		converter:   NewConverter(defaultRequestRegion),
+		batchSize:   batchSize,
+		log:         log,
+	}
+}
+
+func (h *ExportHandler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/runtimes/export", h.exportRuntimes).Methods(http.MethodGet)
+}
+
+type exportWriter interface {
+	Write(record pkg.RuntimeExportRecord) error
+	// Flush writes the buffered records and returns the errors of the previous writes
+	Flush() error
+}
+
+func (h *ExportHandler) exportRuntimes(w http.ResponseWriter, req *http.Request) {
+	filter, err := getFilters(req)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while getting query parameters"))
+		return
+	}
+
+	var (
+		contentType string
+		newWriter   func(io.Writer) exportWriter
+	)
+	switch format := pkg.ExportFormat(req.URL.Query().Get(pkg.FormatParam)); format {
+	case "", pkg.ExportFormatCSV:
+		contentType, newWriter = "text/csv", newCSVExportWriter
+	case pkg.ExportFormatNDJSON:
+		contentType, newWriter = "application/x-ndjson", newNDJSONExportWriter
+	default:
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Errorf("unsupported %s value %q", pkg.FormatParam, format))
+		return
+	}
+
+	// the first batch is fetched before the response is started to return its errors with the proper status
+	filter.Page = 1
+	filter.PageSize = h.batchSize
+	records, err := h.nextBatch(&filter)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	ew := newWriter(w)
+	flusher, _ := w.(http.Flusher)
+	for {
+		for _, record := range records {
+			if err := ew.Write(record); err != nil {
+				h.log.Warnf("while writing runtimes export: %s", err)
+				return
+			}
+		}
+		if err := ew.Flush(); err != nil {
+			h.log.Warnf("while writing runtimes export: %s", err)
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if len(records) == 0 || len(records) < h.batchSize {
+			return
+		}
+
+		records, err = h.nextBatch(&filter)
+		if err != nil {
+			// the status was already sent, aborting the response lets the client detect the incomplete export
+			h.log.Errorf("while exporting runtimes: %s", err)
+			panic(http.ErrAbortHandler)
+		}
+	}
+}
+
+// nextBatch fetches the runtimes following the cursor of the filter and moves the cursor to the last of them
+func (h *ExportHandler) nextBatch(filter *dbmodel.InstanceFilter) ([]pkg.RuntimeExportRecord, error) {
+	instances, _, _, err := h.instancesDb.List(*filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "while fetching instances")
+	}
+	if len(instances) == 0 {
+		return nil, nil
+	}
+	last := instances[len(instances)-1]
+	if filter.SortBy == dbmodel.InstanceSortByUpdatedAt {
+		filter.Cursor = pagination.NewCursor(last.UpdatedAt, last.InstanceID)
+	} else {
+		filter.Cursor = pagination.NewCursor(last.CreatedAt, last.InstanceID)
+	}
+
+	ids := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		ids = append(ids, instance.InstanceID)
+	}
+	rows, err := h.instancesDb.FindAllJoinedWithOperations(predicate.WithInstanceIDs(ids...))
+	if err != nil {
+		return nil, errors.Wrap(err, "while fetching operations of instances")
+	}
+	operations := make(map[string][]internal.InstanceWithOperation, len(instances))
+	for _, row := range rows {
+		operations[row.InstanceID] = append(operations[row.InstanceID], row)
+	}
+
+	records := make([]pkg.RuntimeExportRecord, 0, len(instances))
+	for _, instance := range instances {
+		record, err := h.newRecord(instance, operations[instance.InstanceID])
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (h *ExportHandler) newRecord(instance internal.Instance, operations []internal.InstanceWithOperation) (pkg.RuntimeExportRecord, error) {
+	dto, err := h.converter.NewDTO(instance)
+	if err != nil {
+		return pkg.RuntimeExportRecord{}, errors.Wrapf(err, "while converting instance %s", instance.InstanceID)
+	}
+	record := pkg.RuntimeExportRecord{
+		InstanceID:       dto.InstanceID,
+		RuntimeID:        dto.RuntimeID,
+		GlobalAccountID:  dto.GlobalAccountID,
+		SubAccountID:     dto.SubAccountID,
+		ProviderRegion:   dto.ProviderRegion,
+		SubAccountRegion: dto.SubAccountRegion,
+		ShootName:        dto.ShootName,
+		ServicePlanName:  dto.ServicePlanName,
+		CreatedAt:        dto.Status.CreatedAt,
+	}
+
+	var kymaVersionAt time.Time
+	for _, op := range operations {
+		if !op.OperationCreatedAt.Valid {
+			continue
+		}
+		createdAt := op.OperationCreatedAt.Time
+		if op.KymaVersion.Valid && createdAt.After(kymaVersionAt) {
+			record.KymaVersion, kymaVersionAt = op.KymaVersion.String, createdAt
+		}
+		if record.LastOperation == nil || createdAt.After(record.LastOperation.CreatedAt) {
+			record.LastOperation = &pkg.ExportedOperation{
+				Type:        op.Type.String,
+				State:       op.State.String,
+				Description: op.Description.String,
+				CreatedAt:   createdAt,
+			}
+		}
+	}
+
+	return record, nil
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+// newCSVExportWriter returns the writer which starts the output with the header row
+func newCSVExportWriter(w io.Writer) exportWriter {
+	cw := csv.NewWriter(w)
+	// the error is buffered and returned by Flush
+	_ = cw.Write(csvExportHeader)
+	return &csvExportWriter{w: cw}
+}
+
+func (e *csvExportWriter) Write(record pkg.RuntimeExportRecord) error {
+	var opType, opState, opDescription, opCreatedAt string
+	if op := record.LastOperation; op != nil {
+		opType, opState, opDescription, opCreatedAt = op.Type, op.State, op.Description, op.CreatedAt.Format(time.RFC3339)
+	}
+	return e.w.Write([]string{
+		record.InstanceID, record.RuntimeID, record.GlobalAccountID, record.SubAccountID, record.ProviderRegion, record.SubAccountRegion,
+		record.ShootName, record.ServicePlanName, record.CreatedAt.Format(time.RFC3339),
+		record.KymaVersion, opType, opState, opDescription, opCreatedAt,
+	})
+}
+
+func (e *csvExportWriter) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONExportWriter(w io.Writer) exportWriter {
+	return &ndjsonExportWriter{encoder: json.NewEncoder(w)}
+}
+
+// Write encodes the record as a single line
+func (e *ndjsonExportWriter) Write(record pkg.RuntimeExportRecord) error {
+	return e.encoder.Encode(record)
+}
+
+func (e *ndjsonExportWriter) Flush() error {
+	return nil
+}
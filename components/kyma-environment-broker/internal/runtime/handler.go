@@ -1,41 +1,59 @@
 package runtime
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const numberOfUpgradeOperationsToReturn = 2
 
 type Handler struct {
-	instancesDb  storage.Instances
-	operationsDb storage.Operations
-	converter    Converter
+	instancesDb     storage.Instances
+	operationsDb    storage.Operations
+	runtimeStatesDb storage.RuntimeStates
+	converter       Converter
+	statusFetcher   *ProvisionerStatusFetcher
 
 	defaultMaxPage int
 }
 
-func NewHandler(instanceDb storage.Instances, operationDb storage.Operations, defaultMaxPage int, defaultRequestRegion string) *Handler {
+func NewHandler(instanceDb storage.Instances, operationDb storage.Operations, runtimeStatesDb storage.RuntimeStates, provisionerClient provisioner.Client, provisionerStatusTTL time.Duration, defaultMaxPage int, defaultRequestRegion string) *Handler {
+	converter := NewConverter(defaultRequestRegion)
 	return &Handler{
-		instancesDb:    instanceDb,
-		operationsDb:   operationDb,
-		converter:      NewConverter(defaultRequestRegion),
-		defaultMaxPage: defaultMaxPage,
+		instancesDb:     instanceDb,
+		operationsDb:    operationDb,
+		runtimeStatesDb: runtimeStatesDb,
+		converter:       converter,
+		statusFetcher:   NewProvisionerStatusFetcher(provisionerClient, converter, provisionerStatusTTL),
+		defaultMaxPage:  defaultMaxPage,
 	}
 }
 
 func (h *Handler) AttachRoutes(router *mux.Router) {
 	router.HandleFunc("/runtimes", h.getRuntimes)
+	router.HandleFunc("/runtimes/{runtime_id}/states", h.getRuntimeStates).Methods(http.MethodGet)
+	router.HandleFunc("/runtimes/{runtime_id}/states/diff", h.getRuntimeStatesDiff).Methods(http.MethodGet)
+	router.HandleFunc("/runtimes/{runtime_id}/labels", h.getLabels).Methods(http.MethodGet)
+	router.HandleFunc("/runtimes/{runtime_id}/labels", h.setLabels).Methods(http.MethodPut)
+	// label keys can contain the prefix separated with a slash
+	router.HandleFunc("/runtimes/{runtime_id}/labels/{key:.+}", h.deleteLabel).Methods(http.MethodDelete)
 }
 
 func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
@@ -46,15 +64,47 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while getting query parameters"))
 		return
 	}
-	filter := h.getFilters(req)
+	cursor, err := pagination.ExtractCursorFromRequest(req)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while getting query parameters"))
+		return
+	}
+	filter, err := getFilters(req)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while getting query parameters"))
+		return
+	}
+	expandProvisioner, err := getExpandProvisioner(req)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while getting query parameters"))
+		return
+	}
 	filter.PageSize = pageSize
 	filter.Page = page
+	if cursor != nil {
+		// fetch one more instance to find out if the next page exists
+		filter.Cursor = cursor
+		filter.PageSize = pageSize + 1
+	}
 
 	instances, count, totalCount, err := h.instancesDb.List(filter)
 	if err != nil {
 		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while fetching instances"))
 		return
 	}
+	var nextCursor string
+	if pagination.HasNextPage(cursor, pageSize, page, count, totalCount) {
+		if len(instances) > pageSize {
+			instances = instances[:pageSize]
+			count = pageSize
+		}
+		last := instances[count-1]
+		if filter.SortBy == dbmodel.InstanceSortByUpdatedAt {
+			nextCursor = pagination.NewCursor(last.UpdatedAt, last.InstanceID).Encode()
+		} else {
+			nextCursor = pagination.NewCursor(last.CreatedAt, last.InstanceID).Encode()
+		}
+	}
 
 	for _, instance := range instances {
 		dto, err := h.converter.NewDTO(instance)
@@ -87,15 +137,193 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 
 		toReturn = append(toReturn, dto)
 	}
+	if expandProvisioner {
+		h.statusFetcher.Apply(toReturn)
+	}
 
 	runtimePage := pkg.RuntimesPage{
 		Data:       toReturn,
 		Count:      count,
 		TotalCount: totalCount,
+		NextCursor: nextCursor,
 	}
 	httputil.WriteResponse(w, http.StatusOK, runtimePage)
 }
 
+func (h *Handler) getRuntimeStates(w http.ResponseWriter, req *http.Request) {
+	runtimeID := mux.Vars(req)["runtime_id"]
+
+	pageSize, page, err := pagination.ExtractPaginationConfigFromRequest(req, h.defaultMaxPage)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while getting query parameters"))
+		return
+	}
+
+	states, ok := h.listRuntimeStates(w, runtimeID)
+	if !ok {
+		return
+	}
+
+	toReturn := make([]pkg.RuntimeStateDTO, 0, pageSize)
+	for i := (page - 1) * pageSize; i < len(states) && len(toReturn) < pageSize; i++ {
+		toReturn = append(toReturn, h.converter.NewRuntimeStateDTO(states[i]))
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, pkg.RuntimeStatesPage{
+		Data:       toReturn,
+		Count:      len(toReturn),
+		TotalCount: len(states),
+	})
+}
+
+func (h *Handler) getRuntimeStatesDiff(w http.ResponseWriter, req *http.Request) {
+	runtimeID := mux.Vars(req)["runtime_id"]
+	query := req.URL.Query()
+
+	states, ok := h.listRuntimeStates(w, runtimeID)
+	if !ok {
+		return
+	}
+	states = WithClusterConfig(states)
+
+	// by default the most recent state is compared with the preceding one
+	to := 0
+	if id := query.Get(pkg.ToStateParam); id != "" {
+		if to = indexOfRuntimeState(states, id); to < 0 {
+			httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Errorf("runtime state %s not found for runtime %s", id, runtimeID))
+			return
+		}
+	}
+	from := to + 1
+	if id := query.Get(pkg.FromStateParam); id != "" {
+		if from = indexOfRuntimeState(states, id); from < 0 {
+			httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Errorf("runtime state %s not found for runtime %s", id, runtimeID))
+			return
+		}
+	}
+	if from >= len(states) {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Errorf("runtime state %s has no preceding state to compare with", states[to].ID))
+		return
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, DiffRuntimeStates(states[from], states[to]))
+}
+
+// listRuntimeStates writes the error response and returns false if the states cannot be listed
+func (h *Handler) listRuntimeStates(w http.ResponseWriter, runtimeID string) ([]internal.RuntimeState, bool) {
+	states, err := h.runtimeStatesDb.ListByRuntimeID(runtimeID)
+	switch {
+	case err != nil && !dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while fetching runtime states"))
+		return nil, false
+	case len(states) == 0:
+		httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Errorf("runtime states not found for runtime %s", runtimeID))
+		return nil, false
+	}
+
+	return states, true
+}
+
+func indexOfRuntimeState(states []internal.RuntimeState, id string) int {
+	for i, state := range states {
+		if state.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (h *Handler) getLabels(w http.ResponseWriter, req *http.Request) {
+	instanceID, ok := h.instanceIDForRuntime(w, mux.Vars(req)["runtime_id"])
+	if !ok {
+		return
+	}
+
+	h.writeLabels(w, instanceID)
+}
+
+func (h *Handler) setLabels(w http.ResponseWriter, req *http.Request) {
+	instanceID, ok := h.instanceIDForRuntime(w, mux.Vars(req)["runtime_id"])
+	if !ok {
+		return
+	}
+
+	var labels map[string]string
+	if err := json.NewDecoder(req.Body).Decode(&labels); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while decoding labels"))
+		return
+	}
+	if err := validateLabels(labels); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.instancesDb.SetLabels(instanceID, labels); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while setting labels"))
+		return
+	}
+
+	h.writeLabels(w, instanceID)
+}
+
+func (h *Handler) deleteLabel(w http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+	instanceID, ok := h.instanceIDForRuntime(w, vars["runtime_id"])
+	if !ok {
+		return
+	}
+
+	if err := h.instancesDb.DeleteLabels(instanceID, []string{vars["key"]}); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while deleting label"))
+		return
+	}
+
+	h.writeLabels(w, instanceID)
+}
+
+func (h *Handler) writeLabels(w http.ResponseWriter, instanceID string) {
+	labels, err := h.instancesDb.GetLabels(instanceID)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while fetching labels"))
+		return
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, labels)
+}
+
+// instanceIDForRuntime writes the error response and returns false if the instance of the runtime cannot be found
+func (h *Handler) instanceIDForRuntime(w http.ResponseWriter, runtimeID string) (string, bool) {
+	instances, err := h.instancesDb.FindAllInstancesForRuntimes([]string{runtimeID})
+	switch {
+	case dberr.IsNotFound(err), err == nil && len(instances) == 0:
+		httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Errorf("instance not found for runtime %s", runtimeID))
+		return "", false
+	case err != nil:
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while fetching instance"))
+		return "", false
+	}
+
+	return instances[0].InstanceID, true
+}
+
+// validateLabels checks if the labels follow the syntax of the Kubernetes labels
+func validateLabels(labels map[string]string) error {
+	var msgs []string
+	for key, value := range labels {
+		for _, msg := range validation.IsQualifiedName(key) {
+			msgs = append(msgs, fmt.Sprintf("label key %q: %s", key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			msgs = append(msgs, fmt.Sprintf("label %q value %q: %s", key, value, msg))
+		}
+	}
+	if len(msgs) > 0 {
+		sort.Strings(msgs)
+		return errors.Errorf("invalid labels: %s", strings.Join(msgs, "; "))
+	}
+
+	return nil
+}
+
 func (h *Handler) takeLastNonDryRunOperations(oprs []internal.UpgradeKymaOperation) ([]internal.UpgradeKymaOperation, int) {
 	toReturn := make([]internal.UpgradeKymaOperation, 0)
 	totalCount := 0
@@ -111,7 +339,18 @@ func (h *Handler) takeLastNonDryRunOperations(oprs []internal.UpgradeKymaOperati
 	return toReturn, totalCount
 }
 
-func (h *Handler) getFilters(req *http.Request) dbmodel.InstanceFilter {
+func getExpandProvisioner(req *http.Request) (bool, error) {
+	expandProvisioner := false
+	for _, expand := range req.URL.Query()[pkg.ExpandParam] {
+		if expand != pkg.ExpandProvisioner {
+			return false, errors.Errorf("unsupported %s value %q", pkg.ExpandParam, expand)
+		}
+		expandProvisioner = true
+	}
+	return expandProvisioner, nil
+}
+
+func getFilters(req *http.Request) (dbmodel.InstanceFilter, error) {
 	var filter dbmodel.InstanceFilter
 	query := req.URL.Query()
 	// For optional filter, zero value (nil) is fine if not supplied
@@ -121,6 +360,33 @@ func (h *Handler) getFilters(req *http.Request) dbmodel.InstanceFilter {
 	filter.RuntimeIDs = query[pkg.RuntimeIDParam]
 	filter.Regions = query[pkg.RegionParam]
 	filter.Domains = query[pkg.ShootParam]
+	filter.Plans = query[pkg.PlanParam]
+	filter.States = query[pkg.StateParam]
+	filter.OperationTypes = query[pkg.OperationTypeParam]
+	filter.KymaVersions = query[pkg.KymaVersionParam]
+	for _, label := range query[pkg.LabelParam] {
+		key, value, err := pkg.ParseLabel(label)
+		if err != nil {
+			return filter, err
+		}
+		if filter.Labels == nil {
+			filter.Labels = make(map[string]string)
+		}
+		filter.Labels[key] = value
+	}
+
+	switch sort := pkg.SortField(query.Get(pkg.SortParam)); sort {
+	case "", pkg.SortByCreatedAt:
+	case pkg.SortByCreatedAtDesc:
+		filter.SortDesc = true
+	case pkg.SortByModifiedAt:
+		filter.SortBy = dbmodel.InstanceSortByUpdatedAt
+	case pkg.SortByModifiedAtDesc:
+		filter.SortBy = dbmodel.InstanceSortByUpdatedAt
+		filter.SortDesc = true
+	default:
+		return filter, errors.Errorf("unsupported %s value %q", pkg.SortParam, sort)
+	}
 
-	return filter
+	return filter, nil
 }
@@ -1,10 +1,12 @@
 package runtime_test
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,7 +15,11 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/memory"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,7 +51,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = instances.Insert(testInstance2)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, memory.NewRuntimeStates(), provisioner.NewFakeClient(), time.Minute, 2, "")
 
 		req, err := http.NewRequest("GET", "/runtimes?page_size=1", nil)
 		require.NoError(t, err)
@@ -95,7 +101,7 @@ func TestRuntimeHandler(t *testing.T) {
 		operations := memory.NewOperation()
 		instances := memory.NewInstance(operations)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, 2, "region")
+		runtimeHandler := runtime.NewHandler(instances, operations, memory.NewRuntimeStates(), provisioner.NewFakeClient(), time.Minute, 2, "region")
 
 		req, err := http.NewRequest("GET", "/runtimes?page_size=a", nil)
 		require.NoError(t, err)
@@ -125,6 +131,67 @@ func TestRuntimeHandler(t *testing.T) {
 		require.Equal(t, http.StatusBadRequest, rr.Code)
 	})
 
+	t.Run("test cursor pagination should work", func(t *testing.T) {
+		// given
+		operations := memory.NewOperation()
+		instances := memory.NewInstance(operations)
+		now := time.Now()
+		for i, id := range []string{"Test1", "Test2", "Test3"} {
+			err := instances.Insert(fixInstance(id, now.Add(time.Duration(i)*time.Minute)))
+			require.NoError(t, err)
+		}
+
+		runtimeHandler := runtime.NewHandler(instances, operations, memory.NewRuntimeStates(), provisioner.NewFakeClient(), time.Minute, 2, "")
+		router := mux.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+		list := func(url string) (int, pkg.RuntimesPage) {
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			var out pkg.RuntimesPage
+			if rr.Code == http.StatusOK {
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+			}
+			return rr.Code, out
+		}
+
+		// when
+		code, out := list("/runtimes?page_size=2")
+
+		// then
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 2, out.Count)
+		assert.Equal(t, "Test2", out.Data[1].InstanceID)
+		require.NotEmpty(t, out.NextCursor)
+
+		// when
+		err := instances.Insert(fixInstance("Test0", now.Add(-time.Minute)))
+		require.NoError(t, err)
+		code, out = list("/runtimes?page_size=2&cursor=" + out.NextCursor)
+
+		// then
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 1, out.Count)
+		assert.Equal(t, "Test3", out.Data[0].InstanceID)
+		assert.Empty(t, out.NextCursor)
+
+		// when
+		code, out = list("/runtimes?page_size=1&sort=-created_at")
+		require.Equal(t, http.StatusOK, code)
+		code, out = list("/runtimes?page_size=1&sort=-created_at&cursor=" + out.NextCursor)
+
+		// then
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "Test2", out.Data[0].InstanceID)
+		assert.NotEmpty(t, out.NextCursor)
+
+		code, _ = list("/runtimes?page=2&cursor=" + out.NextCursor)
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, _ = list("/runtimes?cursor=malformed")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
 	t.Run("test filtering should work", func(t *testing.T) {
 		// given
 		operations := memory.NewOperation()
@@ -141,7 +208,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = instances.Insert(testInstance2)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, memory.NewRuntimeStates(), provisioner.NewFakeClient(), time.Minute, 2, "")
 
 		req, err := http.NewRequest("GET", fmt.Sprintf("/runtimes?account=%s&subaccount=%s&instance_id=%s&runtime_id=%s&region=%s&shoot=%s", testID1, testID1, testID1, testID1, testID1, testID1), nil)
 		require.NoError(t, err)
@@ -165,6 +232,437 @@ func TestRuntimeHandler(t *testing.T) {
 		assert.Equal(t, 1, out.Count)
 		assert.Equal(t, testID1, out.Data[0].InstanceID)
 	})
+
+	t.Run("test filtering by operations and sorting should work", func(t *testing.T) {
+		// given
+		operations := memory.NewOperation()
+		instances := memory.NewInstance(operations)
+		now := time.Now()
+		for i, tc := range []struct {
+			id          string
+			plan        string
+			state       domain.LastOperationState
+			kymaVersion string
+			upgradedTo  string
+		}{
+			{id: "failed-lite", plan: "azure_lite", state: domain.Failed, kymaVersion: "1.16.0"},
+			{id: "succeeded-lite", plan: "azure_lite", state: domain.Succeeded, kymaVersion: "1.16.0"},
+			{id: "upgraded-azure", plan: "azure", state: domain.Succeeded, kymaVersion: "1.16.0", upgradedTo: "1.17.0"},
+		} {
+			instance := fixInstance(tc.id, now.Add(time.Duration(i)*time.Minute))
+			instance.ServicePlanName = tc.plan
+			instance.UpdatedAt = now.Add(-time.Duration(i) * time.Minute)
+			err := instances.Insert(instance)
+			require.NoError(t, err)
+
+			err = operations.InsertProvisioningOperation(internal.ProvisioningOperation{
+				Operation: internal.Operation{
+					ID:         "provisioning-" + tc.id,
+					InstanceID: tc.id,
+					State:      tc.state,
+					CreatedAt:  now,
+				},
+				RuntimeVersion: internal.RuntimeVersionData{Version: tc.kymaVersion},
+			})
+			require.NoError(t, err)
+			if tc.upgradedTo == "" {
+				continue
+			}
+			err = operations.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+				Operation: internal.Operation{
+					ID:         "upgrade-" + tc.id,
+					InstanceID: tc.id,
+					State:      domain.Succeeded,
+					CreatedAt:  now.Add(time.Hour),
+				},
+				RuntimeVersion: internal.RuntimeVersionData{Version: tc.upgradedTo},
+			})
+			require.NoError(t, err)
+		}
+
+		runtimeHandler := runtime.NewHandler(instances, operations, memory.NewRuntimeStates(), provisioner.NewFakeClient(), time.Minute, 10, "")
+		router := mux.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+
+		for name, tc := range map[string]struct {
+			query       string
+			expectedIDs []string
+		}{
+			"failed provisioning of azure_lite": {
+				query:       "plan=azure_lite&state=failed&operation_type=provision",
+				expectedIDs: []string{"failed-lite"},
+			},
+			"last operation is an upgrade": {
+				query:       "operation_type=upgradeKyma",
+				expectedIDs: []string{"upgraded-azure"},
+			},
+			"kyma version": {
+				query:       "kyma_version=1.16.0",
+				expectedIDs: []string{"succeeded-lite"},
+			},
+			"newest first": {
+				query:       "sort=-created_at",
+				expectedIDs: []string{"upgraded-azure", "succeeded-lite", "failed-lite"},
+			},
+			"least recently modified first": {
+				query:       "sort=modified_at",
+				expectedIDs: []string{"upgraded-azure", "succeeded-lite", "failed-lite"},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				req, err := http.NewRequest(http.MethodGet, "/runtimes?"+tc.query, nil)
+				require.NoError(t, err)
+				rr := httptest.NewRecorder()
+
+				// when
+				router.ServeHTTP(rr, req)
+
+				// then
+				require.Equal(t, http.StatusOK, rr.Code)
+				var out pkg.RuntimesPage
+				err = json.Unmarshal(rr.Body.Bytes(), &out)
+				require.NoError(t, err)
+
+				ids := make([]string, 0, len(out.Data))
+				for _, r := range out.Data {
+					ids = append(ids, r.InstanceID)
+				}
+				assert.Equal(t, tc.expectedIDs, ids)
+			})
+		}
+
+		// when
+		req, err := http.NewRequest(http.MethodGet, "/runtimes?sort=name", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("test labels should work", func(t *testing.T) {
+		// given
+		operations := memory.NewOperation()
+		instances := memory.NewInstance(operations)
+		now := time.Now()
+		for _, id := range []string{"Test1", "Test2"} {
+			err := instances.Insert(fixInstance(id, now))
+			require.NoError(t, err)
+		}
+
+		runtimeHandler := runtime.NewHandler(instances, operations, memory.NewRuntimeStates(), provisioner.NewFakeClient(), time.Minute, 10, "")
+		router := mux.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+		call := func(method, path, body string) (int, map[string]string) {
+			req, err := http.NewRequest(method, path, strings.NewReader(body))
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			var labels map[string]string
+			if rr.Code == http.StatusOK {
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &labels))
+			}
+			return rr.Code, labels
+		}
+
+		// when
+		code, labels := call(http.MethodPut, "/runtimes/Test1/labels", `{"customer-pilot": "true", "example.com/tier": "gold"}`)
+
+		// then
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, map[string]string{"customer-pilot": "true", "example.com/tier": "gold"}, labels)
+
+		// when
+		code, labels = call(http.MethodDelete, "/runtimes/Test1/labels/example.com/tier", "")
+
+		// then
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, map[string]string{"customer-pilot": "true"}, labels)
+
+		// when
+		code, labels = call(http.MethodGet, "/runtimes/Test2/labels", "")
+
+		// then
+		require.Equal(t, http.StatusOK, code)
+		assert.Empty(t, labels)
+
+		// when
+		req, err := http.NewRequest(http.MethodGet, "/runtimes?label=customer-pilot=true", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var out pkg.RuntimesPage
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+		require.Equal(t, 1, out.TotalCount)
+		assert.Equal(t, "Test1", out.Data[0].InstanceID)
+		assert.Equal(t, map[string]string{"customer-pilot": "true"}, out.Data[0].Labels)
+
+		code, _ = call(http.MethodPut, "/runtimes/Test1/labels", `{"invalid key": "true"}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, _ = call(http.MethodGet, "/runtimes/unknown/labels", "")
+		assert.Equal(t, http.StatusNotFound, code)
+		code, _ = call(http.MethodGet, "/runtimes?label=customer-pilot", "")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("test provisioner status expand should work", func(t *testing.T) {
+		// given
+		operations := memory.NewOperation()
+		instances := memory.NewInstance(operations)
+		err := instances.Insert(fixInstance("Test1", time.Now()))
+		require.NoError(t, err)
+		err = instances.Insert(fixInstance("Test2", time.Now().Add(time.Minute)))
+		require.NoError(t, err)
+
+		provisionerClient := provisioner.NewFakeClient()
+		provisionerClient.SetRuntimeStatus("Test1", fixRuntimeStatus("1.17.0"))
+
+		runtimeHandler := runtime.NewHandler(instances, operations, memory.NewRuntimeStates(), provisionerClient, time.Minute, 10, "")
+		router := mux.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+		list := func(url string) (int, pkg.RuntimesPage) {
+			req, err := http.NewRequest("GET", url, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			var out pkg.RuntimesPage
+			if rr.Code == http.StatusOK {
+				err = json.Unmarshal(rr.Body.Bytes(), &out)
+				require.NoError(t, err)
+			}
+			return rr.Code, out
+		}
+
+		// when
+		code, out := list("/runtimes?expand=provisioner")
+
+		// then
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, out.Data, 2)
+		require.NotNil(t, out.Data[0].Provisioner)
+		assert.Equal(t, "Connected", out.Data[0].Provisioner.RuntimeConnectionStatus)
+		assert.Equal(t, "1.17.0", out.Data[0].Provisioner.KymaVersion)
+		assert.Equal(t, "1.18.12", *out.Data[0].Provisioner.GardenerConfig.KubernetesVersion)
+		assert.Empty(t, out.Data[0].Provisioner.Error)
+		require.NotNil(t, out.Data[1].Provisioner)
+		assert.NotEmpty(t, out.Data[1].Provisioner.Error)
+
+		// when the status changes within the TTL
+		provisionerClient.SetRuntimeStatus("Test1", fixRuntimeStatus("1.18.0"))
+		code, out = list("/runtimes?expand=provisioner")
+
+		// then the cached status is returned
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "1.17.0", out.Data[0].Provisioner.KymaVersion)
+
+		// when
+		code, out = list("/runtimes")
+
+		// then
+		require.Equal(t, http.StatusOK, code)
+		assert.Nil(t, out.Data[0].Provisioner)
+
+		// when
+		code, _ = list("/runtimes?expand=unknown")
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("test runtime states history and diff should work", func(t *testing.T) {
+		// given
+		operations := memory.NewOperation()
+		instances := memory.NewInstance(operations)
+		states := memory.NewRuntimeStates()
+		now := time.Now()
+
+		provisioning := fixRuntimeState("provisioning", "1.16.0", "1.17")
+		provisioning.CreatedAt = now
+		provisioning.KymaConfig.Configuration = []*gqlschema.ConfigEntryInput{{Key: "password", Value: "secret", Secret: ptrBool(true)}}
+		upgrade := fixRuntimeState("upgrade", "1.17.0", "")
+		upgrade.CreatedAt = now.Add(time.Hour)
+		upgrade.ClusterConfig = gqlschema.GardenerConfigInput{}
+		clusterUpgrade := fixRuntimeState("cluster-upgrade", "1.17.0", "1.18")
+		clusterUpgrade.CreatedAt = now.Add(2 * time.Hour)
+		for _, state := range []internal.RuntimeState{provisioning, upgrade, clusterUpgrade} {
+			err := states.Insert(state)
+			require.NoError(t, err)
+		}
+
+		runtimeHandler := runtime.NewHandler(instances, operations, states, provisioner.NewFakeClient(), time.Minute, 10, "")
+		router := mux.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+
+		get := func(path string) *httptest.ResponseRecorder {
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			return rr
+		}
+
+		// when
+		rr := get("/runtimes/runtime-id/states?page_size=2")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var page pkg.RuntimeStatesPage
+		err := json.Unmarshal(rr.Body.Bytes(), &page)
+		require.NoError(t, err)
+		assert.Equal(t, 3, page.TotalCount)
+		assert.Equal(t, 2, page.Count)
+		assert.Equal(t, "cluster-upgrade", page.Data[0].ID)
+		assert.Equal(t, "upgrade", page.Data[1].ID)
+
+		// when
+		rr = get("/runtimes/runtime-id/states?page=2&page_size=2")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		err = json.Unmarshal(rr.Body.Bytes(), &page)
+		require.NoError(t, err)
+		require.Equal(t, 1, page.Count)
+		assert.Equal(t, "*****", page.Data[0].KymaConfig.Configuration[0].Value)
+
+		// when
+		rr = get("/runtimes/runtime-id/states/diff")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var diff pkg.RuntimeStateDiff
+		err = json.Unmarshal(rr.Body.Bytes(), &diff)
+		require.NoError(t, err)
+		assert.Equal(t, "upgrade", diff.From)
+		assert.Equal(t, "cluster-upgrade", diff.To)
+		assert.Empty(t, diff.Kyma)
+		assert.Equal(t, []pkg.ValueChange{{Name: "kubernetesVersion", Change: pkg.Modified, From: "1.17", To: "1.18"}}, diff.Cluster)
+
+		// when
+		rr = get("/runtimes/runtime-id/states/diff?from=provisioning&to=upgrade")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		diff = pkg.RuntimeStateDiff{}
+		err = json.Unmarshal(rr.Body.Bytes(), &diff)
+		require.NoError(t, err)
+		assert.Equal(t, []pkg.ValueChange{{Name: "version", Change: pkg.Modified, From: "1.16.0", To: "1.17.0"}}, diff.Kyma)
+		assert.Empty(t, diff.Cluster)
+		assert.Equal(t, []pkg.ValueChange{{Name: "password", Change: pkg.Removed, From: "*****"}}, diff.Overrides)
+
+		assert.Equal(t, http.StatusBadRequest, get("/runtimes/runtime-id/states/diff?to=provisioning").Code)
+		assert.Equal(t, http.StatusNotFound, get("/runtimes/runtime-id/states/diff?from=unknown").Code)
+		assert.Equal(t, http.StatusNotFound, get("/runtimes/unknown/states").Code)
+	})
+}
+
+func TestExportHandler(t *testing.T) {
+	// given
+	operations := memory.NewOperation()
+	instances := memory.NewInstance(operations)
+	now := time.Now().UTC().Truncate(time.Second)
+	for i, tc := range []struct {
+		id         string
+		plan       string
+		upgradedTo string
+	}{
+		{id: "lite", plan: "azure_lite"},
+		{id: "upgraded-azure", plan: "azure", upgradedTo: "1.17.0"},
+		{id: "azure", plan: "azure"},
+	} {
+		instance := fixInstance(tc.id, now.Add(time.Duration(i)*time.Minute))
+		instance.ServicePlanName = tc.plan
+		err := instances.Insert(instance)
+		require.NoError(t, err)
+
+		err = operations.InsertProvisioningOperation(internal.ProvisioningOperation{
+			Operation: internal.Operation{
+				ID:          "provisioning-" + tc.id,
+				InstanceID:  tc.id,
+				State:       domain.Succeeded,
+				Description: "provisioned",
+				CreatedAt:   now,
+			},
+			RuntimeVersion: internal.RuntimeVersionData{Version: "1.16.0"},
+		})
+		require.NoError(t, err)
+		if tc.upgradedTo == "" {
+			continue
+		}
+		err = operations.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+			Operation: internal.Operation{
+				ID:          "upgrade-" + tc.id,
+				InstanceID:  tc.id,
+				State:       domain.InProgress,
+				Description: "upgrading",
+				CreatedAt:   now.Add(time.Hour),
+			},
+			RuntimeVersion: internal.RuntimeVersionData{Version: tc.upgradedTo},
+		})
+		require.NoError(t, err)
+	}
+
+	// the batch smaller than the number of runtimes makes the export fetch them in more queries
+	exportHandler := runtime.NewExportHandler(instances, 2, "cf-eu10", logrus.New())
+	router := mux.NewRouter()
+	exportHandler.AttachRoutes(router)
+	get := func(url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should export runtimes as NDJSON", func(t *testing.T) {
+		// when
+		rr := get("/runtimes/export?format=ndjson&plan=azure")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/x-ndjson", rr.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		require.Len(t, lines, 2)
+
+		var upgraded, provisioned pkg.RuntimeExportRecord
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &upgraded))
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &provisioned))
+		assert.Equal(t, "upgraded-azure", upgraded.InstanceID)
+		assert.Equal(t, "cf-eu10", upgraded.SubAccountRegion)
+		assert.Equal(t, "upgraded-azure", upgraded.ShootName)
+		assert.Equal(t, "1.16.0", upgraded.KymaVersion)
+		require.NotNil(t, upgraded.LastOperation)
+		assert.Equal(t, "upgradeKyma", upgraded.LastOperation.Type)
+		assert.Equal(t, "in progress", upgraded.LastOperation.State)
+		assert.Equal(t, "azure", provisioned.InstanceID)
+		require.NotNil(t, provisioned.LastOperation)
+		assert.Equal(t, "provision", provisioned.LastOperation.Type)
+	})
+
+	t.Run("should export runtimes as CSV", func(t *testing.T) {
+		// when
+		rr := get("/runtimes/export?sort=-created_at")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+		rows, err := csv.NewReader(rr.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		assert.Equal(t, "instanceID", rows[0][0])
+		assert.Equal(t, []string{"azure", "upgraded-azure", "lite"}, []string{rows[1][0], rows[2][0], rows[3][0]})
+		assert.Equal(t, []string{
+			"lite", "lite", "lite", "lite", "lite", "cf-eu10", "lite", "azure_lite", now.Format(time.RFC3339),
+			"1.16.0", "provision", "succeeded", "provisioned", now.Format(time.RFC3339),
+		}, rows[3])
+	})
+
+	t.Run("should reject unsupported format", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("/runtimes/export?format=xml").Code)
+	})
 }
 
 func fixInstance(id string, t time.Time) internal.Instance {
@@ -183,3 +681,18 @@ func fixInstance(id string, t time.Time) internal.Instance {
 		ProvisioningParameters: "{}",
 	}
 }
+
+func fixRuntimeStatus(kymaVersion string) gqlschema.RuntimeStatus {
+	return gqlschema.RuntimeStatus{
+		RuntimeConnectionStatus: &gqlschema.RuntimeConnectionStatus{Status: gqlschema.RuntimeAgentConnectionStatusConnected},
+		RuntimeConfiguration: &gqlschema.RuntimeConfig{
+			ClusterConfig: &gqlschema.GardenerConfig{
+				Name:                   ptr.String("shoot"),
+				KubernetesVersion:      ptr.String("1.18.12"),
+				Provider:               ptr.String("gcp"),
+				ProviderSpecificConfig: &gqlschema.GCPProviderConfig{Zones: []string{"europe-west3-b"}},
+			},
+			KymaConfig: &gqlschema.KymaConfig{Version: ptr.String(kymaVersion)},
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+)
+
+// ProvisionerStatusFetcher fetches the current runtime statuses from the provisioner, batched per global account.
+// The fetched statuses are kept for the TTL to limit the load on the provisioner.
+type ProvisionerStatusFetcher struct {
+	client    provisioner.Client
+	converter Converter
+	ttl       time.Duration
+
+	mu      sync.Mutex
+	entries map[string]provisionerStatusEntry
+	now     func() time.Time
+}
+
+type provisionerStatusEntry struct {
+	status    pkg.ProvisionerStatus
+	expiresAt time.Time
+}
+
+func NewProvisionerStatusFetcher(client provisioner.Client, converter Converter, ttl time.Duration) *ProvisionerStatusFetcher {
+	return &ProvisionerStatusFetcher{
+		client:    client,
+		converter: converter,
+		ttl:       ttl,
+		entries:   make(map[string]provisionerStatusEntry),
+		now:       time.Now,
+	}
+}
+
+// Apply sets the provisioner status of the runtimes. The runtimes without the runtime ID are skipped.
+// If the status cannot be fetched, the error is reported in the status instead of failing the whole list.
+func (f *ProvisionerStatusFetcher) Apply(dtos []pkg.RuntimeDTO) {
+	missing := make(map[string][]string)
+	for i := range dtos {
+		if dtos[i].RuntimeID == "" {
+			continue
+		}
+		if status, found := f.get(dtos[i].RuntimeID); found {
+			dtos[i].Provisioner = &status
+			continue
+		}
+		missing[dtos[i].GlobalAccountID] = append(missing[dtos[i].GlobalAccountID], dtos[i].RuntimeID)
+	}
+
+	if len(missing) > 0 {
+		f.dropExpired()
+	}
+	fetched := make(map[string]pkg.ProvisionerStatus)
+	for globalAccountID, runtimeIDs := range missing {
+		statuses, err := f.client.RuntimeStatuses(context.TODO(), globalAccountID, runtimeIDs)
+		for _, runtimeID := range runtimeIDs {
+			status, found := statuses[runtimeID]
+			switch {
+			case err != nil:
+				fetched[runtimeID] = pkg.ProvisionerStatus{Error: err.Error()}
+			case !found:
+				fetched[runtimeID] = pkg.ProvisionerStatus{Error: "runtime status not returned by the provisioner"}
+			default:
+				fetched[runtimeID] = f.converter.NewProvisionerStatus(status)
+				f.put(runtimeID, fetched[runtimeID])
+			}
+		}
+	}
+
+	for i := range dtos {
+		if status, found := fetched[dtos[i].RuntimeID]; found {
+			dtos[i].Provisioner = &status
+		}
+	}
+}
+
+func (f *ProvisionerStatusFetcher) get(runtimeID string) (pkg.ProvisionerStatus, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry, found := f.entries[runtimeID]
+	if !found || !f.now().Before(entry.expiresAt) {
+		return pkg.ProvisionerStatus{}, false
+	}
+	return entry.status, true
+}
+
+func (f *ProvisionerStatusFetcher) put(runtimeID string, status pkg.ProvisionerStatus) {
+	if f.ttl <= 0 {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.entries[runtimeID] = provisionerStatusEntry{status: status, expiresAt: f.now().Add(f.ttl)}
+}
+
+// dropExpired removes the expired statuses, e.g. of deprovisioned runtimes, so that the cache does not grow
+func (f *ProvisionerStatusFetcher) dropExpired() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	for id, entry := range f.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(f.entries, id)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+)
+
+// maskedValue replaces the values of secret overrides in the diff
+const maskedValue = "*****"
+
+// DiffRuntimeStates returns the structured difference between the Kyma and cluster configuration of two runtime states
+func DiffRuntimeStates(from, to internal.RuntimeState) pkg.RuntimeStateDiff {
+	diff := pkg.RuntimeStateDiff{
+		From:       from.ID,
+		To:         to.ID,
+		Components: diffComponents(from.KymaConfig.Components, to.KymaConfig.Components),
+		Overrides:  diffOverrides(from.KymaConfig.Configuration, to.KymaConfig.Configuration),
+		Cluster:    diffClusterConfig(from.ClusterConfig, to.ClusterConfig),
+	}
+	diff.Kyma = appendValueChange(diff.Kyma, "version", from.KymaConfig.Version, to.KymaConfig.Version)
+	diff.Kyma = appendValueChange(diff.Kyma, "profile", profileName(from.KymaConfig.Profile), profileName(to.KymaConfig.Profile))
+
+	return diff
+}
+
+func diffComponents(from, to []*gqlschema.ComponentConfigurationInput) []pkg.ComponentChange {
+	names := keySet{}
+	fromByName := componentsByName(from, names)
+	toByName := componentsByName(to, names)
+
+	var changes []pkg.ComponentChange
+	for _, name := range names.sorted() {
+		f, t := fromByName[name], toByName[name]
+		switch {
+		case f == nil:
+			changes = append(changes, pkg.ComponentChange{Name: name, Change: pkg.Added, Overrides: diffOverrides(nil, t.Configuration)})
+		case t == nil:
+			changes = append(changes, pkg.ComponentChange{Name: name, Change: pkg.Removed})
+		default:
+			var settings []pkg.ValueChange
+			settings = appendValueChange(settings, "namespace", f.Namespace, t.Namespace)
+			settings = appendValueChange(settings, "sourceURL", stringValue(f.SourceURL), stringValue(t.SourceURL))
+			overrides := diffOverrides(f.Configuration, t.Configuration)
+			if len(settings) == 0 && len(overrides) == 0 {
+				continue
+			}
+			changes = append(changes, pkg.ComponentChange{Name: name, Change: pkg.Modified, Settings: settings, Overrides: overrides})
+		}
+	}
+
+	return changes
+}
+
+func diffOverrides(from, to []*gqlschema.ConfigEntryInput) []pkg.ValueChange {
+	keys := keySet{}
+	fromByKey := overridesByKey(from, keys)
+	toByKey := overridesByKey(to, keys)
+
+	var changes []pkg.ValueChange
+	for _, key := range keys.sorted() {
+		f, t := fromByKey[key], toByKey[key]
+		switch {
+		case f == nil:
+			changes = append(changes, pkg.ValueChange{Name: key, Change: pkg.Added, To: overrideValue(t)})
+		case t == nil:
+			changes = append(changes, pkg.ValueChange{Name: key, Change: pkg.Removed, From: overrideValue(f)})
+		case f.Value != t.Value || isSecret(f) != isSecret(t):
+			changes = append(changes, pkg.ValueChange{Name: key, Change: pkg.Modified, From: overrideValue(f), To: overrideValue(t)})
+		}
+	}
+
+	return changes
+}
+
+// diffClusterConfig compares the Gardener settings by their JSON representation, so that the new settings are compared as well
+func diffClusterConfig(from, to gqlschema.GardenerConfigInput) []pkg.ValueChange {
+	names := keySet{}
+	fromFields := jsonFields(from, names)
+	toFields := jsonFields(to, names)
+
+	var changes []pkg.ValueChange
+	for _, name := range names.sorted() {
+		changes = appendValueChange(changes, name, fromFields[name], toFields[name])
+	}
+
+	return changes
+}
+
+func appendValueChange(changes []pkg.ValueChange, name, from, to string) []pkg.ValueChange {
+	switch {
+	case from == to:
+		return changes
+	case from == "":
+		return append(changes, pkg.ValueChange{Name: name, Change: pkg.Added, To: to})
+	case to == "":
+		return append(changes, pkg.ValueChange{Name: name, Change: pkg.Removed, From: from})
+	default:
+		return append(changes, pkg.ValueChange{Name: name, Change: pkg.Modified, From: from, To: to})
+	}
+}
+
+func componentsByName(components []*gqlschema.ComponentConfigurationInput, names keySet) map[string]*gqlschema.ComponentConfigurationInput {
+	result := make(map[string]*gqlschema.ComponentConfigurationInput, len(components))
+	for _, c := range components {
+		if c != nil {
+			result[c.Component] = c
+			names[c.Component] = struct{}{}
+		}
+	}
+	return result
+}
+
+func overridesByKey(entries []*gqlschema.ConfigEntryInput, keys keySet) map[string]*gqlschema.ConfigEntryInput {
+	result := make(map[string]*gqlschema.ConfigEntryInput, len(entries))
+	for _, e := range entries {
+		if e != nil {
+			result[e.Key] = e
+			keys[e.Key] = struct{}{}
+		}
+	}
+	return result
+}
+
+func jsonFields(cfg gqlschema.GardenerConfigInput, names keySet) map[string]string {
+	result := make(map[string]string)
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return result
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return result
+	}
+
+	for name, value := range fields {
+		var v interface{}
+		if err := json.Unmarshal(value, &v); err != nil {
+			continue
+		}
+		switch typed := v.(type) {
+		case nil:
+			continue
+		case string:
+			result[name] = typed
+		case float64:
+			result[name] = fmt.Sprint(typed)
+		default:
+			result[name] = string(value)
+		}
+		names[name] = struct{}{}
+	}
+
+	return result
+}
+
+type keySet map[string]struct{}
+
+func (s keySet) sorted() []string {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func overrideValue(e *gqlschema.ConfigEntryInput) string {
+	if isSecret(e) {
+		return maskedValue
+	}
+	return e.Value
+}
+
+func isSecret(e *gqlschema.ConfigEntryInput) bool {
+	return e.Secret != nil && *e.Secret
+}
+
+func profileName(p *gqlschema.KymaProfile) string {
+	if p == nil {
+		return ""
+	}
+	return string(*p)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// WithClusterConfig fills in the cluster configuration of the states which did not change the cluster,
+// e.g. Kyma upgrades, with the configuration of the preceding state. The states must be ordered from the most recent one.
+func WithClusterConfig(states []internal.RuntimeState) []internal.RuntimeState {
+	result := make([]internal.RuntimeState, len(states))
+	copy(result, states)
+
+	for i := len(result) - 2; i >= 0; i-- {
+		if isEmptyClusterConfig(result[i].ClusterConfig) {
+			result[i].ClusterConfig = result[i+1].ClusterConfig
+		}
+	}
+
+	return result
+}
+
+func isEmptyClusterConfig(cfg gqlschema.GardenerConfigInput) bool {
+	return cfg.Provider == "" && cfg.KubernetesVersion == ""
+}
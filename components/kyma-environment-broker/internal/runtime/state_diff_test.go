@@ -0,0 +1,117 @@
+package runtime_test
+
+import (
+	"testing"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffRuntimeStates(t *testing.T) {
+	// given
+	from := fixRuntimeState("state-1", "1.16.0", "1.17")
+	from.KymaConfig.Configuration = []*gqlschema.ConfigEntryInput{
+		{Key: "global.domain", Value: "old.kyma.local"},
+		{Key: "global.password", Value: "old", Secret: ptrBool(true)},
+		{Key: "removed", Value: "value"},
+	}
+	from.KymaConfig.Components = []*gqlschema.ComponentConfigurationInput{
+		{Component: "istio", Namespace: "istio-system"},
+		{Component: "knative-eventing", Namespace: "knative-eventing"},
+		{Component: "monitoring", Namespace: "kyma-system", Configuration: []*gqlschema.ConfigEntryInput{{Key: "retention", Value: "1d"}}},
+	}
+
+	to := fixRuntimeState("state-2", "1.17.0", "1.18")
+	to.KymaConfig.Configuration = []*gqlschema.ConfigEntryInput{
+		{Key: "global.domain", Value: "new.kyma.local"},
+		{Key: "global.password", Value: "new", Secret: ptrBool(true)},
+	}
+	to.KymaConfig.Components = []*gqlschema.ComponentConfigurationInput{
+		{Component: "istio", Namespace: "istio-system"},
+		{Component: "monitoring", Namespace: "kyma-system", Configuration: []*gqlschema.ConfigEntryInput{{Key: "retention", Value: "7d"}}},
+		{Component: "serverless", Namespace: "kyma-system", SourceURL: ptrString("https://serverless.local")},
+	}
+
+	// when
+	diff := runtime.DiffRuntimeStates(from, to)
+
+	// then
+	assert.Equal(t, pkg.RuntimeStateDiff{
+		From: "state-1",
+		To:   "state-2",
+		Kyma: []pkg.ValueChange{
+			{Name: "version", Change: pkg.Modified, From: "1.16.0", To: "1.17.0"},
+		},
+		Components: []pkg.ComponentChange{
+			{Name: "knative-eventing", Change: pkg.Removed},
+			{Name: "monitoring", Change: pkg.Modified, Overrides: []pkg.ValueChange{
+				{Name: "retention", Change: pkg.Modified, From: "1d", To: "7d"},
+			}},
+			{Name: "serverless", Change: pkg.Added},
+		},
+		Overrides: []pkg.ValueChange{
+			{Name: "global.domain", Change: pkg.Modified, From: "old.kyma.local", To: "new.kyma.local"},
+			{Name: "global.password", Change: pkg.Modified, From: "*****", To: "*****"},
+			{Name: "removed", Change: pkg.Removed, From: "value"},
+		},
+		Cluster: []pkg.ValueChange{
+			{Name: "kubernetesVersion", Change: pkg.Modified, From: "1.17", To: "1.18"},
+		},
+	}, diff)
+}
+
+func TestDiffRuntimeStates_NoChanges(t *testing.T) {
+	// given
+	state := fixRuntimeState("state-1", "1.16.0", "1.17")
+
+	// when
+	diff := runtime.DiffRuntimeStates(state, state)
+
+	// then
+	assert.True(t, diff.IsEmpty())
+}
+
+func TestWithClusterConfig(t *testing.T) {
+	// given
+	upgrade := fixRuntimeState("upgrade", "1.17.0", "")
+	upgrade.ClusterConfig = gqlschema.GardenerConfigInput{}
+	provisioning := fixRuntimeState("provisioning", "1.16.0", "1.17")
+
+	// when
+	states := runtime.WithClusterConfig([]internal.RuntimeState{upgrade, provisioning})
+
+	// then
+	assert.Equal(t, provisioning.ClusterConfig, states[0].ClusterConfig)
+	assert.Equal(t, provisioning.ClusterConfig, states[1].ClusterConfig)
+	assert.Empty(t, upgrade.ClusterConfig.KubernetesVersion)
+}
+
+func fixRuntimeState(id, kymaVersion, kubernetesVersion string) internal.RuntimeState {
+	return internal.RuntimeState{
+		ID:          id,
+		RuntimeID:   "runtime-id",
+		OperationID: "operation-" + id,
+		KymaConfig: gqlschema.KymaConfigInput{
+			Version: kymaVersion,
+		},
+		ClusterConfig: gqlschema.GardenerConfigInput{
+			KubernetesVersion: kubernetesVersion,
+			Provider:          "azure",
+			Region:            "westeurope",
+			AutoScalerMin:     2,
+			AutoScalerMax:     4,
+		},
+	}
+}
+
+func ptrBool(b bool) *bool {
+	return &b
+}
+
+func ptrString(s string) *string {
+	return &s
+}
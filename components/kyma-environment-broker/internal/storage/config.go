@@ -17,11 +17,26 @@ type Config struct {
 	Name     string `envconfig:"default=broker"`
 	SSLMode  string `envconfig:"default=disable"`
 
+	// SecretKey encrypts the stored secrets with the "default" key ID, unless another SecretKeyID is given.
+	// It also decrypts the values encrypted with AES-CFB before the key IDs were introduced.
 	SecretKey string `envconfig:"optional"`
+	// SecretKeys is the keyring in the "id1=key1,id2=key2" format. The key with the SecretKeyID encrypts new values,
+	// the other ones only decrypt the values until they are re-encrypted.
+	SecretKeys  string `envconfig:"optional"`
+	SecretKeyID string `envconfig:"default=default"`
+
+	// ReEncryptionInterval is the interval of the job which re-encrypts the stored secrets with the active key
+	ReEncryptionInterval  time.Duration `envconfig:"default=1h"`
+	ReEncryptionBatchSize int           `envconfig:"default=100"`
 
 	MaxOpenConns    int           `envconfig:"default=8"`
 	MaxIdleConns    int           `envconfig:"default=2"`
 	ConnMaxLifetime time.Duration `envconfig:"default=30m"`
+
+	// SqlitePath switches the storage to the embedded SQLite database for the local development and tests.
+	// The ":memory:" value keeps the database only in memory.
+	SqlitePath           string `envconfig:"optional"`
+	SqliteMigrationsPath string `envconfig:"default=../schema-migrator/migrations/kyma-environment-broker"`
 }
 
 func (cfg *Config) ConnectionURL() string {
@@ -0,0 +1,15 @@
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+)
+
+type AccountAssignmentDTO struct {
+	ID              string
+	SecretName      string
+	HyperscalerType string
+	TenantName      string
+	CreatedAt       time.Time
+	ReleasedAt      sql.NullTime
+}
@@ -1,5 +1,17 @@
 package dbmodel
 
+import "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
+
+// InstanceSortField defines the instance attribute by which the listed Instances are ordered
+type InstanceSortField string
+
+const (
+	// InstanceSortByCreatedAt orders Instances by the creation time
+	InstanceSortByCreatedAt InstanceSortField = "created_at"
+	// InstanceSortByUpdatedAt orders Instances by the last modification time
+	InstanceSortByUpdatedAt InstanceSortField = "updated_at"
+)
+
 // InstanceFilter holds the filters when queryíing Instances
 type InstanceFilter struct {
 	PageSize         int
@@ -11,4 +23,26 @@ type InstanceFilter struct {
 	Regions          []string
 	Plans            []string
 	Domains          []string
+	// States and OperationTypes match the last operation of the Instance
+	States         []string
+	OperationTypes []string
+	// KymaVersions match the Kyma version of the last succeeded provisioning or Kyma upgrade operation of the Instance
+	KymaVersions []string
+	// Labels match the Instances having all of the given labels
+	Labels map[string]string
+
+	// SortBy defaults to InstanceSortByCreatedAt
+	SortBy   InstanceSortField
+	SortDesc bool
+
+	// Cursor replaces the Page, the Instances following the cursor in the sort order are returned.
+	// The cursor time is the value of the SortBy field.
+	Cursor *pagination.Cursor
+}
+
+// InstanceLabelDTO is a single label of an Instance
+type InstanceLabelDTO struct {
+	InstanceID string
+	Key        string
+	Value      string
 }
@@ -3,6 +3,8 @@ package dbmodel
 import (
 	"database/sql"
 	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 )
 
 // OperationFilter holds the filters when listing multiple operations
@@ -10,6 +12,9 @@ type OperationFilter struct {
 	Page     int
 	PageSize int
 	States   []string
+
+	// Cursor replaces the Page, the operations created after the cursor are returned
+	Cursor *pagination.Cursor
 }
 
 // OperationType defines the possible types of an asynchronous operation to a broker.
@@ -43,6 +48,12 @@ type OperationDTO struct {
 	Type OperationType
 }
 
+// ArchivedOperationDTO is the operation moved to the archive table by the retention
+type ArchivedOperationDTO struct {
+	OperationDTO
+	ArchivedAt time.Time
+}
+
 type OperationStatEntry struct {
 	Type  string
 	State string
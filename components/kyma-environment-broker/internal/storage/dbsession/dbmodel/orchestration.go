@@ -1,10 +1,12 @@
 package dbmodel
 
 import (
+	"database/sql"
 	"encoding/json"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 )
 
@@ -13,6 +15,9 @@ type OrchestrationFilter struct {
 	Page     int
 	PageSize int
 	States   []string
+
+	// Cursor replaces the Page, the orchestrations created after the cursor are returned
+	Cursor *pagination.Cursor
 }
 
 type OrchestrationDTO struct {
@@ -22,6 +27,13 @@ type OrchestrationDTO struct {
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	Parameters      string
+
+	ParentOrchestrationID sql.NullString
+}
+
+type OrchestrationStatEntry struct {
+	State string
+	Total int
 }
 
 func NewOrchestrationDTO(o internal.Orchestration) (OrchestrationDTO, error) {
@@ -37,6 +49,10 @@ func NewOrchestrationDTO(o internal.Orchestration) (OrchestrationDTO, error) {
 		UpdatedAt:       o.UpdatedAt,
 		Description:     o.Description,
 		Parameters:      string(params),
+		ParentOrchestrationID: sql.NullString{
+			String: o.ParentOrchestrationID,
+			Valid:  o.ParentOrchestrationID != "",
+		},
 	}
 	return dto, nil
 }
@@ -54,5 +70,7 @@ func (o *OrchestrationDTO) ToOrchestration() (internal.Orchestration, error) {
 		CreatedAt:       o.CreatedAt,
 		UpdatedAt:       o.UpdatedAt,
 		Parameters:      params,
+
+		ParentOrchestrationID: o.ParentOrchestrationID.String,
 	}, nil
 }
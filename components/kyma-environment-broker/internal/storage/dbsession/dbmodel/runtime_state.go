@@ -20,3 +20,20 @@ type RuntimeStateDTO struct {
 	KymaVersion string `json:"kyma_version"`
 	K8SVersion  string `json:"k8s_version"`
 }
+
+// ArchivedRuntimeStateDTO is the runtime state moved to the archive table by the retention
+type ArchivedRuntimeStateDTO struct {
+	RuntimeStateDTO
+	ArchivedAt time.Time `json:"archived_at"`
+}
+
+// ReEncryptionBatch is the result of re-encrypting a batch of runtime states
+type ReEncryptionBatch struct {
+	// LastID is the ID of the last state in the batch, the next batch starts after it
+	LastID string
+	// Listed is the number of states in the batch, it is lower than the limit for the last batch
+	Listed      int
+	ReEncrypted int
+	// Skipped are the IDs of the states which cannot be decrypted with any key of the keyring
+	Skipped []string
+}
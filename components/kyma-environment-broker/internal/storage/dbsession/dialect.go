@@ -0,0 +1,88 @@
+package dbsession
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gocraft/dbr"
+	"github.com/gocraft/dbr/dialect"
+	"github.com/lib/pq"
+)
+
+const (
+	UniqueViolationErrorCode = "23505"
+)
+
+// sqlDialect provides the SQL fragments which differ between PostgreSQL and SQLite
+type sqlDialect interface {
+	// jsonText returns the expression which extracts the value at the given path of the JSON column as text
+	jsonText(column string, path ...string) string
+	// jsonTrue returns the condition which is met if the value at the given path of the JSON column is true
+	jsonTrue(column string, path ...string) string
+	// jsonRemove returns the expression which removes the given key from the JSON column
+	jsonRemove(column, key string) string
+	// regexpMatch returns the condition which matches the column with the regular expression given as the argument
+	regexpMatch(column string) string
+	isUniqueViolation(err error) bool
+}
+
+func newSQLDialect(connection *dbr.Connection) sqlDialect {
+	if connection.Dialect == dialect.SQLite3 {
+		return sqliteDialect{}
+	}
+	return postgresDialect{}
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) jsonText(column string, path ...string) string {
+	expr := column + "::json"
+	for i, key := range path {
+		op := "->"
+		if i == len(path)-1 {
+			op = "->>"
+		}
+		expr += fmt.Sprintf("%s'%s'", op, key)
+	}
+	return expr
+}
+
+func (d postgresDialect) jsonTrue(column string, path ...string) string {
+	return fmt.Sprintf("COALESCE((%s)::boolean, false)", d.jsonText(column, path...))
+}
+
+func (postgresDialect) jsonRemove(column, key string) string {
+	return fmt.Sprintf("(%s::jsonb - '%s')::json", column, key)
+}
+
+func (postgresDialect) regexpMatch(column string) string {
+	return column + " ~ ?"
+}
+
+func (postgresDialect) isUniqueViolation(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == UniqueViolationErrorCode
+}
+
+// sqliteDialect requires the regexp, json_text and json_remove_key functions registered in the connection by the sqlite package
+type sqliteDialect struct{}
+
+func (sqliteDialect) jsonText(column string, path ...string) string {
+	return fmt.Sprintf("CAST(json_text(%s, '%s') AS TEXT)", column, strings.Join(path, "', '"))
+}
+
+func (d sqliteDialect) jsonTrue(column string, path ...string) string {
+	return fmt.Sprintf("COALESCE(%s, 'false') = 'true'", d.jsonText(column, path...))
+}
+
+func (sqliteDialect) jsonRemove(column, key string) string {
+	return fmt.Sprintf("json_remove_key(%s, '%s')", column, key)
+}
+
+func (sqliteDialect) regexpMatch(column string) string {
+	return column + " REGEXP ?"
+}
+
+func (sqliteDialect) isUniqueViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
@@ -1,6 +1,8 @@
 package dbsession
 
 import (
+	"time"
+
 	dbr "github.com/gocraft/dbr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
@@ -32,24 +34,54 @@ type ReadSession interface {
 	GetNumberOfInstancesForGlobalAccountID(globalAccountID string) (int, error)
 	GetRuntimeStateByOperationID(operationID string) (dbmodel.RuntimeStateDTO, dberr.Error)
 	ListRuntimeStateByRuntimeID(runtimeID string) ([]dbmodel.RuntimeStateDTO, dberr.Error)
+	ListRuntimeStatesWithoutKeyPrefix(prefix, afterID string, limit int) ([]dbmodel.RuntimeStateDTO, dberr.Error)
 	GetOrchestrationByID(oID string) (dbmodel.OrchestrationDTO, dberr.Error)
 	ListOrchestrations(filter dbmodel.OrchestrationFilter) ([]dbmodel.OrchestrationDTO, int, int, error)
+	GetOrchestrationStats() ([]dbmodel.OrchestrationStatEntry, error)
 	ListInstances(filter dbmodel.InstanceFilter) ([]internal.Instance, int, int, error)
+	ListInstanceLabels(instanceIDs []string) ([]dbmodel.InstanceLabelDTO, dberr.Error)
 	ListOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]dbmodel.OperationDTO, int, int, error)
 	GetOperationStatsForOrchestration(orchestrationID string) ([]dbmodel.OperationStatEntry, error)
+	CountOperationsToArchive(before time.Time) (int, dberr.Error)
+	ListOperationIDsToArchive(before time.Time, limit int) ([]string, dberr.Error)
+	CountRuntimeStatesToArchive(before time.Time) (int, dberr.Error)
+	ListRuntimeStateIDsToArchive(before time.Time, limit int) ([]string, dberr.Error)
+	ListInstanceIDsToPurge(deprovisionedBefore time.Time, limit int) ([]string, dberr.Error)
+	CountInstancesToPurge(deprovisionedBefore time.Time) (int, dberr.Error)
+	ListInstancesAfterID(afterID string, limit int) ([]internal.Instance, dberr.Error)
+	ListOperationsAfterID(afterID string, limit int) ([]dbmodel.OperationDTO, dberr.Error)
+	ListOrchestrationsAfterID(afterID string, limit int) ([]dbmodel.OrchestrationDTO, dberr.Error)
+	ListRuntimeStatesAfterID(afterID string, limit int) ([]dbmodel.RuntimeStateDTO, dberr.Error)
+	ListLMSTenantsAfterID(afterID string, limit int) ([]dbmodel.LMSTenantDTO, dberr.Error)
+	ListAccountAssignmentsAfterID(afterID string, limit int) ([]dbmodel.AccountAssignmentDTO, dberr.Error)
+	ListArchivedOperationsAfterID(afterID string, limit int) ([]dbmodel.ArchivedOperationDTO, dberr.Error)
+	ListArchivedRuntimeStatesAfterID(afterID string, limit int) ([]dbmodel.ArchivedRuntimeStateDTO, dberr.Error)
+	ListActiveAccountAssignments() ([]dbmodel.AccountAssignmentDTO, dberr.Error)
 }
 
 //go:generate mockery -name=WriteSession
 type WriteSession interface {
 	InsertInstance(instance internal.Instance) dberr.Error
+	RestoreInstance(instance internal.Instance) dberr.Error
 	UpdateInstance(instance internal.Instance) dberr.Error
 	DeleteInstance(instanceID string) dberr.Error
+	UpsertInstanceLabel(label dbmodel.InstanceLabelDTO) dberr.Error
+	DeleteInstanceLabels(instanceID string, keys []string) dberr.Error
 	InsertOperation(dto dbmodel.OperationDTO) dberr.Error
 	UpdateOperation(instance dbmodel.OperationDTO) dberr.Error
 	InsertOrchestration(o dbmodel.OrchestrationDTO) dberr.Error
 	UpdateOrchestration(o dbmodel.OrchestrationDTO) dberr.Error
 	InsertRuntimeState(state dbmodel.RuntimeStateDTO) dberr.Error
+	UpdateRuntimeStateKymaConfig(id, kymaConfig string) dberr.Error
 	InsertLMSTenant(dto dbmodel.LMSTenantDTO) dberr.Error
+	InsertAccountAssignment(dto dbmodel.AccountAssignmentDTO) dberr.Error
+	InsertArchivedOperation(dto dbmodel.ArchivedOperationDTO) dberr.Error
+	InsertArchivedRuntimeState(dto dbmodel.ArchivedRuntimeStateDTO) dberr.Error
+	ReleaseAccountAssignment(secretName string, releasedAt time.Time) dberr.Error
+	ArchiveOperations(ids []string) dberr.Error
+	ArchiveRuntimeStates(ids []string) dberr.Error
+	PurgeOperationsData(instanceIDs []string) dberr.Error
+	DeleteRuntimeStatesByInstanceIDs(instanceIDs []string) dberr.Error
 }
 
 type Transaction interface {
@@ -65,23 +97,28 @@ type WriteSessionWithinTransaction interface {
 
 type factory struct {
 	connection *dbr.Connection
+	dialect    sqlDialect
 }
 
+// NewFactory creates the sessions for the PostgreSQL or the SQLite connection
 func NewFactory(connection *dbr.Connection) Factory {
 	return &factory{
 		connection: connection,
+		dialect:    newSQLDialect(connection),
 	}
 }
 
 func (sf *factory) NewReadSession() ReadSession {
 	return readSession{
 		session: sf.connection.NewSession(nil),
+		dialect: sf.dialect,
 	}
 }
 
 func (sf *factory) NewWriteSession() WriteSession {
 	return writeSession{
 		session: sf.connection.NewSession(nil),
+		dialect: sf.dialect,
 	}
 }
 
@@ -96,5 +133,6 @@ func (sf *factory) NewSessionWithinTransaction() (WriteSessionWithinTransaction,
 	return writeSession{
 		session:     dbSession,
 		transaction: dbTransaction,
+		dialect:     sf.dialect,
 	}, nil
 }
@@ -3,9 +3,11 @@ package dbsession
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
@@ -18,12 +20,17 @@ import (
 
 type readSession struct {
 	session *dbr.Session
+	dialect sqlDialect
 }
 
 func (r readSession) getInstancesJoinedWithOperationStatement() *dbr.SelectStmt {
 	join := fmt.Sprintf("%s.instance_id = %s.instance_id", postsql.InstancesTableName, postsql.OperationTableName)
+	// the same Kyma version as matched by the kyma_version filter of the instances list
+	kymaVersion := fmt.Sprintf("CASE WHEN operations.state = '%s' AND operations.type IN ('%s', '%s') AND NOT %s THEN %s END AS kyma_version",
+		domain.Succeeded, dbmodel.OperationTypeProvision, dbmodel.OperationTypeUpgradeKyma,
+		r.dialect.jsonTrue("operations.data", "runtime_operation", "dryRun"), r.dialect.jsonText("operations.data", "runtime_version", "version"))
 	stmt := r.session.
-		Select("instances.instance_id, instances.runtime_id, instances.global_account_id, instances.service_id, instances.service_plan_id, instances.dashboard_url, instances.provisioning_parameters, instances.created_at, instances.updated_at, instances.deleted_at, instances.sub_account_id, instances.service_name, instances.service_plan_name, instances.provider_region, operations.state, operations.description, operations.type").
+		Select("instances.instance_id, instances.runtime_id, instances.global_account_id, instances.service_id, instances.service_plan_id, instances.dashboard_url, instances.provisioning_parameters, instances.created_at, instances.updated_at, instances.deleted_at, instances.sub_account_id, instances.service_name, instances.service_plan_name, instances.provider_region, operations.state, operations.description, operations.type, operations.created_at AS operation_created_at, "+kymaVersion).
 		From(postsql.InstancesTableName).
 		LeftJoin(postsql.OperationTableName, join)
 	return stmt
@@ -130,13 +137,10 @@ func (r readSession) ListOrchestrations(filter dbmodel.OrchestrationFilter) ([]d
 	var orchestrations []dbmodel.OrchestrationDTO
 
 	stmt := r.session.Select("*").
-		From(postsql.OrchestrationTableName).
-		OrderBy(postsql.CreatedAtField)
+		From(postsql.OrchestrationTableName)
 
-	// Add pagination if provided
-	if filter.Page > 0 && filter.PageSize > 0 {
-		stmt.Paginate(uint64(filter.Page), uint64(filter.PageSize))
-	}
+	// Order by created at and add pagination if provided
+	paginate(stmt, postsql.CreatedAtField, "orchestration_id", false, filter.Page, filter.PageSize, filter.Cursor)
 
 	// Apply filtering if provided
 	addOrchestrationFilters(stmt, filter)
@@ -233,13 +237,10 @@ func (r readSession) ListOperationsByOrchestrationID(orchestrationID string, fil
 	stmt := r.session.
 		Select("*").
 		From(postsql.OperationTableName).
-		Where(condition).
-		OrderBy(postsql.CreatedAtField)
+		Where(condition)
 
-		// Add pagination if provided
-	if filter.Page > 0 && filter.PageSize > 0 {
-		stmt.Paginate(uint64(filter.Page), uint64(filter.PageSize))
-	}
+	// Order by created at and add pagination if provided
+	paginate(stmt, postsql.CreatedAtField, "id", false, filter.Page, filter.PageSize, filter.Cursor)
 
 	// Apply filtering if provided
 	addOperationFilters(stmt, filter)
@@ -286,6 +287,27 @@ func (r readSession) ListRuntimeStateByRuntimeID(runtimeID string) ([]dbmodel.Ru
 		Select("*").
 		From(postsql.RuntimeStateTableName).
 		Where(stateCondition).
+		OrderDir(postsql.CreatedAtField, false).
+		Load(&states)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get states: %s", err)
+	}
+	return states, nil
+}
+
+// ListRuntimeStatesWithoutKeyPrefix returns up to the given number of states following the given ID,
+// with the Kyma config not starting with the prefix
+func (r readSession) ListRuntimeStatesWithoutKeyPrefix(prefix, afterID string, limit int) ([]dbmodel.RuntimeStateDTO, dberr.Error) {
+	var states []dbmodel.RuntimeStateDTO
+
+	_, err := r.session.
+		Select("*").
+		From(postsql.RuntimeStateTableName).
+		Where("kyma_config <> ''").
+		Where("substr(kyma_config, 1, ?) <> ?", len(prefix), prefix).
+		Where("id > ?", afterID).
+		OrderBy("id").
+		Limit(uint64(limit)).
 		Load(&states)
 	if err != nil {
 		return nil, dberr.Internal("Failed to get states: %s", err)
@@ -293,6 +315,179 @@ func (r readSession) ListRuntimeStateByRuntimeID(runtimeID string) ([]dbmodel.Ru
 	return states, nil
 }
 
+func (r readSession) CountOperationsToArchive(before time.Time) (int, dberr.Error) {
+	var res struct {
+		Total int
+	}
+	err := r.session.Select("count(*) as total").
+		From(postsql.OperationTableName).
+		Where(operationsToArchiveCondition, operationsToArchiveArgs(before)...).
+		LoadOne(&res)
+	if err != nil {
+		return 0, dberr.Internal("Failed to count operations to archive: %s", err)
+	}
+	return res.Total, nil
+}
+
+// ListOperationIDsToArchive returns up to the given number of the least recently updated operations to archive
+func (r readSession) ListOperationIDsToArchive(before time.Time, limit int) ([]string, dberr.Error) {
+	var ids []string
+	_, err := r.session.Select("id").
+		From(postsql.OperationTableName).
+		Where(operationsToArchiveCondition, operationsToArchiveArgs(before)...).
+		OrderBy(postsql.UpdatedAtField).
+		Limit(uint64(limit)).
+		Load(&ids)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get operations to archive: %s", err)
+	}
+	return ids, nil
+}
+
+func (r readSession) CountRuntimeStatesToArchive(before time.Time) (int, dberr.Error) {
+	var res struct {
+		Total int
+	}
+	err := r.session.Select("count(*) as total").
+		From(postsql.RuntimeStateTableName).
+		Where(runtimeStatesToArchiveCondition, before).
+		LoadOne(&res)
+	if err != nil {
+		return 0, dberr.Internal("Failed to count runtime states to archive: %s", err)
+	}
+	return res.Total, nil
+}
+
+// ListRuntimeStateIDsToArchive returns up to the given number of the oldest runtime states to archive
+func (r readSession) ListRuntimeStateIDsToArchive(before time.Time, limit int) ([]string, dberr.Error) {
+	var ids []string
+	_, err := r.session.Select("id").
+		From(postsql.RuntimeStateTableName).
+		Where(runtimeStatesToArchiveCondition, before).
+		OrderBy(postsql.CreatedAtField).
+		Limit(uint64(limit)).
+		Load(&ids)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get runtime states to archive: %s", err)
+	}
+	return ids, nil
+}
+
+// ListInstanceIDsToPurge returns up to the given number of instances deprovisioned before the given time,
+// whose operations still contain the provisioning parameters
+func (r readSession) ListInstanceIDsToPurge(deprovisionedBefore time.Time, limit int) ([]string, dberr.Error) {
+	var ids []string
+	_, err := r.session.
+		SelectBySql(fmt.Sprintf("%s SELECT DISTINCT instance_id FROM ops WHERE %s ORDER BY instance_id LIMIT ?", allOperationsQuery, r.instancesToPurgeCondition()),
+			append(instancesToPurgeArgs(deprovisionedBefore), limit)...).
+		Load(&ids)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get instances to purge: %s", err)
+	}
+	return ids, nil
+}
+
+func (r readSession) CountInstancesToPurge(deprovisionedBefore time.Time) (int, dberr.Error) {
+	var res struct {
+		Total int
+	}
+	err := r.session.
+		SelectBySql(fmt.Sprintf("%s SELECT count(DISTINCT instance_id) AS total FROM ops WHERE %s", allOperationsQuery, r.instancesToPurgeCondition()),
+			instancesToPurgeArgs(deprovisionedBefore)...).
+		LoadOne(&res)
+	if err != nil {
+		return 0, dberr.Internal("Failed to count instances to purge: %s", err)
+	}
+	return res.Total, nil
+}
+
+// ListInstancesAfterID returns up to the given number of instances with the IDs following the given one, ordered by the ID
+func (r readSession) ListInstancesAfterID(afterID string, limit int) ([]internal.Instance, dberr.Error) {
+	var instances []internal.Instance
+	if err := r.listAfterID(postsql.InstancesTableName, "instance_id", afterID, limit, &instances); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// ListOperationsAfterID returns up to the given number of operations of all types with the IDs following the given one, ordered by the ID
+func (r readSession) ListOperationsAfterID(afterID string, limit int) ([]dbmodel.OperationDTO, dberr.Error) {
+	var operations []dbmodel.OperationDTO
+	if err := r.listAfterID(postsql.OperationTableName, "id", afterID, limit, &operations); err != nil {
+		return nil, err
+	}
+	return operations, nil
+}
+
+// ListOrchestrationsAfterID returns up to the given number of orchestrations with the IDs following the given one, ordered by the ID
+func (r readSession) ListOrchestrationsAfterID(afterID string, limit int) ([]dbmodel.OrchestrationDTO, dberr.Error) {
+	var orchestrations []dbmodel.OrchestrationDTO
+	if err := r.listAfterID(postsql.OrchestrationTableName, "orchestration_id", afterID, limit, &orchestrations); err != nil {
+		return nil, err
+	}
+	return orchestrations, nil
+}
+
+// ListRuntimeStatesAfterID returns up to the given number of runtime states with the IDs following the given one, ordered by the ID
+func (r readSession) ListRuntimeStatesAfterID(afterID string, limit int) ([]dbmodel.RuntimeStateDTO, dberr.Error) {
+	var states []dbmodel.RuntimeStateDTO
+	if err := r.listAfterID(postsql.RuntimeStateTableName, "id", afterID, limit, &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// ListLMSTenantsAfterID returns up to the given number of LMS tenants with the IDs following the given one, ordered by the ID
+func (r readSession) ListLMSTenantsAfterID(afterID string, limit int) ([]dbmodel.LMSTenantDTO, dberr.Error) {
+	var tenants []dbmodel.LMSTenantDTO
+	if err := r.listAfterID(postsql.LMSTenantTableName, "id", afterID, limit, &tenants); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+// ListAccountAssignmentsAfterID returns up to the given number of active and released account assignments
+// with the IDs following the given one, ordered by the ID
+func (r readSession) ListAccountAssignmentsAfterID(afterID string, limit int) ([]dbmodel.AccountAssignmentDTO, dberr.Error) {
+	var assignments []dbmodel.AccountAssignmentDTO
+	if err := r.listAfterID(postsql.AccountAssignmentTableName, "id", afterID, limit, &assignments); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// ListArchivedOperationsAfterID returns up to the given number of archived operations with the IDs following the given one, ordered by the ID
+func (r readSession) ListArchivedOperationsAfterID(afterID string, limit int) ([]dbmodel.ArchivedOperationDTO, dberr.Error) {
+	var operations []dbmodel.ArchivedOperationDTO
+	if err := r.listAfterID(postsql.OperationArchiveTableName, "id", afterID, limit, &operations); err != nil {
+		return nil, err
+	}
+	return operations, nil
+}
+
+// ListArchivedRuntimeStatesAfterID returns up to the given number of archived runtime states with the IDs following the given one, ordered by the ID
+func (r readSession) ListArchivedRuntimeStatesAfterID(afterID string, limit int) ([]dbmodel.ArchivedRuntimeStateDTO, dberr.Error) {
+	var states []dbmodel.ArchivedRuntimeStateDTO
+	if err := r.listAfterID(postsql.RuntimeStateArchiveTableName, "id", afterID, limit, &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+func (r readSession) listAfterID(table, idColumn, afterID string, limit int, dest interface{}) dberr.Error {
+	_, err := r.session.
+		Select("*").
+		From(table).
+		Where(dbr.Gt(idColumn, afterID)).
+		OrderBy(idColumn).
+		Limit(uint64(limit)).
+		Load(dest)
+	if err != nil {
+		return dberr.Internal("Failed to get records from %s table: %s", table, err)
+	}
+	return nil
+}
+
 func (r readSession) getOperation(condition dbr.Builder) (dbmodel.OperationDTO, dberr.Error) {
 	var operation dbmodel.OperationDTO
 
@@ -347,6 +542,20 @@ func (r readSession) GetLMSTenant(name, region string) (dbmodel.LMSTenantDTO, db
 	return dto, nil
 }
 
+func (r readSession) ListActiveAccountAssignments() ([]dbmodel.AccountAssignmentDTO, dberr.Error) {
+	var assignments []dbmodel.AccountAssignmentDTO
+	_, err := r.session.
+		Select("*").
+		From(postsql.AccountAssignmentTableName).
+		Where("released_at IS NULL").
+		OrderBy("created_at").
+		Load(&assignments)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get account assignments: %s", err)
+	}
+	return assignments, nil
+}
+
 func (r readSession) GetOperationStats() ([]dbmodel.OperationStatEntry, error) {
 	var rows []dbmodel.OperationStatEntry
 	_, err := r.session.SelectBySql(fmt.Sprintf("select type, state, count(*) as total from %s group by type, state",
@@ -365,6 +574,16 @@ func (r readSession) GetOperationStatsForOrchestration(orchestrationID string) (
 	return rows, err
 }
 
+func (r readSession) GetOrchestrationStats() ([]dbmodel.OrchestrationStatEntry, error) {
+	var rows []dbmodel.OrchestrationStatEntry
+	_, err := r.session.Select("state, count(*) as total").
+		From(postsql.OrchestrationTableName).
+		GroupBy("state").
+		Load(&rows)
+
+	return rows, err
+}
+
 func (r readSession) GetInstanceStats() ([]dbmodel.InstanceByGlobalAccountIDStatEntry, error) {
 	var rows []dbmodel.InstanceByGlobalAccountIDStatEntry
 	_, err := r.session.SelectBySql(fmt.Sprintf("select global_account_id, count(*) as total from %s group by global_account_id",
@@ -387,18 +606,19 @@ func (r readSession) GetNumberOfInstancesForGlobalAccountID(globalAccountID stri
 func (r readSession) ListInstances(filter dbmodel.InstanceFilter) ([]internal.Instance, int, int, error) {
 	var instances []internal.Instance
 
-	// Base select and order by created at
+	// Base select and order by created at, unless the other order is requested
+	sortField := postsql.CreatedAtField
+	if filter.SortBy == dbmodel.InstanceSortByUpdatedAt {
+		sortField = postsql.UpdatedAtField
+	}
 	stmt := r.session.
 		Select("*").
-		From(postsql.InstancesTableName).
-		OrderBy(postsql.CreatedAtField)
+		From(postsql.InstancesTableName)
 
 	// Add pagination
-	if filter.Page > 0 && filter.PageSize > 0 {
-		stmt = stmt.Paginate(uint64(filter.Page), uint64(filter.PageSize))
-	}
+	paginate(stmt, sortField, "instance_id", filter.SortDesc, filter.Page, filter.PageSize, filter.Cursor)
 
-	addInstanceFilters(stmt, filter)
+	r.addInstanceFilters(stmt, filter)
 
 	_, err := stmt.Load(&instances)
 	if err != nil {
@@ -416,18 +636,36 @@ func (r readSession) ListInstances(filter dbmodel.InstanceFilter) ([]internal.In
 		nil
 }
 
+func (r readSession) ListInstanceLabels(instanceIDs []string) ([]dbmodel.InstanceLabelDTO, dberr.Error) {
+	var labels []dbmodel.InstanceLabelDTO
+	if len(instanceIDs) == 0 {
+		return labels, nil
+	}
+
+	_, err := r.session.
+		Select("*").
+		From(postsql.InstanceLabelsTableName).
+		Where("instance_id IN ?", instanceIDs).
+		OrderBy("key").
+		Load(&labels)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get instance labels: %s", err)
+	}
+	return labels, nil
+}
+
 func (r readSession) getInstanceCount(filter dbmodel.InstanceFilter) (int, error) {
 	var res struct {
 		Total int
 	}
 	stmt := r.session.Select("count(*) as total").From(postsql.InstancesTableName)
-	addInstanceFilters(stmt, filter)
+	r.addInstanceFilters(stmt, filter)
 	err := stmt.LoadOne(&res)
 
 	return res.Total, err
 }
 
-func addInstanceFilters(stmt *dbr.SelectStmt, filter dbmodel.InstanceFilter) {
+func (r readSession) addInstanceFilters(stmt *dbr.SelectStmt, filter dbmodel.InstanceFilter) {
 	if len(filter.GlobalAccountIDs) > 0 {
 		stmt.Where("global_account_id IN ?", filter.GlobalAccountIDs)
 	}
@@ -451,7 +689,72 @@ func addInstanceFilters(stmt *dbr.SelectStmt, filter dbmodel.InstanceFilter) {
 		// match subdomain inputs
 		// match any .upperdomain zero or more times
 		domainMatch := fmt.Sprintf(`[./](%s)(\.[0-9A-Za-z-]+)*$`, strings.Join(filter.Domains, "|"))
-		stmt.Where("dashboard_url ~ ?", domainMatch)
+		stmt.Where(r.dialect.regexpMatch("dashboard_url"), domainMatch)
+	}
+	if len(filter.States) > 0 || len(filter.OperationTypes) > 0 {
+		// match the most recent operation of each instance
+		lastOperation := r.session.
+			Select("instance_id, state, type, " + latestRowNumber("instance_id")).
+			From(postsql.OperationTableName)
+		matching := r.session.
+			Select("instance_id").
+			From(lastOperation.As("last_operations")).
+			Where("row_no = 1")
+		if len(filter.States) > 0 {
+			matching.Where("state IN ?", filter.States)
+		}
+		if len(filter.OperationTypes) > 0 {
+			matching.Where("type IN ?", filter.OperationTypes)
+		}
+		stmt.Where("instance_id IN (?)", matching)
+	}
+	for key, value := range filter.Labels {
+		labeled := r.session.
+			Select("instance_id").
+			From(postsql.InstanceLabelsTableName).
+			Where(dbr.Eq("key", key)).
+			Where(dbr.Eq("value", value))
+		stmt.Where("instance_id IN (?)", labeled)
+	}
+	if len(filter.KymaVersions) > 0 {
+		// match the Kyma version of the most recent succeeded operation which installed or upgraded Kyma, skipping dry runs
+		lastVersion := r.session.
+			Select(fmt.Sprintf("instance_id, %s AS kyma_version, %s", r.dialect.jsonText("data", "runtime_version", "version"), latestRowNumber("instance_id"))).
+			From(postsql.OperationTableName).
+			Where("state = ?", string(domain.Succeeded)).
+			Where("type IN ?", []string{string(dbmodel.OperationTypeProvision), string(dbmodel.OperationTypeUpgradeKyma)}).
+			Where("NOT " + r.dialect.jsonTrue("data", "runtime_operation", "dryRun"))
+		matching := r.session.
+			Select("instance_id").
+			From(lastVersion.As("last_versions")).
+			Where("row_no = 1").
+			Where("kyma_version IN ?", filter.KymaVersions)
+		stmt.Where("instance_id IN (?)", matching)
+	}
+}
+
+// latestRowNumber returns the row_no column which numbers the rows with the same value of the partition column from the most recent one
+func latestRowNumber(partitionColumn string) string {
+	return fmt.Sprintf("ROW_NUMBER() OVER (PARTITION BY %s ORDER BY %s DESC) AS row_no", partitionColumn, postsql.CreatedAtField)
+}
+
+// paginate orders the statement by the time and the ID columns, and selects the page following the cursor, if given, or the page with the given number
+func paginate(stmt *dbr.SelectStmt, timeColumn, idColumn string, desc bool, page, pageSize int, cursor *pagination.Cursor) {
+	stmt.OrderDir(timeColumn, !desc).OrderDir(idColumn, !desc)
+
+	if cursor != nil {
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		stmt.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", timeColumn, idColumn, op), cursor.Time, cursor.ID)
+		if pageSize > 0 {
+			stmt.Limit(uint64(pageSize))
+		}
+		return
+	}
+	if page > 0 && pageSize > 0 {
+		stmt.Paginate(uint64(page), uint64(pageSize))
 	}
 }
 
@@ -490,3 +793,36 @@ func (r readSession) getOrchestrationCount(filter dbmodel.OrchestrationFilter) (
 
 	return res.Total, err
 }
+
+// operationsToArchiveCondition selects the finished operations updated before the given time. The provisioning operations
+// of the existing instances are kept, because the broker reads them during the whole lifecycle of the instance.
+var operationsToArchiveCondition = fmt.Sprintf("state IN ? AND updated_at < ? AND NOT (type = ? AND instance_id IN (SELECT instance_id FROM %s))",
+	postsql.InstancesTableName)
+
+func operationsToArchiveArgs(before time.Time) []interface{} {
+	return []interface{}{[]string{string(domain.Succeeded), string(domain.Failed)}, before, string(dbmodel.OperationTypeProvision)}
+}
+
+// runtimeStatesToArchiveCondition selects the states created before the given time, except the latest state of each runtime
+var runtimeStatesToArchiveCondition = fmt.Sprintf("created_at < ? AND id NOT IN (SELECT id FROM (SELECT id, %s FROM %s) AS latest_states WHERE row_no = 1)",
+	latestRowNumber("runtime_id"), postsql.RuntimeStateTableName)
+
+// allOperationsQuery defines the ops view over the operations and the archived operations
+var allOperationsQuery = fmt.Sprintf(`WITH ops AS (
+	SELECT id, instance_id, type, state, updated_at, data FROM %s
+	UNION ALL
+	SELECT id, instance_id, type, state, updated_at, data FROM %s
+)`, postsql.OperationTableName, postsql.OperationArchiveTableName)
+
+// instancesToPurgeCondition selects the operations with the provisioning parameters of the instances which do not exist anymore
+// and were deprovisioned successfully before the given time
+func (r readSession) instancesToPurgeCondition() string {
+	return fmt.Sprintf(`%s IS NOT NULL
+	AND instance_id NOT IN (SELECT instance_id FROM %s)
+	AND instance_id IN (SELECT instance_id FROM ops WHERE type = ? AND state = ? AND updated_at < ?)`,
+		r.dialect.jsonText("data", "provisioning_parameters"), postsql.InstancesTableName)
+}
+
+func instancesToPurgeArgs(deprovisionedBefore time.Time) []interface{} {
+	return []interface{}{string(dbmodel.OperationTypeDeprovision), string(domain.Succeeded), deprovisionedBefore}
+}
@@ -1,6 +1,7 @@
 package dbsession
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
@@ -9,19 +10,27 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
-	"github.com/lib/pq"
-)
-
-const (
-	UniqueViolationErrorCode = "23505"
 )
 
 type writeSession struct {
 	session     *dbr.Session
 	transaction *dbr.Tx
+	dialect     sqlDialect
 }
 
 func (ws writeSession) InsertInstance(instance internal.Instance) dberr.Error {
+	now := time.Now()
+	// set here instead of the column defaults, which SQLite does not support for the added columns
+	instance.CreatedAt = now
+	instance.UpdatedAt = now
+	// in postgres database it will be equal to "0001-01-01 00:00:00+00"
+	instance.DeletedAt = time.Time{}
+
+	return ws.RestoreInstance(instance)
+}
+
+// RestoreInstance inserts the instance keeping its timestamps
+func (ws writeSession) RestoreInstance(instance internal.Instance) dberr.Error {
 	_, err := ws.insertInto(postsql.InstancesTableName).
 		Pair("instance_id", instance.InstanceID).
 		Pair("runtime_id", instance.RuntimeID).
@@ -34,15 +43,14 @@ func (ws writeSession) InsertInstance(instance internal.Instance) dberr.Error {
 		Pair("dashboard_url", instance.DashboardURL).
 		Pair("provisioning_parameters", instance.ProvisioningParameters).
 		Pair("provider_region", instance.ProviderRegion).
-		// in postgres database it will be equal to "0001-01-01 00:00:00+00"
-		Pair("deleted_at", time.Time{}).
+		Pair("created_at", instance.CreatedAt).
+		Pair("updated_at", instance.UpdatedAt).
+		Pair("deleted_at", instance.DeletedAt).
 		Exec()
 
 	if err != nil {
-		if err, ok := err.(*pq.Error); ok {
-			if err.Code == UniqueViolationErrorCode {
-				return dberr.AlreadyExists("operation with id %s already exist", instance.InstanceID)
-			}
+		if ws.dialect.isUniqueViolation(err) {
+			return dberr.AlreadyExists("operation with id %s already exist", instance.InstanceID)
 		}
 		return dberr.Internal("Failed to insert record to Instance table: %s", err)
 	}
@@ -61,6 +69,30 @@ func (ws writeSession) DeleteInstance(instanceID string) dberr.Error {
 	return nil
 }
 
+func (ws writeSession) UpsertInstanceLabel(label dbmodel.InstanceLabelDTO) dberr.Error {
+	_, err := ws.insertBySql(fmt.Sprintf(
+		"INSERT INTO %s (instance_id, key, value) VALUES (?, ?, ?) ON CONFLICT (instance_id, key) DO UPDATE SET value = EXCLUDED.value",
+		postsql.InstanceLabelsTableName), label.InstanceID, label.Key, label.Value).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to upsert record to instance labels table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) DeleteInstanceLabels(instanceID string, keys []string) dberr.Error {
+	_, err := ws.deleteFrom(postsql.InstanceLabelsTableName).
+		Where(dbr.Eq("instance_id", instanceID)).
+		Where(dbr.Eq("key", keys)).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to delete records from instance labels table: %s", err)
+	}
+
+	return nil
+}
+
 func (ws writeSession) UpdateInstance(instance internal.Instance) dberr.Error {
 	_, err := ws.update(postsql.InstancesTableName).
 		Where(dbr.Eq("instance_id", instance.InstanceID)).
@@ -97,10 +129,8 @@ func (ws writeSession) InsertOperation(op dbmodel.OperationDTO) dberr.Error {
 		Exec()
 
 	if err != nil {
-		if err, ok := err.(*pq.Error); ok {
-			if err.Code == UniqueViolationErrorCode {
-				return dberr.AlreadyExists("operation with id %s already exist", op.ID)
-			}
+		if ws.dialect.isUniqueViolation(err) {
+			return dberr.AlreadyExists("operation with id %s already exist", op.ID)
 		}
 		return dberr.Internal("Failed to insert record to operations table: %s", err)
 	}
@@ -116,13 +146,12 @@ func (ws writeSession) InsertOrchestration(o dbmodel.OrchestrationDTO) dberr.Err
 		Pair("description", o.Description).
 		Pair("state", o.State).
 		Pair("parameters", o.Parameters).
+		Pair("parent_orchestration_id", o.ParentOrchestrationID).
 		Exec()
 
 	if err != nil {
-		if err, ok := err.(*pq.Error); ok {
-			if err.Code == UniqueViolationErrorCode {
-				return dberr.AlreadyExists("Orchestration with id %s already exist", o.OrchestrationID)
-			}
+		if ws.dialect.isUniqueViolation(err) {
+			return dberr.AlreadyExists("Orchestration with id %s already exist", o.OrchestrationID)
 		}
 		return dberr.Internal("Failed to insert record to orchestration table: %s", err)
 	}
@@ -171,10 +200,8 @@ func (ws writeSession) InsertRuntimeState(state dbmodel.RuntimeStateDTO) dberr.E
 		Exec()
 
 	if err != nil {
-		if err, ok := err.(*pq.Error); ok {
-			if err.Code == UniqueViolationErrorCode {
-				return dberr.AlreadyExists("RuntimeState with id %s already exist", state.ID)
-			}
+		if ws.dialect.isUniqueViolation(err) {
+			return dberr.AlreadyExists("RuntimeState with id %s already exist", state.ID)
 		}
 		return dberr.Internal("Failed to insert record to RuntimeState table: %s", err)
 	}
@@ -182,6 +209,131 @@ func (ws writeSession) InsertRuntimeState(state dbmodel.RuntimeStateDTO) dberr.E
 	return nil
 }
 
+func (ws writeSession) UpdateRuntimeStateKymaConfig(id, kymaConfig string) dberr.Error {
+	_, err := ws.update(postsql.RuntimeStateTableName).
+		Where(dbr.Eq("id", id)).
+		Set("kyma_config", kymaConfig).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update record to RuntimeState table: %s", err)
+	}
+
+	return nil
+}
+
+const (
+	operationColumns    = "id, instance_id, target_operation_id, version, state, description, type, data, orchestration_id, created_at, updated_at"
+	runtimeStateColumns = "id, runtime_id, operation_id, created_at, kyma_config, cluster_config, kyma_version, k8s_version"
+)
+
+// ArchiveOperations moves the given operations to the archive table, it must be called within a transaction
+func (ws writeSession) ArchiveOperations(ids []string) dberr.Error {
+	return ws.archive(postsql.OperationTableName, postsql.OperationArchiveTableName, operationColumns, ids)
+}
+
+// ArchiveRuntimeStates moves the given runtime states to the archive table, it must be called within a transaction
+func (ws writeSession) ArchiveRuntimeStates(ids []string) dberr.Error {
+	return ws.archive(postsql.RuntimeStateTableName, postsql.RuntimeStateArchiveTableName, runtimeStateColumns, ids)
+}
+
+func (ws writeSession) archive(table, archiveTable, columns string, ids []string) dberr.Error {
+	_, err := ws.insertBySql(fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s WHERE id IN ?", archiveTable, columns, columns, table), ids).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to insert records to %s table: %s", archiveTable, err)
+	}
+	_, err = ws.deleteFrom(table).
+		Where(dbr.Eq("id", ids)).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to delete records from %s table: %s", table, err)
+	}
+
+	return nil
+}
+
+// InsertArchivedOperation inserts the operation to the archive table keeping its archiving time, it is used to restore the backup
+func (ws writeSession) InsertArchivedOperation(op dbmodel.ArchivedOperationDTO) dberr.Error {
+	_, err := ws.insertInto(postsql.OperationArchiveTableName).
+		Pair("id", op.ID).
+		Pair("instance_id", op.InstanceID).
+		Pair("version", op.Version).
+		Pair("created_at", op.CreatedAt).
+		Pair("updated_at", op.UpdatedAt).
+		Pair("description", op.Description).
+		Pair("state", op.State).
+		Pair("target_operation_id", op.TargetOperationID).
+		Pair("type", op.Type).
+		Pair("data", op.Data).
+		Pair("orchestration_id", op.OrchestrationID.String).
+		Pair("archived_at", op.ArchivedAt).
+		Exec()
+
+	if err != nil {
+		if ws.dialect.isUniqueViolation(err) {
+			return dberr.AlreadyExists("archived operation with id %s already exist", op.ID)
+		}
+		return dberr.Internal("Failed to insert record to %s table: %s", postsql.OperationArchiveTableName, err)
+	}
+
+	return nil
+}
+
+// InsertArchivedRuntimeState inserts the runtime state to the archive table keeping its archiving time, it is used to restore the backup
+func (ws writeSession) InsertArchivedRuntimeState(state dbmodel.ArchivedRuntimeStateDTO) dberr.Error {
+	_, err := ws.insertInto(postsql.RuntimeStateArchiveTableName).
+		Pair("id", state.ID).
+		Pair("operation_id", state.OperationID).
+		Pair("runtime_id", state.RuntimeID).
+		Pair("created_at", state.CreatedAt).
+		Pair("kyma_version", state.KymaVersion).
+		Pair("k8s_version", state.K8SVersion).
+		Pair("kyma_config", state.KymaConfig).
+		Pair("cluster_config", state.ClusterConfig).
+		Pair("archived_at", state.ArchivedAt).
+		Exec()
+
+	if err != nil {
+		if ws.dialect.isUniqueViolation(err) {
+			return dberr.AlreadyExists("archived RuntimeState with id %s already exist", state.ID)
+		}
+		return dberr.Internal("Failed to insert record to %s table: %s", postsql.RuntimeStateArchiveTableName, err)
+	}
+
+	return nil
+}
+
+// PurgeOperationsData removes the provisioning parameters from the operations and the archived operations of the given instances
+func (ws writeSession) PurgeOperationsData(instanceIDs []string) dberr.Error {
+	for _, table := range []string{postsql.OperationTableName, postsql.OperationArchiveTableName} {
+		_, err := ws.update(table).
+			Set("data", dbr.Expr(ws.dialect.jsonRemove("data", "provisioning_parameters"))).
+			Where(dbr.Eq("instance_id", instanceIDs)).
+			Exec()
+		if err != nil {
+			return dberr.Internal("Failed to purge records in %s table: %s", table, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteRuntimeStatesByInstanceIDs deletes the runtime states and the archived runtime states created by the operations of the given instances
+func (ws writeSession) DeleteRuntimeStatesByInstanceIDs(instanceIDs []string) dberr.Error {
+	operations := fmt.Sprintf("operation_id IN (SELECT id FROM %s WHERE instance_id IN ? UNION SELECT id FROM %s WHERE instance_id IN ?)",
+		postsql.OperationTableName, postsql.OperationArchiveTableName)
+	for _, table := range []string{postsql.RuntimeStateTableName, postsql.RuntimeStateArchiveTableName} {
+		_, err := ws.deleteFrom(table).
+			Where(operations, instanceIDs, instanceIDs).
+			Exec()
+		if err != nil {
+			return dberr.Internal("Failed to delete records from %s table: %s", table, err)
+		}
+	}
+
+	return nil
+}
+
 func (ws writeSession) InsertLMSTenant(dto dbmodel.LMSTenantDTO) dberr.Error {
 	_, err := ws.insertInto(postsql.LMSTenantTableName).
 		Pair("id", dto.ID).
@@ -191,10 +343,8 @@ func (ws writeSession) InsertLMSTenant(dto dbmodel.LMSTenantDTO) dberr.Error {
 		Exec()
 
 	if err != nil {
-		if err, ok := err.(*pq.Error); ok {
-			if err.Code == UniqueViolationErrorCode {
-				return dberr.AlreadyExists("lms tenant already exist")
-			}
+		if ws.dialect.isUniqueViolation(err) {
+			return dberr.AlreadyExists("lms tenant already exist")
 		}
 		return dberr.Internal("Failed to insert record to lms tenant table: %s", err)
 	}
@@ -202,6 +352,46 @@ func (ws writeSession) InsertLMSTenant(dto dbmodel.LMSTenantDTO) dberr.Error {
 	return nil
 }
 
+func (ws writeSession) InsertAccountAssignment(dto dbmodel.AccountAssignmentDTO) dberr.Error {
+	_, err := ws.insertInto(postsql.AccountAssignmentTableName).
+		Pair("id", dto.ID).
+		Pair("secret_name", dto.SecretName).
+		Pair("hyperscaler_type", dto.HyperscalerType).
+		Pair("tenant_name", dto.TenantName).
+		Pair("created_at", dto.CreatedAt).
+		Pair("released_at", dto.ReleasedAt).
+		Exec()
+
+	if err != nil {
+		if ws.dialect.isUniqueViolation(err) {
+			return dberr.AlreadyExists("active assignment of the secret %s already exists", dto.SecretName)
+		}
+		return dberr.Internal("Failed to insert record to account assignments table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) ReleaseAccountAssignment(secretName string, releasedAt time.Time) dberr.Error {
+	res, err := ws.update(postsql.AccountAssignmentTableName).
+		Where(dbr.Eq("secret_name", secretName)).
+		Where("released_at IS NULL").
+		Set("released_at", releasedAt).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update record in account assignments table: %s", err)
+	}
+	rAffected, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Internal("Failed to get number of affected rows: %s", err)
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find active assignment of the secret %s", secretName)
+	}
+
+	return nil
+}
+
 func (ws writeSession) UpdateOperation(op dbmodel.OperationDTO) dberr.Error {
 	res, err := ws.update(postsql.OperationTableName).
 		Where(dbr.Eq("id", op.ID)).
@@ -257,6 +447,14 @@ func (ws writeSession) insertInto(table string) *dbr.InsertStmt {
 	return ws.session.InsertInto(table)
 }
 
+func (ws writeSession) insertBySql(query string, values ...interface{}) *dbr.InsertStmt {
+	if ws.transaction != nil {
+		return ws.transaction.InsertBySql(query, values...)
+	}
+
+	return ws.session.InsertBySql(query, values...)
+}
+
 func (ws writeSession) deleteFrom(table string) *dbr.DeleteStmt {
 	if ws.transaction != nil {
 		return ws.transaction.DeleteFrom(table)
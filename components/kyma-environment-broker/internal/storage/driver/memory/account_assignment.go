@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+)
+
+type accountAssignments struct {
+	mu sync.Mutex
+
+	data map[string]internal.AccountAssignment
+}
+
+func NewAccountAssignments() *accountAssignments {
+	return &accountAssignments{
+		data: make(map[string]internal.AccountAssignment, 0),
+	}
+}
+
+func (s *accountAssignments) Insert(assignment internal.AccountAssignment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.data[assignment.ID]; exists {
+		return dberr.AlreadyExists("account assignment with id %s already exists", assignment.ID)
+	}
+	if assignment.ReleasedAt == nil {
+		if _, found := s.findActive(assignment.SecretName); found {
+			return dberr.AlreadyExists("active assignment of the secret %s already exists", assignment.SecretName)
+		}
+	}
+	s.data[assignment.ID] = assignment
+
+	return nil
+}
+
+func (s *accountAssignments) Release(secretName string, releasedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	assignment, found := s.findActive(secretName)
+	if !found {
+		return dberr.NotFound("Cannot find active assignment of the secret %s", secretName)
+	}
+	assignment.ReleasedAt = &releasedAt
+	s.data[assignment.ID] = assignment
+
+	return nil
+}
+
+func (s *accountAssignments) ListActive() ([]internal.AccountAssignment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	assignments := make([]internal.AccountAssignment, 0)
+	for _, assignment := range s.data {
+		if assignment.ReleasedAt == nil {
+			assignments = append(assignments, assignment)
+		}
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		return assignments[i].CreatedAt.Before(assignments[j].CreatedAt)
+	})
+
+	return assignments, nil
+}
+
+func (s *accountAssignments) findActive(secretName string) (internal.AccountAssignment, bool) {
+	for _, assignment := range s.data {
+		if assignment.SecretName == secretName && assignment.ReleasedAt == nil {
+			return assignment, true
+		}
+	}
+	return internal.AccountAssignment{}, false
+}
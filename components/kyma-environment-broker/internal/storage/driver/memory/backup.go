@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
+	"github.com/pkg/errors"
+)
+
+// errRestoreNotSupported is returned on restoring, the in-memory storage is not persisted, so it is never restored from a backup
+var errRestoreNotSupported = errors.New("the in-memory storage does not support restoring from a backup")
+
+// backup lists no records, the in-memory storage is not persisted, so there is no state to back up
+type backup struct{}
+
+func NewBackup() *backup {
+	return &backup{}
+}
+
+func (s *backup) ListInstances(afterID string, limit int) ([]internal.Instance, error) {
+	return nil, nil
+}
+
+func (s *backup) ListOperations(afterID string, limit int) ([]dbmodel.OperationDTO, error) {
+	return nil, nil
+}
+
+func (s *backup) ListOrchestrations(afterID string, limit int) ([]internal.Orchestration, error) {
+	return nil, nil
+}
+
+func (s *backup) ListRuntimeStates(afterID string, limit int) ([]internal.RuntimeState, error) {
+	return nil, nil
+}
+
+func (s *backup) ListLMSTenants(afterID string, limit int) ([]internal.LMSTenant, error) {
+	return nil, nil
+}
+
+func (s *backup) ListAccountAssignments(afterID string, limit int) ([]internal.AccountAssignment, error) {
+	return nil, nil
+}
+
+func (s *backup) ListArchivedOperations(afterID string, limit int) ([]dbmodel.ArchivedOperationDTO, error) {
+	return nil, nil
+}
+
+func (s *backup) ListArchivedRuntimeStates(afterID string, limit int) ([]internal.ArchivedRuntimeState, error) {
+	return nil, nil
+}
+
+func (s *backup) RestoreInstance(instance internal.Instance) (bool, error) {
+	return false, errRestoreNotSupported
+}
+
+func (s *backup) RestoreOperation(operation dbmodel.OperationDTO) (bool, error) {
+	return false, errRestoreNotSupported
+}
+
+func (s *backup) RestoreOrchestration(orchestration internal.Orchestration) (bool, error) {
+	return false, errRestoreNotSupported
+}
+
+func (s *backup) RestoreRuntimeState(state internal.RuntimeState) (bool, error) {
+	return false, errRestoreNotSupported
+}
+
+func (s *backup) RestoreLMSTenant(tenant internal.LMSTenant) (bool, error) {
+	return false, errRestoreNotSupported
+}
+
+func (s *backup) RestoreAccountAssignment(assignment internal.AccountAssignment) (bool, error) {
+	return false, errRestoreNotSupported
+}
+
+func (s *backup) RestoreArchivedOperation(operation dbmodel.ArchivedOperationDTO) (bool, error) {
+	return false, errRestoreNotSupported
+}
+
+func (s *backup) RestoreArchivedRuntimeState(state internal.ArchivedRuntimeState) (bool, error) {
+	return false, errRestoreNotSupported
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"sort"
 	"sync"
+	"time"
 
 	"fmt"
 
@@ -13,17 +14,21 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/predicate"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
 )
 
 type Instance struct {
 	mu                sync.Mutex
 	instances         map[string]internal.Instance
+	labels            map[string]map[string]string
 	operationsStorage *operations
 }
 
 func NewInstance(operations *operations) *Instance {
 	return &Instance{
 		instances:         make(map[string]internal.Instance, 0),
+		labels:            make(map[string]map[string]string),
 		operationsStorage: operations,
 	}
 }
@@ -47,26 +52,31 @@ func (s *Instance) FindAllJoinedWithOperations(prct ...predicate.Predicate) ([]i
 
 		if !dberr.IsNotFound(dErr) {
 			instances = append(instances, internal.InstanceWithOperation{
-				Instance:    v,
-				Type:        sql.NullString{String: string(dbmodel.OperationTypeDeprovision), Valid: true},
-				State:       sql.NullString{String: string(dOp.State), Valid: true},
-				Description: sql.NullString{String: dOp.Description, Valid: true},
+				Instance:           v,
+				Type:               sql.NullString{String: string(dbmodel.OperationTypeDeprovision), Valid: true},
+				State:              sql.NullString{String: string(dOp.State), Valid: true},
+				Description:        sql.NullString{String: dOp.Description, Valid: true},
+				OperationCreatedAt: sql.NullTime{Time: dOp.CreatedAt, Valid: true},
 			})
 		}
 		if !dberr.IsNotFound(pErr) {
 			instances = append(instances, internal.InstanceWithOperation{
-				Instance:    v,
-				Type:        sql.NullString{String: string(dbmodel.OperationTypeProvision), Valid: true},
-				State:       sql.NullString{String: string(pOp.State), Valid: true},
-				Description: sql.NullString{String: pOp.Description, Valid: true},
+				Instance:           v,
+				Type:               sql.NullString{String: string(dbmodel.OperationTypeProvision), Valid: true},
+				State:              sql.NullString{String: string(pOp.State), Valid: true},
+				Description:        sql.NullString{String: pOp.Description, Valid: true},
+				OperationCreatedAt: sql.NullTime{Time: pOp.CreatedAt, Valid: true},
+				KymaVersion:        installedKymaVersion(pOp.Operation, pOp.RuntimeVersion, false),
 			})
 		}
 		if !dberr.IsNotFound(uErr) {
 			instances = append(instances, internal.InstanceWithOperation{
-				Instance:    v,
-				Type:        sql.NullString{String: string(dbmodel.OperationTypeUpgradeKyma), Valid: true},
-				State:       sql.NullString{String: string(uOp.State), Valid: true},
-				Description: sql.NullString{String: uOp.Description, Valid: true},
+				Instance:           v,
+				Type:               sql.NullString{String: string(dbmodel.OperationTypeUpgradeKyma), Valid: true},
+				State:              sql.NullString{String: string(uOp.State), Valid: true},
+				Description:        sql.NullString{String: uOp.Description, Valid: true},
+				OperationCreatedAt: sql.NullTime{Time: uOp.CreatedAt, Valid: true},
+				KymaVersion:        installedKymaVersion(uOp.Operation, uOp.RuntimeVersion, uOp.DryRun),
 			})
 		}
 		if dberr.IsNotFound(dErr) && dberr.IsNotFound(pErr) {
@@ -75,12 +85,20 @@ func (s *Instance) FindAllJoinedWithOperations(prct ...predicate.Predicate) ([]i
 	}
 
 	for _, p := range prct {
-		p.ApplyToInMemory(instances)
+		instances = p.ApplyToInMemory(instances)
 	}
 
 	return instances, nil
 }
 
+// installedKymaVersion returns the Kyma version if the operation succeeded to install or upgrade Kyma
+func installedKymaVersion(op internal.Operation, version internal.RuntimeVersionData, dryRun bool) sql.NullString {
+	if op.State != domain.Succeeded || dryRun {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: version.Version, Valid: true}
+}
+
 func (s *Instance) FindAllInstancesForRuntimes(runtimeIdList []string) ([]internal.Instance, error) {
 	var instances []internal.Instance
 
@@ -137,12 +155,14 @@ func (s *Instance) Delete(instanceID string) error {
 	defer s.mu.Unlock()
 
 	delete(s.instances, instanceID)
+	delete(s.labels, instanceID)
 	return nil
 }
 
 func (s *Instance) Insert(instance internal.Instance) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	instance.Labels = nil
 	s.instances[instance.InstanceID] = instance
 
 	return nil
@@ -151,6 +171,7 @@ func (s *Instance) Insert(instance internal.Instance) error {
 func (s *Instance) Update(instance internal.Instance) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	instance.Labels = nil
 	s.instances[instance.InstanceID] = instance
 
 	return nil
@@ -168,10 +189,17 @@ func (s *Instance) List(filter dbmodel.InstanceFilter) ([]internal.Instance, int
 	offset := pagination.ConvertPageAndPageSizeToOffset(filter.PageSize, filter.Page)
 
 	instances := s.filterInstances(filter)
-	sortInstancesByCreatedAt(instances)
+	sortInstances(instances, filter.SortBy, filter.SortDesc)
+	if filter.Cursor != nil {
+		offset = startAfterCursor(filter.Cursor, filter.SortDesc, len(instances), func(i int) (time.Time, string) {
+			return instanceTimestamp(instances[i], filter.SortBy), instances[i].InstanceID
+		})
+	}
 
 	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(instances); i++ {
-		toReturn = append(toReturn, s.instances[instances[i].InstanceID])
+		instance := s.instances[instances[i].InstanceID]
+		instance.Labels = s.copyLabels(instance.InstanceID)
+		toReturn = append(toReturn, instance)
 	}
 
 	return toReturn,
@@ -180,12 +208,77 @@ func (s *Instance) List(filter dbmodel.InstanceFilter) ([]internal.Instance, int
 		nil
 }
 
-func sortInstancesByCreatedAt(instances []internal.Instance) {
+func (s *Instance) GetLabels(instanceID string) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.instances[instanceID]; !ok {
+		return nil, dberr.NotFound("instance with id %s not exist", instanceID)
+	}
+
+	labels := s.copyLabels(instanceID)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return labels, nil
+}
+
+func (s *Instance) SetLabels(instanceID string, labels map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.instances[instanceID]; !ok {
+		return dberr.NotFound("instance with id %s not exist", instanceID)
+	}
+
+	if s.labels[instanceID] == nil {
+		s.labels[instanceID] = make(map[string]string)
+	}
+	for key, value := range labels {
+		s.labels[instanceID][key] = value
+	}
+	return nil
+}
+
+func (s *Instance) DeleteLabels(instanceID string, keys []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.instances[instanceID]; !ok {
+		return dberr.NotFound("instance with id %s not exist", instanceID)
+	}
+
+	for _, key := range keys {
+		delete(s.labels[instanceID], key)
+	}
+	if len(s.labels[instanceID]) == 0 {
+		delete(s.labels, instanceID)
+	}
+	return nil
+}
+
+func (s *Instance) copyLabels(instanceID string) map[string]string {
+	if len(s.labels[instanceID]) == 0 {
+		return nil
+	}
+	labels := make(map[string]string, len(s.labels[instanceID]))
+	for key, value := range s.labels[instanceID] {
+		labels[key] = value
+	}
+	return labels
+}
+
+func sortInstances(instances []internal.Instance, sortBy dbmodel.InstanceSortField, desc bool) {
 	sort.Slice(instances, func(i, j int) bool {
-		return instances[i].CreatedAt.Before(instances[j].CreatedAt)
+		return less(instanceTimestamp(instances[i], sortBy), instances[i].InstanceID,
+			instanceTimestamp(instances[j], sortBy), instances[j].InstanceID) != desc
 	})
 }
 
+func instanceTimestamp(instance internal.Instance, sortBy dbmodel.InstanceSortField) time.Time {
+	if sortBy == dbmodel.InstanceSortByUpdatedAt {
+		return instance.UpdatedAt
+	}
+	return instance.CreatedAt
+}
+
 func (s *Instance) filterInstances(filter dbmodel.InstanceFilter) []internal.Instance {
 	inst := make([]internal.Instance, 0, len(s.instances))
 	var ok bool
@@ -224,6 +317,26 @@ func (s *Instance) filterInstances(filter dbmodel.InstanceFilter) []internal.Ins
 		if ok = matchFilter(v.DashboardURL, filter.Domains, domainMatch); !ok {
 			continue
 		}
+		if len(filter.States) > 0 || len(filter.OperationTypes) > 0 {
+			state, opType, found := s.operationsStorage.lastOperation(v.InstanceID)
+			if !found {
+				continue
+			}
+			if ok = matchFilter(string(state), filter.States, equal); !ok {
+				continue
+			}
+			if ok = matchFilter(string(opType), filter.OperationTypes, equal); !ok {
+				continue
+			}
+		}
+		if len(filter.KymaVersions) > 0 {
+			if ok = matchFilter(s.operationsStorage.kymaVersion(v.InstanceID), filter.KymaVersions, equal); !ok {
+				continue
+			}
+		}
+		if ok = matchLabels(s.labels[v.InstanceID], filter.Labels); !ok {
+			continue
+		}
 
 		inst = append(inst, v)
 	}
@@ -231,6 +344,35 @@ func (s *Instance) filterInstances(filter dbmodel.InstanceFilter) []internal.Ins
 	return inst
 }
 
+// less orders the items by the time and then by the ID, the same as the keyset pagination
+func less(t1 time.Time, id1 string, t2 time.Time, id2 string) bool {
+	if !t1.Equal(t2) {
+		return t1.Before(t2)
+	}
+	return id1 < id2
+}
+
+// startAfterCursor returns the index of the first of the sorted items which follows the cursor
+func startAfterCursor(cursor *pagination.Cursor, desc bool, count int, key func(i int) (time.Time, string)) int {
+	for i := 0; i < count; i++ {
+		t, id := key(i)
+		if cursor.Precedes(t, id, desc) {
+			return i
+		}
+	}
+	return count
+}
+
+// matchLabels returns true if the labels contain all of the selector labels
+func matchLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
 func matchFilter(value string, filters []string, match func(string, string) bool) bool {
 	if len(filters) == 0 {
 		return true
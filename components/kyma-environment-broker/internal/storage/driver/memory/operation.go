@@ -3,6 +3,7 @@ package memory
 import (
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 
@@ -281,7 +282,9 @@ func (s *operations) GetOperationStatsForOrchestration(orchestrationID string) (
 		domain.Failed:     0,
 	}
 	for _, op := range s.upgradeKymaOperations {
-		result[op.State] = result[op.State] + 1
+		if op.OrchestrationID == orchestrationID {
+			result[op.State] = result[op.State] + 1
+		}
 	}
 	return result, nil
 }
@@ -293,11 +296,21 @@ func (s *operations) ListUpgradeKymaOperationsByOrchestrationID(orchestrationID
 	result := make([]internal.UpgradeKymaOperation, 0)
 	offset := pagination.ConvertPageAndPageSizeToOffset(filter.PageSize, filter.Page)
 
-	operations := s.filterUpgrade(filter)
+	operations := make([]internal.UpgradeKymaOperation, 0)
+	for _, op := range s.filterUpgrade(filter) {
+		if op.OrchestrationID == orchestrationID {
+			operations = append(operations, op)
+		}
+	}
 	s.sortUpgradeByCreatedAt(operations)
+	if filter.Cursor != nil {
+		offset = startAfterCursor(filter.Cursor, false, len(operations), func(i int) (time.Time, string) {
+			return operations[i].CreatedAt, operations[i].Operation.ID
+		})
+	}
 
-	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations)+offset; i++ {
-		result = append(result, s.upgradeKymaOperations[operations[i].OrchestrationID])
+	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations); i++ {
+		result = append(result, operations[i])
 	}
 
 	return result,
@@ -319,7 +332,7 @@ func (s *operations) ListUpgradeKymaOperationsByInstanceID(instanceID string) ([
 
 func (s *operations) sortUpgradeByCreatedAt(operations []internal.UpgradeKymaOperation) {
 	sort.Slice(operations, func(i, j int) bool {
-		return operations[i].CreatedAt.Before(operations[j].CreatedAt)
+		return less(operations[i].CreatedAt, operations[i].Operation.ID, operations[j].CreatedAt, operations[j].Operation.ID)
 	})
 }
 
@@ -336,3 +349,59 @@ func (s *operations) filterUpgrade(filter dbmodel.OperationFilter) []internal.Up
 
 	return operations
 }
+
+// lastOperation returns the state and the type of the most recent operation of the instance
+func (s *operations) lastOperation(instanceID string) (domain.LastOperationState, dbmodel.OperationType, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		last   internal.Operation
+		opType dbmodel.OperationType
+		found  bool
+	)
+	consider := func(op internal.Operation, t dbmodel.OperationType) {
+		if op.InstanceID != instanceID || (found && !op.CreatedAt.After(last.CreatedAt)) {
+			return
+		}
+		last, opType, found = op, t, true
+	}
+	for _, op := range s.provisioningOperations {
+		consider(op.Operation, dbmodel.OperationTypeProvision)
+	}
+	for _, op := range s.deprovisioningOperations {
+		consider(op.Operation, dbmodel.OperationTypeDeprovision)
+	}
+	for _, op := range s.upgradeKymaOperations {
+		consider(op.Operation, dbmodel.OperationTypeUpgradeKyma)
+	}
+
+	return last.State, opType, found
+}
+
+// kymaVersion returns the Kyma version of the most recent succeeded provisioning or non dry run Kyma upgrade operation of the instance
+func (s *operations) kymaVersion(instanceID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		version   string
+		createdAt time.Time
+	)
+	consider := func(op internal.Operation, v internal.RuntimeVersionData) {
+		if op.InstanceID != instanceID || op.State != domain.Succeeded || (version != "" && !op.CreatedAt.After(createdAt)) {
+			return
+		}
+		version, createdAt = v.Version, op.CreatedAt
+	}
+	for _, op := range s.provisioningOperations {
+		consider(op.Operation, op.RuntimeVersion)
+	}
+	for _, op := range s.upgradeKymaOperations {
+		if !op.DryRun {
+			consider(op.Operation, op.RuntimeVersion)
+		}
+	}
+
+	return version
+}
@@ -3,6 +3,7 @@ package memory
 import (
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 
@@ -52,6 +53,11 @@ func (s *orchestration) List(filter dbmodel.OrchestrationFilter) ([]internal.Orc
 
 	orchestrations := s.filter(filter)
 	s.sortByCreatedAt(orchestrations)
+	if filter.Cursor != nil {
+		offset = startAfterCursor(filter.Cursor, false, len(orchestrations), func(i int) (time.Time, string) {
+			return orchestrations[i].CreatedAt, orchestrations[i].OrchestrationID
+		})
+	}
 
 	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(orchestrations); i++ {
 		result = append(result, s.orchestrations[orchestrations[i].OrchestrationID])
@@ -89,9 +95,21 @@ func (s *orchestration) ListByState(state string) ([]internal.Orchestration, err
 	return result, nil
 }
 
+func (s *orchestration) CountByState() (map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make(map[string]int)
+
+	for _, o := range s.orchestrations {
+		result[o.State]++
+	}
+
+	return result, nil
+}
+
 func (s *orchestration) sortByCreatedAt(orchestrations []internal.Orchestration) {
 	sort.Slice(orchestrations, func(i, j int) bool {
-		return orchestrations[i].CreatedAt.Before(orchestrations[j].CreatedAt)
+		return less(orchestrations[i].CreatedAt, orchestrations[i].OrchestrationID, orchestrations[j].CreatedAt, orchestrations[j].OrchestrationID)
 	})
 }
 
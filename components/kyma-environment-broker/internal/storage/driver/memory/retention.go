@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"time"
+)
+
+// retention is a no-op, the in-memory storage is not persisted, so it keeps no history to archive or purge
+type retention struct{}
+
+func NewRetention() *retention {
+	return &retention{}
+}
+
+func (s *retention) CountOperationsToArchive(before time.Time) (int, error) {
+	return 0, nil
+}
+
+func (s *retention) ArchiveOperations(before time.Time, limit int) (int, error) {
+	return 0, nil
+}
+
+func (s *retention) CountRuntimeStatesToArchive(before time.Time) (int, error) {
+	return 0, nil
+}
+
+func (s *retention) ArchiveRuntimeStates(before time.Time, limit int) (int, error) {
+	return 0, nil
+}
+
+func (s *retention) CountInstancesToPurge(deprovisionedBefore time.Time) (int, error) {
+	return 0, nil
+}
+
+func (s *retention) PurgeInstances(deprovisionedBefore time.Time, limit int) (int, error) {
+	return 0, nil
+}
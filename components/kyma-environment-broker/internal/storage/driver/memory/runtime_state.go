@@ -1,9 +1,11 @@
 package memory
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 )
@@ -39,6 +41,9 @@ func (s *runtimeState) ListByRuntimeID(runtimeID string) ([]internal.RuntimeStat
 			result = append(result, state)
 		}
 	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
 
 	return result, nil
 }
@@ -55,3 +60,8 @@ func (s *runtimeState) GetByOperationID(operationID string) (internal.RuntimeSta
 
 	return internal.RuntimeState{}, dberr.NotFound("runtime state with operation ID %s not found", operationID)
 }
+
+// ReEncrypt does nothing, the states are not encrypted in memory
+func (s *runtimeState) ReEncrypt(afterID string, limit int) (dbmodel.ReEncryptionBatch, error) {
+	return dbmodel.ReEncryptionBatch{}, nil
+}
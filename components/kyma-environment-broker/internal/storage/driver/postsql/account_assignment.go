@@ -0,0 +1,73 @@
+package postsql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
+)
+
+type accountAssignments struct {
+	dbsession.Factory
+}
+
+func NewAccountAssignments(sess dbsession.Factory) *accountAssignments {
+	return &accountAssignments{
+		Factory: sess,
+	}
+}
+
+func (s *accountAssignments) Insert(assignment internal.AccountAssignment) error {
+	sess := s.NewWriteSession()
+	return sess.InsertAccountAssignment(accountAssignmentToDTO(assignment))
+}
+
+func (s *accountAssignments) Release(secretName string, releasedAt time.Time) error {
+	sess := s.NewWriteSession()
+	return sess.ReleaseAccountAssignment(secretName, releasedAt)
+}
+
+func (s *accountAssignments) ListActive() ([]internal.AccountAssignment, error) {
+	sess := s.NewReadSession()
+	dtos, err := sess.ListActiveAccountAssignments()
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountAssignments(dtos), nil
+}
+
+func accountAssignmentToDTO(assignment internal.AccountAssignment) dbmodel.AccountAssignmentDTO {
+	dto := dbmodel.AccountAssignmentDTO{
+		ID:              assignment.ID,
+		SecretName:      assignment.SecretName,
+		HyperscalerType: assignment.HyperscalerType,
+		TenantName:      assignment.TenantName,
+		CreatedAt:       assignment.CreatedAt,
+	}
+	if assignment.ReleasedAt != nil {
+		dto.ReleasedAt = sql.NullTime{Time: *assignment.ReleasedAt, Valid: true}
+	}
+	return dto
+}
+
+func toAccountAssignments(dtos []dbmodel.AccountAssignmentDTO) []internal.AccountAssignment {
+	assignments := make([]internal.AccountAssignment, 0, len(dtos))
+	for _, dto := range dtos {
+		assignment := internal.AccountAssignment{
+			ID:              dto.ID,
+			SecretName:      dto.SecretName,
+			HyperscalerType: dto.HyperscalerType,
+			TenantName:      dto.TenantName,
+			CreatedAt:       dto.CreatedAt,
+		}
+		if dto.ReleasedAt.Valid {
+			releasedAt := dto.ReleasedAt.Time
+			assignment.ReleasedAt = &releasedAt
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments
+}
@@ -0,0 +1,222 @@
+package postsql
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
+	"github.com/pkg/errors"
+)
+
+type backup struct {
+	dbsession.Factory
+
+	// runtimeStates converts the states and encrypts their secrets
+	runtimeStates *runtimeState
+}
+
+func NewBackup(sess dbsession.Factory, cipher Cipher) *backup {
+	return &backup{
+		Factory:       sess,
+		runtimeStates: NewRuntimeStates(sess, cipher),
+	}
+}
+
+func (s *backup) ListInstances(afterID string, limit int) ([]internal.Instance, error) {
+	sess := s.NewReadSession()
+	instances, dbErr := sess.ListInstancesAfterID(afterID, limit)
+	if dbErr != nil {
+		return nil, errors.Wrap(dbErr, "while listing instances")
+	}
+
+	ids := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		ids = append(ids, instance.InstanceID)
+	}
+	labels, dbErr := sess.ListInstanceLabels(ids)
+	if dbErr != nil {
+		return nil, errors.Wrap(dbErr, "while listing instance labels")
+	}
+	byInstance := map[string]map[string]string{}
+	for _, label := range labels {
+		if byInstance[label.InstanceID] == nil {
+			byInstance[label.InstanceID] = map[string]string{}
+		}
+		byInstance[label.InstanceID][label.Key] = label.Value
+	}
+	for i := range instances {
+		instances[i].Labels = byInstance[instances[i].InstanceID]
+	}
+
+	return instances, nil
+}
+
+func (s *backup) ListOperations(afterID string, limit int) ([]dbmodel.OperationDTO, error) {
+	operations, err := s.NewReadSession().ListOperationsAfterID(afterID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing operations")
+	}
+	return operations, nil
+}
+
+func (s *backup) ListOrchestrations(afterID string, limit int) ([]internal.Orchestration, error) {
+	dtos, dbErr := s.NewReadSession().ListOrchestrationsAfterID(afterID, limit)
+	if dbErr != nil {
+		return nil, errors.Wrap(dbErr, "while listing orchestrations")
+	}
+
+	orchestrations := make([]internal.Orchestration, 0, len(dtos))
+	for _, dto := range dtos {
+		o, err := dto.ToOrchestration()
+		if err != nil {
+			return nil, errors.Wrapf(err, "while converting orchestration %s", dto.OrchestrationID)
+		}
+		orchestrations = append(orchestrations, o)
+	}
+	return orchestrations, nil
+}
+
+func (s *backup) ListRuntimeStates(afterID string, limit int) ([]internal.RuntimeState, error) {
+	states, err := s.NewReadSession().ListRuntimeStatesAfterID(afterID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing runtime states")
+	}
+	return s.runtimeStates.toRuntimeStates(states)
+}
+
+func (s *backup) ListLMSTenants(afterID string, limit int) ([]internal.LMSTenant, error) {
+	dtos, err := s.NewReadSession().ListLMSTenantsAfterID(afterID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing lms tenants")
+	}
+
+	tenants := make([]internal.LMSTenant, 0, len(dtos))
+	for _, dto := range dtos {
+		tenants = append(tenants, internal.LMSTenant{
+			ID:        dto.ID,
+			Name:      dto.Name,
+			Region:    dto.Region,
+			CreatedAt: dto.CreatedAt,
+		})
+	}
+	return tenants, nil
+}
+
+func (s *backup) ListAccountAssignments(afterID string, limit int) ([]internal.AccountAssignment, error) {
+	dtos, err := s.NewReadSession().ListAccountAssignmentsAfterID(afterID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing account assignments")
+	}
+	return toAccountAssignments(dtos), nil
+}
+
+func (s *backup) ListArchivedOperations(afterID string, limit int) ([]dbmodel.ArchivedOperationDTO, error) {
+	operations, err := s.NewReadSession().ListArchivedOperationsAfterID(afterID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing archived operations")
+	}
+	return operations, nil
+}
+
+func (s *backup) ListArchivedRuntimeStates(afterID string, limit int) ([]internal.ArchivedRuntimeState, error) {
+	dtos, err := s.NewReadSession().ListArchivedRuntimeStatesAfterID(afterID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing archived runtime states")
+	}
+
+	states := make([]internal.ArchivedRuntimeState, 0, len(dtos))
+	for _, dto := range dtos {
+		state, err := s.runtimeStates.toRuntimeState(&dto.RuntimeStateDTO)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while converting archived runtime state %s", dto.ID)
+		}
+		states = append(states, internal.ArchivedRuntimeState{RuntimeState: state, ArchivedAt: dto.ArchivedAt})
+	}
+	return states, nil
+}
+
+func (s *backup) RestoreInstance(instance internal.Instance) (bool, error) {
+	sess, dbErr := s.NewSessionWithinTransaction()
+	if dbErr != nil {
+		return false, errors.Wrap(dbErr, "while starting transaction")
+	}
+	defer sess.RollbackUnlessCommitted()
+
+	dbErr = sess.RestoreInstance(instance)
+	switch {
+	case dberr.IsAlreadyExists(dbErr):
+		return false, nil
+	case dbErr != nil:
+		return false, errors.Wrapf(dbErr, "while inserting instance %s", instance.InstanceID)
+	}
+	for key, value := range instance.Labels {
+		dbErr := sess.UpsertInstanceLabel(dbmodel.InstanceLabelDTO{InstanceID: instance.InstanceID, Key: key, Value: value})
+		if dbErr != nil {
+			return false, errors.Wrapf(dbErr, "while inserting labels of instance %s", instance.InstanceID)
+		}
+	}
+	if dbErr := sess.Commit(); dbErr != nil {
+		return false, errors.Wrap(dbErr, "while committing transaction")
+	}
+
+	return true, nil
+}
+
+func (s *backup) RestoreOperation(operation dbmodel.OperationDTO) (bool, error) {
+	return restored(s.NewWriteSession().InsertOperation(operation), "operation %s", operation.ID)
+}
+
+func (s *backup) RestoreOrchestration(orchestration internal.Orchestration) (bool, error) {
+	dto, err := dbmodel.NewOrchestrationDTO(orchestration)
+	if err != nil {
+		return false, errors.Wrapf(err, "while converting orchestration %s", orchestration.OrchestrationID)
+	}
+	return restored(s.NewWriteSession().InsertOrchestration(dto), "orchestration %s", orchestration.OrchestrationID)
+}
+
+func (s *backup) RestoreRuntimeState(state internal.RuntimeState) (bool, error) {
+	dto, err := s.runtimeStates.runtimeStateToDB(state)
+	if err != nil {
+		return false, errors.Wrapf(err, "while converting runtime state %s", state.ID)
+	}
+	return restored(s.NewWriteSession().InsertRuntimeState(dto), "runtime state %s", state.ID)
+}
+
+func (s *backup) RestoreLMSTenant(tenant internal.LMSTenant) (bool, error) {
+	dbErr := s.NewWriteSession().InsertLMSTenant(dbmodel.LMSTenantDTO{
+		ID:        tenant.ID,
+		Name:      tenant.Name,
+		Region:    tenant.Region,
+		CreatedAt: tenant.CreatedAt,
+	})
+	return restored(dbErr, "lms tenant %s", tenant.ID)
+}
+
+func (s *backup) RestoreAccountAssignment(assignment internal.AccountAssignment) (bool, error) {
+	dbErr := s.NewWriteSession().InsertAccountAssignment(accountAssignmentToDTO(assignment))
+	return restored(dbErr, "account assignment %s", assignment.ID)
+}
+
+func (s *backup) RestoreArchivedOperation(operation dbmodel.ArchivedOperationDTO) (bool, error) {
+	return restored(s.NewWriteSession().InsertArchivedOperation(operation), "archived operation %s", operation.ID)
+}
+
+func (s *backup) RestoreArchivedRuntimeState(state internal.ArchivedRuntimeState) (bool, error) {
+	dto, err := s.runtimeStates.runtimeStateToDB(state.RuntimeState)
+	if err != nil {
+		return false, errors.Wrapf(err, "while converting archived runtime state %s", state.ID)
+	}
+	dbErr := s.NewWriteSession().InsertArchivedRuntimeState(dbmodel.ArchivedRuntimeStateDTO{RuntimeStateDTO: dto, ArchivedAt: state.ArchivedAt})
+	return restored(dbErr, "archived runtime state %s", state.ID)
+}
+
+// restored reports whether the record was inserted, the existing records are skipped
+func restored(dbErr dberr.Error, format string, args ...interface{}) (bool, error) {
+	switch {
+	case dberr.IsAlreadyExists(dbErr):
+		return false, nil
+	case dbErr != nil:
+		return false, errors.Wrapf(dbErr, "while inserting "+format, args...)
+	}
+	return true, nil
+}
@@ -3,4 +3,6 @@ package postsql
 type Cipher interface {
 	Encrypt(text []byte) ([]byte, error)
 	Decrypt(text []byte) ([]byte, error)
+	// KeyPrefix returns the prefix of the values encrypted with the active key
+	KeyPrefix() string
 }
@@ -176,5 +176,90 @@ func (s *Instance) GetInstanceStats() (internal.InstanceStats, error) {
 }
 
 func (s *Instance) List(filter dbmodel.InstanceFilter) ([]internal.Instance, int, int, error) {
-	return s.NewReadSession().ListInstances(filter)
+	sess := s.NewReadSession()
+	instances, count, totalCount, err := sess.ListInstances(filter)
+	if err != nil {
+		return nil, -1, -1, err
+	}
+
+	ids := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		ids = append(ids, instance.InstanceID)
+	}
+	labels, err := sess.ListInstanceLabels(ids)
+	if err != nil {
+		return nil, -1, -1, errors.Wrap(err, "while fetching instance labels")
+	}
+	labelsByInstance := labelsToMaps(labels)
+	for i := range instances {
+		instances[i].Labels = labelsByInstance[instances[i].InstanceID]
+	}
+
+	return instances, count, totalCount, nil
+}
+
+func (s *Instance) GetLabels(instanceID string) (map[string]string, error) {
+	if _, err := s.GetByID(instanceID); err != nil {
+		return nil, err
+	}
+
+	labels, err := s.NewReadSession().ListInstanceLabels([]string{instanceID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while fetching labels of instance %s", instanceID)
+	}
+	result := labelsToMaps(labels)[instanceID]
+	if result == nil {
+		result = map[string]string{}
+	}
+
+	return result, nil
+}
+
+func (s *Instance) SetLabels(instanceID string, labels map[string]string) error {
+	if _, err := s.GetByID(instanceID); err != nil {
+		return err
+	}
+
+	sess, err := s.NewSessionWithinTransaction()
+	if err != nil {
+		return errors.Wrap(err, "while starting transaction")
+	}
+	defer sess.RollbackUnlessCommitted()
+
+	for key, value := range labels {
+		err := sess.UpsertInstanceLabel(dbmodel.InstanceLabelDTO{InstanceID: instanceID, Key: key, Value: value})
+		if err != nil {
+			return errors.Wrapf(err, "while setting label %s of instance %s", key, instanceID)
+		}
+	}
+
+	return sess.Commit()
+}
+
+func (s *Instance) DeleteLabels(instanceID string, keys []string) error {
+	if _, err := s.GetByID(instanceID); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	err := s.NewWriteSession().DeleteInstanceLabels(instanceID, keys)
+	if err != nil {
+		return errors.Wrapf(err, "while deleting labels of instance %s", instanceID)
+	}
+
+	return nil
+}
+
+func labelsToMaps(labels []dbmodel.InstanceLabelDTO) map[string]map[string]string {
+	result := make(map[string]map[string]string)
+	for _, label := range labels {
+		if result[label.InstanceID] == nil {
+			result[label.InstanceID] = make(map[string]string)
+		}
+		result[label.InstanceID][label.Key] = label.Value
+	}
+
+	return result
 }
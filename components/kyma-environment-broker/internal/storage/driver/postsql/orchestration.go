@@ -154,3 +154,15 @@ func (s *orchestration) ListByState(state string) ([]internal.Orchestration, err
 	}
 	return result, nil
 }
+
+func (s *orchestration) CountByState() (map[string]int, error) {
+	entries, err := s.NewReadSession().GetOrchestrationStats()
+	if err != nil {
+		return nil, errors.Wrap(err, "while counting orchestrations by state")
+	}
+	result := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		result[entry.State] = entry.Total
+	}
+	return result, nil
+}
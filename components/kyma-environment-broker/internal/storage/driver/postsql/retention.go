@@ -0,0 +1,118 @@
+package postsql
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession"
+	"github.com/pkg/errors"
+)
+
+type retention struct {
+	dbsession.Factory
+}
+
+func NewRetention(sess dbsession.Factory) *retention {
+	return &retention{
+		Factory: sess,
+	}
+}
+
+func (s *retention) CountOperationsToArchive(before time.Time) (int, error) {
+	count, err := s.NewReadSession().CountOperationsToArchive(before)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *retention) ArchiveOperations(before time.Time, limit int) (int, error) {
+	ids, dbErr := s.NewReadSession().ListOperationIDsToArchive(before, limit)
+	if dbErr != nil {
+		return 0, errors.Wrap(dbErr, "while listing operations to archive")
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	err := s.inTransaction(func(sess dbsession.WriteSession) dberr.Error {
+		return sess.ArchiveOperations(ids)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "while archiving operations")
+	}
+
+	return len(ids), nil
+}
+
+func (s *retention) CountRuntimeStatesToArchive(before time.Time) (int, error) {
+	count, err := s.NewReadSession().CountRuntimeStatesToArchive(before)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *retention) ArchiveRuntimeStates(before time.Time, limit int) (int, error) {
+	ids, dbErr := s.NewReadSession().ListRuntimeStateIDsToArchive(before, limit)
+	if dbErr != nil {
+		return 0, errors.Wrap(dbErr, "while listing runtime states to archive")
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	err := s.inTransaction(func(sess dbsession.WriteSession) dberr.Error {
+		return sess.ArchiveRuntimeStates(ids)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "while archiving runtime states")
+	}
+
+	return len(ids), nil
+}
+
+func (s *retention) CountInstancesToPurge(deprovisionedBefore time.Time) (int, error) {
+	count, err := s.NewReadSession().CountInstancesToPurge(deprovisionedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *retention) PurgeInstances(deprovisionedBefore time.Time, limit int) (int, error) {
+	instanceIDs, dbErr := s.NewReadSession().ListInstanceIDsToPurge(deprovisionedBefore, limit)
+	if dbErr != nil {
+		return 0, errors.Wrap(dbErr, "while listing instances to purge")
+	}
+	if len(instanceIDs) == 0 {
+		return 0, nil
+	}
+	err := s.inTransaction(func(sess dbsession.WriteSession) dberr.Error {
+		// the runtime states are found by the operations, so they are deleted first
+		if dbErr := sess.DeleteRuntimeStatesByInstanceIDs(instanceIDs); dbErr != nil {
+			return dbErr
+		}
+		return sess.PurgeOperationsData(instanceIDs)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "while purging instances")
+	}
+
+	return len(instanceIDs), nil
+}
+
+func (s *retention) inTransaction(fn func(sess dbsession.WriteSession) dberr.Error) error {
+	sess, dbErr := s.NewSessionWithinTransaction()
+	if dbErr != nil {
+		return errors.Wrap(dbErr, "while starting transaction")
+	}
+	defer sess.RollbackUnlessCommitted()
+
+	if dbErr := fn(sess); dbErr != nil {
+		return dbErr
+	}
+	if dbErr := sess.Commit(); dbErr != nil {
+		return errors.Wrap(dbErr, "while committing transaction")
+	}
+
+	return nil
+}
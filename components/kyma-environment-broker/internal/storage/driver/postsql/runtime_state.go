@@ -93,6 +93,36 @@ func (s *runtimeState) GetByOperationID(operationID string) (internal.RuntimeSta
 	return result, nil
 }
 
+func (s *runtimeState) ReEncrypt(afterID string, limit int) (dbmodel.ReEncryptionBatch, error) {
+	batch := dbmodel.ReEncryptionBatch{LastID: afterID}
+	states, err := s.NewReadSession().ListRuntimeStatesWithoutKeyPrefix(s.cipher.KeyPrefix(), afterID, limit)
+	if err != nil {
+		return batch, errors.Wrap(err, "while listing runtime states to re-encrypt")
+	}
+	batch.Listed = len(states)
+
+	sess := s.NewWriteSession()
+	for _, state := range states {
+		kymaCfg, err := s.cipher.Decrypt([]byte(state.KymaConfig))
+		if err != nil {
+			batch.Skipped = append(batch.Skipped, state.ID)
+			batch.LastID = state.ID
+			continue
+		}
+		encKymaCfg, err := s.cipher.Encrypt(kymaCfg)
+		if err != nil {
+			return batch, errors.Wrapf(err, "while encrypting kyma config of runtime state %s", state.ID)
+		}
+		if err := sess.UpdateRuntimeStateKymaConfig(state.ID, string(encKymaCfg)); err != nil {
+			return batch, errors.Wrapf(err, "while updating runtime state %s", state.ID)
+		}
+		batch.ReEncrypted++
+		batch.LastID = state.ID
+	}
+
+	return batch, nil
+}
+
 func (s *runtimeState) runtimeStateToDB(op internal.RuntimeState) (dbmodel.RuntimeStateDTO, error) {
 	kymaCfg, err := json.Marshal(op.KymaConfig)
 	if err != nil {
@@ -6,41 +6,129 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"io"
+	"strings"
 
 	"github.com/pkg/errors"
 )
 
-func NewEncrypter(secretKey string) *Encrypter {
-	return &Encrypter{key: []byte(secretKey)}
+// DefaultSecretKeyID is the ID of the key given in the SecretKey setting
+const DefaultSecretKeyID = "default"
+
+// keyIDSeparator separates the key ID from the ciphertext. The legacy values are base64 encoded, so they never contain it.
+const keyIDSeparator = ":"
+
+// Keyring holds the encryption keys by their IDs. New values are encrypted with the active key,
+// the retired keys only decrypt the values encrypted before the rotation.
+type Keyring struct {
+	ActiveKeyID string
+	Keys        map[string]string
+	// LegacyKey decrypts the values encrypted with AES-CFB, which were stored without the key ID
+	LegacyKey string
 }
 
+// NewKeyring creates the keyring from the SecretKeys setting in the "id1=key1,id2=key2" format.
+// The SecretKey is added to the keyring with the DefaultSecretKeyID and is also used as the legacy key.
+func NewKeyring(cfg Config) (Keyring, error) {
+	keyring := Keyring{
+		ActiveKeyID: cfg.SecretKeyID,
+		Keys:        map[string]string{},
+		LegacyKey:   cfg.SecretKey,
+	}
+	if cfg.SecretKey != "" {
+		keyring.Keys[DefaultSecretKeyID] = cfg.SecretKey
+	}
+	for _, entry := range strings.Split(cfg.SecretKeys, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		kv := strings.SplitN(entry, "=", 2)
+		id := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || id == "" || strings.Contains(id, keyIDSeparator) {
+			return Keyring{}, errors.New("secret keys must be given in the id1=key1,id2=key2 format, the IDs must not contain a colon")
+		}
+		keyring.Keys[id] = kv[1]
+	}
+	if keyring.ActiveKeyID == "" {
+		keyring.ActiveKeyID = DefaultSecretKeyID
+	}
+	if _, found := keyring.Keys[keyring.ActiveKeyID]; !found {
+		return Keyring{}, errors.Errorf("active secret key %s not found in the keyring", keyring.ActiveKeyID)
+	}
+
+	return keyring, nil
+}
+
+func NewEncrypter(keyring Keyring) *Encrypter {
+	return &Encrypter{keyring: keyring}
+}
+
+// Encrypter encrypts the values with AES-GCM and prefixes them with the ID of the key
 type Encrypter struct {
-	key []byte
+	keyring Keyring
 }
 
 func (e *Encrypter) Encrypt(obj []byte) ([]byte, error) {
-	block, err := aes.NewCipher(e.key)
+	aead, err := e.aead(e.keyring.ActiveKeyID)
 	if err != nil {
 		return nil, err
 	}
-	b := base64.StdEncoding.EncodeToString(obj)
-	bytes := make([]byte, aes.BlockSize+len(b))
-	iv := bytes[:aes.BlockSize]
-	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(obj)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
 		return nil, err
 	}
-	cfb := cipher.NewCFBEncrypter(block, iv)
-	cfb.XORKeyStream(bytes[aes.BlockSize:], []byte(b))
+	sealed := aead.Seal(nonce, nonce, obj, nil)
 
-	return []byte(base64.StdEncoding.EncodeToString(bytes)), nil
+	return []byte(e.KeyPrefix() + base64.StdEncoding.EncodeToString(sealed)), nil
 }
 
 func (e *Encrypter) Decrypt(obj []byte) ([]byte, error) {
+	kv := strings.SplitN(string(obj), keyIDSeparator, 2)
+	if len(kv) != 2 {
+		return e.decryptLegacy(obj)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(kv[1])
+	if err != nil {
+		return nil, errors.Wrap(err, "while decoding object")
+	}
+	aead, err := e.aead(kv[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("cipher text is too short")
+	}
+	data, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while decrypting object with key %s", kv[0])
+	}
+	return data, nil
+}
+
+// KeyPrefix returns the prefix of the values encrypted with the active key. The values without it need to be re-encrypted.
+func (e *Encrypter) KeyPrefix() string {
+	return e.keyring.ActiveKeyID + keyIDSeparator
+}
+
+func (e *Encrypter) aead(keyID string) (cipher.AEAD, error) {
+	key, found := e.keyring.Keys[keyID]
+	if !found {
+		return nil, errors.Errorf("secret key %s not found", keyID)
+	}
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return nil, errors.Wrapf(err, "while creating cipher with key %s", keyID)
+	}
+	return cipher.NewGCM(block)
+}
+
+// decryptLegacy decrypts the values encrypted with AES-CFB, which provides no integrity protection
+func (e *Encrypter) decryptLegacy(obj []byte) ([]byte, error) {
 	obj, err := base64.StdEncoding.DecodeString(string(obj))
 	if err != nil {
 		return nil, errors.Wrap(err, "while decoding object")
 	}
-	block, err := aes.NewCipher(e.key)
+	block, err := aes.NewCipher([]byte(e.keyring.LegacyKey))
 	if err != nil {
 		return nil, err
 	}
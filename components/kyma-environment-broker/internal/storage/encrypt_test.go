@@ -1,12 +1,18 @@
 package storage
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"k8s.io/apimachinery/pkg/util/rand"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 )
 
 func TestNewEncrypter(t *testing.T) {
@@ -16,9 +22,9 @@ func TestNewEncrypter(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		secretKey := rand.String(32)
+		secretKey := utilrand.String(32)
 
-		e := NewEncrypter(secretKey)
+		e := NewEncrypter(fixKeyring(secretKey))
 		dto := testDto{
 			Data: secretKey,
 		}
@@ -29,6 +35,7 @@ func TestNewEncrypter(t *testing.T) {
 		enc, err := e.Encrypt(j)
 		require.NoError(t, err)
 		assert.NotEqual(t, j, enc)
+		assert.True(t, strings.HasPrefix(string(enc), e.KeyPrefix()))
 
 		enc, err = e.Decrypt(enc)
 		require.NoError(t, err)
@@ -41,7 +48,7 @@ func TestNewEncrypter(t *testing.T) {
 	t.Run("wrong key", func(t *testing.T) {
 		secretKey := ""
 
-		e := NewEncrypter(secretKey)
+		e := NewEncrypter(fixKeyring(secretKey))
 
 		dto := testDto{
 			Data: secretKey,
@@ -54,4 +61,80 @@ func TestNewEncrypter(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("tampered value", func(t *testing.T) {
+		e := NewEncrypter(fixKeyring(utilrand.String(32)))
+		enc, err := e.Encrypt([]byte("secret"))
+		require.NoError(t, err)
+
+		sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(string(enc), e.KeyPrefix()))
+		require.NoError(t, err)
+		sealed[len(sealed)-1] ^= 1
+
+		_, err = e.Decrypt([]byte(e.KeyPrefix() + base64.StdEncoding.EncodeToString(sealed)))
+		require.Error(t, err)
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		oldKey, newKey := utilrand.String(32), utilrand.String(32)
+		oldEncrypter := NewEncrypter(fixKeyring(oldKey))
+		enc, err := oldEncrypter.Encrypt([]byte("secret"))
+		require.NoError(t, err)
+
+		keyring, err := NewKeyring(Config{SecretKey: oldKey, SecretKeys: "new=" + newKey, SecretKeyID: "new"})
+		require.NoError(t, err)
+		e := NewEncrypter(keyring)
+
+		// the value encrypted with the retired key is still readable
+		data, err := e.Decrypt(enc)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", string(data))
+
+		reEnc, err := e.Encrypt(data)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(reEnc), "new:"))
+
+		_, err = oldEncrypter.Decrypt(reEnc)
+		require.Error(t, err)
+	})
+
+	t.Run("legacy value", func(t *testing.T) {
+		secretKey := utilrand.String(32)
+		legacy := encryptLegacy(t, secretKey, []byte("secret"))
+
+		e := NewEncrypter(fixKeyring(secretKey))
+		data, err := e.Decrypt(legacy)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", string(data))
+	})
+
+	t.Run("invalid keyring", func(t *testing.T) {
+		_, err := NewKeyring(Config{SecretKeys: "key-without-id"})
+		require.Error(t, err)
+
+		_, err = NewKeyring(Config{SecretKey: utilrand.String(32), SecretKeyID: "missing"})
+		require.Error(t, err)
+	})
+}
+
+func fixKeyring(secretKey string) Keyring {
+	return Keyring{
+		ActiveKeyID: DefaultSecretKeyID,
+		Keys:        map[string]string{DefaultSecretKeyID: secretKey},
+		LegacyKey:   secretKey,
+	}
+}
+
+// encryptLegacy encrypts the value with AES-CFB the same as the previous versions of the encrypter
+func encryptLegacy(t *testing.T, secretKey string, obj []byte) []byte {
+	block, err := aes.NewCipher([]byte(secretKey))
+	require.NoError(t, err)
+	b := base64.StdEncoding.EncodeToString(obj)
+	bytes := make([]byte, aes.BlockSize+len(b))
+	iv := bytes[:aes.BlockSize]
+	_, err = io.ReadFull(rand.Reader, iv)
+	require.NoError(t, err)
+	cfb := cipher.NewCFBEncrypter(block, iv)
+	cfb.XORKeyStream(bytes[aes.BlockSize:], []byte(b))
+
+	return []byte(base64.StdEncoding.EncodeToString(bytes))
 }
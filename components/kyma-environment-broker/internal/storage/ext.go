@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"time"
+
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/predicate"
@@ -18,6 +20,11 @@ type Instances interface {
 	GetInstanceStats() (internal.InstanceStats, error)
 	GetNumberOfInstancesForGlobalAccountID(globalAccountID string) (int, error)
 	List(dbmodel.InstanceFilter) ([]internal.Instance, int, int, error)
+
+	GetLabels(instanceID string) (map[string]string, error)
+	// SetLabels adds the given labels to the instance, overwriting the values of the existing ones
+	SetLabels(instanceID string, labels map[string]string) error
+	DeleteLabels(instanceID string, keys []string) error
 }
 
 type Operations interface {
@@ -52,12 +59,64 @@ type Orchestrations interface {
 	GetByID(orchestrationID string) (*internal.Orchestration, error)
 	List(filter dbmodel.OrchestrationFilter) ([]internal.Orchestration, int, int, error)
 	ListByState(state string) ([]internal.Orchestration, error)
+	// CountByState returns the number of orchestrations in each state, the states without orchestrations are omitted
+	CountByState() (map[string]int, error)
 }
 
 type RuntimeStates interface {
 	Insert(runtimeState internal.RuntimeState) error
 	GetByOperationID(operationID string) (internal.RuntimeState, error)
+	// ListByRuntimeID returns the states of the runtime ordered from the most recent one
 	ListByRuntimeID(runtimeID string) ([]internal.RuntimeState, error)
+	// ReEncrypt encrypts up to the given number of states following the given ID which are not encrypted with the active key yet.
+	// The states which cannot be decrypted are skipped and reported in the batch, so the next batch can start after them.
+	ReEncrypt(afterID string, limit int) (dbmodel.ReEncryptionBatch, error)
+}
+
+// Retention moves the records which are not used by the broker anymore to the archive tables,
+// and purges the personal data of the deprovisioned instances
+type Retention interface {
+	CountOperationsToArchive(before time.Time) (int, error)
+	// ArchiveOperations archives up to the given number of finished operations updated before the given time,
+	// except the provisioning operations of the existing instances, and returns the number of archived operations
+	ArchiveOperations(before time.Time, limit int) (int, error)
+	CountRuntimeStatesToArchive(before time.Time) (int, error)
+	// ArchiveRuntimeStates archives up to the given number of states created before the given time, except the latest
+	// state of each runtime, and returns the number of archived states
+	ArchiveRuntimeStates(before time.Time, limit int) (int, error)
+	CountInstancesToPurge(deprovisionedBefore time.Time) (int, error)
+	// PurgeInstances removes the provisioning parameters and the runtime states of up to the given number of instances
+	// deprovisioned before the given time, and returns the number of purged instances
+	PurgeInstances(deprovisionedBefore time.Time, limit int) (int, error)
+}
+
+// Backup lists the complete state of the broker in batches ordered by the record IDs, and restores it.
+// The Restore methods skip the existing records and report whether the record was inserted, so restoring can be repeated.
+type Backup interface {
+	// ListInstances returns up to the given number of instances with the labels, following the given instance ID
+	ListInstances(afterID string, limit int) ([]internal.Instance, error)
+	// ListOperations returns the stored records of the operations of all types
+	ListOperations(afterID string, limit int) ([]dbmodel.OperationDTO, error)
+	ListOrchestrations(afterID string, limit int) ([]internal.Orchestration, error)
+	// ListRuntimeStates returns the states with the decrypted secrets, they are encrypted with the active key on restoring
+	ListRuntimeStates(afterID string, limit int) ([]internal.RuntimeState, error)
+	ListLMSTenants(afterID string, limit int) ([]internal.LMSTenant, error)
+	// ListAccountAssignments returns the active and the released assignments of the account pool secrets
+	ListAccountAssignments(afterID string, limit int) ([]internal.AccountAssignment, error)
+	ListArchivedOperations(afterID string, limit int) ([]dbmodel.ArchivedOperationDTO, error)
+	// ListArchivedRuntimeStates returns the archived states with the decrypted secrets, like ListRuntimeStates
+	ListArchivedRuntimeStates(afterID string, limit int) ([]internal.ArchivedRuntimeState, error)
+
+	// RestoreInstance inserts the instance with the labels, keeping its timestamps
+	RestoreInstance(instance internal.Instance) (bool, error)
+	RestoreOperation(operation dbmodel.OperationDTO) (bool, error)
+	RestoreOrchestration(orchestration internal.Orchestration) (bool, error)
+	RestoreRuntimeState(state internal.RuntimeState) (bool, error)
+	RestoreLMSTenant(tenant internal.LMSTenant) (bool, error)
+	RestoreAccountAssignment(assignment internal.AccountAssignment) (bool, error)
+	// RestoreArchivedOperation inserts the operation to the archive, keeping its archiving time
+	RestoreArchivedOperation(operation dbmodel.ArchivedOperationDTO) (bool, error)
+	RestoreArchivedRuntimeState(state internal.ArchivedRuntimeState) (bool, error)
 }
 
 type UpgradeKyma interface {
@@ -73,3 +132,12 @@ type LMSTenants interface {
 	FindTenantByName(name, region string) (internal.LMSTenant, bool, error)
 	InsertTenant(tenant internal.LMSTenant) error
 }
+
+// AccountAssignments keeps the history of the assignments of the hyperscaler account pool secrets to the tenants
+type AccountAssignments interface {
+	Insert(assignment internal.AccountAssignment) error
+	// Release marks the active assignment of the secret as released, it returns the not found error if there is none
+	Release(secretName string, releasedAt time.Time) error
+	// ListActive returns the assignments which are not released
+	ListActive() ([]internal.AccountAssignment, error)
+}
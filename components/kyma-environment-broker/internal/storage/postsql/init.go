@@ -13,13 +13,18 @@ import (
 )
 
 const (
-	schemaName             = "public"
-	InstancesTableName     = "instances"
-	OperationTableName     = "operations"
-	OrchestrationTableName = "orchestrations"
-	RuntimeStateTableName  = "runtime_states"
-	LMSTenantTableName     = "lms_tenants"
-	CreatedAtField         = "created_at"
+	schemaName                   = "public"
+	InstancesTableName           = "instances"
+	InstanceLabelsTableName      = "instance_labels"
+	OperationTableName           = "operations"
+	OperationArchiveTableName    = "operations_archive"
+	OrchestrationTableName       = "orchestrations"
+	RuntimeStateTableName        = "runtime_states"
+	RuntimeStateArchiveTableName = "runtime_states_archive"
+	LMSTenantTableName           = "lms_tenants"
+	AccountAssignmentTableName   = "hyperscaler_account_assignments"
+	CreatedAtField               = "created_at"
+	UpdatedAtField               = "updated_at"
 )
 
 // InitializeDatabase opens database connection and initializes schema if it does not exist
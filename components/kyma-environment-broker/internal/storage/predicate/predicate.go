@@ -11,8 +11,9 @@ import (
 // {{{ "Functional" Option Interfaces
 
 // InMemoryPredicate allows to apply predicates for InMemory queries.
+// It returns the items left after applying the predicate.
 type InMemoryPredicate interface {
-	ApplyToInMemory([]internal.InstanceWithOperation)
+	ApplyToInMemory([]internal.InstanceWithOperation) []internal.InstanceWithOperation
 }
 
 // PostgresPredicate allows to apply predicates for Postgres queries.
@@ -43,13 +44,45 @@ func (w SortByCreatedAt) ApplyToPostgres(stmt *dbr.SelectStmt) {
 }
 
 // TODO: It can be more generic but right now there's no reason to complicate it.
-func (w SortByCreatedAt) ApplyToInMemory(in []internal.InstanceWithOperation) {
+func (w SortByCreatedAt) ApplyToInMemory(in []internal.InstanceWithOperation) []internal.InstanceWithOperation {
 	sort.Slice(in, func(i, j int) bool {
 		return in[i].CreatedAt.Before(in[j].CreatedAt)
 	})
+	return in
 }
 
 var _ InMemoryPredicate = &SortByCreatedAt{}
 var _ PostgresPredicate = &SortByCreatedAt{}
 
+// WithInstanceIDs limits the query output to the given instances. At least one ID must be given.
+func WithInstanceIDs(ids ...string) InstanceIDs {
+	return InstanceIDs{ids: ids}
+}
+
+type InstanceIDs struct {
+	ids []string
+}
+
+func (w InstanceIDs) ApplyToPostgres(stmt *dbr.SelectStmt) {
+	stmt.Where("instances.instance_id IN ?", w.ids)
+}
+
+func (w InstanceIDs) ApplyToInMemory(in []internal.InstanceWithOperation) []internal.InstanceWithOperation {
+	ids := make(map[string]struct{}, len(w.ids))
+	for _, id := range w.ids {
+		ids[id] = struct{}{}
+	}
+
+	out := in[:0]
+	for _, item := range in {
+		if _, found := ids[item.InstanceID]; found {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+var _ InMemoryPredicate = &InstanceIDs{}
+var _ PostgresPredicate = &InstanceIDs{}
+
 // }}}
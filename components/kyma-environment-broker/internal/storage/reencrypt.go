@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ReEncryptionJob re-encrypts the stored secrets with the active key, so the retired keys can be removed from the keyring
+type ReEncryptionJob struct {
+	runtimeStates RuntimeStates
+	batchSize     int
+	interval      time.Duration
+	log           logrus.FieldLogger
+}
+
+func NewReEncryptionJob(runtimeStates RuntimeStates, batchSize int, interval time.Duration, log logrus.FieldLogger) *ReEncryptionJob {
+	return &ReEncryptionJob{
+		runtimeStates: runtimeStates,
+		batchSize:     batchSize,
+		interval:      interval,
+		log:           log,
+	}
+}
+
+// Run re-encrypts the secrets every interval until the stop channel is closed
+func (j *ReEncryptionJob) Run(stop <-chan struct{}) {
+	go wait.Until(j.reEncrypt, j.interval, stop)
+}
+
+func (j *ReEncryptionJob) reEncrypt() {
+	afterID := ""
+	reEncrypted, skipped := 0, 0
+	for {
+		batch, err := j.runtimeStates.ReEncrypt(afterID, j.batchSize)
+		reEncrypted += batch.ReEncrypted
+		skipped += len(batch.Skipped)
+		for _, id := range batch.Skipped {
+			j.log.Warnf("Skipping runtime state %s which cannot be decrypted with the configured keys", id)
+		}
+		if err != nil {
+			j.log.Errorf("while re-encrypting runtime states: %v", err)
+			break
+		}
+		if batch.Listed < j.batchSize {
+			break
+		}
+		afterID = batch.LastID
+	}
+
+	if reEncrypted > 0 || skipped > 0 {
+		j.log.Infof("Re-encrypted %d runtime states with the active key, skipped %d runtime states", reEncrypted, skipped)
+	}
+}
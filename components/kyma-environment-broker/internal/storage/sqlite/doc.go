@@ -0,0 +1,3 @@
+// Package sqlite provides the SQLite database for the local development and tests. The driver requires cgo,
+// so the package is built only with the sqlite build tag and the released images do not contain it.
+package sqlite
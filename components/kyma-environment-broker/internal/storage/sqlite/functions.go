@@ -0,0 +1,65 @@
+// +build sqlite
+
+package sqlite
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// registerFunctions registers the functions used by the broker queries, which SQLite does not provide without extensions
+func registerFunctions(conn *sqlite3.SQLiteConn) error {
+	if err := conn.RegisterFunc("regexp", regexpMatch, true); err != nil {
+		return err
+	}
+	if err := conn.RegisterFunc("json_text", jsonText, true); err != nil {
+		return err
+	}
+	return conn.RegisterFunc("json_remove_key", jsonRemoveKey, true)
+}
+
+// regexpMatch implements the REGEXP operator, "X REGEXP Y" calls regexp(Y, X)
+func regexpMatch(pattern, value string) (bool, error) {
+	return regexp.MatchString(pattern, value)
+}
+
+// jsonText returns the value at the path of the JSON document as text. The functions cannot return the NULL text,
+// so the value is returned as BLOB, which is NULL if there is no value, and the queries cast it to text.
+func jsonText(doc string, path ...string) ([]byte, error) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(doc), &value); err != nil {
+		return nil, err
+	}
+	for _, key := range path {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		value = obj[key]
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(v), nil
+	case bool, float64:
+		return []byte(fmt.Sprint(v)), nil
+	default:
+		return json.Marshal(v)
+	}
+}
+
+// jsonRemoveKey removes the key from the JSON object
+func jsonRemoveKey(doc, key string) (string, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(doc), &obj); err != nil {
+		return "", err
+	}
+	delete(obj, key)
+	raw, err := json.Marshal(obj)
+	return string(raw), err
+}
@@ -0,0 +1,160 @@
+// +build sqlite
+
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gocraft/dbr"
+	"github.com/gocraft/dbr/dialect"
+	"github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// InMemoryPath creates the database which is removed when the connection is closed
+	InMemoryPath = ":memory:"
+
+	driverName           = "sqlite3_keb"
+	migrationsTableName  = "schema_migrations"
+	migrationFilePattern = "*.up.sql"
+)
+
+func init() {
+	sql.Register(driverName, &sqlite3.SQLiteDriver{
+		ConnectHook: registerFunctions,
+	})
+}
+
+// InitializeDatabase opens the SQLite database and applies the PostgreSQL migrations of the schema migrator,
+// which are not applied yet. The database is meant only for the local development and tests.
+func InitializeDatabase(path, migrationsPath string, log logrus.FieldLogger) (*dbr.Connection, error) {
+	db, err := sql.Open(driverName, fmt.Sprintf("file:%s?_foreign_keys=1&_busy_timeout=5000", path))
+	if err != nil {
+		return nil, errors.Wrap(err, "while opening database")
+	}
+	// the in-memory database exists only within its connection, and SQLite serializes the writes anyway
+	db.SetMaxOpenConns(1)
+
+	connection := &dbr.Connection{
+		DB:            db,
+		Dialect:       dialect.SQLite3,
+		EventReceiver: &dbr.NullEventReceiver{},
+	}
+	if err := migrate(connection, migrationsPath, log); err != nil {
+		closeDBConnection(connection, log)
+		return nil, errors.Wrap(err, "while applying migrations")
+	}
+
+	return connection, nil
+}
+
+func closeDBConnection(db *dbr.Connection, log logrus.FieldLogger) {
+	err := db.Close()
+	if err != nil {
+		log.Warnf("Failed to close database connection: %s", err.Error())
+	}
+}
+
+func migrate(connection *dbr.Connection, migrationsPath string, log logrus.FieldLogger) error {
+	files, err := filepath.Glob(filepath.Join(migrationsPath, migrationFilePattern))
+	if err != nil {
+		return errors.Wrap(err, "while listing migrations")
+	}
+	if len(files) == 0 {
+		return errors.Errorf("no migrations found in %s", migrationsPath)
+	}
+	sort.Strings(files)
+
+	_, err = connection.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (version varchar(255) PRIMARY KEY)", migrationsTableName))
+	if err != nil {
+		return errors.Wrap(err, "while creating migrations table")
+	}
+	var applied []string
+	_, err = connection.NewSession(nil).Select("version").From(migrationsTableName).Load(&applied)
+	if err != nil {
+		return errors.Wrap(err, "while getting applied migrations")
+	}
+	isApplied := make(map[string]bool, len(applied))
+	for _, version := range applied {
+		isApplied[version] = true
+	}
+
+	for _, file := range files {
+		version := strings.SplitN(filepath.Base(file), "_", 2)[0]
+		if isApplied[version] {
+			continue
+		}
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return errors.Wrapf(err, "while reading migration %s", file)
+		}
+		if err := applyMigration(connection, version, string(content)); err != nil {
+			return errors.Wrapf(err, "while applying migration %s", filepath.Base(file))
+		}
+		log.Infof("Applied migration %s", filepath.Base(file))
+	}
+
+	return nil
+}
+
+func applyMigration(connection *dbr.Connection, version, migration string) error {
+	tx, err := connection.NewSession(nil).Begin()
+	if err != nil {
+		return errors.Wrap(err, "while starting transaction")
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	for _, stmt := range translate(migration) {
+		if _, err := tx.Exec(stmt); err != nil {
+			return errors.Wrapf(err, "while executing %q", stmt)
+		}
+	}
+	if _, err := tx.InsertInto(migrationsTableName).Pair("version", version).Exec(); err != nil {
+		return errors.Wrap(err, "while saving migration version")
+	}
+
+	return tx.Commit()
+}
+
+var (
+	commentRegexp     = regexp.MustCompile(`--[^\n]*`)
+	timestampTZRegexp = regexp.MustCompile(`(?i)\bTIMESTAMPTZ\b`)
+	nowRegexp         = regexp.MustCompile(`(?i)\bNOW\(\)`)
+	alterTableRegexp  = regexp.MustCompile(`(?is)^ALTER TABLE\s+(\S+)\s+(ADD COLUMN\s.*)$`)
+	addColumnRegexp   = regexp.MustCompile(`(?i),\s*ADD COLUMN\s`)
+)
+
+// translate splits the PostgreSQL migration into the SQLite statements. SQLite stores the time zone in the value,
+// supports only constant defaults of the added columns and adds one column per statement.
+func translate(migration string) []string {
+	var stmts []string
+	for _, stmt := range strings.Split(commentRegexp.ReplaceAllString(migration, ""), ";") {
+		stmt = strings.TrimSpace(timestampTZRegexp.ReplaceAllString(stmt, "TIMESTAMP"))
+		if stmt == "" {
+			continue
+		}
+
+		match := alterTableRegexp.FindStringSubmatch(stmt)
+		if match == nil {
+			stmts = append(stmts, nowRegexp.ReplaceAllString(stmt, "CURRENT_TIMESTAMP"))
+			continue
+		}
+		for _, column := range addColumnRegexp.Split(match[2], -1) {
+			if !strings.HasPrefix(strings.ToUpper(column), "ADD COLUMN") {
+				column = "ADD COLUMN " + column
+			}
+			// the broker sets the time columns on insert, the default is needed only for the NOT NULL constraint
+			column = nowRegexp.ReplaceAllString(column, "'0001-01-01 00:00:00'")
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s %s", match[1], column))
+		}
+	}
+	return stmts
+}
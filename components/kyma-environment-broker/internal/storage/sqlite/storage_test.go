@@ -0,0 +1,335 @@
+// +build sqlite
+
+package sqlite_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbsession/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/sqlite"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const migrationsPath = "../../../../schema-migrator/migrations/kyma-environment-broker"
+
+func TestSQLiteStorage(t *testing.T) {
+	t.Run("Instances", func(t *testing.T) {
+		brokerStorage := newStorage(t)
+
+		for _, id := range []string{"inst1", "inst2", "inst3"} {
+			err := brokerStorage.Instances().Insert(fixInstance(id))
+			require.NoError(t, err)
+		}
+		err := brokerStorage.Instances().Insert(fixInstance("inst1"))
+		assertError(t, dberr.CodeAlreadyExists, err)
+
+		upgrade := internal.UpgradeKymaOperation{
+			Operation:      fixOperation("inst2", time.Hour),
+			RuntimeVersion: internal.RuntimeVersionData{Version: "1.17.0"},
+		}
+		err = brokerStorage.Operations().InsertUpgradeKymaOperation(upgrade)
+		require.NoError(t, err)
+		err = brokerStorage.Instances().SetLabels("inst3", map[string]string{"team": "a"})
+		require.NoError(t, err)
+
+		// when
+		out, count, totalCount, err := brokerStorage.Instances().List(dbmodel.InstanceFilter{Page: 1, PageSize: 2})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, 3, totalCount)
+		assert.Equal(t, "inst1", out[0].InstanceID)
+
+		for name, tc := range map[string]struct {
+			filter   dbmodel.InstanceFilter
+			expected string
+		}{
+			"domain":       {filter: dbmodel.InstanceFilter{Domains: []string{"inst2.kyma"}}, expected: "inst2"},
+			"kyma version": {filter: dbmodel.InstanceFilter{KymaVersions: []string{"1.17.0"}}, expected: "inst2"},
+			"state":        {filter: dbmodel.InstanceFilter{States: []string{string(domain.Succeeded)}}, expected: "inst2"},
+			"label":        {filter: dbmodel.InstanceFilter{Labels: map[string]string{"team": "a"}}, expected: "inst3"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				out, count, totalCount, err := brokerStorage.Instances().List(tc.filter)
+
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+				assert.Equal(t, 1, totalCount)
+				assert.Equal(t, tc.expected, out[0].InstanceID)
+			})
+		}
+
+		got, err := brokerStorage.Instances().GetByID("inst2")
+		require.NoError(t, err)
+		assert.Equal(t, "inst2", got.InstanceID)
+		assert.False(t, got.CreatedAt.IsZero())
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		brokerStorage := newStorage(t)
+
+		provisioning := internal.ProvisioningOperation{Operation: fixOperation("inst1", 0)}
+		err := brokerStorage.Operations().InsertProvisioningOperation(provisioning)
+		require.NoError(t, err)
+		err = brokerStorage.Operations().InsertProvisioningOperation(provisioning)
+		assertError(t, dberr.CodeAlreadyExists, err)
+
+		// when
+		provisioning.State = domain.Failed
+		updated, err := brokerStorage.Operations().UpdateProvisioningOperation(provisioning)
+		require.NoError(t, err)
+
+		// then
+		got, err := brokerStorage.Operations().GetProvisioningOperationByInstanceID("inst1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Failed, got.State)
+		assert.Equal(t, updated.Version, got.Version)
+		assert.Equal(t, provisioning.CreatedAt.Unix(), got.CreatedAt.Unix())
+
+		// the update with the outdated version is rejected
+		_, err = brokerStorage.Operations().UpdateProvisioningOperation(provisioning)
+		assertError(t, dberr.CodeConflict, err)
+	})
+
+	t.Run("Orchestrations", func(t *testing.T) {
+		svc := newStorage(t).Orchestrations()
+
+		for id, state := range map[string]string{"orch1": orchestration.Succeeded, "orch2": orchestration.Succeeded, "orch3": orchestration.InProgress} {
+			err := svc.Insert(internal.Orchestration{OrchestrationID: id, State: state, CreatedAt: fixTime(), UpdatedAt: fixTime()})
+			require.NoError(t, err)
+		}
+
+		// when
+		counts, err := svc.CountByState()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{orchestration.Succeeded: 2, orchestration.InProgress: 1}, counts)
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		brokerStorage := newStorage(t)
+
+		err := brokerStorage.Instances().Insert(fixInstance("existing"))
+		require.NoError(t, err)
+		existing := internal.ProvisioningOperation{Operation: fixOperation("existing", 0)}
+		removed := internal.ProvisioningOperation{Operation: fixOperation("removed", 0)}
+		removed.ProvisioningParameters = `{"ers_context":{"subaccount_id":"removed"}}`
+		for _, op := range []internal.ProvisioningOperation{existing, removed} {
+			err = brokerStorage.Operations().InsertProvisioningOperation(op)
+			require.NoError(t, err)
+		}
+		err = brokerStorage.Operations().InsertDeprovisioningOperation(internal.DeprovisioningOperation{Operation: fixOperation("removed", time.Hour)})
+		require.NoError(t, err)
+		for i, op := range []internal.ProvisioningOperation{existing, existing, removed} {
+			err = brokerStorage.RuntimeStates().Insert(internal.RuntimeState{
+				ID:          fmt.Sprintf("state-%d", i),
+				CreatedAt:   fixTime().Add(time.Duration(i) * time.Hour),
+				RuntimeID:   op.InstanceID,
+				OperationID: op.ID,
+			})
+			require.NoError(t, err)
+		}
+		svc := brokerStorage.Retention()
+		now := time.Now()
+
+		// when
+		operations, err := svc.ArchiveOperations(now, 10)
+		require.NoError(t, err)
+		runtimeStates, err := svc.ArchiveRuntimeStates(now, 10)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, 2, operations)
+		assert.Equal(t, 1, runtimeStates)
+		_, err = brokerStorage.Operations().GetProvisioningOperationByID(removed.ID)
+		assertError(t, dberr.CodeNotFound, errors.Cause(err))
+		states, err := brokerStorage.RuntimeStates().ListByRuntimeID("existing")
+		require.NoError(t, err)
+		assert.Len(t, states, 1)
+
+		// when
+		archivedOperations, err := brokerStorage.Backup().ListArchivedOperations("", 10)
+		require.NoError(t, err)
+		archivedStates, err := brokerStorage.Backup().ListArchivedRuntimeStates("", 10)
+		require.NoError(t, err)
+		target := newStorage(t)
+		inserted, err := target.Backup().RestoreArchivedOperation(archivedOperations[0])
+		require.NoError(t, err)
+		assert.True(t, inserted)
+		inserted, err = target.Backup().RestoreArchivedRuntimeState(archivedStates[0])
+		require.NoError(t, err)
+		assert.True(t, inserted)
+
+		// then
+		require.Len(t, archivedOperations, 2)
+		assert.Equal(t, removed.ID, archivedOperations[0].ID)
+		assert.False(t, archivedOperations[0].ArchivedAt.IsZero())
+		require.Len(t, archivedStates, 1)
+		assert.Equal(t, "state-0", archivedStates[0].ID)
+		restored, err := target.Backup().ListArchivedOperations("", 10)
+		require.NoError(t, err)
+		assert.Equal(t, archivedOperations[0].ArchivedAt.Unix(), restored[0].ArchivedAt.Unix())
+
+		// when
+		instances, err := svc.PurgeInstances(now, 10)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, 1, instances)
+		instances, err = svc.CountInstancesToPurge(now)
+		require.NoError(t, err)
+		assert.Zero(t, instances)
+	})
+
+	t.Run("Backup", func(t *testing.T) {
+		source := newStorage(t)
+
+		for _, id := range []string{"inst1", "inst2", "inst3"} {
+			err := source.Instances().Insert(fixInstance(id))
+			require.NoError(t, err)
+		}
+		err := source.Instances().SetLabels("inst1", map[string]string{"team": "a"})
+		require.NoError(t, err)
+		provisioning := internal.ProvisioningOperation{Operation: fixOperation("inst1", 0)}
+		err = source.Operations().InsertProvisioningOperation(provisioning)
+		require.NoError(t, err)
+		err = source.RuntimeStates().Insert(internal.RuntimeState{ID: "state1", RuntimeID: "inst1", OperationID: provisioning.ID, CreatedAt: fixTime()})
+		require.NoError(t, err)
+
+		// when
+		instances, err := source.Backup().ListInstances("", 2)
+		require.NoError(t, err)
+		next, err := source.Backup().ListInstances(instances[1].InstanceID, 2)
+		require.NoError(t, err)
+
+		// then
+		require.Len(t, instances, 2)
+		require.Len(t, next, 1)
+		assert.Equal(t, map[string]string{"team": "a"}, instances[0].Labels)
+		assert.Equal(t, "inst3", next[0].InstanceID)
+
+		// when
+		target := newStorage(t)
+		inserted, err := target.Backup().RestoreInstance(instances[0])
+		require.NoError(t, err)
+		assert.True(t, inserted)
+		inserted, err = target.Backup().RestoreInstance(instances[0])
+		require.NoError(t, err)
+		assert.False(t, inserted)
+
+		operations, err := source.Backup().ListOperations("", 10)
+		require.NoError(t, err)
+		require.Len(t, operations, 1)
+		inserted, err = target.Backup().RestoreOperation(operations[0])
+		require.NoError(t, err)
+		assert.True(t, inserted)
+
+		states, err := source.Backup().ListRuntimeStates("", 10)
+		require.NoError(t, err)
+		require.Len(t, states, 1)
+		inserted, err = target.Backup().RestoreRuntimeState(states[0])
+		require.NoError(t, err)
+		assert.True(t, inserted)
+
+		// then
+		got, err := target.Instances().GetByID("inst1")
+		require.NoError(t, err)
+		assert.Equal(t, instances[0].CreatedAt.Unix(), got.CreatedAt.Unix())
+		labels, err := target.Instances().GetLabels("inst1")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"team": "a"}, labels)
+		gotOperation, err := target.Operations().GetProvisioningOperationByID(provisioning.ID)
+		require.NoError(t, err)
+		assert.Equal(t, provisioning.InstanceID, gotOperation.InstanceID)
+		_, err = target.RuntimeStates().GetByOperationID(provisioning.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Account assignments", func(t *testing.T) {
+		svc := newStorage(t).AccountAssignments()
+
+		err := svc.Insert(internal.AccountAssignment{ID: "a1", SecretName: "secret1", HyperscalerType: "gcp", TenantName: "tenant1", CreatedAt: fixTime()})
+		require.NoError(t, err)
+		err = svc.Insert(internal.AccountAssignment{ID: "a2", SecretName: "secret1", HyperscalerType: "gcp", TenantName: "tenant2", CreatedAt: fixTime()})
+		assertError(t, dberr.CodeAlreadyExists, err)
+
+		// when
+		err = svc.Release("secret1", fixTime().Add(time.Hour))
+		require.NoError(t, err)
+		err = svc.Insert(internal.AccountAssignment{ID: "a3", SecretName: "secret1", HyperscalerType: "gcp", TenantName: "tenant3", CreatedAt: fixTime().Add(time.Hour)})
+		require.NoError(t, err)
+
+		// then
+		active, err := svc.ListActive()
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, "tenant3", active[0].TenantName)
+		err = svc.Release("secret2", fixTime())
+		assertError(t, dberr.CodeNotFound, err)
+	})
+}
+
+func newStorage(t *testing.T) storage.BrokerStorage {
+	brokerStorage, connection, err := storage.NewFromConfig(storage.Config{
+		SecretKey:            "################################",
+		SecretKeyID:          storage.DefaultSecretKeyID,
+		SqlitePath:           sqlite.InMemoryPath,
+		SqliteMigrationsPath: migrationsPath,
+	}, logrus.StandardLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, connection.Close())
+	})
+
+	return brokerStorage
+}
+
+func assertError(t *testing.T, expectedCode int, err error) {
+	require.Error(t, err)
+	dbErr, ok := err.(dberr.Error)
+	require.True(t, ok, "the error is not dberr.Error: %s", err)
+	assert.Equal(t, expectedCode, dbErr.Code())
+}
+
+func fixInstance(id string) internal.Instance {
+	return internal.Instance{
+		InstanceID:      id,
+		RuntimeID:       id,
+		GlobalAccountID: id,
+		SubAccountID:    id,
+		ServiceID:       id,
+		ServicePlanID:   id,
+		ServicePlanName: id,
+		DashboardURL:    fmt.Sprintf("https://console.%s.kyma.local", id),
+		ProviderRegion:  id,
+		CreatedAt:       fixTime(),
+	}
+}
+
+func fixOperation(instanceID string, offset time.Duration) internal.Operation {
+	return internal.Operation{
+		ID:          fmt.Sprintf("%s-%d", instanceID, offset),
+		CreatedAt:   fixTime().Add(offset),
+		UpdatedAt:   fixTime().Add(offset),
+		InstanceID:  instanceID,
+		State:       domain.Succeeded,
+		Description: instanceID,
+	}
+}
+
+func fixTime() time.Time {
+	return time.Date(2020, 04, 21, 0, 0, 23, 0, time.UTC)
+}
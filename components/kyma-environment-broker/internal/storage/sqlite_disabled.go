@@ -0,0 +1,13 @@
+// +build !sqlite
+
+package storage
+
+import (
+	"github.com/gocraft/dbr"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+func initializeSQLite(path, migrationsPath string, log logrus.FieldLogger) (*dbr.Connection, error) {
+	return nil, errors.New("the broker is built without the SQLite driver, build it with the sqlite tag to use the SQLite database")
+}
@@ -0,0 +1,14 @@
+// +build sqlite
+
+package storage
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/sqlite"
+
+	"github.com/gocraft/dbr"
+	"github.com/sirupsen/logrus"
+)
+
+func initializeSQLite(path, migrationsPath string, log logrus.FieldLogger) (*dbr.Connection, error) {
+	return sqlite.InitializeDatabase(path, migrationsPath, log)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/memory"
 	postgres "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/postsql"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -17,6 +18,9 @@ type BrokerStorage interface {
 	LMSTenants() LMSTenants
 	Orchestrations() Orchestrations
 	RuntimeStates() RuntimeStates
+	Retention() Retention
+	Backup() Backup
+	AccountAssignments() AccountAssignments
 }
 
 const (
@@ -24,48 +28,76 @@ const (
 )
 
 func NewFromConfig(cfg Config, log logrus.FieldLogger) (BrokerStorage, *dbr.Connection, error) {
+	keyring, err := NewKeyring(cfg)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "while creating keyring")
+	}
+
+	connection, err := initializeDatabase(cfg, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fact := dbsession.NewFactory(connection)
+
+	enc := NewEncrypter(keyring)
+
+	return storage{
+		instance:           postgres.NewInstance(fact),
+		operation:          postgres.NewOperation(fact),
+		lmsTenants:         postgres.NewLMSTenants(fact),
+		orchestrations:     postgres.NewOrchestrations(fact),
+		runtimeStates:      postgres.NewRuntimeStates(fact, enc),
+		retention:          postgres.NewRetention(fact),
+		backup:             postgres.NewBackup(fact, enc),
+		accountAssignments: postgres.NewAccountAssignments(fact),
+	}, connection, nil
+}
+
+func initializeDatabase(cfg Config, log logrus.FieldLogger) (*dbr.Connection, error) {
+	if cfg.SqlitePath != "" {
+		log.Infof("Using SQLite database %s", cfg.SqlitePath)
+		return initializeSQLite(cfg.SqlitePath, cfg.SqliteMigrationsPath, log)
+	}
+
 	log.Infof("Setting DB connection pool params: connectionMaxLifetime=%s "+
 		"maxIdleConnections=%d maxOpenConnections=%d", cfg.ConnMaxLifetime, cfg.MaxIdleConns, cfg.MaxOpenConns)
 
 	connection, err := postsql.InitializeDatabase(cfg.ConnectionURL(), connectionRetries, log)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	connection.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 	connection.SetMaxIdleConns(cfg.MaxIdleConns)
 	connection.SetMaxOpenConns(cfg.MaxOpenConns)
 
-	fact := dbsession.NewFactory(connection)
-
-	enc := NewEncrypter(cfg.SecretKey)
-
-	return storage{
-		instance:       postgres.NewInstance(fact),
-		operation:      postgres.NewOperation(fact),
-		lmsTenants:     postgres.NewLMSTenants(fact),
-		orchestrations: postgres.NewOrchestrations(fact),
-		runtimeStates:  postgres.NewRuntimeStates(fact, enc),
-	}, connection, nil
+	return connection, nil
 }
 
 func NewMemoryStorage() BrokerStorage {
 	op := memory.NewOperation()
 	return storage{
-		operation:      op,
-		instance:       memory.NewInstance(op),
-		lmsTenants:     memory.NewLMSTenants(),
-		orchestrations: memory.NewOrchestrations(),
-		runtimeStates:  memory.NewRuntimeStates(),
+		operation:          op,
+		instance:           memory.NewInstance(op),
+		lmsTenants:         memory.NewLMSTenants(),
+		orchestrations:     memory.NewOrchestrations(),
+		runtimeStates:      memory.NewRuntimeStates(),
+		retention:          memory.NewRetention(),
+		backup:             memory.NewBackup(),
+		accountAssignments: memory.NewAccountAssignments(),
 	}
 }
 
 type storage struct {
-	instance       Instances
-	operation      Operations
-	lmsTenants     LMSTenants
-	orchestrations Orchestrations
-	runtimeStates  RuntimeStates
+	instance           Instances
+	operation          Operations
+	lmsTenants         LMSTenants
+	orchestrations     Orchestrations
+	runtimeStates      RuntimeStates
+	retention          Retention
+	backup             Backup
+	accountAssignments AccountAssignments
 }
 
 func (s storage) Instances() Instances {
@@ -95,3 +127,15 @@ func (s storage) Orchestrations() Orchestrations {
 func (s storage) RuntimeStates() RuntimeStates {
 	return s.runtimeStates
 }
+
+func (s storage) Retention() Retention {
+	return s.retention
+}
+
+func (s storage) Backup() Backup {
+	return s.backup
+}
+
+func (s storage) AccountAssignments() AccountAssignments {
+	return s.accountAssignments
+}
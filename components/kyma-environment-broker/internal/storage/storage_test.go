@@ -20,6 +20,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/predicate"
 
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -312,6 +313,49 @@ func TestSchemaInitializer(t *testing.T) {
 			assert.Equal(t, fixInstances[2].InstanceID, out[0].InstanceID)
 		})
 
+		t.Run("should manage instance labels", func(t *testing.T) {
+			// given
+			containerCleanupFunc, cfg, err := InitTestDBContainer(t, ctx, "test_DB_1")
+			require.NoError(t, err)
+			defer containerCleanupFunc()
+
+			err = InitTestDBTables(t, cfg.ConnectionURL())
+			require.NoError(t, err)
+
+			psqlStorage, _, err := NewFromConfig(cfg, logrus.StandardLogger())
+			require.NoError(t, err)
+			require.NotNil(t, psqlStorage)
+
+			for _, i := range []internal.Instance{*fixInstance(instanceData{val: "1"}), *fixInstance(instanceData{val: "2"})} {
+				err = psqlStorage.Instances().Insert(i)
+				require.NoError(t, err)
+			}
+
+			// when
+			err = psqlStorage.Instances().SetLabels("1", map[string]string{"customer-pilot": "true", "tier": "gold"})
+			require.NoError(t, err)
+			err = psqlStorage.Instances().SetLabels("1", map[string]string{"tier": "silver"})
+			require.NoError(t, err)
+			err = psqlStorage.Instances().SetLabels("2", map[string]string{"tier": "silver"})
+			require.NoError(t, err)
+			err = psqlStorage.Instances().DeleteLabels("2", []string{"tier"})
+			require.NoError(t, err)
+
+			// then
+			labels, err := psqlStorage.Instances().GetLabels("1")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"customer-pilot": "true", "tier": "silver"}, labels)
+
+			out, _, totalCount, err := psqlStorage.Instances().List(dbmodel.InstanceFilter{Labels: map[string]string{"tier": "silver"}})
+			require.NoError(t, err)
+			require.Equal(t, 1, totalCount)
+			assert.Equal(t, "1", out[0].InstanceID)
+			assert.Equal(t, labels, out[0].Labels)
+
+			err = psqlStorage.Instances().SetLabels("unknown", map[string]string{"tier": "gold"})
+			assert.True(t, dberr.IsNotFound(err))
+		})
+
 		t.Run("should list instances based on filters", func(t *testing.T) {
 			// given
 			containerCleanupFunc, cfg, err := InitTestDBContainer(t, ctx, "test_DB_1")
@@ -404,6 +448,54 @@ func TestSchemaInitializer(t *testing.T) {
 			require.Equal(t, 1, totalCount)
 
 			assert.Equal(t, fixInstances[1].InstanceID, out[0].InstanceID)
+
+			// given
+			for i, instance := range fixInstances {
+				op := fixProvisionOperation(instance.InstanceID)
+				op.RuntimeVersion = internal.RuntimeVersionData{Version: "1.16.0"}
+				if i == 0 {
+					op.State = domain.Failed
+				}
+				err = psqlStorage.Operations().InsertProvisioningOperation(op)
+				require.NoError(t, err)
+			}
+			upgrade := internal.UpgradeKymaOperation{
+				Operation:      fixSucceededOperation(fixInstances[2].InstanceID),
+				RuntimeVersion: internal.RuntimeVersionData{Version: "1.17.0"},
+			}
+			upgrade.CreatedAt = upgrade.CreatedAt.Add(time.Hour)
+			err = psqlStorage.Operations().InsertUpgradeKymaOperation(upgrade)
+			require.NoError(t, err)
+
+			// when
+			out, count, totalCount, err = psqlStorage.Instances().List(dbmodel.InstanceFilter{States: []string{string(domain.Failed)}, OperationTypes: []string{string(dbmodel.OperationTypeProvision)}})
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+			require.Equal(t, 1, totalCount)
+
+			assert.Equal(t, fixInstances[0].InstanceID, out[0].InstanceID)
+
+			// when
+			out, count, totalCount, err = psqlStorage.Instances().List(dbmodel.InstanceFilter{KymaVersions: []string{"1.16.0"}})
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+			require.Equal(t, 1, totalCount)
+
+			assert.Equal(t, fixInstances[1].InstanceID, out[0].InstanceID)
+
+			// when
+			out, count, totalCount, err = psqlStorage.Instances().List(dbmodel.InstanceFilter{SortDesc: true})
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, 3, count)
+			require.Equal(t, 3, totalCount)
+
+			assert.Equal(t, fixInstances[2].InstanceID, out[0].InstanceID)
 		})
 	})
 
@@ -824,6 +916,131 @@ func TestSchemaInitializer(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, fixID, state.KymaConfig.Version)
 		assert.Equal(t, fixID, state.ClusterConfig.KubernetesVersion)
+
+		// a state encrypted with a key which is not in the keyring anymore
+		lostCfg := cfg
+		lostCfg.SecretKeys = "lost=G-KaPdSgVkYp3s6v9y$B&E)H+MbQeThW"
+		lostCfg.SecretKeyID = "lost"
+		lostStorage, _, err := NewFromConfig(lostCfg, logrus.StandardLogger())
+		require.NoError(t, err)
+		lostRuntimeState := givenRuntimeState
+		lostRuntimeState.ID = "lost"
+		lostRuntimeState.OperationID = "lost"
+		err = lostStorage.RuntimeStates().Insert(lostRuntimeState)
+		require.NoError(t, err)
+
+		// rotate the key
+		cfg.SecretKeys = "rotated=5v8y/B?E(H+MbQeThWmZq4t7w!z%C&F)"
+		cfg.SecretKeyID = "rotated"
+		brokerStorage, _, err = NewFromConfig(cfg, logrus.StandardLogger())
+		require.NoError(t, err)
+		svc = brokerStorage.RuntimeStates()
+
+		batch, err := svc.ReEncrypt("", 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, batch.Listed)
+		assert.Equal(t, 1, batch.ReEncrypted)
+		assert.Equal(t, []string{"lost"}, batch.Skipped)
+		assert.Equal(t, fixID, batch.LastID)
+
+		// the skipped state does not block the next batches
+		batch, err = svc.ReEncrypt("", 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"lost"}, batch.Skipped)
+		batch, err = svc.ReEncrypt(batch.LastID, 1)
+		require.NoError(t, err)
+		assert.Zero(t, batch.Listed)
+
+		// the retired key is not needed anymore
+		cfg.SecretKey = ""
+		brokerStorage, _, err = NewFromConfig(cfg, logrus.StandardLogger())
+		require.NoError(t, err)
+
+		state, err = brokerStorage.RuntimeStates().GetByOperationID(fixID)
+		require.NoError(t, err)
+		assert.Equal(t, fixID, state.KymaConfig.Version)
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		err = InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+
+		brokerStorage, _, err := NewFromConfig(cfg, logrus.StandardLogger())
+		require.NoError(t, err)
+
+		// given
+		existing := fixInstance(instanceData{val: "existing"})
+		err = brokerStorage.Instances().Insert(*existing)
+		require.NoError(t, err)
+		existingProvisioning := fixProvisionOperation("existing")
+		err = brokerStorage.Operations().InsertProvisioningOperation(existingProvisioning)
+		require.NoError(t, err)
+
+		removedProvisioning := fixProvisionOperation("removed")
+		removedProvisioning.ProvisioningParameters = `{"ers_context":{"subaccount_id":"removed"}}`
+		err = brokerStorage.Operations().InsertProvisioningOperation(removedProvisioning)
+		require.NoError(t, err)
+		err = brokerStorage.Operations().InsertDeprovisioningOperation(fixDeprovisionOperation("removed"))
+		require.NoError(t, err)
+
+		for i, op := range []internal.ProvisioningOperation{existingProvisioning, existingProvisioning, removedProvisioning} {
+			err = brokerStorage.RuntimeStates().Insert(internal.RuntimeState{
+				ID:          fmt.Sprintf("state-%d", i),
+				CreatedAt:   fixTime().Add(time.Duration(i) * time.Hour),
+				RuntimeID:   op.InstanceID,
+				OperationID: op.ID,
+			})
+			require.NoError(t, err)
+		}
+		svc := brokerStorage.Retention()
+		now := time.Now()
+
+		// when
+		operations, err := svc.CountOperationsToArchive(now)
+		require.NoError(t, err)
+		runtimeStates, err := svc.CountRuntimeStatesToArchive(now)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, 2, operations)
+		assert.Equal(t, 1, runtimeStates)
+
+		// when
+		operations, err = svc.ArchiveOperations(now, 10)
+		require.NoError(t, err)
+		runtimeStates, err = svc.ArchiveRuntimeStates(now, 10)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, 2, operations)
+		assert.Equal(t, 1, runtimeStates)
+		_, err = brokerStorage.Operations().GetProvisioningOperationByID(removedProvisioning.ID)
+		assertError(t, dberr.CodeNotFound, errors.Cause(err))
+		_, err = brokerStorage.Operations().GetProvisioningOperationByID(existingProvisioning.ID)
+		require.NoError(t, err)
+		states, err := brokerStorage.RuntimeStates().ListByRuntimeID("existing")
+		require.NoError(t, err)
+		assert.Len(t, states, 1)
+
+		// when
+		instances, err := svc.CountInstancesToPurge(now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, instances)
+		instances, err = svc.PurgeInstances(now, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, instances)
+
+		// then
+		instances, err = svc.CountInstancesToPurge(now)
+		require.NoError(t, err)
+		assert.Zero(t, instances)
+		states, err = brokerStorage.RuntimeStates().ListByRuntimeID("removed")
+		require.NoError(t, err)
+		assert.Empty(t, states)
 	})
 
 	t.Run("LMS Tenants", func(t *testing.T) {
@@ -860,6 +1077,47 @@ func TestSchemaInitializer(t *testing.T) {
 		assert.False(t, differentNameExists)
 		assert.NoError(t, dnErr)
 	})
+
+	t.Run("Account assignments", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		err = InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+
+		brokerStorage, _, err := NewFromConfig(cfg, logrus.StandardLogger())
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		svc := brokerStorage.AccountAssignments()
+
+		now := time.Now()
+		assignment := internal.AccountAssignment{ID: "a1", SecretName: "secret1", HyperscalerType: "gcp", TenantName: "tenant1", CreatedAt: now}
+
+		// when
+		err = svc.Insert(assignment)
+		require.NoError(t, err)
+		err = svc.Insert(internal.AccountAssignment{ID: "a2", SecretName: "secret1", HyperscalerType: "gcp", TenantName: "tenant2", CreatedAt: now})
+
+		// then
+		assert.True(t, dberr.IsAlreadyExists(err))
+
+		// when
+		err = svc.Release("secret1", now.Add(time.Hour))
+		require.NoError(t, err)
+		err = svc.Insert(internal.AccountAssignment{ID: "a3", SecretName: "secret1", HyperscalerType: "gcp", TenantName: "tenant3", CreatedAt: now.Add(time.Hour)})
+		require.NoError(t, err)
+
+		// then
+		active, err := svc.ListActive()
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, "tenant3", active[0].TenantName)
+		assert.Nil(t, active[0].ReleasedAt)
+
+		err = svc.Release("secret2", now)
+		assert.True(t, dberr.IsNotFound(err))
+	})
 }
 
 func assertProvisioningOperation(t *testing.T, expected, got internal.ProvisioningOperation) {
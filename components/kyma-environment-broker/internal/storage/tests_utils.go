@@ -236,6 +236,7 @@ func FixTables() map[string]string {
 			description text,
 			parameters text NOT NULL,
 			runtime_operations text,
+			parent_orchestration_id varchar(255),
 			created_at TIMESTAMPTZ NOT NULL,
 			updated_at TIMESTAMPTZ NOT NULL
 			)`, postsql.OrchestrationTableName),
@@ -258,5 +259,49 @@ func FixTables() map[string]string {
 			kyma_version text,
 			k8s_version text
 			)`, postsql.RuntimeStateTableName),
+		postsql.InstanceLabelsTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			instance_id varchar(255) NOT NULL,
+			key varchar(255) NOT NULL,
+			value varchar(255) NOT NULL,
+			PRIMARY KEY (instance_id, key)
+			)`, postsql.InstanceLabelsTableName),
+		postsql.OperationArchiveTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			id varchar(255) PRIMARY KEY,
+			instance_id varchar(255) NOT NULL,
+			target_operation_id varchar(255) NOT NULL,
+			version integer NOT NULL,
+			state varchar(32) NOT NULL,
+			description text NOT NULL,
+			type varchar(32) NOT NULL,
+			data json NOT NULL,
+			orchestration_id varchar(64),
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+			)`, postsql.OperationArchiveTableName),
+		postsql.RuntimeStateArchiveTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			id varchar(255) PRIMARY KEY,
+			runtime_id varchar(255),
+			operation_id varchar(255),
+			created_at TIMESTAMPTZ NOT NULL,
+			kyma_config text,
+			cluster_config text,
+			kyma_version text,
+			k8s_version text,
+			archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+			)`, postsql.RuntimeStateArchiveTableName),
+		postsql.AccountAssignmentTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %[1]s (
+			id varchar(255) PRIMARY KEY,
+			secret_name varchar(255) NOT NULL,
+			hyperscaler_type varchar(32) NOT NULL,
+			tenant_name varchar(255) NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			released_at TIMESTAMPTZ
+			);
+			CREATE UNIQUE INDEX IF NOT EXISTS %[1]s_active_secret_idx ON %[1]s (secret_name) WHERE released_at IS NULL`, postsql.AccountAssignmentTableName),
 	}
 }
@@ -0,0 +1,187 @@
+package stream
+
+import (
+	"context"
+	"sync"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+
+	"github.com/sirupsen/logrus"
+)
+
+// listenerBufferSize is the number of events kept for a slow listener before the events are dropped
+const listenerBufferSize = 100
+
+// Filter selects the operation events delivered to the listener. Empty lists match all the events.
+type Filter struct {
+	GlobalAccountIDs []string
+	InstanceIDs      []string
+	RuntimeIDs       []string
+	OrchestrationIDs []string
+}
+
+// Match returns true if the event matches all the filters
+func (f Filter) Match(ev pkg.OperationEvent) bool {
+	return matches(f.GlobalAccountIDs, ev.GlobalAccountID) &&
+		matches(f.InstanceIDs, ev.InstanceID) &&
+		matches(f.RuntimeIDs, ev.RuntimeID) &&
+		matches(f.OrchestrationIDs, ev.OrchestrationID)
+}
+
+type listener struct {
+	filter Filter
+	events chan pkg.OperationEvent
+}
+
+// Broadcaster delivers the operation state changes published by the process managers to the listeners of the events stream.
+// The event publisher does not support unsubscribing, so the Broadcaster subscribes once and manages the listeners itself.
+// The events are published in memory by the process managers of this KEB instance, so the listeners receive
+// only the changes of the operations processed by this instance. KEB runs with a single replica.
+type Broadcaster struct {
+	mu  sync.Mutex
+	log logrus.FieldLogger
+
+	listeners map[*listener]struct{}
+}
+
+func NewBroadcaster(sub event.Subscriber, log logrus.FieldLogger) *Broadcaster {
+	b := &Broadcaster{
+		log:       log,
+		listeners: make(map[*listener]struct{}),
+	}
+	sub.Subscribe(process.ProvisioningStepProcessed{}, b.onProvisioningStepProcessed)
+	sub.Subscribe(process.DeprovisioningStepProcessed{}, b.onDeprovisioningStepProcessed)
+	sub.Subscribe(process.UpgradeKymaStepProcessed{}, b.onUpgradeKymaStepProcessed)
+
+	return b
+}
+
+// Listen registers a listener of the events matching the filter. The returned function unregisters the listener and closes the channel.
+func (b *Broadcaster) Listen(filter Filter) (<-chan pkg.OperationEvent, func()) {
+	l := &listener{
+		filter: filter,
+		events: make(chan pkg.OperationEvent, listenerBufferSize),
+	}
+
+	b.mu.Lock()
+	b.listeners[l] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return l.events, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.listeners, l)
+			b.mu.Unlock()
+			close(l.events)
+		})
+	}
+}
+
+func (b *Broadcaster) broadcast(ev pkg.OperationEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for l := range b.listeners {
+		if !l.filter.Match(ev) {
+			continue
+		}
+		select {
+		case l.events <- ev:
+		default:
+			b.log.Warnf("dropping event of operation %s, the listener does not keep up", ev.OperationID)
+		}
+	}
+}
+
+func (b *Broadcaster) onProvisioningStepProcessed(_ context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.ProvisioningStepProcessed)
+	if !ok {
+		return nil
+	}
+	if !changed(stepProcessed.OldOperation.Operation, stepProcessed.Operation.Operation) {
+		return nil
+	}
+
+	op := stepProcessed.Operation
+	opEvent := newOperationEvent(op.Operation, pkg.OperationTypeProvision)
+	opEvent.RuntimeID = op.RuntimeID
+	if pp, err := op.GetProvisioningParameters(); err == nil {
+		opEvent.GlobalAccountID = pp.ErsContext.GlobalAccountID
+		opEvent.SubAccountID = pp.ErsContext.SubAccountID
+	}
+	b.broadcast(opEvent)
+
+	return nil
+}
+
+func (b *Broadcaster) onDeprovisioningStepProcessed(_ context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.DeprovisioningStepProcessed)
+	if !ok {
+		return nil
+	}
+	if !changed(stepProcessed.OldOperation.Operation, stepProcessed.Operation.Operation) {
+		return nil
+	}
+
+	op := stepProcessed.Operation
+	opEvent := newOperationEvent(op.Operation, pkg.OperationTypeDeprovision)
+	opEvent.RuntimeID = op.RuntimeID
+	if pp, err := op.GetProvisioningParameters(); err == nil {
+		opEvent.GlobalAccountID = pp.ErsContext.GlobalAccountID
+		opEvent.SubAccountID = pp.ErsContext.SubAccountID
+	}
+	b.broadcast(opEvent)
+
+	return nil
+}
+
+func (b *Broadcaster) onUpgradeKymaStepProcessed(_ context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.UpgradeKymaStepProcessed)
+	if !ok {
+		return nil
+	}
+	if !changed(stepProcessed.OldOperation.Operation, stepProcessed.Operation.Operation) {
+		return nil
+	}
+
+	op := stepProcessed.Operation
+	opEvent := newOperationEvent(op.Operation, pkg.OperationTypeUpgradeKyma)
+	opEvent.RuntimeID = op.RuntimeOperation.RuntimeID
+	opEvent.GlobalAccountID = op.RuntimeOperation.GlobalAccountID
+	opEvent.SubAccountID = op.RuntimeOperation.SubAccountID
+	b.broadcast(opEvent)
+
+	return nil
+}
+
+func newOperationEvent(op internal.Operation, operationType string) pkg.OperationEvent {
+	return pkg.OperationEvent{
+		OperationID:     op.ID,
+		Type:            operationType,
+		State:           string(op.State),
+		Description:     op.Description,
+		InstanceID:      op.InstanceID,
+		OrchestrationID: op.OrchestrationID,
+		UpdatedAt:       op.UpdatedAt,
+	}
+}
+
+func changed(old, current internal.Operation) bool {
+	return old.State != current.State || old.Description != current.Description
+}
+
+func matches(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package stream_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/stream"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcaster(t *testing.T) {
+	// given
+	pubSub := event.NewPubSub(logrus.New())
+	broadcaster := stream.NewBroadcaster(pubSub, logrus.New())
+
+	orchestrationEvents, stopOrchestration := broadcaster.Listen(stream.Filter{OrchestrationIDs: []string{"orchestration-id"}})
+	defer stopOrchestration()
+	accountEvents, stopAccount := broadcaster.Listen(stream.Filter{GlobalAccountIDs: []string{"ga-id"}})
+	defer stopAccount()
+
+	// when
+	pubSub.Publish(context.TODO(), process.UpgradeKymaStepProcessed{
+		OldOperation: fixUpgradeKymaOperation(domain.InProgress),
+		Operation:    fixUpgradeKymaOperation(domain.Succeeded),
+	})
+
+	// then
+	for _, events := range []<-chan pkg.OperationEvent{orchestrationEvents, accountEvents} {
+		ev := receive(t, events)
+		assert.Equal(t, pkg.OperationEvent{
+			OperationID:     "operation-id",
+			Type:            pkg.OperationTypeUpgradeKyma,
+			State:           string(domain.Succeeded),
+			InstanceID:      "instance-id",
+			RuntimeID:       "runtime-id",
+			GlobalAccountID: "ga-id",
+			SubAccountID:    "sa-id",
+			OrchestrationID: "orchestration-id",
+		}, ev)
+	}
+}
+
+func TestBroadcaster_SkipsUnchangedAndNotMatchingOperations(t *testing.T) {
+	// given
+	pubSub := event.NewPubSub(logrus.New())
+	broadcaster := stream.NewBroadcaster(pubSub, logrus.New())
+
+	events, stop := broadcaster.Listen(stream.Filter{RuntimeIDs: []string{"runtime-id"}})
+
+	// when
+	pubSub.Publish(context.TODO(), process.UpgradeKymaStepProcessed{
+		OldOperation: fixUpgradeKymaOperation(domain.InProgress),
+		Operation:    fixUpgradeKymaOperation(domain.InProgress),
+	})
+	pubSub.Publish(context.TODO(), process.ProvisioningStepProcessed{
+		OldOperation: internal.ProvisioningOperation{Operation: internal.Operation{ID: "other", State: domain.InProgress}, RuntimeID: "other-runtime-id"},
+		Operation:    internal.ProvisioningOperation{Operation: internal.Operation{ID: "other", State: domain.Failed}, RuntimeID: "other-runtime-id"},
+	})
+	pubSub.Publish(context.TODO(), process.ProvisioningStepProcessed{
+		OldOperation: internal.ProvisioningOperation{Operation: internal.Operation{ID: "provisioning", State: domain.InProgress}, RuntimeID: "runtime-id"},
+		Operation:    internal.ProvisioningOperation{Operation: internal.Operation{ID: "provisioning", State: domain.Failed}, RuntimeID: "runtime-id"},
+	})
+
+	// then
+	ev := receive(t, events)
+	assert.Equal(t, "provisioning", ev.OperationID)
+	assert.Equal(t, pkg.OperationTypeProvision, ev.Type)
+
+	// when
+	stop()
+
+	// then
+	_, open := <-events
+	assert.False(t, open)
+}
+
+func receive(t *testing.T, events <-chan pkg.OperationEvent) pkg.OperationEvent {
+	select {
+	case ev := <-events:
+		return ev
+	case <-time.After(time.Second):
+		require.FailNow(t, "event not received")
+	}
+	return pkg.OperationEvent{}
+}
+
+func fixUpgradeKymaOperation(state domain.LastOperationState) internal.UpgradeKymaOperation {
+	return internal.UpgradeKymaOperation{
+		Operation: internal.Operation{
+			ID:              "operation-id",
+			InstanceID:      "instance-id",
+			State:           state,
+			OrchestrationID: "orchestration-id",
+		},
+		RuntimeOperation: orchestration.RuntimeOperation{
+			Runtime: orchestration.Runtime{
+				InstanceID:      "instance-id",
+				RuntimeID:       "runtime-id",
+				GlobalAccountID: "ga-id",
+				SubAccountID:    "sa-id",
+			},
+		},
+	}
+}
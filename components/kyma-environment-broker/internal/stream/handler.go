@@ -0,0 +1,83 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Handler serves the operation events as server-sent events
+type Handler struct {
+	broadcaster *Broadcaster
+	log         logrus.FieldLogger
+
+	// keepAliveInterval is the interval of the comments sent to keep the idle connection open
+	keepAliveInterval time.Duration
+}
+
+func NewHandler(broadcaster *Broadcaster, keepAliveInterval time.Duration, log logrus.FieldLogger) *Handler {
+	return &Handler{
+		broadcaster:       broadcaster,
+		log:               log,
+		keepAliveInterval: keepAliveInterval,
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/events/operations", h.streamOperations).Methods(http.MethodGet)
+}
+
+func (h *Handler) streamOperations(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	query := req.URL.Query()
+	events, stop := h.broadcaster.Listen(Filter{
+		GlobalAccountIDs: query[pkg.GlobalAccountIDParam],
+		InstanceIDs:      query[pkg.InstanceIDParam],
+		RuntimeIDs:       query[pkg.RuntimeIDParam],
+		OrchestrationIDs: query[pkg.OrchestrationIDParam],
+	})
+	defer stop()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(h.keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case ev := <-events:
+			data, err := json.Marshal(ev)
+			if err != nil {
+				h.log.Errorf("while encoding event of operation %s: %s", ev.OperationID, err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", pkg.OperationEventType, data); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,83 @@
+package stream_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/stream"
+
+	"github.com/gorilla/mux"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestHandler_StreamOperations(t *testing.T) {
+	// given
+	pubSub := event.NewPubSub(logrus.New())
+	broadcaster := stream.NewBroadcaster(pubSub, logrus.New())
+	router := mux.NewRouter()
+	stream.NewHandler(broadcaster, time.Minute, logrus.New()).AttachRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/events/operations?orchestration_id=orchestration-id", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// when
+	lines := make(chan string, 10)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	pubSub.Publish(context.TODO(), process.UpgradeKymaStepProcessed{
+		OldOperation: fixUpgradeKymaOperation(domain.InProgress),
+		Operation:    fixUpgradeKymaOperation(domain.Failed),
+	})
+
+	// then
+	var eventType, data string
+	err = wait.PollImmediate(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		select {
+		case line := <-lines:
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				eventType = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			}
+			return data != "", nil
+		default:
+			return false, nil
+		}
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, pkg.OperationEventType, eventType)
+	var ev pkg.OperationEvent
+	require.NoError(t, json.Unmarshal([]byte(data), &ev))
+	assert.Equal(t, "operation-id", ev.OperationID)
+	assert.Equal(t, string(domain.Failed), ev.State)
+}
@@ -0,0 +1,155 @@
+package tracing
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlphttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/kyma-project/control-plane/components/kyma-environment-broker"
+
+	ServiceName = "kyma-environment-broker"
+
+	OperationIDKey   = attribute.Key("keb.operation.id")
+	InstanceIDKey    = attribute.Key("keb.instance.id")
+	OperationTypeKey = attribute.Key("keb.operation.type")
+	StepNameKey      = attribute.Key("keb.step.name")
+)
+
+type Config struct {
+	Enabled bool `envconfig:"default=false"`
+	// Endpoint is the host and port of the OTLP HTTP receiver, for example otel-collector.kcp-system:4318
+	Endpoint string `envconfig:"optional"`
+	Insecure bool   `envconfig:"default=false"`
+	// SampleRatio is the fraction of the traces started by the broker which are exported
+	SampleRatio float64 `envconfig:"default=1"`
+}
+
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Endpoint == "" {
+		return errors.New("the OTLP endpoint is required when tracing is enabled")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return errors.Errorf("the sample ratio must be between 0 and 1, got %v", c.SampleRatio)
+	}
+	return nil
+}
+
+// Init registers the global tracer provider which exports the spans over OTLP and the W3C trace context propagator.
+// The returned function flushes the remaining spans, it must be called before the application exits.
+// If the tracing is disabled the spans are not recorded and the trace context is not propagated.
+func Init(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlphttp.Option{otlphttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		options = append(options, otlphttp.WithInsecure())
+	}
+	exporter, err := otlp.NewExporter(ctx, otlphttp.NewDriver(options...))
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating OTLP exporter")
+	}
+
+	provider := NewTracerProvider(exporter, cfg.SampleRatio)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// NewTracerProvider creates the provider which exports the spans with the given exporter,
+// the tests use it with the in-memory exporter
+func NewTracerProvider(exporter sdktrace.SpanExporter, sampleRatio float64) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(newSampler(sampleRatio)),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(ServiceName))),
+	)
+}
+
+// newSampler samples the given fraction of the traces. The parent derived from the operation ID is not sampled,
+// so the ratio is applied to its trace ID and every execution of the operation gets the same decision.
+func newSampler(sampleRatio float64) sdktrace.Sampler {
+	sampler := sdktrace.TraceIDRatioBased(sampleRatio)
+	return sdktrace.ParentBased(sampler, sdktrace.WithRemoteParentNotSampled(sampler))
+}
+
+// Tracer returns the tracer of the broker from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// NewTransport wraps the transport, so the requests made within a trace create the client spans and pass the trace context
+// to the called service. The requests without the trace in their contexts, like the health checks, do not start new traces.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base, otelhttp.WithFilter(func(req *http.Request) bool {
+		return trace.SpanContextFromContext(req.Context()).IsValid()
+	}))
+}
+
+// StartOperation starts the span of processing the operation of the given type.
+// The operation is processed again after each requeue and after the broker restart, so if the context has no span
+// the span is started in the trace derived from the operation ID and all executions of the operation share one trace.
+func StartOperation(ctx context.Context, operationType string, operationID, instanceID string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, operationSpanContext(operationID))
+	}
+	return Tracer().Start(ctx, operationType, trace.WithAttributes(
+		OperationTypeKey.String(operationType),
+		OperationIDKey.String(operationID),
+		InstanceIDKey.String(instanceID),
+	))
+}
+
+// operationSpanContext returns the span context of the operation, the trace ID and the span ID are taken from
+// the hash of the operation ID
+func operationSpanContext(operationID string) trace.SpanContext {
+	sum := sha256.Sum256([]byte(operationID))
+
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	copy(traceID[:], sum[:len(traceID)])
+	copy(spanID[:], sum[len(traceID):])
+
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+		Remote:  true,
+	})
+}
+
+// StartStep starts the span of the operation step, the context must carry the span of the operation
+func StartStep(ctx context.Context, stepName string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, stepName, trace.WithAttributes(StepNameKey.String(stepName)))
+}
+
+// SetError records the error in the span and marks the span as failed, nil error is ignored
+func SetError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
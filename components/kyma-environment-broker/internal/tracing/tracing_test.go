@@ -0,0 +1,178 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestStartStep(t *testing.T) {
+	// given
+	exporter := setupTracing(t)
+
+	// when
+	ctx, operationSpan := StartOperation(context.Background(), "provisioning", "op-id", "instance-id")
+	_, stepSpan := StartStep(ctx, "Create_Runtime")
+	SetError(stepSpan, errors.New("provisioner is not available"))
+	stepSpan.End()
+	operationSpan.End()
+
+	// then
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	step, operation := spans[0], spans[1]
+
+	assert.Equal(t, "provisioning", operation.Name)
+	assert.Contains(t, operation.Attributes, OperationIDKey.String("op-id"))
+	assert.Contains(t, operation.Attributes, InstanceIDKey.String("instance-id"))
+
+	assert.Equal(t, "Create_Runtime", step.Name)
+	assert.Equal(t, operation.SpanContext.TraceID(), step.SpanContext.TraceID())
+	assert.Equal(t, operation.SpanContext.SpanID(), step.Parent.SpanID())
+	assert.Equal(t, codes.Error, step.StatusCode)
+	assert.Equal(t, codes.Unset, operation.StatusCode)
+}
+
+func TestStartOperation(t *testing.T) {
+	// given
+	exporter := setupTracing(t)
+
+	// when
+	_, firstExecution := StartOperation(context.Background(), "upgrade_kyma", "op-id", "instance-id")
+	firstExecution.End()
+	_, secondExecution := StartOperation(context.Background(), "upgrade_kyma", "op-id", "instance-id")
+	secondExecution.End()
+	_, otherOperation := StartOperation(context.Background(), "upgrade_kyma", "other-op-id", "instance-id")
+	otherOperation.End()
+
+	// then
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, spans[0].SpanContext.TraceID(), spans[1].SpanContext.TraceID())
+	assert.NotEqual(t, spans[0].SpanContext.SpanID(), spans[1].SpanContext.SpanID())
+	assert.NotEqual(t, spans[0].SpanContext.TraceID(), spans[2].SpanContext.TraceID())
+}
+
+func TestStartOperation_WithParent(t *testing.T) {
+	// given
+	exporter := setupTracing(t)
+	ctx, parent := Tracer().Start(context.Background(), "orchestration")
+
+	// when
+	_, span := StartOperation(ctx, "upgrade_kyma", "op-id", "instance-id")
+	span.End()
+	parent.End()
+
+	// then
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, spans[1].SpanContext.TraceID(), spans[0].SpanContext.TraceID())
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+}
+
+func TestNewSampler(t *testing.T) {
+	// given
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(newSampler(0.5)))
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previousProvider)
+
+	sampled := 0
+	for i := 0; i < 100; i++ {
+		operationID := fmt.Sprintf("op-%d", i)
+
+		// when
+		_, first := StartOperation(context.Background(), "upgrade_kyma", operationID, "instance-id")
+		_, second := StartOperation(context.Background(), "upgrade_kyma", operationID, "instance-id")
+
+		// then
+		assert.Equal(t, first.SpanContext().IsSampled(), second.SpanContext().IsSampled())
+		if first.SpanContext().IsSampled() {
+			sampled++
+		}
+	}
+	assert.True(t, sampled > 0 && sampled < 100, "expected a part of the operations to be sampled, got %d", sampled)
+}
+
+func TestNewTransport(t *testing.T) {
+	// given
+	exporter := setupTracing(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	t.Run("should pass the trace context of the step", func(t *testing.T) {
+		// given
+		exporter.Reset()
+		ctx, span := StartStep(context.Background(), "Create_Runtime")
+
+		// when
+		doRequest(t, ctx, client, server.URL)
+		span.End()
+
+		// then
+		spanContext := trace.SpanContextFromContext(ctx)
+		assert.Contains(t, traceparent, spanContext.TraceID().String())
+		assert.Len(t, exporter.GetSpans(), 2)
+	})
+
+	t.Run("should not start the trace for the request without the trace", func(t *testing.T) {
+		// given
+		exporter.Reset()
+		traceparent = ""
+
+		// when
+		doRequest(t, context.Background(), client, server.URL)
+
+		// then
+		assert.Empty(t, traceparent)
+		assert.Empty(t, exporter.GetSpans())
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.NoError(t, Config{Enabled: true, Endpoint: "otel-collector:4318", SampleRatio: 0.5}.Validate())
+	assert.Error(t, Config{Enabled: true, SampleRatio: 1}.Validate())
+	assert.Error(t, Config{Enabled: true, Endpoint: "otel-collector:4318", SampleRatio: 2}.Validate())
+}
+
+// setupTracing registers the tracer provider with the synchronous in-memory exporter for the time of the test
+func setupTracing(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter), sdktrace.WithSampler(newSampler(1)))
+
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	return exporter
+}
+
+func doRequest(t *testing.T, ctx context.Context, client *http.Client, url string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/metrics"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
 
@@ -94,6 +95,8 @@ type config struct {
 
 	MetricsAddress string `envconfig:"default=127.0.0.1:9000"`
 
+	Tracing tracing.Config
+
 	LogLevel string `envconfig:"default=info"`
 }
 
@@ -146,6 +149,14 @@ func main() {
 	log.Infof("Starting Provisioner")
 	log.Infof("Config: %s", cfg.String())
 
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	exitOnError(err, "Failed to initialize tracing")
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Errorf("Failed to flush traces: %s", err.Error())
+		}
+	}()
+
 	connString := fmt.Sprintf(connStringFormat, cfg.Database.Host, cfg.Database.Port, cfg.Database.User,
 		cfg.Database.Password, cfg.Database.Name, cfg.Database.SSLMode)
 
@@ -261,7 +272,7 @@ func main() {
 	router.Use(middlewares.ExtractTenant)
 
 	router.HandleFunc("/", handler.Playground("Dataloader", cfg.PlaygroundAPIEndpoint))
-	router.HandleFunc(cfg.APIEndpoint, handler.GraphQL(executableSchema, handler.ErrorPresenter(presenter.Do)))
+	router.Handle(cfg.APIEndpoint, tracing.NewHandler(handler.GraphQL(executableSchema, handler.ErrorPresenter(presenter.Do)), "graphql"))
 	router.HandleFunc("/healthz", healthz.NewHTTPHandler(log.StandardLogger()))
 
 	// Metrics
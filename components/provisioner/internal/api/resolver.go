@@ -55,7 +55,7 @@ func (r *Resolver) ProvisionRuntime(ctx context.Context, config gqlschema.Provis
 
 	log.Infof("Requested provisioning of Runtime %s.", config.RuntimeInput.Name)
 
-	operationStatus, err := r.provisioning.ProvisionRuntime(config, tenant, subAccount)
+	operationStatus, err := r.provisioning.ProvisionRuntime(ctx, config, tenant, subAccount)
 	if err != nil {
 		log.Errorf("Failed to provision Runtime %s: %s", config.RuntimeInput.Name, err)
 		return nil, err
@@ -74,7 +74,7 @@ func (r *Resolver) DeprovisionRuntime(ctx context.Context, id string) (string, e
 		return "", err
 	}
 
-	operationID, err := r.provisioning.DeprovisionRuntime(id, tenant)
+	operationID, err := r.provisioning.DeprovisionRuntime(ctx, id, tenant)
 	if err != nil {
 		log.Errorf("Failed to deprovision Runtime %s: %s", id, err)
 		return "", err
@@ -99,7 +99,7 @@ func (r *Resolver) UpgradeRuntime(ctx context.Context, runtimeId string, input g
 		return nil, err
 	}
 
-	operationStatus, err := r.provisioning.UpgradeRuntime(runtimeId, input)
+	operationStatus, err := r.provisioning.UpgradeRuntime(ctx, runtimeId, input)
 	if err != nil {
 		log.Errorf("Failed to upgrade Runtime %s: %s", runtimeId, err)
 		return nil, err
@@ -182,7 +182,7 @@ func (r *Resolver) UpgradeShoot(ctx context.Context, runtimeID string, input gql
 		return nil, err
 	}
 
-	status, err := r.provisioning.UpgradeGardenerShoot(runtimeID, input)
+	status, err := r.provisioning.UpgradeGardenerShoot(ctx, runtimeID, input)
 	if err != nil {
 		log.Errorf("Failed to upgrade Gardener Shoot cluster specification for Runtime %s: %s", runtimeID, err)
 		return nil, err
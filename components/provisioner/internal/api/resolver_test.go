@@ -16,6 +16,7 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -81,7 +82,7 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 			KymaConfig:    kymaConfig,
 		}
 
-		provisioningService.On("ProvisionRuntime", config, tenant, "").Return(operation, nil)
+		provisioningService.On("ProvisionRuntime", mock.Anything, config, tenant, "").Return(operation, nil)
 		validator.On("ValidateProvisioningInput", config).Return(nil)
 
 		//when
@@ -139,7 +140,7 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 
 		config := gqlschema.ProvisionRuntimeInput{RuntimeInput: runtimeInput, ClusterConfig: clusterConfig, KymaConfig: kymaConfig}
 
-		provisioningService.On("ProvisionRuntime", config, tenant, "").Return(nil, apperrors.Internal("Provisioning failed"))
+		provisioningService.On("ProvisionRuntime", mock.Anything, config, tenant, "").Return(nil, apperrors.Internal("Provisioning failed"))
 		validator.On("ValidateProvisioningInput", config).Return(nil)
 
 		//when
@@ -197,7 +198,7 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 
 		expectedID := "ec781980-0533-4098-aab7-96b535569732"
 
-		provisioningService.On("DeprovisionRuntime", runtimeID, tenant).Return(expectedID, nil)
+		provisioningService.On("DeprovisionRuntime", mock.Anything, runtimeID, tenant).Return(expectedID, nil)
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
 
 		//when
@@ -214,7 +215,7 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 		validator := &validatorMocks.Validator{}
 		provisioner := api.NewResolver(provisioningService, validator)
 
-		provisioningService.On("DeprovisionRuntime", runtimeID, tenant).Return("", apperrors.Internal("Deprovisioning fails because reasons"))
+		provisioningService.On("DeprovisionRuntime", mock.Anything, runtimeID, tenant).Return("", apperrors.Internal("Deprovisioning fails because reasons"))
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
 
 		//when
@@ -234,7 +235,7 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 
 		expectedID := "ec781980-0533-4098-aab7-96b535569732"
 
-		provisioningService.On("DeprovisionRuntime", runtimeID, tenant).Return(expectedID, nil, nil)
+		provisioningService.On("DeprovisionRuntime", mock.Anything, runtimeID, tenant).Return(expectedID, nil, nil)
 
 		ctx := context.Background()
 
@@ -254,7 +255,7 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 
 		expectedID := "ec781980-0533-4098-aab7-96b535569732"
 
-		provisioningService.On("DeprovisionRuntime", runtimeID, tenant).Return(expectedID, nil, nil)
+		provisioningService.On("DeprovisionRuntime", mock.Anything, runtimeID, tenant).Return(expectedID, nil, nil)
 		validator.On("ValidateTenant", runtimeID, tenant).Return(apperrors.BadRequest("Very bad error"))
 
 		//when
@@ -287,7 +288,7 @@ func TestResolver_UpgradeRuntime(t *testing.T) {
 			RuntimeID: util.StringPtr(runtimeID),
 		}
 
-		provisioningService.On("UpgradeRuntime", runtimeID, upgradeInput).Return(operation, nil)
+		provisioningService.On("UpgradeRuntime", mock.Anything, runtimeID, upgradeInput).Return(operation, nil)
 		validator.On("ValidateUpgradeInput", upgradeInput).Return(nil)
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
 
@@ -309,7 +310,7 @@ func TestResolver_UpgradeRuntime(t *testing.T) {
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
 
-		provisioningService.On("UpgradeRuntime", runtimeID, upgradeInput).Return(nil, apperrors.Internal("error"))
+		provisioningService.On("UpgradeRuntime", mock.Anything, runtimeID, upgradeInput).Return(nil, apperrors.Internal("error"))
 		validator.On("ValidateUpgradeInput", upgradeInput).Return(nil)
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
 
@@ -437,7 +438,7 @@ func TestResolver_UpgradeShoot(t *testing.T) {
 
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
 		validator.On("ValidateUpgradeShootInput", upgradeShootInput).Return(nil)
-		provisioningService.On("UpgradeGardenerShoot", runtimeID, upgradeShootInput).Return(operation, nil)
+		provisioningService.On("UpgradeGardenerShoot", mock.Anything, runtimeID, upgradeShootInput).Return(operation, nil)
 
 		resolver := api.NewResolver(provisioningService, validator)
 
@@ -1,6 +1,7 @@
 package operations
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -11,7 +12,9 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/director"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -73,7 +76,15 @@ func (e *Executor) Execute(operationID string) ProcessingResult {
 	log = log.WithField("ShootName", cluster.ClusterConfig.Name)
 
 	if operation.Type == e.operation {
-		requeue, delay, err := e.process(operation, cluster, log)
+		ctx, span := tracing.Tracer().Start(tracing.OperationContext(operation.ID), string(e.operation), trace.WithAttributes(
+			tracing.OperationIDKey.String(operation.ID),
+			tracing.OperationTypeKey.String(string(e.operation)),
+			tracing.RuntimeIDKey.String(operation.ClusterID),
+		))
+		defer span.End()
+
+		requeue, delay, err := e.process(ctx, operation, cluster, log)
+		tracing.SetError(span, err)
 		if err != nil {
 			nonRecoverable := NonRecoverableError{}
 			if errors.As(err, &nonRecoverable) {
@@ -81,12 +92,16 @@ func (e *Executor) Execute(operationID string) ProcessingResult {
 				e.handleOperationFailure(operation, cluster, log)
 				e.updateOperationStatus(log, operation.ID, nonRecoverable.Error(), model.Failed, time.Now())
 				e.setRuntimeStatusCondition(log, cluster.ID, cluster.Tenant)
+				tracing.FinishOperationTrace(operation.ID)
 
 				return ProcessingResult{Requeue: false}
 			}
 
 			return ProcessingResult{Requeue: true, Delay: defaultDelay}
 		}
+		if !requeue {
+			tracing.FinishOperationTrace(operation.ID)
+		}
 
 		return ProcessingResult{Requeue: requeue, Delay: delay}
 	}
@@ -97,7 +112,7 @@ func (e *Executor) Execute(operationID string) ProcessingResult {
 	}
 }
 
-func (e *Executor) process(operation model.Operation, cluster model.Cluster, logger logrus.FieldLogger) (bool, time.Duration, error) {
+func (e *Executor) process(ctx context.Context, operation model.Operation, cluster model.Cluster, logger logrus.FieldLogger) (bool, time.Duration, error) {
 
 	step, found := e.stages[operation.Stage]
 	if !found {
@@ -113,7 +128,7 @@ func (e *Executor) process(operation model.Operation, cluster model.Cluster, log
 			return false, 0, NewNonRecoverableError(fmt.Errorf("error: timeout while processing operation"))
 		}
 
-		result, err := step.Run(cluster, operation, log)
+		result, err := e.runStage(ctx, step, cluster, operation, log)
 		if err != nil {
 			log.Errorf("error while processing operation, stage failed: %s", err.Error())
 			return false, 0, err
@@ -145,6 +160,16 @@ func (e *Executor) process(operation model.Operation, cluster model.Cluster, log
 	return false, 0, nil
 }
 
+// runStage runs the step within the span of the stage, the span is a child of the operation span
+func (e *Executor) runStage(ctx context.Context, step Step, cluster model.Cluster, operation model.Operation, log logrus.FieldLogger) (StageResult, error) {
+	_, span := tracing.Tracer().Start(ctx, string(step.Name()), trace.WithAttributes(tracing.StageKey.String(string(step.Name()))))
+	defer span.End()
+
+	result, err := step.Run(cluster, operation, log)
+	tracing.SetError(span, err)
+	return result, err
+}
+
 func (e *Executor) timeoutReached(operation model.Operation, timeout time.Duration) bool {
 
 	lastTimestamp := operation.StartTimestamp
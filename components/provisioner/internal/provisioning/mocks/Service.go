@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	apperrors "github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 	gqlschema "github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 
@@ -14,20 +16,20 @@ type Service struct {
 	mock.Mock
 }
 
-// DeprovisionRuntime provides a mock function with given fields: id, tenant
-func (_m *Service) DeprovisionRuntime(id string, tenant string) (string, apperrors.AppError) {
-	ret := _m.Called(id, tenant)
+// DeprovisionRuntime provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) DeprovisionRuntime(ctx context.Context, id string, tenant string) (string, apperrors.AppError) {
+	ret := _m.Called(ctx, id, tenant)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(id, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, id, tenant)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string, string) apperrors.AppError); ok {
-		r1 = rf(id, tenant)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) apperrors.AppError); ok {
+		r1 = rf(ctx, id, tenant)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -37,13 +39,13 @@ func (_m *Service) DeprovisionRuntime(id string, tenant string) (string, apperro
 	return r0, r1
 }
 
-// ProvisionRuntime provides a mock function with given fields: config, tenant, subAccount
-func (_m *Service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant string, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
-	ret := _m.Called(config, tenant, subAccount)
+// ProvisionRuntime provides a mock function with given fields: ctx, config, tenant, subAccount
+func (_m *Service) ProvisionRuntime(ctx context.Context, config gqlschema.ProvisionRuntimeInput, tenant string, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(ctx, config, tenant, subAccount)
 
 	var r0 *gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(gqlschema.ProvisionRuntimeInput, string, string) *gqlschema.OperationStatus); ok {
-		r0 = rf(config, tenant, subAccount)
+	if rf, ok := ret.Get(0).(func(context.Context, gqlschema.ProvisionRuntimeInput, string, string) *gqlschema.OperationStatus); ok {
+		r0 = rf(ctx, config, tenant, subAccount)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gqlschema.OperationStatus)
@@ -51,8 +53,8 @@ func (_m *Service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tena
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(gqlschema.ProvisionRuntimeInput, string, string) apperrors.AppError); ok {
-		r1 = rf(config, tenant, subAccount)
+	if rf, ok := ret.Get(1).(func(context.Context, gqlschema.ProvisionRuntimeInput, string, string) apperrors.AppError); ok {
+		r1 = rf(ctx, config, tenant, subAccount)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -160,13 +162,13 @@ func (_m *Service) RuntimeStatus(id string) (*gqlschema.RuntimeStatus, apperrors
 	return r0, r1
 }
 
-// UpgradeGardenerShoot provides a mock function with given fields: id, input
-func (_m *Service) UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
-	ret := _m.Called(id, input)
+// UpgradeGardenerShoot provides a mock function with given fields: ctx, id, input
+func (_m *Service) UpgradeGardenerShoot(ctx context.Context, id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(ctx, id, input)
 
 	var r0 *gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(string, gqlschema.UpgradeShootInput) *gqlschema.OperationStatus); ok {
-		r0 = rf(id, input)
+	if rf, ok := ret.Get(0).(func(context.Context, string, gqlschema.UpgradeShootInput) *gqlschema.OperationStatus); ok {
+		r0 = rf(ctx, id, input)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gqlschema.OperationStatus)
@@ -174,8 +176,8 @@ func (_m *Service) UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootI
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string, gqlschema.UpgradeShootInput) apperrors.AppError); ok {
-		r1 = rf(id, input)
+	if rf, ok := ret.Get(1).(func(context.Context, string, gqlschema.UpgradeShootInput) apperrors.AppError); ok {
+		r1 = rf(ctx, id, input)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -185,13 +187,13 @@ func (_m *Service) UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootI
 	return r0, r1
 }
 
-// UpgradeRuntime provides a mock function with given fields: id, config
-func (_m *Service) UpgradeRuntime(id string, config gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError) {
-	ret := _m.Called(id, config)
+// UpgradeRuntime provides a mock function with given fields: ctx, id, config
+func (_m *Service) UpgradeRuntime(ctx context.Context, id string, config gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(ctx, id, config)
 
 	var r0 *gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(string, gqlschema.UpgradeRuntimeInput) *gqlschema.OperationStatus); ok {
-		r0 = rf(id, config)
+	if rf, ok := ret.Get(0).(func(context.Context, string, gqlschema.UpgradeRuntimeInput) *gqlschema.OperationStatus); ok {
+		r0 = rf(ctx, id, config)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gqlschema.OperationStatus)
@@ -199,8 +201,8 @@ func (_m *Service) UpgradeRuntime(id string, config gqlschema.UpgradeRuntimeInpu
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string, gqlschema.UpgradeRuntimeInput) apperrors.AppError); ok {
-		r1 = rf(id, config)
+	if rf, ok := ret.Get(1).(func(context.Context, string, gqlschema.UpgradeRuntimeInput) apperrors.AppError); ok {
+		r1 = rf(ctx, id, config)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -1,8 +1,10 @@
 package provisioning
 
 import (
+	"context"
 	"time"
 
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
@@ -25,10 +27,10 @@ import (
 
 //go:generate mockery -name=Service
 type Service interface {
-	ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError)
-	UpgradeRuntime(id string, config gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError)
-	DeprovisionRuntime(id, tenant string) (string, apperrors.AppError)
-	UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError)
+	ProvisionRuntime(ctx context.Context, config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError)
+	UpgradeRuntime(ctx context.Context, id string, config gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError)
+	DeprovisionRuntime(ctx context.Context, id, tenant string) (string, apperrors.AppError)
+	UpgradeGardenerShoot(ctx context.Context, id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError)
 	ReconnectRuntimeAgent(id string) (string, apperrors.AppError)
 	RuntimeStatus(id string) (*gqlschema.RuntimeStatus, apperrors.AppError)
 	RuntimeOperationStatus(id string) (*gqlschema.OperationStatus, apperrors.AppError)
@@ -84,7 +86,7 @@ func NewProvisioningService(
 	}
 }
 
-func (r *service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
+func (r *service) ProvisionRuntime(ctx context.Context, config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
 	runtimeInput := config.RuntimeInput
 
 	var runtimeID string
@@ -129,6 +131,7 @@ func (r *service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenan
 		return nil, apperrors.Internal("Failed to commit transaction: %s", dberr.Error())
 	}
 
+	tracing.StartOperationTrace(ctx, operation.ID)
 	r.provisioningQueue.Add(operation.ID)
 
 	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
@@ -145,7 +148,7 @@ func (r *service) unregisterFailedRuntime(id, tenant string) {
 	}
 }
 
-func (r *service) DeprovisionRuntime(id, tenant string) (string, apperrors.AppError) {
+func (r *service) DeprovisionRuntime(ctx context.Context, id, tenant string) (string, apperrors.AppError) {
 	session := r.dbSessionFactory.NewReadWriteSession()
 
 	err := r.verifyLastOperationFinished(session, id)
@@ -168,12 +171,13 @@ func (r *service) DeprovisionRuntime(id, tenant string) (string, apperrors.AppEr
 		return "", apperrors.Internal("Failed to insert operation to database: %s", dberr.Error())
 	}
 
+	tracing.StartOperationTrace(ctx, operation.ID)
 	r.deprovisioningQueue.Add(operation.ID)
 
 	return operation.ID, nil
 }
 
-func (r *service) UpgradeGardenerShoot(runtimeID string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+func (r *service) UpgradeGardenerShoot(ctx context.Context, runtimeID string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
 	log.Infof("Starting Upgrade of Gardener Shoot for Runtime '%s'...", runtimeID)
 
 	if input.GardenerConfig == nil {
@@ -218,6 +222,7 @@ func (r *service) UpgradeGardenerShoot(runtimeID string, input gqlschema.Upgrade
 		return &gqlschema.OperationStatus{}, apperrors.Internal("Failed to commit upgrade transaction: %s", dbErr.Error())
 	}
 
+	tracing.StartOperationTrace(ctx, operation.ID)
 	r.shootUpgradeQueue.Add(operation.ID)
 
 	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
@@ -236,7 +241,7 @@ func (r *service) verifyLastOperationFinished(session dbsession.ReadSession, run
 	return nil
 }
 
-func (r *service) UpgradeRuntime(runtimeId string, input gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+func (r *service) UpgradeRuntime(ctx context.Context, runtimeId string, input gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError) {
 	if input.KymaConfig == nil {
 		return &gqlschema.OperationStatus{}, apperrors.BadRequest("error: Kyma config is nil")
 	}
@@ -274,6 +279,7 @@ func (r *service) UpgradeRuntime(runtimeId string, input gqlschema.UpgradeRuntim
 		return &gqlschema.OperationStatus{}, apperrors.Internal("failed to commit upgrade transaction: %s", dberr.Error())
 	}
 
+	tracing.StartOperationTrace(ctx, operation.ID)
 	r.upgradeQueue.Add(operation.ID)
 
 	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -113,7 +114,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, provisioningQueue, nil, nil, nil)
 
 		//when
-		operationStatus, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		operationStatus, err := service.ProvisionRuntime(context.Background(), provisionRuntimeInput, tenant, subAccountId)
 		require.NoError(t, err)
 
 		//then
@@ -147,7 +148,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil)
 
 		//when
-		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		_, err := service.ProvisionRuntime(context.Background(), provisionRuntimeInput, tenant, subAccountId)
 		require.Error(t, err)
 
 		//then
@@ -179,7 +180,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil)
 
 		//when
-		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		_, err := service.ProvisionRuntime(context.Background(), provisionRuntimeInput, tenant, subAccountId)
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeInternal)
 
@@ -201,7 +202,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, nil, nil, uuidGenerator, nil, nil, nil, nil)
 
 		//when
-		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		_, err := service.ProvisionRuntime(context.Background(), provisionRuntimeInput, tenant, subAccountId)
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeInternal)
 
@@ -235,7 +236,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, provisioningQueue, nil, nil, nil)
 
 		//when
-		operationStatus, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		operationStatus, err := service.ProvisionRuntime(context.Background(), provisionRuntimeInput, tenant, subAccountId)
 		require.NoError(t, err)
 
 		//then
@@ -291,7 +292,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, deprovisioningQueue, nil, nil)
 
 		//when
-		opID, err := resolver.DeprovisionRuntime(runtimeID, tenant)
+		opID, err := resolver.DeprovisionRuntime(context.Background(), runtimeID, tenant)
 		require.NoError(t, err)
 
 		//then
@@ -315,7 +316,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, nil, nil, nil)
 
 		//when
-		_, err := resolver.DeprovisionRuntime(runtimeID, tenant)
+		_, err := resolver.DeprovisionRuntime(context.Background(), runtimeID, tenant)
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeInternal)
 
@@ -338,7 +339,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil)
 
 		//when
-		_, err := resolver.DeprovisionRuntime(runtimeID, tenant)
+		_, err := resolver.DeprovisionRuntime(context.Background(), runtimeID, tenant)
 		require.Error(t, err)
 
 		//then
@@ -360,7 +361,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil)
 
 		//when
-		_, err := resolver.DeprovisionRuntime(runtimeID, tenant)
+		_, err := resolver.DeprovisionRuntime(context.Background(), runtimeID, tenant)
 		require.Error(t, err)
 
 		//then
@@ -380,7 +381,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil)
 
 		//when
-		_, err := resolver.DeprovisionRuntime(runtimeID, tenant)
+		_, err := resolver.DeprovisionRuntime(context.Background(), runtimeID, tenant)
 		require.Error(t, err)
 
 		//then
@@ -586,7 +587,7 @@ func TestService_UpgradeRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, upgradeShootQueue)
 
 		//when
-		operationStatus, err := service.UpgradeRuntime(runtimeID, upgradeInput)
+		operationStatus, err := service.UpgradeRuntime(context.Background(), runtimeID, upgradeInput)
 		require.NoError(t, err)
 
 		//then
@@ -653,7 +654,7 @@ func TestService_UpgradeRuntime(t *testing.T) {
 			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, upgradeShootQueue)
 
 			//when
-			_, err := service.UpgradeRuntime(runtimeID, upgradeInput)
+			_, err := service.UpgradeRuntime(context.Background(), runtimeID, upgradeInput)
 			require.Error(t, err)
 
 			// then
@@ -719,7 +720,7 @@ func TestService_UpgradeGardenerShoot(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuidGenerator, nil, nil, nil, upgradeShootQueue)
 
 		//when
-		operationStatus, err := service.UpgradeGardenerShoot(runtimeID, upgradeShootInput)
+		operationStatus, err := service.UpgradeGardenerShoot(context.Background(), runtimeID, upgradeShootInput)
 		require.NoError(t, err)
 
 		//then
@@ -814,7 +815,7 @@ func TestService_UpgradeGardenerShoot(t *testing.T) {
 			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuidGenerator, nil, nil, nil, upgradeShootQueue)
 
 			//when
-			_, err := service.UpgradeGardenerShoot(runtimeID, upgradeShootInput)
+			_, err := service.UpgradeGardenerShoot(context.Background(), runtimeID, upgradeShootInput)
 			require.Error(t, err)
 
 			// then
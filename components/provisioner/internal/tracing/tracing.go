@@ -0,0 +1,136 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlphttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/kyma-project/control-plane/components/provisioner"
+
+	ServiceName = "provisioner"
+
+	OperationIDKey   = attribute.Key("provisioner.operation.id")
+	OperationTypeKey = attribute.Key("provisioner.operation.type")
+	RuntimeIDKey     = attribute.Key("provisioner.runtime.id")
+	StageKey         = attribute.Key("provisioner.stage")
+)
+
+type Config struct {
+	Enabled bool `envconfig:"default=false"`
+	// Endpoint is the host and port of the OTLP HTTP receiver, for example otel-collector.kcp-system:4318
+	Endpoint string `envconfig:"optional"`
+	Insecure bool   `envconfig:"default=false"`
+	// SampleRatio is the fraction of the traces started by the provisioner which are exported
+	SampleRatio float64 `envconfig:"default=1"`
+}
+
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Endpoint == "" {
+		return errors.New("the OTLP endpoint is required when tracing is enabled")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return errors.Errorf("the sample ratio must be between 0 and 1, got %v", c.SampleRatio)
+	}
+	return nil
+}
+
+// Init registers the global tracer provider which exports the spans over OTLP and the W3C trace context propagator.
+// The returned function flushes the remaining spans, it must be called before the application exits.
+func Init(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlphttp.Option{otlphttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		options = append(options, otlphttp.WithInsecure())
+	}
+	exporter, err := otlp.NewExporter(ctx, otlphttp.NewDriver(options...))
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating OTLP exporter")
+	}
+
+	provider := NewTracerProvider(exporter, cfg.SampleRatio)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// NewTracerProvider creates the provider which exports the spans with the given exporter,
+// the tests use it with the in-memory exporter
+func NewTracerProvider(exporter sdktrace.SpanExporter, sampleRatio float64) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(ServiceName))),
+	)
+}
+
+// Tracer returns the tracer of the provisioner from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// NewHandler wraps the handler, so the requests continue the traces of the callers
+func NewHandler(handler http.Handler, operation string) http.Handler {
+	return otelhttp.NewHandler(handler, operation)
+}
+
+// SetError records the error in the span and marks the span as failed, nil error is ignored
+func SetError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// operationTraces keeps the trace of the request which started the operation, so the spans of the stages processed
+// later by the queue belong to the same trace. The traces are kept in memory, the operations resumed after the restart
+// start new traces.
+var operationTraces sync.Map
+
+// StartOperationTrace remembers the trace of the given context as the trace of the operation
+func StartOperationTrace(ctx context.Context, operationID string) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return
+	}
+	operationTraces.Store(operationID, spanContext)
+}
+
+// OperationContext returns the context which carries the trace of the operation, if there is any
+func OperationContext(operationID string) context.Context {
+	spanContext, found := operationTraces.Load(operationID)
+	if !found {
+		return context.Background()
+	}
+	return trace.ContextWithRemoteSpanContext(context.Background(), spanContext.(trace.SpanContext))
+}
+
+// FinishOperationTrace forgets the trace of the finished operation
+func FinishOperationTrace(operationID string) {
+	operationTraces.Delete(operationID)
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	operationID = "operation-id"
+	traceparent = "00-4bf92f3577b34da6a3ce929e0e4736ab-00f067aa0ba902b7-01"
+)
+
+func TestOperationTrace(t *testing.T) {
+	// given
+	exporter := setupTracing(t)
+
+	var requestSpan trace.SpanContext
+	handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestSpan = trace.SpanContextFromContext(r.Context())
+		StartOperationTrace(r.Context(), operationID)
+	}), "graphql")
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.Header.Set("traceparent", traceparent)
+
+	// when
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	_, stageSpan := Tracer().Start(OperationContext(operationID), "WaitingForClusterDomain")
+	stageSpan.End()
+
+	// then
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929e0e4736ab", spans[0].SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+	assert.Equal(t, spans[0].SpanContext.TraceID(), spans[1].SpanContext.TraceID())
+	assert.Equal(t, requestSpan.SpanID(), spans[1].Parent.SpanID())
+
+	// when
+	FinishOperationTrace(operationID)
+
+	// then
+	assert.False(t, trace.SpanContextFromContext(OperationContext(operationID)).IsValid())
+}
+
+func TestStartOperationTrace_WithoutTrace(t *testing.T) {
+	// when
+	StartOperationTrace(context.Background(), operationID)
+
+	// then
+	assert.False(t, trace.SpanContextFromContext(OperationContext(operationID)).IsValid())
+}
+
+// setupTracing registers the tracer provider with the synchronous in-memory exporter for the time of the test
+func setupTracing(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	return exporter
+}
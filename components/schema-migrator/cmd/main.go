@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/kyma-project/control-plane/components/schema-migrator/pkg/migrator"
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/vrischmann/envconfig"
+)
+
+const usage = `Usage: migrator [command]
+
+Commands:
+  up          Apply all pending migrations.
+  down [N]    Revert the N most recent migrations, or all of them if N is not given.
+  status      Show the state of the migrations.
+  verify      Check that the applied migrations were not modified and the database is not dirty.
+  drift       Compare the database schema with the schema expected after the applied migrations.
+  force V     Set the database version to V and clear the dirty flag without applying the migrations.
+
+The command defaults to the DIRECTION environment variable. The MIGRATION_PATH environment variable
+selects the migration set, the MIGRATIONS_DIR environment variable loads it from the directory instead
+of the migrations embedded in the binary.`
+
+type dbConfig struct {
+	User     string
+	Password string
+	Host     string
+	Port     string `envconfig:"default=5432"`
+	Name     string
+	SSL      string `envconfig:"default=disable"`
+}
+
+func (c dbConfig) ConnectionURL() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, c.Password, c.Name, c.SSL)
+}
+
+type config struct {
+	MigrationPath string
+	MigrationsDir string `envconfig:"optional"`
+	Direction     string `envconfig:"optional"`
+}
+
+func main() {
+	var (
+		cfg   config
+		dbCfg dbConfig
+	)
+	err := envconfig.Init(&cfg)
+	exitOnError(err, "Failed to load application config")
+	err = envconfig.InitWithPrefix(&dbCfg, "DB")
+	exitOnError(err, "Failed to load database config")
+
+	args := os.Args[1:]
+	if len(args) == 0 && cfg.Direction != "" {
+		args = []string{cfg.Direction}
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var migrations []migrator.Migration
+	if cfg.MigrationsDir != "" {
+		migrations, err = migrator.LoadDir(cfg.MigrationsDir)
+	} else {
+		migrations, err = migrator.Load(cfg.MigrationPath)
+	}
+	exitOnError(err, "Failed to load migrations")
+
+	db, err := sql.Open("postgres", dbCfg.ConnectionURL())
+	exitOnError(err, "Failed to open database connection")
+	defer db.Close()
+
+	m := migrator.New(db, migrations, log.WithField("migrations", cfg.MigrationPath))
+	err = run(context.Background(), m, args)
+	exitOnError(err, fmt.Sprintf("Failed to run %s command", args[0]))
+}
+
+func run(ctx context.Context, m *migrator.Migrator, args []string) error {
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		log.Infof("Applied %d migrations", applied)
+	case "down":
+		steps := 0
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return errors.Errorf("invalid number of migrations to revert: %s", args[1])
+			}
+			steps = n
+		}
+		reverted, err := m.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		log.Infof("Reverted %d migrations", reverted)
+	case "status":
+		return printStatus(ctx, m)
+	case "verify":
+		if err := m.Verify(ctx); err != nil {
+			return err
+		}
+		log.Info("Migrations verified successfully")
+	case "drift":
+		differences, err := m.Drift(ctx)
+		if err != nil {
+			return err
+		}
+		for _, difference := range differences {
+			fmt.Println(difference)
+		}
+		if len(differences) > 0 {
+			return errors.Errorf("found %d differences between the database schema and the migrations", len(differences))
+		}
+		log.Info("No schema drift found")
+	case "force":
+		if len(args) < 2 {
+			return errors.New("the version to force is required")
+		}
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid version %s", args[1])
+		}
+		return m.Force(ctx, version)
+	default:
+		return errors.Errorf("unknown command %s\n\n%s", args[0], usage)
+	}
+
+	return nil
+}
+
+func printStatus(ctx context.Context, m *migrator.Migrator) error {
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	migrations, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Version: %d", version)
+	if dirty {
+		fmt.Print(" (dirty)")
+	}
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, migration := range migrations {
+		appliedAt := ""
+		switch {
+		case migration.Baselined:
+			appliedAt = "before history"
+		case !migration.AppliedAt.IsZero():
+			appliedAt = migration.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", migration.Version, migration.Name, migration.State, appliedAt)
+	}
+	return w.Flush()
+}
+
+func exitOnError(err error, context string) {
+	if err != nil {
+		wrappedError := errors.Wrap(err, context)
+		log.Fatal(wrappedError)
+	}
+}
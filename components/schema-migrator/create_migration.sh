@@ -18,3 +18,5 @@ TRANSACTION_STR=$'BEGIN;\nCOMMIT;'
 
 echo "$TRANSACTION_STR" > "${MIGRATIONS_DIR}/${COMPONENT}/${DATE}_${NAME}.up.sql"
 echo "$TRANSACTION_STR" > "${MIGRATIONS_DIR}/${COMPONENT}/${DATE}_${NAME}.down.sql"
+
+echo "Created ${DATE}_${NAME} migration, run 'go generate ./migrations/' after you edit it"
@@ -1 +0,0 @@
-package schema_migrator
@@ -0,0 +1,12 @@
+module github.com/kyma-project/control-plane/components/schema-migrator
+
+go 1.14
+
+require (
+	github.com/lib/pq v1.7.0
+	github.com/pkg/errors v0.9.1
+	github.com/sirupsen/logrus v1.6.0
+	github.com/stretchr/testify v1.6.1
+	github.com/vrischmann/envconfig v1.3.0
+	golang.org/x/sys v0.0.0-20200803210538-64077c9b5642 // indirect
+)
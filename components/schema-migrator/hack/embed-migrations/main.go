@@ -0,0 +1,64 @@
+// The embed-migrations command generates the Go file with the contents of the SQL migrations, one map entry
+// per directory with the migration set.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+func main() {
+	out := flag.String("out", "zz_generated.go", "Path to the generated file, relative to the migrations directory.")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: embed-migrations -out <file> <migrations directory>")
+	}
+	dir := flag.Arg(0)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*.sql"))
+	if err != nil {
+		log.Fatalf("while listing migrations: %s", err)
+	}
+	sort.Strings(files)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "// Code generated by embed-migrations. DO NOT EDIT.")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "package migrations")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "var embedded = map[string]map[string]string{")
+	set := ""
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatalf("while reading migration: %s", err)
+		}
+		if s := filepath.Base(filepath.Dir(file)); s != set {
+			if set != "" {
+				fmt.Fprintln(buf, "},")
+			}
+			set = s
+			fmt.Fprintf(buf, "%s: {\n", strconv.Quote(set))
+		}
+		fmt.Fprintf(buf, "%s: %s,\n", strconv.Quote(filepath.Base(file)), strconv.Quote(string(content)))
+	}
+	if set != "" {
+		fmt.Fprintln(buf, "},")
+	}
+	fmt.Fprintln(buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("while formatting generated file: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, *out), src, 0644); err != nil {
+		log.Fatalf("while writing generated file: %s", err)
+	}
+}
@@ -0,0 +1 @@
+ALTER TABLE orchestrations DROP COLUMN parent_orchestration_id;
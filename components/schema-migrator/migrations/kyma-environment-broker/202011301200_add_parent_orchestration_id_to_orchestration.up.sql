@@ -0,0 +1,2 @@
+ALTER TABLE orchestrations
+    ADD COLUMN parent_orchestration_id varchar(255);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS instances_created_at_instance_id_idx;
+DROP INDEX IF EXISTS instances_updated_at_instance_id_idx;
+DROP INDEX IF EXISTS orchestrations_created_at_orchestration_id_idx;
+DROP INDEX IF EXISTS operations_orchestration_id_created_at_id_idx;
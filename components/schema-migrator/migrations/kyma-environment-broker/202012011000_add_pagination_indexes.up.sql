@@ -0,0 +1,4 @@
+CREATE INDEX instances_created_at_instance_id_idx ON instances (created_at, instance_id);
+CREATE INDEX instances_updated_at_instance_id_idx ON instances (updated_at, instance_id);
+CREATE INDEX orchestrations_created_at_orchestration_id_idx ON orchestrations (created_at, orchestration_id);
+CREATE INDEX operations_orchestration_id_created_at_id_idx ON operations (orchestration_id, created_at, id);
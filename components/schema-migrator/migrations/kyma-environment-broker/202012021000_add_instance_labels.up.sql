@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS instance_labels (
+    instance_id varchar(255) NOT NULL REFERENCES instances (instance_id) ON DELETE CASCADE,
+    key varchar(255) NOT NULL,
+    value varchar(255) NOT NULL,
+    PRIMARY KEY (instance_id, key)
+);
+
+CREATE INDEX instance_labels_key_value_idx ON instance_labels (key, value);
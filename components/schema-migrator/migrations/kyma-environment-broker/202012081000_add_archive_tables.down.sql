@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS runtime_states_runtime_id_created_at_idx;
+DROP INDEX IF EXISTS operations_state_updated_at_idx;
+DROP TABLE IF EXISTS runtime_states_archive;
+DROP TABLE IF EXISTS operations_archive;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS operations_archive (
+    id varchar(255) PRIMARY KEY,
+    instance_id varchar(255) NOT NULL,
+    target_operation_id varchar(255) NOT NULL,
+    version integer NOT NULL,
+    state varchar(32) NOT NULL,
+    description text NOT NULL,
+    type varchar(32) NOT NULL,
+    data json NOT NULL,
+    orchestration_id varchar(64),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS runtime_states_archive (
+    id varchar(255) PRIMARY KEY,
+    runtime_id varchar(255),
+    operation_id varchar(255),
+    created_at TIMESTAMPTZ NOT NULL,
+    kyma_config text,
+    cluster_config text,
+    kyma_version text,
+    k8s_version text,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX operations_archive_instance_id_idx ON operations_archive (instance_id);
+CREATE INDEX operations_state_updated_at_idx ON operations (state, updated_at);
+CREATE INDEX runtime_states_runtime_id_created_at_idx ON runtime_states (runtime_id, created_at);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS hyperscaler_account_assignments;
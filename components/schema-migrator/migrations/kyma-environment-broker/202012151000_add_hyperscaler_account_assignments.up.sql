@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS hyperscaler_account_assignments (
+    id varchar(255) PRIMARY KEY,
+    secret_name varchar(255) NOT NULL,
+    hyperscaler_type varchar(32) NOT NULL,
+    tenant_name varchar(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    released_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX hyperscaler_account_assignments_active_secret_idx ON hyperscaler_account_assignments (secret_name) WHERE released_at IS NULL;
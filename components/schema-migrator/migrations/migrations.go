@@ -0,0 +1,21 @@
+// Package migrations embeds the SQL migrations of the Kyma Control Plane components, so that the migrations
+// can be applied without the migration files, for example, on the component startup.
+package migrations
+
+//go:generate go run ../hack/embed-migrations -out zz_generated.go .
+
+const (
+	KymaEnvironmentBroker = "kyma-environment-broker"
+	Provisioner           = "provisioner"
+)
+
+// Files returns the contents of the migration files of the given set by the file names
+func Files(set string) (map[string]string, bool) {
+	files, found := embedded[set]
+	return files, found
+}
+
+// Sets returns the names of the embedded migration sets
+func Sets() []string {
+	return []string{KymaEnvironmentBroker, Provisioner}
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrationsUpToDate(t *testing.T) {
+	for _, set := range Sets() {
+		t.Run(set, func(t *testing.T) {
+			paths, err := filepath.Glob(filepath.Join(set, "*.sql"))
+			require.NoError(t, err)
+			onDisk := map[string]string{}
+			for _, path := range paths {
+				content, err := ioutil.ReadFile(path)
+				require.NoError(t, err)
+				onDisk[filepath.Base(path)] = string(content)
+			}
+
+			files, found := Files(set)
+
+			require.True(t, found)
+			assert.Equal(t, onDisk, files, "the embedded migrations are outdated, run go generate ./...")
+		})
+	}
+}
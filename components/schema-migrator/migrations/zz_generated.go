@@ -0,0 +1,68 @@
+// Code generated by embed-migrations. DO NOT EDIT.
+
+package migrations
+
+var embedded = map[string]map[string]string{
+	"kyma-environment-broker": {
+		"202001221020_initialize_schema.down.sql":                            "-- Instances\n\nDROP TABLE instances;\n",
+		"202001221020_initialize_schema.up.sql":                              "-- Instances\n\nCREATE TABLE IF NOT EXISTS  instances (\n    instance_id varchar(255) PRIMARY KEY,\n    runtime_id varchar(255) NOT NULL,\n    global_account_id varchar(255) NOT NULL,\n    service_id varchar(255) NOT NULL,\n    service_plan_id varchar(255) NOT NULL,\n    dashboard_url varchar(255) NOT NULL,\n    provisioning_parameters text NOT NULL\n);\n",
+		"202001231030_add_datetimes_to_instances.down.sql":                   "ALTER TABLE instances DROP COLUMN created_at;\n\nALTER TABLE instances DROP COLUMN updated_at;\n\nALTER TABLE instances DROP COLUMN delated_at;\n",
+		"202001231030_add_datetimes_to_instances.up.sql":                     "ALTER TABLE instances\n    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW();\n\nALTER TABLE instances\n    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();\n\nALTER TABLE instances\n    ADD COLUMN delated_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00+00';\n",
+		"202002121000_add_operations.down.sql":                               "\nDROP TABLE operations;\n",
+		"202002121000_add_operations.up.sql":                                 "CREATE TABLE IF NOT EXISTS operations (\n    id varchar(255) PRIMARY KEY,\n    instance_id varchar(255) NOT NULL,\n    target_operation_id varchar(255) NOT NULL,\n    version integer NOT NULL,\n    state varchar(32) NOT NULL,\n    description text NOT NULL,\n    type varchar(32) NOT NULL,\n    data json NOT NULL,\n    created_at TIMESTAMPTZ NOT NULL,\n    updated_at TIMESTAMPTZ NOT NULL\n);\n",
+		"202002201000_add_lms_tenants.down.sql":                              "\nDROP TABLE lms_tenants;\n",
+		"202002201000_add_lms_tenants.up.sql":                                "CREATE TABLE IF NOT EXISTS lms_tenants (\n    id varchar(255) PRIMARY KEY,\n    name varchar(255) NOT NULL,\n    region varchar(12) NOT NULL,\n    created_at TIMESTAMPTZ NOT NULL,\n    unique (name, region)\n);\n",
+		"202004032115_add_additonal_runtime_info.down.sql":                   "ALTER TABLE instances\n DROP COLUMN sub_account_id,\n DROP COLUMN service_name,\n DROP COLUMN service_plan_name;\n",
+		"202004032115_add_additonal_runtime_info.up.sql":                     "ALTER TABLE instances\n ADD COLUMN sub_account_id varchar(255) DEFAULT '',\n ADD COLUMN service_name varchar(255) DEFAULT '',\n ADD COLUMN service_plan_name varchar(255) DEFAULT '';\n",
+		"202004201217_fix-delated-typo.down.sql":                             "ALTER TABLE instances \nRENAME COLUMN deleted_at TO delated_at;\n",
+		"202004201217_fix-delated-typo.up.sql":                               "ALTER TABLE instances\nRENAME COLUMN delated_at TO deleted_at;\n",
+		"202008241000_add_orchestrations.down.sql":                           "\nDROP TABLE orchestrations;\n",
+		"202008241000_add_orchestrations.up.sql":                             "CREATE TABLE IF NOT EXISTS orchestrations (\n    orchestration_id varchar(255) PRIMARY KEY,\n    created_at TIMESTAMPTZ NOT NULL,\n\tupdated_at TIMESTAMPTZ NOT NULL,\n\tstate varchar(32) NOT NULL,\n\tparameters text NOT NULL,\n\tdescription text,\n\truntime_operations text\n);\n",
+		"202009171000_add_runtime_states.down.sql":                           "\nDROP TABLE runtime_states;\n",
+		"202009171000_add_runtime_states.up.sql":                             "CREATE TABLE IF NOT EXISTS runtime_states (\n    id varchar(255) PRIMARY KEY,\n    runtime_id varchar(255),\n    operation_id varchar(255),\n    created_at TIMESTAMPTZ NOT NULL,\n\tkyma_config text,\n\tcluster_config text,\n\tkyma_version text,\n\tk8s_version text\n);\n",
+		"202009230900_add_orchestration_id_to_operation.down.sql":            "ALTER TABLE operations DROP COLUMN orchestration_id;\n",
+		"202009230900_add_orchestration_id_to_operation.up.sql":              "ALTER TABLE operations\n    ADD COLUMN orchestration_id varchar(64);\n",
+		"202010131417_add_provider_region_to_instance.down.sql":              "ALTER TABLE instances DROP COLUMN provider_region;\n",
+		"202010131417_add_provider_region_to_instance.up.sql":                "ALTER TABLE instances\n  ADD COLUMN provider_region varchar(32) DEFAULT '';\n",
+		"202011301200_add_parent_orchestration_id_to_orchestration.down.sql": "ALTER TABLE orchestrations DROP COLUMN parent_orchestration_id;\n",
+		"202011301200_add_parent_orchestration_id_to_orchestration.up.sql":   "ALTER TABLE orchestrations\n    ADD COLUMN parent_orchestration_id varchar(255);\n",
+		"202012011000_add_pagination_indexes.down.sql":                       "DROP INDEX IF EXISTS instances_created_at_instance_id_idx;\nDROP INDEX IF EXISTS instances_updated_at_instance_id_idx;\nDROP INDEX IF EXISTS orchestrations_created_at_orchestration_id_idx;\nDROP INDEX IF EXISTS operations_orchestration_id_created_at_id_idx;\n",
+		"202012011000_add_pagination_indexes.up.sql":                         "CREATE INDEX instances_created_at_instance_id_idx ON instances (created_at, instance_id);\nCREATE INDEX instances_updated_at_instance_id_idx ON instances (updated_at, instance_id);\nCREATE INDEX orchestrations_created_at_orchestration_id_idx ON orchestrations (created_at, orchestration_id);\nCREATE INDEX operations_orchestration_id_created_at_id_idx ON operations (orchestration_id, created_at, id);\n",
+		"202012021000_add_instance_labels.down.sql":                          "DROP TABLE IF EXISTS instance_labels;\n",
+		"202012021000_add_instance_labels.up.sql":                            "CREATE TABLE IF NOT EXISTS instance_labels (\n    instance_id varchar(255) NOT NULL REFERENCES instances (instance_id) ON DELETE CASCADE,\n    key varchar(255) NOT NULL,\n    value varchar(255) NOT NULL,\n    PRIMARY KEY (instance_id, key)\n);\n\nCREATE INDEX instance_labels_key_value_idx ON instance_labels (key, value);\n",
+		"202012081000_add_archive_tables.down.sql":                           "DROP INDEX IF EXISTS runtime_states_runtime_id_created_at_idx;\nDROP INDEX IF EXISTS operations_state_updated_at_idx;\nDROP TABLE IF EXISTS runtime_states_archive;\nDROP TABLE IF EXISTS operations_archive;\n",
+		"202012081000_add_archive_tables.up.sql":                             "CREATE TABLE IF NOT EXISTS operations_archive (\n    id varchar(255) PRIMARY KEY,\n    instance_id varchar(255) NOT NULL,\n    target_operation_id varchar(255) NOT NULL,\n    version integer NOT NULL,\n    state varchar(32) NOT NULL,\n    description text NOT NULL,\n    type varchar(32) NOT NULL,\n    data json NOT NULL,\n    orchestration_id varchar(64),\n    created_at TIMESTAMPTZ NOT NULL,\n    updated_at TIMESTAMPTZ NOT NULL,\n    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()\n);\n\nCREATE TABLE IF NOT EXISTS runtime_states_archive (\n    id varchar(255) PRIMARY KEY,\n    runtime_id varchar(255),\n    operation_id varchar(255),\n    created_at TIMESTAMPTZ NOT NULL,\n    kyma_config text,\n    cluster_config text,\n    kyma_version text,\n    k8s_version text,\n    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()\n);\n\nCREATE INDEX operations_archive_instance_id_idx ON operations_archive (instance_id);\nCREATE INDEX operations_state_updated_at_idx ON operations (state, updated_at);\nCREATE INDEX runtime_states_runtime_id_created_at_idx ON runtime_states (runtime_id, created_at);\n",
+		"202012151000_add_hyperscaler_account_assignments.down.sql":          "DROP TABLE IF EXISTS hyperscaler_account_assignments;\n",
+		"202012151000_add_hyperscaler_account_assignments.up.sql":            "CREATE TABLE IF NOT EXISTS hyperscaler_account_assignments (\n    id varchar(255) PRIMARY KEY,\n    secret_name varchar(255) NOT NULL,\n    hyperscaler_type varchar(32) NOT NULL,\n    tenant_name varchar(255) NOT NULL,\n    created_at TIMESTAMPTZ NOT NULL,\n    released_at TIMESTAMPTZ\n);\n\nCREATE UNIQUE INDEX hyperscaler_account_assignments_active_secret_idx ON hyperscaler_account_assignments (secret_name) WHERE released_at IS NULL;\n",
+	},
+	"provisioner": {
+		"202002051322_initialize_schema.down.sql":                                  "\n-- Kyma Config\n\nDROP TABLE kyma_component_config;\nDROP TABLE kyma_config;\n\n-- Kyma Release\n\nDROP TABLE kyma_release;\n\n-- Operation\n\nDROP TABLE operation;\nDROP TYPE operation_type;\nDROP TYPE operation_state;\n\n-- Cluster Config\n\nDROP TABLE gardener_config;\nDROP TABLE gcp_config;\n\n-- Cluster\n\nDROP TABLE cluster;\n",
+		"202002051322_initialize_schema.up.sql":                                    "-- Cluster\n\nCREATE TABLE cluster\n(\n    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),\n    kubeconfig text,\n    terraform_state bytea,\n    tenant varchar(256) NOT NULL,\n    credentials_secret_name varchar(256) NOT NULL,\n    creation_timestamp timestamp without time zone NOT NULL,\n    deleted boolean default false\n);\n\n\n-- Cluster Config\n\nCREATE TABLE gardener_config\n(\n    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),\n    cluster_id uuid NOT NULL,\n    name varchar(256) NOT NULL UNIQUE,\n    project_name varchar(256) NOT NULL,\n    kubernetes_version varchar(256) NOT NULL,\n    node_Count integer NOT NULL,\n    volume_size_gb varchar(256) NOT NULL,\n    machine_type varchar(256) NOT NULL,\n    region varchar(256) NOT NULL,\n    provider varchar(256) NOT NULL,\n    seed varchar(256) NOT NULL,\n    target_secret varchar(256) NOT NULL,\n    disk_type varchar(256) NOT NULL,\n    worker_cidr varchar(256) NOT NULL,\n    auto_scaler_min integer NOT NULL,\n    auto_scaler_max integer NOT NULL,\n    max_surge integer NOT NULL,\n    max_unavailable integer NOT NULL,\n    provider_specific_config jsonb,\n    UNIQUE(cluster_id),\n    foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE\n);\n\nCREATE TABLE gcp_config\n(\n    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),\n    cluster_id uuid NOT NULL,\n    name varchar(256) NOT NULL,\n    project_name varchar(256) NOT NULL,\n    kubernetes_version varchar(256) NOT NULL,\n    number_of_nodes integer NOT NULL,\n    boot_disk_size_gb varchar(256) NOT NULL,\n    machine_type varchar(256) NOT NULL,\n    region varchar(256) NOT NULL,\n    zone varchar(256) NOT NULL,\n    UNIQUE(cluster_id),\n    foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE\n);\n\n\n-- Operation\n\nCREATE TYPE operation_state AS ENUM (\n    'IN_PROGRESS',\n    'SUCCEEDED',\n    'FAILED'\n    );\n\nCREATE TYPE operation_type AS ENUM (\n    'PROVISION',\n    'UPGRADE',\n    'DEPROVISION',\n    'RECONNECT_RUNTIME'\n    );\n\nCREATE TABLE operation\n(\n    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),\n    type operation_type NOT NULL,\n    state operation_state NOT NULL,\n    message text,\n    start_timestamp timestamp without time zone NOT NULL,\n    end_timestamp timestamp without time zone,\n    cluster_id uuid NOT NULL,\n    foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE\n);\n\n-- Kyma Release\n\nCREATE TABLE kyma_release\n(\n    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),\n    version varchar(256) NOT NULL,\n    tiller_yaml text NOT NULL,\n    installer_yaml text NOT NULL,\n    unique(version)\n);\n\n-- Kyma Config\n\nCREATE TABLE kyma_config\n(\n    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),\n    release_id uuid NOT NULL,\n    cluster_id uuid NOT NULL,\n    global_configuration jsonb,\n    UNIQUE(cluster_id),\n    foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE,\n    foreign key (release_id) REFERENCES kyma_release (id) ON DELETE RESTRICT\n);\n\nCREATE TABLE kyma_component_config\n(\n    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),\n    component varchar(256) NOT NULL,\n    namespace varchar(256) NOT NULL,\n    configuration jsonb,\n    kyma_config_id uuid NOT NULL,\n    foreign key (kyma_config_id) REFERENCES kyma_config (id) ON DELETE CASCADE\n);\n",
+		"202003050749_add_sub_account_id.down.sql":                                 "ALTER TABLE cluster DROP COLUMN sub_account_id;\n",
+		"202003050749_add_sub_account_id.up.sql":                                   "ALTER TABLE cluster ADD COLUMN sub_account_id varchar(256);\n",
+		"202003190820_remove_node_count.down.sql":                                  "ALTER TABLE gardener_config ADD COLUMN node_Count integer NOT NULL;",
+		"202003190820_remove_node_count.up.sql":                                    "ALTER TABLE gardener_config DROP COLUMN node_Count;",
+		"202003241337_add_source_url.down.sql":                                     "ALTER TABLE kyma_component_config DROP COLUMN source_url;\n",
+		"202003241337_add_source_url.up.sql":                                       "ALTER TABLE kyma_component_config ADD COLUMN source_url varchar(256);\n",
+		"202003311308_add_component_order.down.sql":                                "ALTER TABLE kyma_component_config DROP COLUMN component_order;\n",
+		"202003311308_add_component_order.up.sql":                                  "ALTER TABLE kyma_component_config ADD COLUMN component_order integer;\n",
+		"202004021035_add_runtime_upgrade.down.sql":                                "DROP TABLE runtime_upgrade;\nDROP TYPE runtime_upgrade_state;\n\nALTER TABLE cluster DROP COLUMN active_kyma_config_id;\n\nALTER TABLE kyma_config ADD CONSTRAINT kyma_config_cluster_id_key UNIQUE (cluster_id);\n\nALTER TABLE operation DROP COLUMN stage;\nALTER TABLE operation DROP COLUMN last_transition;\n",
+		"202004021035_add_runtime_upgrade.up.sql":                                  "ALTER TABLE operation ADD COLUMN stage varchar(256);\nALTER TABLE operation ADD COLUMN last_transition timestamp without time zone;\n\nALTER TABLE kyma_config DROP CONSTRAINT kyma_config_cluster_id_key;\n\nBEGIN TRANSACTION;\n\nALTER TABLE cluster ADD COLUMN active_kyma_config_id uuid;\nALTER TABLE cluster ADD CONSTRAINT cluster_active_kyma_config_id_fkey foreign key (active_kyma_config_id) REFERENCES kyma_config (id) DEFERRABLE INITIALLY DEFERRED;\n\nUPDATE cluster\nSET active_kyma_config_id=subquery.id\nFROM (SELECT id, cluster_id\n      FROM  kyma_config) AS subquery\nWHERE cluster.id=subquery.cluster_id;\n\nUPDATE operation\nSET stage='StartingInstallation'\nWHERE (operation.state='IN_PROGRESS' OR operation.state='FAILED') AND operation.type='PROVISION';\n\nUPDATE operation\nSET stage='Deprovisioning'\nWHERE (operation.state='IN_PROGRESS' OR operation.state='FAILED') AND operation.type='DEPROVISION';\n\nUPDATE operation\nSET stage='Finished'\nWHERE operation.state='SUCCEEDED';\n\nEND TRANSACTION;\n\nALTER TABLE cluster ALTER COLUMN active_kyma_config_id SET NOT NULL;\nALTER TABLE operation ALTER COLUMN stage SET NOT NULL;\n\nCREATE TYPE runtime_upgrade_state AS ENUM (\n    'IN_PROGRESS',\n    'SUCCEEDED',\n    'FAILED',\n    'ROLLED_BACK'\n    );\n\nCREATE TABLE runtime_upgrade\n(\n    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),\n    operation_id uuid NOT NULL,\n    state runtime_upgrade_state NOT NULL,\n    pre_upgrade_kyma_config_id uuid NOT NULL,\n    post_upgrade_kyma_config_id uuid NOT NULL,\n    foreign key (operation_id) REFERENCES operation (id) ON DELETE CASCADE,\n    foreign key (pre_upgrade_kyma_config_id) REFERENCES kyma_config (id) ON DELETE CASCADE,\n    foreign key (post_upgrade_kyma_config_id) REFERENCES kyma_config (id) ON DELETE CASCADE\n);\n",
+		"202006161055_add_purpose_for_gardener_config.down.sql":                    "ALTER TABLE gardener_config DROP COLUMN purpose;\n",
+		"202006161055_add_purpose_for_gardener_config.up.sql":                      "ALTER TABLE gardener_config ADD COLUMN purpose varchar(256);\n",
+		"202006251135_remove_gcp_provisioning.down.sql":                            "BEGIN;\n\nALTER TABLE cluster ADD COLUMN terraform_state bytea;\nALTER TABLE cluster ADD COLUMN credentials_secret_name varchar(256) NOT NULL default '';\n\nCREATE TABLE gcp_config\n(\n    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),\n    cluster_id uuid NOT NULL,\n    name varchar(256) NOT NULL,\n    project_name varchar(256) NOT NULL,\n    kubernetes_version varchar(256) NOT NULL,\n    number_of_nodes integer NOT NULL,\n    boot_disk_size_gb varchar(256) NOT NULL,\n    machine_type varchar(256) NOT NULL,\n    region varchar(256) NOT NULL,\n    zone varchar(256) NOT NULL,\n    UNIQUE(cluster_id),\n    foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE\n);\n\nCOMMIT;\n",
+		"202006251135_remove_gcp_provisioning.up.sql":                              "BEGIN;\n\nALTER TABLE cluster DROP COLUMN terraform_state;\nALTER TABLE cluster DROP COLUMN credentials_secret_name;\n\nDROP TABLE gcp_config;\n\nCOMMIT;\n",
+		"202006261510_add_type_for_gardener_config.down.sql":                       "ALTER TABLE gardener_config DROP COLUMN licence_type;\n",
+		"202006261510_add_type_for_gardener_config.up.sql":                         "ALTER TABLE gardener_config ADD COLUMN licence_type varchar(256);\n",
+		"202007081031_add_maintenance_autoupdate.down.sql":                         "BEGIN;\n\nALTER TABLE gardener_config DROP COLUMN enable_kubernetes_version_auto_update;\n\nALTER TABLE gardener_config DROP COLUMN enable_machine_image_version_auto_update;\n\nCOMMIT;\n",
+		"202007081031_add_maintenance_autoupdate.up.sql":                           "BEGIN;\n\nALTER TABLE gardener_config ADD COLUMN enable_kubernetes_version_auto_update boolean NOT NULL DEFAULT false;\nALTER TABLE gardener_config ALTER COLUMN enable_kubernetes_version_auto_update DROP DEFAULT;\n\nALTER TABLE gardener_config ADD COLUMN enable_machine_image_version_auto_update boolean NOT NULL DEFAULT false;\nALTER TABLE gardener_config ALTER COLUMN enable_machine_image_version_auto_update DROP DEFAULT;\n\nCOMMIT;\n",
+		"202007101344_add_machine_image_for_gardener_config.down.sql":              "ALTER TABLE gardener_config DROP COLUMN machine_image;\nALTER TABLE gardener_config DROP COLUMN machine_image_version;\n",
+		"202007101344_add_machine_image_for_gardener_config.up.sql":                "ALTER TABLE gardener_config ADD COLUMN machine_image varchar(256);\nALTER TABLE gardener_config ADD COLUMN machine_image_version varchar(256);\n",
+		"202008061313_add_upgrade_shoot_for_operation_type.down.sql":               "BEGIN;\n\nDELETE FROM operation WHERE type = 'UPGRADE_SHOOT';\n\nALTER TYPE operation_type RENAME TO operation_type_old;\n\nCREATE TYPE operation_type AS ENUM (\n    'PROVISION',\n    'UPGRADE',\n    'DEPROVISION',\n    'RECONNECT_RUNTIME'\n    );\n\n\nALTER TABLE operation ALTER COLUMN type TYPE operation_type USING type::text::operation_type;\n\nDROP TYPE operation_type_old;\n\nCOMMIT;",
+		"202008061313_add_upgrade_shoot_for_operation_type.up.sql":                 "ALTER TYPE operation_type ADD VALUE 'UPGRADE_SHOOT' AFTER 'RECONNECT_RUNTIME';",
+		"202008061314_add_allow_privileged_containers_to_gardener_config.down.sql": "BEGIN;\n\nALTER TABLE gardener_config DROP COLUMN allow_privileged_containers;\n\nCOMMIT;\n",
+		"202008061314_add_allow_privileged_containers_to_gardener_config.up.sql":   "BEGIN;\n\nALTER TABLE gardener_config ADD COLUMN allow_privileged_containers boolean NOT NULL DEFAULT true;\nALTER TABLE gardener_config ALTER COLUMN allow_privileged_containers DROP DEFAULT;\n\nCOMMIT;\n",
+		"202011131137_add_profile_to_kyma_config.down.sql":                         "BEGIN;\n\nALTER TABLE kyma_config DROP COLUMN profile;\n\nDROP TYPE kyma_profile;\n\nCOMMIT;\n",
+		"202011131137_add_profile_to_kyma_config.up.sql":                           "BEGIN;\n\nCREATE TYPE kyma_profile AS ENUM (\n    'EVALUATION',\n    'PRODUCTION'\n);\n\n\nALTER TABLE kyma_config ADD COLUMN profile kyma_profile;\n\nCOMMIT;\n",
+	},
+}
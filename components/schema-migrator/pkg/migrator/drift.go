@@ -0,0 +1,184 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Difference is the schema object which differs between the database and the schema expected after the applied migrations
+type Difference struct {
+	// Object identifies the schema object, for example, "column instances.runtime_id"
+	Object string
+	// Expected is the definition of the object after the migrations, empty if the object is not expected
+	Expected string
+	// Actual is the definition of the object in the database, empty if the object does not exist
+	Actual string
+}
+
+func (d Difference) String() string {
+	switch {
+	case d.Expected == "":
+		return fmt.Sprintf("unexpected %s: %s", d.Object, d.Actual)
+	case d.Actual == "":
+		return fmt.Sprintf("missing %s: %s", d.Object, d.Expected)
+	default:
+		return fmt.Sprintf("changed %s: expected %s, got %s", d.Object, d.Expected, d.Actual)
+	}
+}
+
+// catalogQueries read the definitions of the schema objects from the PostgreSQL catalog by the object identifiers.
+// Each query takes the schema name and returns the identifier and the definition of the object.
+var catalogQueries = []string{
+	`SELECT 'table ' || c.relname, 'table'
+	FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE n.nspname = $1 AND c.relkind = 'r'`,
+	`SELECT 'column ' || c.relname || '.' || a.attname,
+		format_type(a.atttypid, a.atttypmod)
+		|| CASE WHEN a.attnotnull THEN ' NOT NULL' ELSE '' END
+		|| COALESCE(' DEFAULT ' || pg_get_expr(d.adbin, d.adrelid), '')
+	FROM pg_attribute a
+	JOIN pg_class c ON c.oid = a.attrelid
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+	WHERE n.nspname = $1 AND c.relkind = 'r' AND a.attnum > 0 AND NOT a.attisdropped`,
+	`SELECT 'constraint ' || c.relname || '.' || con.conname, pg_get_constraintdef(con.oid)
+	FROM pg_constraint con
+	JOIN pg_class c ON c.oid = con.conrelid
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE n.nspname = $1`,
+	`SELECT 'index ' || indexname, indexdef FROM pg_indexes WHERE schemaname = $1`,
+	`SELECT 'type ' || t.typname, 'ENUM (' || string_agg(quote_literal(e.enumlabel), ', ' ORDER BY e.enumsortorder) || ')'
+	FROM pg_type t
+	JOIN pg_enum e ON e.enumtypid = t.oid
+	JOIN pg_namespace n ON n.oid = t.typnamespace
+	WHERE n.nspname = $1
+	GROUP BY t.typname`,
+}
+
+// Drift compares the current schema of the database with the schema created by the applied migrations.
+// The expected schema is created by applying the migrations in a temporary schema, which is dropped afterwards,
+// so the database user needs the privilege to create schemas.
+func (m *Migrator) Drift(ctx context.Context) ([]Difference, error) {
+	var differences []Difference
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return errors.Errorf("migration %d failed, the schema cannot be compared", version)
+		}
+
+		var current string
+		if err := conn.QueryRowContext(ctx, "SELECT current_schema()").Scan(&current); err != nil {
+			return errors.Wrap(err, "while reading current schema")
+		}
+		actual, err := readSchema(ctx, conn, current)
+		if err != nil {
+			return err
+		}
+		expected, err := m.expectedSchema(ctx, conn, version)
+		if err != nil {
+			return err
+		}
+
+		differences = compareSchemas(expected, actual)
+		return nil
+	})
+
+	return differences, err
+}
+
+// expectedSchema applies the migrations up to the given version in the temporary schema and reads its definition
+func (m *Migrator) expectedSchema(ctx context.Context, conn *sql.Conn, version uint64) (map[string]string, error) {
+	schema := fmt.Sprintf("schema_migrator_expected_%d", time.Now().UnixNano())
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("CREATE SCHEMA %s", schema)); err != nil {
+		return nil, errors.Wrap(err, "while creating temporary schema")
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "RESET search_path"); err != nil {
+			m.log.Warnf("Failed to reset search path: %s", err)
+		}
+		if _, err := conn.ExecContext(context.Background(), fmt.Sprintf("DROP SCHEMA %s CASCADE", schema)); err != nil {
+			m.log.Warnf("Failed to drop temporary schema %s: %s", schema, err)
+		}
+	}()
+
+	// the migrations do not qualify the object names, so they create the objects in the first schema of the search path
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("SET search_path TO %s", schema)); err != nil {
+		return nil, errors.Wrap(err, "while setting search path")
+	}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+		if _, err := conn.ExecContext(ctx, migration.Up); err != nil {
+			return nil, errors.Wrapf(err, "while applying migration %d_%s to temporary schema", migration.Version, migration.Name)
+		}
+	}
+
+	return readSchema(ctx, conn, schema)
+}
+
+// readSchema returns the definitions of the schema objects by their identifiers, without the objects of the migrator
+func readSchema(ctx context.Context, conn *sql.Conn, schema string) (map[string]string, error) {
+	objects := map[string]string{}
+	for _, query := range catalogQueries {
+		rows, err := conn.QueryContext(ctx, query, schema)
+		if err != nil {
+			return nil, errors.Wrap(err, "while reading schema catalog")
+		}
+		for rows.Next() {
+			var id, definition string
+			if err := rows.Scan(&id, &definition); err != nil {
+				rows.Close()
+				return nil, errors.Wrap(err, "while reading schema catalog")
+			}
+			if isMigratorObject(id) {
+				continue
+			}
+			// the definitions of the objects in the schema outside the search path are qualified with the schema name
+			objects[id] = strings.NewReplacer(schema+".", "", fmt.Sprintf("%q.", schema), "").Replace(definition)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, errors.Wrap(err, "while reading schema catalog")
+		}
+	}
+
+	return objects, nil
+}
+
+func isMigratorObject(id string) bool {
+	for _, table := range []string{versionTableName, historyTableName} {
+		if strings.HasSuffix(id, " "+table) || strings.Contains(id, " "+table+".") || strings.HasPrefix(id, "index "+table+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+func compareSchemas(expected, actual map[string]string) []Difference {
+	var differences []Difference
+	for id, definition := range expected {
+		if actual[id] != definition {
+			differences = append(differences, Difference{Object: id, Expected: definition, Actual: actual[id]})
+		}
+	}
+	for id, definition := range actual {
+		if _, found := expected[id]; !found {
+			differences = append(differences, Difference{Object: id, Actual: definition})
+		}
+	}
+	sort.Slice(differences, func(i, j int) bool {
+		return differences[i].Object < differences[j].Object
+	})
+
+	return differences
+}
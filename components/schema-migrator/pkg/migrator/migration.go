@@ -0,0 +1,101 @@
+package migrator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/kyma-project/control-plane/components/schema-migrator/migrations"
+	"github.com/pkg/errors"
+)
+
+// fileNameRegexp matches the migration file names in the {version}_{name}.{up|down}.sql format
+var fileNameRegexp = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is a single schema change with the SQL statements applying and reverting it
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Checksum identifies the content of the up migration, so that the changes of the applied migrations are detected
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// Load returns the embedded migrations of the given set sorted by the version
+func Load(set string) ([]Migration, error) {
+	files, found := migrations.Files(set)
+	if !found {
+		return nil, errors.Errorf("migration set %s not found", set)
+	}
+	return Parse(files)
+}
+
+// LoadDir returns the migrations from the files in the given directory sorted by the version
+func LoadDir(dir string) ([]Migration, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing migration files")
+	}
+	files := make(map[string]string, len(paths))
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while reading migration file %s", path)
+		}
+		files[filepath.Base(path)] = string(content)
+	}
+	return Parse(files)
+}
+
+// Parse returns the migrations from the contents of the migration files given by the file names
+func Parse(files map[string]string) ([]Migration, error) {
+	byVersion := map[uint64]*Migration{}
+	hasUp := map[uint64]bool{}
+	for name, content := range files {
+		match := fileNameRegexp.FindStringSubmatch(name)
+		if match == nil {
+			return nil, errors.Errorf("migration file name %s does not match the {version}_{name}.{up|down}.sql format", name)
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while parsing version of migration file %s", name)
+		}
+
+		m, found := byVersion[version]
+		if !found {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, errors.Errorf("migrations %s and %s have the same version", m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = content
+			hasUp[version] = true
+		} else {
+			m.Down = content
+		}
+	}
+
+	result := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if !hasUp[m.Version] {
+			return nil, errors.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+
+	return result, nil
+}
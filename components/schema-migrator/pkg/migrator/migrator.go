@@ -0,0 +1,417 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// versionTableName is the table of the migrate tool, which holds the version of the last applied migration.
+	// The migrator keeps it up to date, so the databases can be migrated with both tools.
+	versionTableName = "schema_migrations"
+	// historyTableName holds the checksums of the applied migrations
+	historyTableName = "schema_migrations_history"
+	// advisoryLockID serializes the migrations of the replicas started at once
+	advisoryLockID = 1845229305
+)
+
+// State is the state of the migration in the database
+type State string
+
+const (
+	StateApplied State = "applied"
+	StatePending State = "pending"
+	// StateModified means the migration was changed after it was applied
+	StateModified State = "modified"
+	// StateMissing means the applied migration is not in the migration set anymore
+	StateMissing State = "missing"
+)
+
+// Status is the state of a single migration
+type Status struct {
+	Version uint64
+	Name    string
+	State   State
+	// Baselined is true for the migrations applied before the migrator recorded their checksums,
+	// the checksums of such migrations are taken from the migration set
+	Baselined bool
+	AppliedAt time.Time
+}
+
+type historyEntry struct {
+	name      string
+	checksum  string
+	baselined bool
+	appliedAt time.Time
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Migrator applies the migrations to the PostgreSQL database
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	log        logrus.FieldLogger
+}
+
+func New(db *sql.DB, migrations []Migration, log logrus.FieldLogger) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		log:        log,
+	}
+}
+
+// Up applies all pending migrations and returns the number of the applied ones
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := m.prepare(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				continue
+			}
+			if err := m.up(ctx, conn, migration); err != nil {
+				return err
+			}
+			applied++
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the given number of the most recent migrations, or all of them if the steps are not positive,
+// and returns the number of the reverted ones
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := m.prepare(ctx, conn)
+		if err != nil {
+			return err
+		}
+		var applied []Migration
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				applied = append(applied, migration)
+			}
+		}
+		if version > 0 && (len(applied) == 0 || applied[len(applied)-1].Version != version) {
+			return errors.Errorf("applied migration %d not found in the migration set", version)
+		}
+
+		for i := len(applied) - 1; i >= 0 && (steps <= 0 || reverted < steps); i-- {
+			var previous uint64
+			if i > 0 {
+				previous = applied[i-1].Version
+			}
+			if err := m.down(ctx, conn, applied[i], previous); err != nil {
+				return err
+			}
+			reverted++
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Force sets the version of the database without applying the migrations and clears the dirty flag.
+// It is used after the failed migration was fixed manually.
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return inTransaction(ctx, conn, func(tx *sql.Tx) error {
+			if err := setVersion(ctx, tx, version, false); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE version > $1", historyTableName), version)
+			return errors.Wrap(err, "while deleting history of reverted migrations")
+		})
+	})
+}
+
+// Version returns the version of the last applied migration and whether the migration failed
+func (m *Migrator) Version(ctx context.Context) (uint64, bool, error) {
+	var (
+		version uint64
+		dirty   bool
+	)
+	err := m.withLock(ctx, func(conn *sql.Conn) (err error) {
+		version, dirty, err = readVersion(ctx, conn)
+		return err
+	})
+
+	return version, dirty, err
+}
+
+// Status returns the state of the migrations of the set and of the applied migrations missing in the set
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var result []Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, _, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		history, err := readHistory(ctx, conn)
+		if err != nil {
+			return err
+		}
+		result = statuses(m.migrations, version, history)
+		return nil
+	})
+
+	return result, err
+}
+
+// Verify checks that the last migration succeeded and that the applied migrations were not modified or removed
+func (m *Migrator) Verify(ctx context.Context) error {
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return errors.Errorf("migration %d failed, the database is dirty", version)
+	}
+
+	migrations, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	var problems []string
+	for _, migration := range migrations {
+		if migration.State == StateModified || migration.State == StateMissing {
+			problems = append(problems, fmt.Sprintf("migration %d_%s is %s", migration.Version, migration.Name, migration.State))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("verification failed: %s", strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+func (m *Migrator) up(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	m.log.Infof("Applying migration %d_%s", migration.Version, migration.Name)
+	if err := setVersion(ctx, conn, migration.Version, true); err != nil {
+		return err
+	}
+	// the migration is executed as is, the files may contain the statements which cannot run inside a transaction
+	if _, err := conn.ExecContext(ctx, migration.Up); err != nil {
+		return errors.Wrapf(err, "while applying migration %d_%s, the database is left dirty", migration.Version, migration.Name)
+	}
+
+	return inTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if err := setVersion(ctx, tx, migration.Version, false); err != nil {
+			return err
+		}
+		return insertHistory(ctx, tx, migration, false)
+	})
+}
+
+func (m *Migrator) down(ctx context.Context, conn *sql.Conn, migration Migration, previous uint64) error {
+	m.log.Infof("Reverting migration %d_%s", migration.Version, migration.Name)
+	if err := setVersion(ctx, conn, migration.Version, true); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, migration.Down); err != nil {
+		return errors.Wrapf(err, "while reverting migration %d_%s, the database is left dirty", migration.Version, migration.Name)
+	}
+
+	return inTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if err := setVersion(ctx, tx, previous, false); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE version = $1", historyTableName), migration.Version)
+		return errors.Wrap(err, "while deleting migration history")
+	})
+}
+
+// prepare returns the version of the database, which must not be dirty, and records the checksums of the migrations
+// applied before the history was introduced
+func (m *Migrator) prepare(ctx context.Context, conn *sql.Conn) (uint64, error) {
+	version, dirty, err := readVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, errors.Errorf("migration %d failed, fix the database manually and force the version", version)
+	}
+
+	history, err := readHistory(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	for _, migration := range m.migrations {
+		if _, found := history[migration.Version]; found || migration.Version > version {
+			continue
+		}
+		if err := insertHistory(ctx, conn, migration, true); err != nil {
+			return 0, err
+		}
+	}
+
+	return version, nil
+}
+
+// withLock runs the function with the connection holding the advisory lock, after the migration tables are created
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "while getting database connection")
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockID); err != nil {
+		return errors.Wrap(err, "while acquiring migration lock")
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockID); err != nil {
+			m.log.Warnf("Failed to release migration lock: %s", err)
+		}
+	}()
+
+	if err := createTables(ctx, conn); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func createTables(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	version bigint NOT NULL PRIMARY KEY,
+	dirty boolean NOT NULL
+)`, versionTableName))
+	if err != nil {
+		return errors.Wrap(err, "while creating version table")
+	}
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	version bigint NOT NULL PRIMARY KEY,
+	name varchar(255) NOT NULL,
+	checksum varchar(64) NOT NULL,
+	baselined boolean NOT NULL DEFAULT false,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`, historyTableName))
+	if err != nil {
+		return errors.Wrap(err, "while creating history table")
+	}
+
+	return nil
+}
+
+func readVersion(ctx context.Context, conn *sql.Conn) (uint64, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := conn.QueryRowContext(ctx, fmt.Sprintf("SELECT version, dirty FROM %s LIMIT 1", versionTableName)).Scan(&version, &dirty)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, false, nil
+	case err != nil:
+		return 0, false, errors.Wrap(err, "while reading database version")
+	case version < 0:
+		// the migrate tool marks the failed revert of the first migration with the negative version
+		return 0, dirty, nil
+	}
+
+	return uint64(version), dirty, nil
+}
+
+func setVersion(ctx context.Context, db execer, version uint64, dirty bool) error {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", versionTableName)); err != nil {
+		return errors.Wrap(err, "while clearing database version")
+	}
+	if version == 0 && !dirty {
+		return nil
+	}
+	_, err := db.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (version, dirty) VALUES ($1, $2)", versionTableName), version, dirty)
+	return errors.Wrap(err, "while setting database version")
+}
+
+func readHistory(ctx context.Context, conn *sql.Conn) (map[uint64]historyEntry, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf("SELECT version, name, checksum, baselined, applied_at FROM %s", historyTableName))
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading migration history")
+	}
+	defer rows.Close()
+
+	history := map[uint64]historyEntry{}
+	for rows.Next() {
+		var (
+			version uint64
+			entry   historyEntry
+		)
+		if err := rows.Scan(&version, &entry.name, &entry.checksum, &entry.baselined, &entry.appliedAt); err != nil {
+			return nil, errors.Wrap(err, "while reading migration history")
+		}
+		history[version] = entry
+	}
+
+	return history, errors.Wrap(rows.Err(), "while reading migration history")
+}
+
+func insertHistory(ctx context.Context, db execer, migration Migration, baselined bool) error {
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (version, name, checksum, baselined) VALUES ($1, $2, $3, $4)
+ON CONFLICT (version) DO UPDATE SET name = EXCLUDED.name, checksum = EXCLUDED.checksum, baselined = EXCLUDED.baselined, applied_at = NOW()`,
+		historyTableName), migration.Version, migration.Name, migration.Checksum(), baselined)
+	return errors.Wrapf(err, "while recording migration %d_%s", migration.Version, migration.Name)
+}
+
+func inTransaction(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "while starting transaction")
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "while committing transaction")
+}
+
+// statuses matches the migrations with the database version and the history. The version is the source of truth
+// of the applied migrations, because the database may be migrated also with the migrate tool.
+func statuses(migrations []Migration, version uint64, history map[uint64]historyEntry) []Status {
+	result := make([]Status, 0, len(migrations))
+	known := map[uint64]bool{}
+	for _, migration := range migrations {
+		known[migration.Version] = true
+		status := Status{Version: migration.Version, Name: migration.Name, State: StatePending}
+		if migration.Version <= version {
+			entry, found := history[migration.Version]
+			status.State = StateApplied
+			status.Baselined = !found || entry.baselined
+			status.AppliedAt = entry.appliedAt
+			if found && entry.checksum != migration.Checksum() {
+				status.State = StateModified
+			}
+		}
+		result = append(result, status)
+	}
+	for v, entry := range history {
+		if !known[v] && v <= version {
+			result = append(result, Status{Version: v, Name: entry.name, State: StateMissing, Baselined: entry.baselined, AppliedAt: entry.appliedAt})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+
+	return result
+}
@@ -0,0 +1,112 @@
+package migrator
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/schema-migrator/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should parse migrations sorted by version", func(t *testing.T) {
+		// when
+		result, err := Parse(map[string]string{
+			"202002_second.up.sql":   "CREATE TABLE b ();",
+			"202001_first.up.sql":    "CREATE TABLE a ();",
+			"202001_first.down.sql":  "DROP TABLE a;",
+			"202002_second.down.sql": "DROP TABLE b;",
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []Migration{
+			{Version: 202001, Name: "first", Up: "CREATE TABLE a ();", Down: "DROP TABLE a;"},
+			{Version: 202002, Name: "second", Up: "CREATE TABLE b ();", Down: "DROP TABLE b;"},
+		}, result)
+	})
+
+	for name, files := range map[string]map[string]string{
+		"invalid file name": {"first.up.sql": ""},
+		"missing up file":   {"202001_first.down.sql": ""},
+		"duplicated version": {
+			"202001_first.up.sql":  "",
+			"202001_second.up.sql": "",
+		},
+	} {
+		t.Run("should fail on "+name, func(t *testing.T) {
+			_, err := Parse(files)
+
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("should load embedded migrations", func(t *testing.T) {
+		for _, set := range migrations.Sets() {
+			result, err := Load(set)
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, result)
+		}
+	})
+}
+
+func TestStatuses(t *testing.T) {
+	// given
+	set := []Migration{
+		{Version: 1, Name: "baselined", Up: "1"},
+		{Version: 2, Name: "applied", Up: "2"},
+		{Version: 3, Name: "modified", Up: "3"},
+		{Version: 5, Name: "pending", Up: "5"},
+	}
+	history := map[uint64]historyEntry{
+		2: {name: "applied", checksum: set[1].Checksum()},
+		3: {name: "modified", checksum: "outdated"},
+		4: {name: "missing", checksum: "removed"},
+	}
+
+	// when
+	result := statuses(set, 4, history)
+
+	// then
+	require.Len(t, result, 5)
+	assert.Equal(t, Status{Version: 1, Name: "baselined", State: StateApplied, Baselined: true}, result[0])
+	assert.Equal(t, Status{Version: 2, Name: "applied", State: StateApplied}, result[1])
+	assert.Equal(t, Status{Version: 3, Name: "modified", State: StateModified}, result[2])
+	assert.Equal(t, Status{Version: 4, Name: "missing", State: StateMissing}, result[3])
+	assert.Equal(t, Status{Version: 5, Name: "pending", State: StatePending}, result[4])
+}
+
+func TestCompareSchemas(t *testing.T) {
+	// given
+	expected := map[string]string{
+		"table instances":             "table",
+		"column instances.runtime_id": "character varying(255) NOT NULL",
+		"index instances_pkey":        "CREATE UNIQUE INDEX instances_pkey ON instances USING btree (instance_id)",
+	}
+	actual := map[string]string{
+		"table instances":             "table",
+		"column instances.runtime_id": "text",
+		"column instances.extra":      "text",
+	}
+
+	// when
+	result := compareSchemas(expected, actual)
+
+	// then
+	assert.Equal(t, []Difference{
+		{Object: "column instances.extra", Actual: "text"},
+		{Object: "column instances.runtime_id", Expected: "character varying(255) NOT NULL", Actual: "text"},
+		{Object: "index instances_pkey", Expected: "CREATE UNIQUE INDEX instances_pkey ON instances USING btree (instance_id)"},
+	}, result)
+	assert.Equal(t, "unexpected column instances.extra: text", result[0].String())
+	assert.Equal(t, "missing index instances_pkey: CREATE UNIQUE INDEX instances_pkey ON instances USING btree (instance_id)", result[2].String())
+}
+
+func TestIsMigratorObject(t *testing.T) {
+	assert.True(t, isMigratorObject("table schema_migrations"))
+	assert.True(t, isMigratorObject("column schema_migrations_history.checksum"))
+	assert.True(t, isMigratorObject("index schema_migrations_history_pkey"))
+	assert.False(t, isMigratorObject("table instances"))
+	assert.False(t, isMigratorObject("column instances.version"))
+}
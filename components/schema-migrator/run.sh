@@ -36,16 +36,6 @@ if [ "${dbReady}" != true ] ; then
     exit 1
 fi
 
-if [ -n "${DB_SSL}" ] ; then
-  DB_NAME="${DB_NAME}?sslmode=${DB_SSL}"
-fi
-
-CONNECTION_STRING="postgres://$DB_USER:$DB_PASSWORD@$DB_HOST:$DB_PORT/$DB_NAME"
-
-CMD="migrate -path migrations/${MIGRATION_PATH} -database "$CONNECTION_STRING" ${DIRECTION}"
+# the migrator reads the database configuration and the MIGRATION_PATH from the environment
 echo '# STARTING MIGRATION #'
-if [[ "${NON_INTERACTIVE}" == "true" ]]; then
-    yes | $CMD
-else
-    $CMD
-fi
+migrator "${DIRECTION}"
@@ -65,6 +65,25 @@ function migrationUP() {
     docker exec ${POSTGRES_CONTAINER} psql -U usr ${db_name} -c "select * from schema_migrations"
 }
 
+function migrationVerify() {
+    echo -e "${GREEN}Verify checksums and schema drift ${NC}"
+
+    migration_path=$1
+    db_name=$2
+    for cmd in status verify drift; do
+        docker run --rm --network=${NETWORK} \
+                -e DB_USER=${DB_USER} \
+                -e DB_PASSWORD=${DB_PWD} \
+                -e DB_HOST=${POSTGRES_CONTAINER} \
+                -e DB_PORT=${DB_PORT} \
+                -e DB_NAME=${db_name} \
+                -e DB_SSL=${DB_SSL_PARAM} \
+                -e MIGRATION_PATH=${migration_path} \
+                --entrypoint migrator \
+            ${IMG_NAME} ${cmd}
+    done
+}
+
 function migrationDOWN() {
     echo -e "${GREEN}Run DOWN migrations ${NC}"
 
@@ -79,7 +98,6 @@ function migrationDOWN() {
             -e DB_SSL=${DB_SSL_PARAM} \
             -e MIGRATION_PATH=${migration_path} \
             -e DIRECTION="down" \
-            -e NON_INTERACTIVE="true" \
         ${IMG_NAME}
 
     echo -e "${GREEN}Show schema_migrations table after DOWN migrations${NC}"
@@ -92,6 +110,7 @@ function migrationProcess() {
 
     echo -e "${GREEN}Migrations for \"${db}\" database and \"${path}\" path${NC}"
     migrationUP "${path}" "${db}"
+    migrationVerify "${path}" "${db}"
     migrationDOWN "${path}" "${db}"
 }
 
@@ -0,0 +1,183 @@
+package cloudprovider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	awsAccessKeyIDKey     = "accessKeyID"
+	awsSecretAccessKeyKey = "secretAccessKey"
+
+	// awsShootTagPrefix is the prefix of the tag which Gardener and the cloud provider of the shoot cluster
+	// put on the resources of the shoot, e.g. kubernetes.io/cluster/shoot--kyma--c1
+	awsShootTagPrefix = "kubernetes.io/cluster/" + shootPrefix
+
+	awsPollInterval = 10 * time.Second
+	awsPollTimeout  = 5 * time.Minute
+
+	// awsHTTPTimeout limits a single request to the AWS API
+	awsHTTPTimeout = 30 * time.Second
+)
+
+// awsResourceTypes are deleted in the given order, the resources which use other resources go first
+var awsResourceTypes = []awsResourceType{
+	awsLoadBalancers,
+	awsLoadBalancersV2,
+	awsTargetGroups,
+	awsInstances,
+	awsNATGateways,
+	awsElasticIPs,
+	awsVolumes,
+	awsVPCs,
+}
+
+// the deletion of these resources takes a while and blocks the deletion of the next resources
+var awsAwaitedResourceTypes = map[awsResourceType]bool{
+	awsInstances:   true,
+	awsNATGateways: true,
+}
+
+type awsResourceCleaner struct {
+	api          awsAPI
+	pollInterval time.Duration
+	pollTimeout  time.Duration
+}
+
+func NewAWSResourcesCleaner(secretData map[string][]byte) (ResourceCleaner, error) {
+	accessKeyID, exists := secretData[awsAccessKeyIDKey]
+	if !exists {
+		return nil, errors.Errorf("%s not provided in the secret", awsAccessKeyIDKey)
+	}
+	secretAccessKey, exists := secretData[awsSecretAccessKeyKey]
+	if !exists {
+		return nil, errors.Errorf("%s not provided in the secret", awsSecretAccessKeyKey)
+	}
+
+	sess, err := session.NewSession(aws.NewConfig().
+		WithCredentials(credentials.NewStaticCredentials(string(accessKeyID), string(secretAccessKey), "")).
+		WithHTTPClient(&http.Client{Timeout: awsHTTPTimeout}))
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating the AWS session")
+	}
+
+	return &awsResourceCleaner{
+		api:          newAWSClient(sess),
+		pollInterval: awsPollInterval,
+		pollTimeout:  awsPollTimeout,
+	}, nil
+}
+
+// Do deletes the resources of the Gardener shoots left in all regions of the account. It returns an error if any resource
+// is left after the deletion, so the secret is not returned to the pool until the account is clean.
+func (rc awsResourceCleaner) Do() error {
+	ctx := context.Background()
+	regions, err := rc.api.Regions(ctx)
+	if err != nil {
+		return err
+	}
+
+	left := 0
+	for _, region := range regions {
+		regionLeft, err := rc.cleanRegion(ctx, region)
+		if err != nil {
+			return err
+		}
+		left += regionLeft
+	}
+	if left > 0 {
+		return errors.Errorf("%d resources of Gardener shoots are left in the account", left)
+	}
+
+	return nil
+}
+
+func (rc awsResourceCleaner) cleanRegion(ctx context.Context, region string) (int, error) {
+	for _, resourceType := range awsResourceTypes {
+		resources, err := rc.shootResources(ctx, region, resourceType)
+		if err != nil {
+			return 0, err
+		}
+		if len(resources) == 0 {
+			continue
+		}
+
+		for _, resource := range resources {
+			log.Infof("Deleting %s '%s' in %s", resourceType, resource.ID, region)
+			err := rc.api.Delete(ctx, region, resource)
+			if err != nil {
+				log.Errorf("failed to remove %s '%s': %s", resourceType, resource.ID, err.Error())
+			}
+		}
+
+		if awsAwaitedResourceTypes[resourceType] {
+			if err := rc.waitForDeletion(ctx, region, resourceType); err != nil {
+				log.Warnf("%ss in %s are not deleted yet: %s", resourceType, region, err.Error())
+			}
+		}
+	}
+
+	left := 0
+	for _, resourceType := range awsResourceTypes {
+		resources, err := rc.shootResources(ctx, region, resourceType)
+		if err != nil {
+			return 0, err
+		}
+		left += len(resources)
+	}
+
+	return left, nil
+}
+
+func (rc awsResourceCleaner) waitForDeletion(ctx context.Context, region string, resourceType awsResourceType) error {
+	deadline := time.Now().Add(rc.pollTimeout)
+	for {
+		resources, err := rc.shootResources(ctx, region, resourceType)
+		if err != nil {
+			return err
+		}
+		if len(resources) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("%d left after %s", len(resources), rc.pollTimeout)
+		}
+		time.Sleep(rc.pollInterval)
+	}
+}
+
+func (rc awsResourceCleaner) shootResources(ctx context.Context, region string, resourceType awsResourceType) ([]awsResource, error) {
+	resources, err := rc.api.List(ctx, region, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	var shootResources []awsResource
+	for _, resource := range resources {
+		if belongsToAWSShoot(resource) {
+			shootResources = append(shootResources, resource)
+		}
+	}
+
+	return shootResources, nil
+}
+
+// belongsToAWSShoot checks if the resource is tagged with the cluster tag of the shoot, which Gardener puts
+// on the infrastructure of the shoot and the cloud provider of the shoot cluster on the load balancers and volumes
+func belongsToAWSShoot(resource awsResource) bool {
+	for key := range resource.Tags {
+		if strings.HasPrefix(key, awsShootTagPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,466 @@
+package cloudprovider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+)
+
+const (
+	// awsDefaultRegion is used to list the regions enabled for the account
+	awsDefaultRegion = "us-east-1"
+
+	awsELBTagsMaxSize = 20
+)
+
+type awsResourceType string
+
+const (
+	awsLoadBalancers   awsResourceType = "load balancer"
+	awsLoadBalancersV2 awsResourceType = "network or application load balancer"
+	awsTargetGroups    awsResourceType = "target group"
+	awsInstances       awsResourceType = "instance"
+	awsNATGateways     awsResourceType = "NAT gateway"
+	awsElasticIPs      awsResourceType = "elastic IP"
+	awsVolumes         awsResourceType = "volume"
+	awsVPCs            awsResourceType = "VPC"
+)
+
+type awsResource struct {
+	Type awsResourceType
+	// ID is the name of the classic load balancer, the ARN of the load balancer v2 and the target group,
+	// and the ID of the other resources
+	ID string
+	// AssociationID is the ID of the association of the elastic IP with an instance or a network interface
+	AssociationID string
+	Tags          map[string]string
+}
+
+// awsAPI is the part of the AWS EC2 and Elastic Load Balancing APIs used by the cleaner
+type awsAPI interface {
+	Regions(ctx context.Context) ([]string, error)
+	List(ctx context.Context, region string, resourceType awsResourceType) ([]awsResource, error)
+	// Delete starts the deletion of the resource, the VPC is deleted together with its subnets,
+	// internet gateways, route tables and security groups
+	Delete(ctx context.Context, region string, resource awsResource) error
+}
+
+// ec2API is the part of the EC2 client used by the cleaner
+type ec2API interface {
+	DescribeRegionsWithContext(aws.Context, *ec2.DescribeRegionsInput, ...request.Option) (*ec2.DescribeRegionsOutput, error)
+	DescribeInstancesPagesWithContext(aws.Context, *ec2.DescribeInstancesInput, func(*ec2.DescribeInstancesOutput, bool) bool, ...request.Option) error
+	TerminateInstancesWithContext(aws.Context, *ec2.TerminateInstancesInput, ...request.Option) (*ec2.TerminateInstancesOutput, error)
+	DescribeNatGatewaysPagesWithContext(aws.Context, *ec2.DescribeNatGatewaysInput, func(*ec2.DescribeNatGatewaysOutput, bool) bool, ...request.Option) error
+	DeleteNatGatewayWithContext(aws.Context, *ec2.DeleteNatGatewayInput, ...request.Option) (*ec2.DeleteNatGatewayOutput, error)
+	DescribeAddressesWithContext(aws.Context, *ec2.DescribeAddressesInput, ...request.Option) (*ec2.DescribeAddressesOutput, error)
+	DisassociateAddressWithContext(aws.Context, *ec2.DisassociateAddressInput, ...request.Option) (*ec2.DisassociateAddressOutput, error)
+	ReleaseAddressWithContext(aws.Context, *ec2.ReleaseAddressInput, ...request.Option) (*ec2.ReleaseAddressOutput, error)
+	DescribeVolumesPagesWithContext(aws.Context, *ec2.DescribeVolumesInput, func(*ec2.DescribeVolumesOutput, bool) bool, ...request.Option) error
+	DeleteVolumeWithContext(aws.Context, *ec2.DeleteVolumeInput, ...request.Option) (*ec2.DeleteVolumeOutput, error)
+	DescribeVpcsPagesWithContext(aws.Context, *ec2.DescribeVpcsInput, func(*ec2.DescribeVpcsOutput, bool) bool, ...request.Option) error
+	DeleteVpcWithContext(aws.Context, *ec2.DeleteVpcInput, ...request.Option) (*ec2.DeleteVpcOutput, error)
+	DescribeSubnetsWithContext(aws.Context, *ec2.DescribeSubnetsInput, ...request.Option) (*ec2.DescribeSubnetsOutput, error)
+	DeleteSubnetWithContext(aws.Context, *ec2.DeleteSubnetInput, ...request.Option) (*ec2.DeleteSubnetOutput, error)
+	DescribeInternetGatewaysWithContext(aws.Context, *ec2.DescribeInternetGatewaysInput, ...request.Option) (*ec2.DescribeInternetGatewaysOutput, error)
+	DetachInternetGatewayWithContext(aws.Context, *ec2.DetachInternetGatewayInput, ...request.Option) (*ec2.DetachInternetGatewayOutput, error)
+	DeleteInternetGatewayWithContext(aws.Context, *ec2.DeleteInternetGatewayInput, ...request.Option) (*ec2.DeleteInternetGatewayOutput, error)
+	DescribeRouteTablesWithContext(aws.Context, *ec2.DescribeRouteTablesInput, ...request.Option) (*ec2.DescribeRouteTablesOutput, error)
+	DeleteRouteTableWithContext(aws.Context, *ec2.DeleteRouteTableInput, ...request.Option) (*ec2.DeleteRouteTableOutput, error)
+	DescribeSecurityGroupsWithContext(aws.Context, *ec2.DescribeSecurityGroupsInput, ...request.Option) (*ec2.DescribeSecurityGroupsOutput, error)
+	DeleteSecurityGroupWithContext(aws.Context, *ec2.DeleteSecurityGroupInput, ...request.Option) (*ec2.DeleteSecurityGroupOutput, error)
+}
+
+// elbAPI is the part of the classic Elastic Load Balancing client used by the cleaner
+type elbAPI interface {
+	DescribeLoadBalancersPagesWithContext(aws.Context, *elb.DescribeLoadBalancersInput, func(*elb.DescribeLoadBalancersOutput, bool) bool, ...request.Option) error
+	DescribeTagsWithContext(aws.Context, *elb.DescribeTagsInput, ...request.Option) (*elb.DescribeTagsOutput, error)
+	DeleteLoadBalancerWithContext(aws.Context, *elb.DeleteLoadBalancerInput, ...request.Option) (*elb.DeleteLoadBalancerOutput, error)
+}
+
+// elbv2API is the part of the Elastic Load Balancing v2 client used by the cleaner, it manages the network
+// and the application load balancers
+type elbv2API interface {
+	DescribeLoadBalancersPagesWithContext(aws.Context, *elbv2.DescribeLoadBalancersInput, func(*elbv2.DescribeLoadBalancersOutput, bool) bool, ...request.Option) error
+	DescribeTargetGroupsPagesWithContext(aws.Context, *elbv2.DescribeTargetGroupsInput, func(*elbv2.DescribeTargetGroupsOutput, bool) bool, ...request.Option) error
+	DescribeTagsWithContext(aws.Context, *elbv2.DescribeTagsInput, ...request.Option) (*elbv2.DescribeTagsOutput, error)
+	DeleteLoadBalancerWithContext(aws.Context, *elbv2.DeleteLoadBalancerInput, ...request.Option) (*elbv2.DeleteLoadBalancerOutput, error)
+	DeleteTargetGroupWithContext(aws.Context, *elbv2.DeleteTargetGroupInput, ...request.Option) (*elbv2.DeleteTargetGroupOutput, error)
+}
+
+// awsClient creates the clients of the services for the given region from the session
+type awsClient struct {
+	session *session.Session
+
+	ec2   func(region string) ec2API
+	elb   func(region string) elbAPI
+	elbv2 func(region string) elbv2API
+}
+
+func newAWSClient(sess *session.Session) *awsClient {
+	return &awsClient{
+		session: sess,
+		ec2: func(region string) ec2API {
+			return ec2.New(sess, aws.NewConfig().WithRegion(region))
+		},
+		elb: func(region string) elbAPI {
+			return elb.New(sess, aws.NewConfig().WithRegion(region))
+		},
+		elbv2: func(region string) elbv2API {
+			return elbv2.New(sess, aws.NewConfig().WithRegion(region))
+		},
+	}
+}
+
+func (c *awsClient) Regions(ctx context.Context) ([]string, error) {
+	out, err := c.ec2(awsDefaultRegion).DescribeRegionsWithContext(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing regions")
+	}
+
+	regions := make([]string, 0, len(out.Regions))
+	for _, region := range out.Regions {
+		regions = append(regions, aws.StringValue(region.RegionName))
+	}
+	return regions, nil
+}
+
+func (c *awsClient) List(ctx context.Context, region string, resourceType awsResourceType) ([]awsResource, error) {
+	var resources []awsResource
+	var err error
+	switch resourceType {
+	case awsLoadBalancers:
+		resources, err = c.listLoadBalancers(ctx, region)
+	case awsLoadBalancersV2:
+		resources, err = c.listLoadBalancersV2(ctx, region)
+	case awsTargetGroups:
+		resources, err = c.listTargetGroups(ctx, region)
+	case awsInstances:
+		resources, err = c.listInstances(ctx, region)
+	case awsNATGateways:
+		resources, err = c.listNATGateways(ctx, region)
+	case awsElasticIPs:
+		resources, err = c.listElasticIPs(ctx, region)
+	case awsVolumes:
+		resources, err = c.listVolumes(ctx, region)
+	case awsVPCs:
+		resources, err = c.listVPCs(ctx, region)
+	default:
+		return nil, errors.Errorf("unknown resource type %s", resourceType)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "while listing %ss in %s", resourceType, region)
+	}
+
+	return resources, nil
+}
+
+func (c *awsClient) Delete(ctx context.Context, region string, resource awsResource) error {
+	var err error
+	switch resource.Type {
+	case awsLoadBalancers:
+		_, err = c.elb(region).DeleteLoadBalancerWithContext(ctx, &elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String(resource.ID)})
+	case awsLoadBalancersV2:
+		_, err = c.elbv2(region).DeleteLoadBalancerWithContext(ctx, &elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(resource.ID)})
+	case awsTargetGroups:
+		_, err = c.elbv2(region).DeleteTargetGroupWithContext(ctx, &elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(resource.ID)})
+	case awsInstances:
+		_, err = c.ec2(region).TerminateInstancesWithContext(ctx, &ec2.TerminateInstancesInput{InstanceIds: aws.StringSlice([]string{resource.ID})})
+	case awsNATGateways:
+		_, err = c.ec2(region).DeleteNatGatewayWithContext(ctx, &ec2.DeleteNatGatewayInput{NatGatewayId: aws.String(resource.ID)})
+	case awsElasticIPs:
+		err = c.releaseElasticIP(ctx, region, resource)
+	case awsVolumes:
+		_, err = c.ec2(region).DeleteVolumeWithContext(ctx, &ec2.DeleteVolumeInput{VolumeId: aws.String(resource.ID)})
+	case awsVPCs:
+		err = c.deleteVPC(ctx, region, resource.ID)
+	default:
+		return errors.Errorf("unknown resource type %s", resource.Type)
+	}
+	if isAWSNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (c *awsClient) listLoadBalancers(ctx context.Context, region string) ([]awsResource, error) {
+	api := c.elb(region)
+	var names []string
+	err := api.DescribeLoadBalancersPagesWithContext(ctx, &elb.DescribeLoadBalancersInput{}, func(out *elb.DescribeLoadBalancersOutput, _ bool) bool {
+		for _, description := range out.LoadBalancerDescriptions {
+			names = append(names, aws.StringValue(description.LoadBalancerName))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []awsResource
+	for _, batch := range inBatches(names, awsELBTagsMaxSize) {
+		out, err := api.DescribeTagsWithContext(ctx, &elb.DescribeTagsInput{LoadBalancerNames: aws.StringSlice(batch)})
+		if err != nil {
+			return nil, err
+		}
+		for _, description := range out.TagDescriptions {
+			tags := make(map[string]string, len(description.Tags))
+			for _, tag := range description.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			resources = append(resources, awsResource{Type: awsLoadBalancers, ID: aws.StringValue(description.LoadBalancerName), Tags: tags})
+		}
+	}
+
+	return resources, nil
+}
+
+func (c *awsClient) listLoadBalancersV2(ctx context.Context, region string) ([]awsResource, error) {
+	var arns []string
+	err := c.elbv2(region).DescribeLoadBalancersPagesWithContext(ctx, &elbv2.DescribeLoadBalancersInput{}, func(out *elbv2.DescribeLoadBalancersOutput, _ bool) bool {
+		for _, loadBalancer := range out.LoadBalancers {
+			arns = append(arns, aws.StringValue(loadBalancer.LoadBalancerArn))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.tagELBV2Resources(ctx, region, awsLoadBalancersV2, arns)
+}
+
+func (c *awsClient) listTargetGroups(ctx context.Context, region string) ([]awsResource, error) {
+	var arns []string
+	err := c.elbv2(region).DescribeTargetGroupsPagesWithContext(ctx, &elbv2.DescribeTargetGroupsInput{}, func(out *elbv2.DescribeTargetGroupsOutput, _ bool) bool {
+		for _, targetGroup := range out.TargetGroups {
+			arns = append(arns, aws.StringValue(targetGroup.TargetGroupArn))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.tagELBV2Resources(ctx, region, awsTargetGroups, arns)
+}
+
+// tagELBV2Resources reads the tags of the load balancers or the target groups with the given ARNs
+func (c *awsClient) tagELBV2Resources(ctx context.Context, region string, resourceType awsResourceType, arns []string) ([]awsResource, error) {
+	var resources []awsResource
+	for _, batch := range inBatches(arns, awsELBTagsMaxSize) {
+		out, err := c.elbv2(region).DescribeTagsWithContext(ctx, &elbv2.DescribeTagsInput{ResourceArns: aws.StringSlice(batch)})
+		if err != nil {
+			return nil, err
+		}
+		for _, description := range out.TagDescriptions {
+			tags := make(map[string]string, len(description.Tags))
+			for _, tag := range description.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			resources = append(resources, awsResource{Type: resourceType, ID: aws.StringValue(description.ResourceArn), Tags: tags})
+		}
+	}
+
+	return resources, nil
+}
+
+func (c *awsClient) listInstances(ctx context.Context, region string) ([]awsResource, error) {
+	var resources []awsResource
+	err := c.ec2(region).DescribeInstancesPagesWithContext(ctx, &ec2.DescribeInstancesInput{}, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				if instance.State != nil && aws.StringValue(instance.State.Name) == ec2.InstanceStateNameTerminated {
+					continue
+				}
+				resources = append(resources, awsResource{Type: awsInstances, ID: aws.StringValue(instance.InstanceId), Tags: ec2Tags(instance.Tags)})
+			}
+		}
+		return true
+	})
+
+	return resources, err
+}
+
+func (c *awsClient) listNATGateways(ctx context.Context, region string) ([]awsResource, error) {
+	var resources []awsResource
+	err := c.ec2(region).DescribeNatGatewaysPagesWithContext(ctx, &ec2.DescribeNatGatewaysInput{}, func(out *ec2.DescribeNatGatewaysOutput, _ bool) bool {
+		for _, natGateway := range out.NatGateways {
+			if aws.StringValue(natGateway.State) == ec2.NatGatewayStateDeleted {
+				continue
+			}
+			resources = append(resources, awsResource{Type: awsNATGateways, ID: aws.StringValue(natGateway.NatGatewayId), Tags: ec2Tags(natGateway.Tags)})
+		}
+		return true
+	})
+
+	return resources, err
+}
+
+func (c *awsClient) listElasticIPs(ctx context.Context, region string) ([]awsResource, error) {
+	out, err := c.ec2(region).DescribeAddressesWithContext(ctx, &ec2.DescribeAddressesInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []awsResource
+	for _, address := range out.Addresses {
+		resources = append(resources, awsResource{
+			Type:          awsElasticIPs,
+			ID:            aws.StringValue(address.AllocationId),
+			AssociationID: aws.StringValue(address.AssociationId),
+			Tags:          ec2Tags(address.Tags),
+		})
+	}
+
+	return resources, nil
+}
+
+func (c *awsClient) listVolumes(ctx context.Context, region string) ([]awsResource, error) {
+	var resources []awsResource
+	err := c.ec2(region).DescribeVolumesPagesWithContext(ctx, &ec2.DescribeVolumesInput{}, func(out *ec2.DescribeVolumesOutput, _ bool) bool {
+		for _, volume := range out.Volumes {
+			resources = append(resources, awsResource{Type: awsVolumes, ID: aws.StringValue(volume.VolumeId), Tags: ec2Tags(volume.Tags)})
+		}
+		return true
+	})
+
+	return resources, err
+}
+
+func (c *awsClient) listVPCs(ctx context.Context, region string) ([]awsResource, error) {
+	var resources []awsResource
+	err := c.ec2(region).DescribeVpcsPagesWithContext(ctx, &ec2.DescribeVpcsInput{}, func(out *ec2.DescribeVpcsOutput, _ bool) bool {
+		for _, vpc := range out.Vpcs {
+			resources = append(resources, awsResource{Type: awsVPCs, ID: aws.StringValue(vpc.VpcId), Tags: ec2Tags(vpc.Tags)})
+		}
+		return true
+	})
+
+	return resources, err
+}
+
+func (c *awsClient) releaseElasticIP(ctx context.Context, region string, resource awsResource) error {
+	api := c.ec2(region)
+	if resource.AssociationID != "" {
+		_, err := api.DisassociateAddressWithContext(ctx, &ec2.DisassociateAddressInput{AssociationId: aws.String(resource.AssociationID)})
+		if err != nil && !isAWSNotFound(err) {
+			return errors.Wrap(err, "while disassociating the address")
+		}
+	}
+
+	_, err := api.ReleaseAddressWithContext(ctx, &ec2.ReleaseAddressInput{AllocationId: aws.String(resource.ID)})
+	return err
+}
+
+// deleteVPC deletes the resources which block the deletion of the VPC, the instances, NAT gateways
+// and load balancers must be deleted before
+func (c *awsClient) deleteVPC(ctx context.Context, region, vpcID string) error {
+	api := c.ec2(region)
+
+	subnets, err := api.DescribeSubnetsWithContext(ctx, &ec2.DescribeSubnetsInput{Filters: vpcFilter("vpc-id", vpcID)})
+	if err != nil {
+		return errors.Wrap(err, "while listing subnets")
+	}
+	for _, subnet := range subnets.Subnets {
+		_, err := api.DeleteSubnetWithContext(ctx, &ec2.DeleteSubnetInput{SubnetId: subnet.SubnetId})
+		if err != nil && !isAWSNotFound(err) {
+			return errors.Wrapf(err, "while deleting subnet %s", aws.StringValue(subnet.SubnetId))
+		}
+	}
+
+	gateways, err := api.DescribeInternetGatewaysWithContext(ctx, &ec2.DescribeInternetGatewaysInput{Filters: vpcFilter("attachment.vpc-id", vpcID)})
+	if err != nil {
+		return errors.Wrap(err, "while listing internet gateways")
+	}
+	for _, gateway := range gateways.InternetGateways {
+		_, err := api.DetachInternetGatewayWithContext(ctx, &ec2.DetachInternetGatewayInput{InternetGatewayId: gateway.InternetGatewayId, VpcId: aws.String(vpcID)})
+		if err != nil && !isAWSNotFound(err) {
+			return errors.Wrapf(err, "while detaching internet gateway %s", aws.StringValue(gateway.InternetGatewayId))
+		}
+		_, err = api.DeleteInternetGatewayWithContext(ctx, &ec2.DeleteInternetGatewayInput{InternetGatewayId: gateway.InternetGatewayId})
+		if err != nil && !isAWSNotFound(err) {
+			return errors.Wrapf(err, "while deleting internet gateway %s", aws.StringValue(gateway.InternetGatewayId))
+		}
+	}
+
+	routeTables, err := api.DescribeRouteTablesWithContext(ctx, &ec2.DescribeRouteTablesInput{Filters: vpcFilter("vpc-id", vpcID)})
+	if err != nil {
+		return errors.Wrap(err, "while listing route tables")
+	}
+	for _, routeTable := range routeTables.RouteTables {
+		// the main route table is deleted together with the VPC
+		if isMainRouteTable(routeTable) {
+			continue
+		}
+		_, err := api.DeleteRouteTableWithContext(ctx, &ec2.DeleteRouteTableInput{RouteTableId: routeTable.RouteTableId})
+		if err != nil && !isAWSNotFound(err) {
+			return errors.Wrapf(err, "while deleting route table %s", aws.StringValue(routeTable.RouteTableId))
+		}
+	}
+
+	securityGroups, err := api.DescribeSecurityGroupsWithContext(ctx, &ec2.DescribeSecurityGroupsInput{Filters: vpcFilter("vpc-id", vpcID)})
+	if err != nil {
+		return errors.Wrap(err, "while listing security groups")
+	}
+	for _, group := range securityGroups.SecurityGroups {
+		// the default security group is deleted together with the VPC
+		if aws.StringValue(group.GroupName) == "default" {
+			continue
+		}
+		_, err := api.DeleteSecurityGroupWithContext(ctx, &ec2.DeleteSecurityGroupInput{GroupId: group.GroupId})
+		if err != nil && !isAWSNotFound(err) {
+			return errors.Wrapf(err, "while deleting security group %s", aws.StringValue(group.GroupId))
+		}
+	}
+
+	_, err = api.DeleteVpcWithContext(ctx, &ec2.DeleteVpcInput{VpcId: aws.String(vpcID)})
+	return err
+}
+
+func vpcFilter(name, vpcID string) []*ec2.Filter {
+	return []*ec2.Filter{{Name: aws.String(name), Values: aws.StringSlice([]string{vpcID})}}
+}
+
+func isMainRouteTable(routeTable *ec2.RouteTable) bool {
+	for _, association := range routeTable.Associations {
+		if aws.BoolValue(association.Main) {
+			return true
+		}
+	}
+	return false
+}
+
+func ec2Tags(tags []*ec2.Tag) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		result[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return result
+}
+
+// inBatches splits the values to the batches of the given size
+func inBatches(values []string, size int) [][]string {
+	var batches [][]string
+	for start := 0; start < len(values); start += size {
+		end := start + size
+		if end > len(values) {
+			end = len(values)
+		}
+		batches = append(batches, values[start:end])
+	}
+	return batches
+}
+
+// isAWSNotFound checks if the resource does not exist, e.g. InvalidInstanceID.NotFound or LoadBalancerNotFound
+func isAWSNotFound(err error) bool {
+	awsErr, ok := errors.Cause(err).(awserr.Error)
+	return ok && strings.HasSuffix(awsErr.Code(), "NotFound")
+}
@@ -0,0 +1,711 @@
+package cloudprovider
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAWSShootTag    = "kubernetes.io/cluster/shoot--kyma--c1"
+	testAWSNLB         = "arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/net/d4e5f6/1"
+	testAWSTargetGroup = "arn:aws:elasticloadbalancing:eu-central-1:123456789012:targetgroup/k8s-d4e5f6/1"
+)
+
+func TestAWSResourceCleaner_Do(t *testing.T) {
+	t.Run("should delete resources of Gardener shoots", func(t *testing.T) {
+		// given
+		fake := newFakeAWS(t, "eu-central-1", "us-east-1")
+		fake.add("eu-central-1", awsLoadBalancers, "a1b2c3", map[string]string{testAWSShootTag: "owned"})
+		fake.add("eu-central-1", awsLoadBalancers, "other", nil)
+		fake.add("eu-central-1", awsTargetGroups, testAWSTargetGroup, map[string]string{testAWSShootTag: "owned"})
+		fake.add("eu-central-1", awsLoadBalancersV2, testAWSNLB, map[string]string{testAWSShootTag: "owned"})
+		fake.add("eu-central-1", awsInstances, "i-1", map[string]string{testAWSShootTag: "1", "Name": "shoot--kyma--c1-worker-z1"})
+		fake.add("eu-central-1", awsInstances, "i-2", map[string]string{"Name": "bastion"})
+		fake.add("eu-central-1", awsNATGateways, "nat-1", map[string]string{testAWSShootTag: "1"})
+		fake.addElasticIP("eu-central-1", "eipalloc-1", "eipassoc-1", map[string]string{testAWSShootTag: "1"})
+		fake.add("eu-central-1", awsVolumes, "vol-1", map[string]string{testAWSShootTag: "owned"})
+		fake.add("eu-central-1", awsVolumes, "vol-2", nil)
+		fake.add("eu-central-1", awsVPCs, "vpc-1", map[string]string{testAWSShootTag: "1"})
+		fake.add("us-east-1", awsVolumes, "vol-3", map[string]string{"kubernetes.io/cluster/shoot--kyma--c2": "owned"})
+		cleaner := newTestAWSResourceCleaner(fake)
+
+		// when
+		err := cleaner.Do()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"eu-central-1/load balancer/a1b2c3",
+			"eu-central-1/network or application load balancer/" + testAWSNLB,
+			"eu-central-1/target group/" + testAWSTargetGroup,
+			"eu-central-1/instance/i-1",
+			"eu-central-1/NAT gateway/nat-1",
+			"eu-central-1/address association/eipassoc-1",
+			"eu-central-1/elastic IP/eipalloc-1",
+			"eu-central-1/volume/vol-1",
+			"eu-central-1/subnet/subnet-vpc-1",
+			"eu-central-1/internet gateway/igw-vpc-1",
+			"eu-central-1/route table/rtb-vpc-1",
+			"eu-central-1/security group/sg-vpc-1",
+			"eu-central-1/VPC/vpc-1",
+			"us-east-1/volume/vol-3",
+		}, fake.deleted)
+		assert.ElementsMatch(t, []string{
+			"eu-central-1/load balancer/other",
+			"eu-central-1/instance/i-2",
+			"eu-central-1/volume/vol-2",
+		}, fake.left())
+	})
+
+	t.Run("should read the tags of many load balancers in batches", func(t *testing.T) {
+		// given
+		fake := newFakeAWS(t, "eu-central-1")
+		for i := 0; i < 2*awsELBTagsMaxSize+1; i++ {
+			fake.add("eu-central-1", awsLoadBalancers, fmt.Sprintf("lb-%d", i), map[string]string{testAWSShootTag: "owned"})
+			fake.add("eu-central-1", awsLoadBalancersV2, fmt.Sprintf("%s-%d", testAWSNLB, i), map[string]string{testAWSShootTag: "owned"})
+		}
+		cleaner := newTestAWSResourceCleaner(fake)
+
+		// when
+		err := cleaner.Do()
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, fake.deleted, 2*(2*awsELBTagsMaxSize+1))
+		assert.Empty(t, fake.left())
+	})
+
+	t.Run("should return error when resources are left", func(t *testing.T) {
+		// given
+		fake := newFakeAWS(t, "eu-central-1")
+		fake.add("eu-central-1", awsVolumes, "vol-1", map[string]string{testAWSShootTag: "owned"})
+		fake.add("eu-central-1", awsVPCs, "vpc-1", map[string]string{testAWSShootTag: "1"})
+		fake.failDeletion("eu-central-1/volume/vol-1")
+		cleaner := newTestAWSResourceCleaner(fake)
+
+		// when
+		err := cleaner.Do()
+
+		// then
+		require.EqualError(t, err, "1 resources of Gardener shoots are left in the account")
+		assert.Contains(t, fake.deleted, "eu-central-1/VPC/vpc-1")
+		assert.Equal(t, []string{"eu-central-1/volume/vol-1"}, fake.left())
+	})
+
+	t.Run("should return error when listing fails", func(t *testing.T) {
+		// given
+		fake := newFakeAWS(t, "eu-central-1")
+		fake.failAction("DescribeVolumes")
+		cleaner := newTestAWSResourceCleaner(fake)
+
+		// when
+		err := cleaner.Do()
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while listing volumes in eu-central-1")
+		assert.Contains(t, err.Error(), "UnauthorizedOperation")
+	})
+}
+
+func TestNewAWSResourcesCleaner(t *testing.T) {
+	t.Run("should read the credentials from the secret", func(t *testing.T) {
+		// when
+		cleaner, err := NewAWSResourcesCleaner(map[string][]byte{"accessKeyID": []byte("id"), "secretAccessKey": []byte("secret")})
+
+		// then
+		require.NoError(t, err)
+		config := cleaner.(*awsResourceCleaner).api.(*awsClient).session.Config
+		credentials, err := config.Credentials.Get()
+		require.NoError(t, err)
+		assert.Equal(t, "id", credentials.AccessKeyID)
+		assert.Equal(t, "secret", credentials.SecretAccessKey)
+		assert.Equal(t, awsHTTPTimeout, config.HTTPClient.Timeout)
+	})
+
+	t.Run("should return error when the secret access key is missing", func(t *testing.T) {
+		// when
+		_, err := NewAWSResourcesCleaner(map[string][]byte{"accessKeyID": []byte("id")})
+
+		// then
+		require.EqualError(t, err, "secretAccessKey not provided in the secret")
+	})
+}
+
+func newTestAWSResourceCleaner(fake *fakeAWS) awsResourceCleaner {
+	return awsResourceCleaner{
+		api: &awsClient{
+			ec2: func(region string) ec2API {
+				return &fakeEC2{fakeAWS: fake, region: region}
+			},
+			elb: func(region string) elbAPI {
+				return &fakeELB{fakeAWS: fake, region: region}
+			},
+			elbv2: func(region string) elbv2API {
+				return &fakeELBV2{fakeAWS: fake, region: region}
+			},
+		},
+		pollInterval: time.Millisecond,
+		pollTimeout:  time.Second,
+	}
+}
+
+type fakeAWSResource struct {
+	region        string
+	resourceType  awsResourceType
+	id            string
+	associationID string
+	state         string
+	tags          map[string]string
+}
+
+func (r fakeAWSResource) path() string {
+	return fmt.Sprintf("%s/%s/%s", r.region, r.resourceType, r.id)
+}
+
+// fakeAWS keeps the resources of the account for the fake EC2 and Elastic Load Balancing clients of the regions.
+// The terminated instances are reported as shutting down once, so the cleaner must wait for them. Every VPC has
+// a subnet, an internet gateway and a route table and a security group, which must be deleted before the VPC.
+type fakeAWS struct {
+	t       *testing.T
+	regions []string
+
+	mu             sync.Mutex
+	resources      []*fakeAWSResource
+	vpcResources   map[string][]string
+	failing        map[string]bool
+	failingActions map[string]bool
+	deleted        []string
+}
+
+func newFakeAWS(t *testing.T, regions ...string) *fakeAWS {
+	return &fakeAWS{
+		t:              t,
+		regions:        regions,
+		vpcResources:   map[string][]string{},
+		failing:        map[string]bool{},
+		failingActions: map[string]bool{},
+	}
+}
+
+func (f *fakeAWS) add(region string, resourceType awsResourceType, id string, tags map[string]string) {
+	f.resources = append(f.resources, &fakeAWSResource{region: region, resourceType: resourceType, id: id, tags: tags})
+	if resourceType == awsVPCs {
+		f.vpcResources[id] = []string{"subnet-" + id, "igw-" + id, "rtb-" + id, "sg-" + id}
+	}
+}
+
+func (f *fakeAWS) addElasticIP(region, allocationID, associationID string, tags map[string]string) {
+	f.resources = append(f.resources, &fakeAWSResource{region: region, resourceType: awsElasticIPs, id: allocationID, associationID: associationID, tags: tags})
+}
+
+func (f *fakeAWS) failDeletion(path string) {
+	f.failing[path] = true
+}
+
+func (f *fakeAWS) failAction(action string) {
+	f.failingActions[action] = true
+}
+
+func (f *fakeAWS) left() []string {
+	var left []string
+	for _, r := range f.resources {
+		if r.state != "terminated" {
+			left = append(left, r.path())
+		}
+	}
+	return left
+}
+
+// call locks the account for the action and returns the error of the failing action
+func (f *fakeAWS) call(action string) error {
+	f.mu.Lock()
+	if f.failingActions[action] {
+		f.mu.Unlock()
+		return awserr.New("UnauthorizedOperation", "fake error", nil)
+	}
+	return nil
+}
+
+func (f *fakeAWS) list(region string, resourceType awsResourceType) []*fakeAWSResource {
+	var resources []*fakeAWSResource
+	for _, r := range f.resources {
+		if r.region == region && r.resourceType == resourceType && r.state != "terminated" {
+			resources = append(resources, r)
+		}
+	}
+	return resources
+}
+
+func (f *fakeAWS) find(region string, resourceType awsResourceType, id string) *fakeAWSResource {
+	for _, r := range f.list(region, resourceType) {
+		if r.id == id {
+			return r
+		}
+	}
+	return nil
+}
+
+func (f *fakeAWS) delete(region string, resourceType awsResourceType, id string) error {
+	for i, r := range f.resources {
+		if r.region != region || r.resourceType != resourceType || r.id != id || r.state == "terminated" {
+			continue
+		}
+		if f.failing[r.path()] {
+			return awserr.New("VolumeInUse", "fake error", nil)
+		}
+		f.deleted = append(f.deleted, r.path())
+		if resourceType == awsInstances {
+			r.state = "shutting-down"
+		} else {
+			f.resources = append(f.resources[:i], f.resources[i+1:]...)
+		}
+		return nil
+	}
+	return awserr.New("InvalidID.NotFound", "fake error", nil)
+}
+
+func (f *fakeAWS) vpcResourceIDs(filters []*ec2.Filter, prefix string) []string {
+	require.Len(f.t, filters, 1)
+	var ids []string
+	for _, id := range f.vpcResources[aws.StringValue(filters[0].Values[0])] {
+		if strings.HasPrefix(id, prefix) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (f *fakeAWS) deleteVPCResource(region, resourceType, id string) error {
+	for vpcID, ids := range f.vpcResources {
+		for i, vpcResource := range ids {
+			if vpcResource == id {
+				f.vpcResources[vpcID] = append(ids[:i], ids[i+1:]...)
+				f.deleted = append(f.deleted, fmt.Sprintf("%s/%s/%s", region, resourceType, id))
+				return nil
+			}
+		}
+	}
+	return awserr.New("InvalidID.NotFound", "fake error", nil)
+}
+
+func fakeEC2Tags(tags map[string]string) []*ec2.Tag {
+	var ec2Tags []*ec2.Tag
+	for key, value := range tags {
+		ec2Tags = append(ec2Tags, &ec2.Tag{Key: aws.String(key), Value: aws.String(value)})
+	}
+	return ec2Tags
+}
+
+type fakeEC2 struct {
+	*fakeAWS
+	region string
+}
+
+func (f *fakeEC2) DescribeRegionsWithContext(aws.Context, *ec2.DescribeRegionsInput, ...request.Option) (*ec2.DescribeRegionsOutput, error) {
+	if err := f.call("DescribeRegions"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	out := &ec2.DescribeRegionsOutput{}
+	for _, region := range f.regions {
+		out.Regions = append(out.Regions, &ec2.Region{RegionName: aws.String(region)})
+	}
+	return out, nil
+}
+
+func (f *fakeEC2) DescribeInstancesPagesWithContext(_ aws.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+	if err := f.call("DescribeInstances"); err != nil {
+		return err
+	}
+	defer f.mu.Unlock()
+
+	reservation := &ec2.Reservation{}
+	for _, res := range f.list(f.region, awsInstances) {
+		state := res.state
+		switch state {
+		case "":
+			state = ec2.InstanceStateNameRunning
+		case "shutting-down":
+			res.state = "terminated"
+		}
+		reservation.Instances = append(reservation.Instances, &ec2.Instance{
+			InstanceId: aws.String(res.id),
+			State:      &ec2.InstanceState{Name: aws.String(state)},
+			Tags:       fakeEC2Tags(res.tags),
+		})
+	}
+	fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{reservation}}, true)
+	return nil
+}
+
+func (f *fakeEC2) TerminateInstancesWithContext(_ aws.Context, input *ec2.TerminateInstancesInput, _ ...request.Option) (*ec2.TerminateInstancesOutput, error) {
+	if err := f.call("TerminateInstances"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	require.Len(f.t, input.InstanceIds, 1)
+	return &ec2.TerminateInstancesOutput{}, f.delete(f.region, awsInstances, aws.StringValue(input.InstanceIds[0]))
+}
+
+func (f *fakeEC2) DescribeNatGatewaysPagesWithContext(_ aws.Context, _ *ec2.DescribeNatGatewaysInput, fn func(*ec2.DescribeNatGatewaysOutput, bool) bool, _ ...request.Option) error {
+	if err := f.call("DescribeNatGateways"); err != nil {
+		return err
+	}
+	defer f.mu.Unlock()
+
+	out := &ec2.DescribeNatGatewaysOutput{}
+	for _, res := range f.list(f.region, awsNATGateways) {
+		out.NatGateways = append(out.NatGateways, &ec2.NatGateway{
+			NatGatewayId: aws.String(res.id),
+			State:        aws.String(ec2.NatGatewayStateAvailable),
+			Tags:         fakeEC2Tags(res.tags),
+		})
+	}
+	fn(out, true)
+	return nil
+}
+
+func (f *fakeEC2) DeleteNatGatewayWithContext(_ aws.Context, input *ec2.DeleteNatGatewayInput, _ ...request.Option) (*ec2.DeleteNatGatewayOutput, error) {
+	if err := f.call("DeleteNatGateway"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	return &ec2.DeleteNatGatewayOutput{}, f.delete(f.region, awsNATGateways, aws.StringValue(input.NatGatewayId))
+}
+
+func (f *fakeEC2) DescribeAddressesWithContext(aws.Context, *ec2.DescribeAddressesInput, ...request.Option) (*ec2.DescribeAddressesOutput, error) {
+	if err := f.call("DescribeAddresses"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	out := &ec2.DescribeAddressesOutput{}
+	for _, res := range f.list(f.region, awsElasticIPs) {
+		out.Addresses = append(out.Addresses, &ec2.Address{
+			AllocationId:  aws.String(res.id),
+			AssociationId: aws.String(res.associationID),
+			Tags:          fakeEC2Tags(res.tags),
+		})
+	}
+	return out, nil
+}
+
+func (f *fakeEC2) DisassociateAddressWithContext(_ aws.Context, input *ec2.DisassociateAddressInput, _ ...request.Option) (*ec2.DisassociateAddressOutput, error) {
+	if err := f.call("DisassociateAddress"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	f.deleted = append(f.deleted, fmt.Sprintf("%s/address association/%s", f.region, aws.StringValue(input.AssociationId)))
+	return &ec2.DisassociateAddressOutput{}, nil
+}
+
+func (f *fakeEC2) ReleaseAddressWithContext(_ aws.Context, input *ec2.ReleaseAddressInput, _ ...request.Option) (*ec2.ReleaseAddressOutput, error) {
+	if err := f.call("ReleaseAddress"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	return &ec2.ReleaseAddressOutput{}, f.delete(f.region, awsElasticIPs, aws.StringValue(input.AllocationId))
+}
+
+func (f *fakeEC2) DescribeVolumesPagesWithContext(_ aws.Context, _ *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool, _ ...request.Option) error {
+	if err := f.call("DescribeVolumes"); err != nil {
+		return err
+	}
+	defer f.mu.Unlock()
+
+	out := &ec2.DescribeVolumesOutput{}
+	for _, res := range f.list(f.region, awsVolumes) {
+		out.Volumes = append(out.Volumes, &ec2.Volume{VolumeId: aws.String(res.id), Tags: fakeEC2Tags(res.tags)})
+	}
+	fn(out, true)
+	return nil
+}
+
+func (f *fakeEC2) DeleteVolumeWithContext(_ aws.Context, input *ec2.DeleteVolumeInput, _ ...request.Option) (*ec2.DeleteVolumeOutput, error) {
+	if err := f.call("DeleteVolume"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	return &ec2.DeleteVolumeOutput{}, f.delete(f.region, awsVolumes, aws.StringValue(input.VolumeId))
+}
+
+func (f *fakeEC2) DescribeVpcsPagesWithContext(_ aws.Context, _ *ec2.DescribeVpcsInput, fn func(*ec2.DescribeVpcsOutput, bool) bool, _ ...request.Option) error {
+	if err := f.call("DescribeVpcs"); err != nil {
+		return err
+	}
+	defer f.mu.Unlock()
+
+	out := &ec2.DescribeVpcsOutput{}
+	for _, res := range f.list(f.region, awsVPCs) {
+		out.Vpcs = append(out.Vpcs, &ec2.Vpc{VpcId: aws.String(res.id), Tags: fakeEC2Tags(res.tags)})
+	}
+	fn(out, true)
+	return nil
+}
+
+func (f *fakeEC2) DeleteVpcWithContext(_ aws.Context, input *ec2.DeleteVpcInput, _ ...request.Option) (*ec2.DeleteVpcOutput, error) {
+	if err := f.call("DeleteVpc"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	id := aws.StringValue(input.VpcId)
+	if len(f.vpcResources[id]) > 0 {
+		return nil, awserr.New("DependencyViolation", "fake error", nil)
+	}
+	return &ec2.DeleteVpcOutput{}, f.delete(f.region, awsVPCs, id)
+}
+
+func (f *fakeEC2) DescribeSubnetsWithContext(_ aws.Context, input *ec2.DescribeSubnetsInput, _ ...request.Option) (*ec2.DescribeSubnetsOutput, error) {
+	if err := f.call("DescribeSubnets"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	out := &ec2.DescribeSubnetsOutput{}
+	for _, id := range f.vpcResourceIDs(input.Filters, "subnet-") {
+		out.Subnets = append(out.Subnets, &ec2.Subnet{SubnetId: aws.String(id)})
+	}
+	return out, nil
+}
+
+func (f *fakeEC2) DeleteSubnetWithContext(_ aws.Context, input *ec2.DeleteSubnetInput, _ ...request.Option) (*ec2.DeleteSubnetOutput, error) {
+	if err := f.call("DeleteSubnet"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	return &ec2.DeleteSubnetOutput{}, f.deleteVPCResource(f.region, "subnet", aws.StringValue(input.SubnetId))
+}
+
+func (f *fakeEC2) DescribeInternetGatewaysWithContext(_ aws.Context, input *ec2.DescribeInternetGatewaysInput, _ ...request.Option) (*ec2.DescribeInternetGatewaysOutput, error) {
+	if err := f.call("DescribeInternetGateways"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	assert.Equal(f.t, "attachment.vpc-id", aws.StringValue(input.Filters[0].Name))
+	out := &ec2.DescribeInternetGatewaysOutput{}
+	for _, id := range f.vpcResourceIDs(input.Filters, "igw-") {
+		out.InternetGateways = append(out.InternetGateways, &ec2.InternetGateway{InternetGatewayId: aws.String(id)})
+	}
+	return out, nil
+}
+
+func (f *fakeEC2) DetachInternetGatewayWithContext(aws.Context, *ec2.DetachInternetGatewayInput, ...request.Option) (*ec2.DetachInternetGatewayOutput, error) {
+	if err := f.call("DetachInternetGateway"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	return &ec2.DetachInternetGatewayOutput{}, nil
+}
+
+func (f *fakeEC2) DeleteInternetGatewayWithContext(_ aws.Context, input *ec2.DeleteInternetGatewayInput, _ ...request.Option) (*ec2.DeleteInternetGatewayOutput, error) {
+	if err := f.call("DeleteInternetGateway"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	return &ec2.DeleteInternetGatewayOutput{}, f.deleteVPCResource(f.region, "internet gateway", aws.StringValue(input.InternetGatewayId))
+}
+
+func (f *fakeEC2) DescribeRouteTablesWithContext(_ aws.Context, input *ec2.DescribeRouteTablesInput, _ ...request.Option) (*ec2.DescribeRouteTablesOutput, error) {
+	if err := f.call("DescribeRouteTables"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	// the main route table is always listed and must not be deleted
+	out := &ec2.DescribeRouteTablesOutput{RouteTables: []*ec2.RouteTable{{
+		RouteTableId: aws.String("rtb-main"),
+		Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+	}}}
+	for _, id := range f.vpcResourceIDs(input.Filters, "rtb-") {
+		out.RouteTables = append(out.RouteTables, &ec2.RouteTable{RouteTableId: aws.String(id)})
+	}
+	return out, nil
+}
+
+func (f *fakeEC2) DeleteRouteTableWithContext(_ aws.Context, input *ec2.DeleteRouteTableInput, _ ...request.Option) (*ec2.DeleteRouteTableOutput, error) {
+	if err := f.call("DeleteRouteTable"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	return &ec2.DeleteRouteTableOutput{}, f.deleteVPCResource(f.region, "route table", aws.StringValue(input.RouteTableId))
+}
+
+func (f *fakeEC2) DescribeSecurityGroupsWithContext(_ aws.Context, input *ec2.DescribeSecurityGroupsInput, _ ...request.Option) (*ec2.DescribeSecurityGroupsOutput, error) {
+	if err := f.call("DescribeSecurityGroups"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	// the default security group is always listed and must not be deleted
+	out := &ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{{GroupId: aws.String("sg-default"), GroupName: aws.String("default")}}}
+	for _, id := range f.vpcResourceIDs(input.Filters, "sg-") {
+		out.SecurityGroups = append(out.SecurityGroups, &ec2.SecurityGroup{GroupId: aws.String(id), GroupName: aws.String("nodes")})
+	}
+	return out, nil
+}
+
+func (f *fakeEC2) DeleteSecurityGroupWithContext(_ aws.Context, input *ec2.DeleteSecurityGroupInput, _ ...request.Option) (*ec2.DeleteSecurityGroupOutput, error) {
+	if err := f.call("DeleteSecurityGroup"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	return &ec2.DeleteSecurityGroupOutput{}, f.deleteVPCResource(f.region, "security group", aws.StringValue(input.GroupId))
+}
+
+type fakeELB struct {
+	*fakeAWS
+	region string
+}
+
+func (f *fakeELB) DescribeLoadBalancersPagesWithContext(_ aws.Context, _ *elb.DescribeLoadBalancersInput, fn func(*elb.DescribeLoadBalancersOutput, bool) bool, _ ...request.Option) error {
+	if err := f.call("DescribeLoadBalancers"); err != nil {
+		return err
+	}
+	defer f.mu.Unlock()
+
+	out := &elb.DescribeLoadBalancersOutput{}
+	for _, res := range f.list(f.region, awsLoadBalancers) {
+		out.LoadBalancerDescriptions = append(out.LoadBalancerDescriptions, &elb.LoadBalancerDescription{LoadBalancerName: aws.String(res.id)})
+	}
+	fn(out, true)
+	return nil
+}
+
+func (f *fakeELB) DescribeTagsWithContext(_ aws.Context, input *elb.DescribeTagsInput, _ ...request.Option) (*elb.DescribeTagsOutput, error) {
+	if err := f.call("DescribeTags"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	assert.True(f.t, len(input.LoadBalancerNames) <= awsELBTagsMaxSize)
+	out := &elb.DescribeTagsOutput{}
+	for _, name := range input.LoadBalancerNames {
+		res := f.find(f.region, awsLoadBalancers, aws.StringValue(name))
+		if res == nil {
+			return nil, awserr.New(elb.ErrCodeAccessPointNotFoundException, "fake error", nil)
+		}
+		description := &elb.TagDescription{LoadBalancerName: name}
+		for key, value := range res.tags {
+			description.Tags = append(description.Tags, &elb.Tag{Key: aws.String(key), Value: aws.String(value)})
+		}
+		out.TagDescriptions = append(out.TagDescriptions, description)
+	}
+	return out, nil
+}
+
+func (f *fakeELB) DeleteLoadBalancerWithContext(_ aws.Context, input *elb.DeleteLoadBalancerInput, _ ...request.Option) (*elb.DeleteLoadBalancerOutput, error) {
+	if err := f.call("DeleteLoadBalancer"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	return &elb.DeleteLoadBalancerOutput{}, f.delete(f.region, awsLoadBalancers, aws.StringValue(input.LoadBalancerName))
+}
+
+type fakeELBV2 struct {
+	*fakeAWS
+	region string
+}
+
+func (f *fakeELBV2) DescribeLoadBalancersPagesWithContext(_ aws.Context, _ *elbv2.DescribeLoadBalancersInput, fn func(*elbv2.DescribeLoadBalancersOutput, bool) bool, _ ...request.Option) error {
+	if err := f.call("DescribeLoadBalancersV2"); err != nil {
+		return err
+	}
+	defer f.mu.Unlock()
+
+	out := &elbv2.DescribeLoadBalancersOutput{}
+	for _, res := range f.list(f.region, awsLoadBalancersV2) {
+		out.LoadBalancers = append(out.LoadBalancers, &elbv2.LoadBalancer{LoadBalancerArn: aws.String(res.id)})
+	}
+	fn(out, true)
+	return nil
+}
+
+func (f *fakeELBV2) DescribeTargetGroupsPagesWithContext(_ aws.Context, _ *elbv2.DescribeTargetGroupsInput, fn func(*elbv2.DescribeTargetGroupsOutput, bool) bool, _ ...request.Option) error {
+	if err := f.call("DescribeTargetGroups"); err != nil {
+		return err
+	}
+	defer f.mu.Unlock()
+
+	out := &elbv2.DescribeTargetGroupsOutput{}
+	for _, res := range f.list(f.region, awsTargetGroups) {
+		out.TargetGroups = append(out.TargetGroups, &elbv2.TargetGroup{TargetGroupArn: aws.String(res.id)})
+	}
+	fn(out, true)
+	return nil
+}
+
+func (f *fakeELBV2) DescribeTagsWithContext(_ aws.Context, input *elbv2.DescribeTagsInput, _ ...request.Option) (*elbv2.DescribeTagsOutput, error) {
+	if err := f.call("DescribeTagsV2"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	assert.True(f.t, len(input.ResourceArns) <= awsELBTagsMaxSize)
+	out := &elbv2.DescribeTagsOutput{}
+	for _, arn := range input.ResourceArns {
+		res := f.find(f.region, awsLoadBalancersV2, aws.StringValue(arn))
+		if res == nil {
+			res = f.find(f.region, awsTargetGroups, aws.StringValue(arn))
+		}
+		if res == nil {
+			return nil, awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "fake error", nil)
+		}
+		description := &elbv2.TagDescription{ResourceArn: arn}
+		for key, value := range res.tags {
+			description.Tags = append(description.Tags, &elbv2.Tag{Key: aws.String(key), Value: aws.String(value)})
+		}
+		out.TagDescriptions = append(out.TagDescriptions, description)
+	}
+	return out, nil
+}
+
+func (f *fakeELBV2) DeleteLoadBalancerWithContext(_ aws.Context, input *elbv2.DeleteLoadBalancerInput, _ ...request.Option) (*elbv2.DeleteLoadBalancerOutput, error) {
+	if err := f.call("DeleteLoadBalancerV2"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	return &elbv2.DeleteLoadBalancerOutput{}, f.delete(f.region, awsLoadBalancersV2, aws.StringValue(input.LoadBalancerArn))
+}
+
+func (f *fakeELBV2) DeleteTargetGroupWithContext(_ aws.Context, input *elbv2.DeleteTargetGroupInput, _ ...request.Option) (*elbv2.DeleteTargetGroupOutput, error) {
+	if err := f.call("DeleteTargetGroup"); err != nil {
+		return nil, err
+	}
+	defer f.mu.Unlock()
+
+	// the target group is in use until the load balancers which forward to it are deleted
+	for _, res := range f.list(f.region, awsLoadBalancersV2) {
+		if res.tags[testAWSShootTag] != "" {
+			return nil, awserr.New(elbv2.ErrCodeResourceInUseException, "fake error", nil)
+		}
+	}
+	return &elbv2.DeleteTargetGroupOutput{}, f.delete(f.region, awsTargetGroups, aws.StringValue(input.TargetGroupArn))
+}
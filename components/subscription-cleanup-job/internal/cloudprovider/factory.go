@@ -26,12 +26,16 @@ func (pf *providerFactory) New(hyperscalerType model.HyperscalerType, secretData
 	switch hyperscalerType {
 	case model.GCP:
 		{
-			return NewGCPeResourcesCleaner(secretData), nil
+			return NewGCPeResourcesCleaner(secretData)
 		}
 	case model.Azure:
 		{
 			return NewAzureResourcesCleaner(secretData)
 		}
+	case model.AWS:
+		{
+			return NewAWSResourcesCleaner(secretData)
+		}
 	default:
 		return nil, errors.New(fmt.Sprintf("unknown hyperscaler type"))
 	}
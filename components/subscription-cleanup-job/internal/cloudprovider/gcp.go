@@ -1,12 +1,151 @@
 package cloudprovider
 
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	gcpServiceAccountKey = "serviceaccount.json"
+	gcpComputeScope      = "https://www.googleapis.com/auth/compute"
+
+	// shootPrefix is the prefix of the technical ID of the Gardener shoot, which is used in the names,
+	// labels and network tags of the resources created for the shoot
+	shootPrefix = "shoot--"
+
+	// gcpDeleteTimeout limits the deletion of a single resource, including waiting for the deletion operation
+	gcpDeleteTimeout = 5 * time.Minute
+)
+
+// gcpResourceTypes are deleted in the given order, the resources which use other resources go first
+var gcpResourceTypes = []gcpResourceType{
+	gcpInstances,
+	gcpForwardingRules,
+	gcpTargetPools,
+	gcpFirewalls,
+	gcpAddresses,
+	gcpDisks,
+}
+
 type gcpResourceCleaner struct {
+	project       string
+	compute       computeAPI
+	deleteTimeout time.Duration
 }
 
-func NewGCPeResourcesCleaner(secretData map[string][]byte) ResourceCleaner {
-	return &gcpResourceCleaner{}
+func NewGCPeResourcesCleaner(secretData map[string][]byte) (ResourceCleaner, error) {
+	serviceAccount, exists := secretData[gcpServiceAccountKey]
+	if !exists {
+		return nil, errors.Errorf("%s not provided in the secret", gcpServiceAccountKey)
+	}
+
+	ctx := context.Background()
+	credentials, err := google.CredentialsFromJSON(ctx, serviceAccount, gcpComputeScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading the service account")
+	}
+	if credentials.ProjectID == "" {
+		return nil, errors.New("project_id not provided in the service account")
+	}
+
+	return &gcpResourceCleaner{
+		project:       credentials.ProjectID,
+		compute:       newGCPComputeClient(oauth2.NewClient(ctx, credentials.TokenSource), gcpComputeEndpoint),
+		deleteTimeout: gcpDeleteTimeout,
+	}, nil
 }
 
+// Do deletes the resources of the Gardener shoots left in the project. It returns an error if any resource is left
+// after the deletion, so the secret is not returned to the pool until the project is clean.
 func (rc gcpResourceCleaner) Do() error {
+	ctx := context.Background()
+	for _, resourceType := range gcpResourceTypes {
+		resources, err := rc.shootResources(ctx, resourceType)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources {
+			log.Infof("Deleting %s '%s' in %s", resourceType, resource.Name, resource.Scope)
+			err := rc.delete(ctx, resource)
+			if err != nil {
+				log.Errorf("failed to remove %s '%s': %s", resourceType, resource.Name, err.Error())
+			}
+		}
+	}
+
+	left := 0
+	for _, resourceType := range gcpResourceTypes {
+		resources, err := rc.shootResources(ctx, resourceType)
+		if err != nil {
+			return err
+		}
+		left += len(resources)
+	}
+	if left > 0 {
+		return errors.Errorf("%d resources of Gardener shoots are left in the project %s", left, rc.project)
+	}
+
 	return nil
 }
+
+// delete deletes the resource and waits for the deletion until the timeout, the resource which is still deleted
+// after the timeout is reported as left by the cleaner
+func (rc gcpResourceCleaner) delete(ctx context.Context, resource gcpResource) error {
+	ctx, cancel := context.WithTimeout(ctx, rc.deleteTimeout)
+	defer cancel()
+
+	return rc.compute.Delete(ctx, rc.project, resource)
+}
+
+func (rc gcpResourceCleaner) shootResources(ctx context.Context, resourceType gcpResourceType) ([]gcpResource, error) {
+	resources, err := rc.compute.List(ctx, rc.project, resourceType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while listing resources in the project %s", rc.project)
+	}
+
+	var shootResources []gcpResource
+	for _, resource := range resources {
+		if belongsToShoot(resource) {
+			shootResources = append(shootResources, resource)
+		}
+	}
+
+	return shootResources, nil
+}
+
+// belongsToShoot checks if the resource was created by Gardener for the shoot or by the cloud provider of the shoot cluster,
+// e.g. the load balancers and the volumes of the cluster, which have the Kubernetes metadata in the description
+func belongsToShoot(resource gcpResource) bool {
+	if strings.HasPrefix(resource.Name, shootPrefix) {
+		return true
+	}
+	if strings.Contains(resource.Description, "kubernetes.io/") {
+		return true
+	}
+	if strings.HasPrefix(lastSegment(resource.Network), shootPrefix) {
+		return true
+	}
+	for key := range resource.Labels {
+		if strings.HasPrefix(key, "kubernetes-io-cluster-"+shootPrefix) {
+			return true
+		}
+	}
+	for _, tag := range append(resource.Tags.Items, resource.TargetTags...) {
+		if strings.HasPrefix(tag, shootPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lastSegment(selfLink string) string {
+	return selfLink[strings.LastIndex(selfLink, "/")+1:]
+}
@@ -0,0 +1,232 @@
+package cloudprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const gcpComputeEndpoint = "https://compute.googleapis.com/compute/v1/"
+
+type gcpResourceType string
+
+const (
+	gcpInstances       gcpResourceType = "instances"
+	gcpForwardingRules gcpResourceType = "forwardingRules"
+	gcpTargetPools     gcpResourceType = "targetPools"
+	gcpFirewalls       gcpResourceType = "firewalls"
+	gcpAddresses       gcpResourceType = "addresses"
+	gcpDisks           gcpResourceType = "disks"
+)
+
+// global resources are not listed per zone or region
+var gcpGlobalResourceTypes = map[gcpResourceType]bool{
+	gcpFirewalls: true,
+}
+
+type gcpResource struct {
+	Type gcpResourceType
+	// Scope is the path of the zone or the region of the resource, e.g. zones/europe-west4-a, or global
+	Scope string
+
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Network     string            `json:"network"`
+	Labels      map[string]string `json:"labels"`
+	Tags        struct {
+		Items []string `json:"items"`
+	} `json:"tags"`
+	TargetTags []string `json:"targetTags"`
+}
+
+// computeAPI is the part of the GCP Compute Engine API used by the cleaner
+type computeAPI interface {
+	List(ctx context.Context, project string, resourceType gcpResourceType) ([]gcpResource, error)
+	// Delete deletes the resource and waits until the deletion is finished
+	Delete(ctx context.Context, project string, resource gcpResource) error
+}
+
+type gcpComputeClient struct {
+	httpClient *http.Client
+	endpoint   string
+}
+
+func newGCPComputeClient(httpClient *http.Client, endpoint string) *gcpComputeClient {
+	return &gcpComputeClient{
+		httpClient: httpClient,
+		endpoint:   strings.TrimSuffix(endpoint, "/") + "/",
+	}
+}
+
+type gcpAggregatedList struct {
+	Items         map[string]map[string]json.RawMessage `json:"items"`
+	NextPageToken string                                `json:"nextPageToken"`
+}
+
+type gcpList struct {
+	Items         []gcpResource `json:"items"`
+	NextPageToken string        `json:"nextPageToken"`
+}
+
+type gcpOperation struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  *struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"error"`
+}
+
+func (c *gcpComputeClient) List(ctx context.Context, project string, resourceType gcpResourceType) ([]gcpResource, error) {
+	if gcpGlobalResourceTypes[resourceType] {
+		return c.listGlobal(ctx, project, resourceType)
+	}
+
+	var resources []gcpResource
+	pageToken := ""
+	for {
+		var page gcpAggregatedList
+		err := c.do(ctx, http.MethodGet, withPageToken(fmt.Sprintf("projects/%s/aggregated/%s", project, resourceType), pageToken), &page)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while listing %s", resourceType)
+		}
+
+		scopes := make([]string, 0, len(page.Items))
+		for scope := range page.Items {
+			scopes = append(scopes, scope)
+		}
+		sort.Strings(scopes)
+		for _, scope := range scopes {
+			raw, found := page.Items[scope][string(resourceType)]
+			if !found {
+				continue
+			}
+			var items []gcpResource
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return nil, errors.Wrapf(err, "while decoding %s in %s", resourceType, scope)
+			}
+			for _, item := range items {
+				item.Type = resourceType
+				item.Scope = scope
+				resources = append(resources, item)
+			}
+		}
+
+		if page.NextPageToken == "" {
+			return resources, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+func (c *gcpComputeClient) listGlobal(ctx context.Context, project string, resourceType gcpResourceType) ([]gcpResource, error) {
+	var resources []gcpResource
+	pageToken := ""
+	for {
+		var page gcpList
+		err := c.do(ctx, http.MethodGet, withPageToken(fmt.Sprintf("projects/%s/global/%s", project, resourceType), pageToken), &page)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while listing %s", resourceType)
+		}
+
+		for _, item := range page.Items {
+			item.Type = resourceType
+			item.Scope = "global"
+			resources = append(resources, item)
+		}
+
+		if page.NextPageToken == "" {
+			return resources, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+func (c *gcpComputeClient) Delete(ctx context.Context, project string, resource gcpResource) error {
+	var operation gcpOperation
+	err := c.do(ctx, http.MethodDelete, fmt.Sprintf("projects/%s/%s/%s/%s", project, resource.Scope, resource.Type, resource.Name), &operation)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// the wait call returns when the operation is done or after about 2 minutes
+	for operation.Status != "DONE" {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		err = c.do(ctx, http.MethodPost, fmt.Sprintf("projects/%s/%s/operations/%s/wait", project, resource.Scope, operation.Name), &operation)
+		if err != nil {
+			return errors.Wrapf(err, "while waiting for the operation %s", operation.Name)
+		}
+	}
+
+	if operation.Error != nil && len(operation.Error.Errors) > 0 {
+		messages := make([]string, 0, len(operation.Error.Errors))
+		for _, e := range operation.Error.Errors {
+			messages = append(messages, fmt.Sprintf("%s: %s", e.Code, e.Message))
+		}
+		return errors.Errorf("operation %s failed: %s", operation.Name, strings.Join(messages, ", "))
+	}
+
+	return nil
+}
+
+type gcpStatusError struct {
+	statusCode int
+	body       string
+}
+
+func (e gcpStatusError) Error() string {
+	return fmt.Sprintf("got unexpected status code %d: %s", e.statusCode, e.body)
+}
+
+func isNotFound(err error) bool {
+	statusErr, ok := err.(gcpStatusError)
+	return ok && statusErr.statusCode == http.StatusNotFound
+}
+
+func (c *gcpComputeClient) do(ctx context.Context, method, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, nil)
+	if err != nil {
+		return errors.Wrap(err, "while creating request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "while calling %s %s", method, path)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "while reading response body")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return gcpStatusError{statusCode: resp.StatusCode, body: string(body)}
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return errors.Wrap(err, "while decoding response body")
+	}
+
+	return nil
+}
+
+func withPageToken(path, pageToken string) string {
+	if pageToken == "" {
+		return path
+	}
+	return path + "?pageToken=" + url.QueryEscape(pageToken)
+}
@@ -0,0 +1,235 @@
+package cloudprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProject = "test-project"
+
+func TestGCPResourceCleaner_Do(t *testing.T) {
+	t.Run("should delete resources of Gardener shoots", func(t *testing.T) {
+		// given
+		fake := newFakeGCPCompute(t)
+		fake.add(gcpInstances, "zones/europe-west4-a", `{"name": "shoot--kyma--c1-worker-z1-abc", "tags": {"items": ["shoot--kyma--c1"]}}`)
+		fake.add(gcpInstances, "zones/europe-west4-a", `{"name": "bastion"}`)
+		fake.add(gcpForwardingRules, "regions/europe-west4", `{"name": "a1b2c3", "description": "{\"kubernetes.io/service-name\":\"istio-system/istio-ingressgateway\"}"}`)
+		fake.add(gcpTargetPools, "regions/europe-west4", `{"name": "a1b2c3", "description": "{\"kubernetes.io/service-name\":\"istio-system/istio-ingressgateway\"}"}`)
+		fake.add(gcpFirewalls, "global", `{"name": "k8s-fw-a1b2c3", "network": "https://www.googleapis.com/compute/v1/projects/test-project/global/networks/shoot--kyma--c1", "targetTags": ["shoot--kyma--c1"]}`)
+		fake.add(gcpFirewalls, "global", `{"name": "default-allow-ssh", "network": "https://www.googleapis.com/compute/v1/projects/test-project/global/networks/default"}`)
+		fake.add(gcpAddresses, "regions/europe-west4", `{"name": "shoot--kyma--c1-nat"}`)
+		fake.add(gcpDisks, "zones/europe-west4-a", `{"name": "pv-123", "labels": {"kubernetes-io-cluster-shoot--kyma--c1": "1"}}`)
+		fake.add(gcpDisks, "zones/europe-west4-b", `{"name": "pv-456", "description": "{\"kubernetes.io/created-for/pv/name\":\"pv-456\"}"}`)
+		fake.add(gcpDisks, "zones/europe-west4-b", `{"name": "backup"}`)
+		cleaner := fake.cleaner(gcpDeleteTimeout)
+
+		// when
+		err := cleaner.Do()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"zones/europe-west4-a/instances/shoot--kyma--c1-worker-z1-abc",
+			"regions/europe-west4/forwardingRules/a1b2c3",
+			"regions/europe-west4/targetPools/a1b2c3",
+			"global/firewalls/k8s-fw-a1b2c3",
+			"regions/europe-west4/addresses/shoot--kyma--c1-nat",
+			"zones/europe-west4-a/disks/pv-123",
+			"zones/europe-west4-b/disks/pv-456",
+		}, fake.deleted)
+		assert.ElementsMatch(t, []string{
+			"zones/europe-west4-a/instances/bastion",
+			"global/firewalls/default-allow-ssh",
+			"zones/europe-west4-b/disks/backup",
+		}, fake.left())
+	})
+
+	t.Run("should return error when resources are left", func(t *testing.T) {
+		// given
+		fake := newFakeGCPCompute(t)
+		fake.add(gcpDisks, "zones/europe-west4-a", `{"name": "shoot--kyma--c1-disk"}`)
+		fake.add(gcpAddresses, "regions/europe-west4", `{"name": "shoot--kyma--c1-nat"}`)
+		fake.failDeletion("zones/europe-west4-a/disks/shoot--kyma--c1-disk")
+		cleaner := fake.cleaner(gcpDeleteTimeout)
+
+		// when
+		err := cleaner.Do()
+
+		// then
+		require.EqualError(t, err, "1 resources of Gardener shoots are left in the project test-project")
+		assert.Equal(t, []string{"regions/europe-west4/addresses/shoot--kyma--c1-nat"}, fake.deleted)
+	})
+
+	t.Run("should stop waiting for the deletion after the timeout", func(t *testing.T) {
+		// given
+		fake := newFakeGCPCompute(t)
+		fake.add(gcpDisks, "zones/europe-west4-a", `{"name": "shoot--kyma--c1-disk"}`)
+		fake.add(gcpAddresses, "regions/europe-west4", `{"name": "shoot--kyma--c1-nat"}`)
+		fake.blockDeletion("regions/europe-west4/addresses/shoot--kyma--c1-nat")
+		cleaner := fake.cleaner(100 * time.Millisecond)
+
+		// when
+		err := cleaner.Do()
+
+		// then
+		require.EqualError(t, err, "1 resources of Gardener shoots are left in the project test-project")
+		assert.Equal(t, []string{"zones/europe-west4-a/disks/shoot--kyma--c1-disk"}, fake.deleted)
+	})
+}
+
+func TestNewGCPeResourcesCleaner(t *testing.T) {
+	t.Run("should read the project from the service account", func(t *testing.T) {
+		// given
+		serviceAccount := `{"type": "service_account", "project_id": "test-project", "client_email": "sa@test-project.iam.gserviceaccount.com", "private_key": "key"}`
+
+		// when
+		cleaner, err := NewGCPeResourcesCleaner(map[string][]byte{"serviceaccount.json": []byte(serviceAccount)})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "test-project", cleaner.(*gcpResourceCleaner).project)
+	})
+
+	t.Run("should return error when the service account is missing", func(t *testing.T) {
+		// when
+		_, err := NewGCPeResourcesCleaner(map[string][]byte{})
+
+		// then
+		require.EqualError(t, err, "serviceaccount.json not provided in the secret")
+	})
+}
+
+// fakeGCPCompute serves the subset of the Compute Engine API used by the cleaner, the deletion operations
+// are reported as running, so the cleaner must wait for them
+type fakeGCPCompute struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu        sync.Mutex
+	resources map[string]json.RawMessage
+	failing   map[string]bool
+	blocked   map[string]bool
+	deleted   []string
+}
+
+func newFakeGCPCompute(t *testing.T) *fakeGCPCompute {
+	fake := &fakeGCPCompute{
+		t:         t,
+		resources: map[string]json.RawMessage{},
+		failing:   map[string]bool{},
+		blocked:   map[string]bool{},
+	}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.handle))
+	t.Cleanup(fake.server.Close)
+
+	return fake
+}
+
+func (f *fakeGCPCompute) add(resourceType gcpResourceType, scope, resource string) {
+	var r struct {
+		Name string `json:"name"`
+	}
+	require.NoError(f.t, json.Unmarshal([]byte(resource), &r))
+	f.resources[fmt.Sprintf("%s/%s/%s", scope, resourceType, r.Name)] = json.RawMessage(resource)
+}
+
+func (f *fakeGCPCompute) failDeletion(path string) {
+	f.failing[path] = true
+}
+
+// blockDeletion makes the deletion operation of the resource run forever
+func (f *fakeGCPCompute) blockDeletion(path string) {
+	f.blocked[path] = true
+}
+
+func (f *fakeGCPCompute) cleaner(deleteTimeout time.Duration) gcpResourceCleaner {
+	return gcpResourceCleaner{
+		project:       testProject,
+		compute:       newGCPComputeClient(f.server.Client(), f.server.URL),
+		deleteTimeout: deleteTimeout,
+	}
+}
+
+func (f *fakeGCPCompute) left() []string {
+	var left []string
+	for path := range f.resources {
+		left = append(left, path)
+	}
+	return left
+}
+
+func (f *fakeGCPCompute) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	prefix := fmt.Sprintf("/projects/%s/", testProject)
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, prefix)
+	segments := strings.Split(path, "/")
+
+	switch {
+	case r.Method == http.MethodGet && segments[0] == "aggregated":
+		items := map[string]map[string][]json.RawMessage{}
+		for resourcePath, resource := range f.resources {
+			parts := strings.Split(resourcePath, "/")
+			if len(parts) != 4 || parts[2] != segments[1] {
+				continue
+			}
+			scope := parts[0] + "/" + parts[1]
+			if items[scope] == nil {
+				items[scope] = map[string][]json.RawMessage{}
+			}
+			items[scope][segments[1]] = append(items[scope][segments[1]], resource)
+		}
+		f.write(w, map[string]interface{}{"items": items})
+	case r.Method == http.MethodGet && segments[0] == "global":
+		items := []json.RawMessage{}
+		for resourcePath, resource := range f.resources {
+			if strings.HasPrefix(resourcePath, "global/"+segments[1]+"/") {
+				items = append(items, resource)
+			}
+		}
+		f.write(w, map[string]interface{}{"items": items})
+	case r.Method == http.MethodDelete:
+		if _, found := f.resources[path]; !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if f.failing[path] {
+			f.write(w, map[string]interface{}{"name": "op-" + path, "status": "DONE", "error": map[string]interface{}{
+				"errors": []map[string]string{{"code": "RESOURCE_IN_USE_BY_ANOTHER_RESOURCE", "message": "in use"}},
+			}})
+			return
+		}
+		if f.blocked[path] {
+			f.write(w, map[string]string{"name": "blocked", "status": "RUNNING"})
+			return
+		}
+		delete(f.resources, path)
+		f.deleted = append(f.deleted, path)
+		f.write(w, map[string]string{"name": "op", "status": "RUNNING"})
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/operations/op/wait"):
+		f.write(w, map[string]string{"name": "op", "status": "DONE"})
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/operations/blocked/wait"):
+		f.write(w, map[string]string{"name": "blocked", "status": "RUNNING"})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeGCPCompute) write(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(f.t, json.NewEncoder(w).Encode(body))
+}
@@ -4,9 +4,8 @@ go 1.14
 
 require (
 	github.com/int128/kubelogin v1.22.0
-	github.com/kyma-project/control-plane v0.0.0-20201124071301-7535a2baeef2
 	github.com/kyma-project/control-plane/components/kubeconfig-service v0.0.0-20201124071301-7535a2baeef2
-	github.com/kyma-project/control-plane/components/provisioner v0.0.0-20201124071301-7535a2baeef2 // indirect
+	github.com/kyma-project/control-plane/components/provisioner v0.0.0-20201124071301-7535a2baeef2
 	github.com/liggitt/tabwriter v0.0.0-20181228230101-89fcab3d43de
 	github.com/pkg/errors v0.9.1
 	github.com/spf13/cobra v1.1.1
@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/keb/orchestration"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/keb/runtime"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -28,6 +29,7 @@ const (
 	runtimeIDTarget  = "runtime-id"
 	regionTarget     = "region"
 	planTarget       = "plan"
+	labelTarget      = "label"
 )
 
 const (
@@ -148,7 +150,8 @@ A target specifier is a comma-separated list of the following selectors:
   subaccount={REGEXP} : Regex pattern to match against the Runtime's subaccount field, e.g. "0d20e315-d0b4-48a2-9512-49bc8eb03cd1"
   region={REGEXP}     : Regex pattern to match against the Runtime's provider region field, e.g. "europe|eu-"
   runtime-id={ID}     : Specific Runtime by Runtime ID
-  plan={NAME}         : Name of the Runtime's service plan. The possible values are: azure, azure_lite, trial, gcp`)
+  plan={NAME}         : Name of the Runtime's service plan. The possible values are: azure, azure_lite, trial, gcp
+  label={KEY}={VALUE} : Label of the Runtime set with the kcp runtimes label command. If specified multiple times, the Runtime must have all of the labels`)
 	cmd.Flags().StringArrayVarP(targetExcludeInputs, "target-exclude", "e", nil,
 		`List of Runtime target specifiers to exclude. You can specify this option multiple times.
 A target specifier is a comma-separated list of the selectors described under the --target option.`)
@@ -185,7 +188,7 @@ func parseRuntimeTarget(targetInput string, targets *[]orchestration.RuntimeTarg
 	}
 
 	for _, selector := range selectors {
-		sv := strings.Split(selector, "=")
+		sv := strings.SplitN(selector, "=", 2)
 		selectorKey := sv[0]
 		var selectorValue string
 		if len(sv) > 1 {
@@ -212,6 +215,15 @@ func parseRuntimeTarget(targetInput string, targets *[]orchestration.RuntimeTarg
 			target.Region = selectorValue
 		case runtimeIDTarget:
 			target.RuntimeID = selectorValue
+		case labelTarget:
+			key, value, err := runtime.ParseLabel(selectorValue)
+			if err != nil {
+				return fmt.Errorf("invalid value for selector: %s %s=%s, expected %s={KEY}={VALUE}", flagName, selectorKey, selectorValue, labelTarget)
+			}
+			if target.Labels == nil {
+				target.Labels = map[string]string{}
+			}
+			target.Labels[key] = value
 		case planTarget:
 			switch selectorValue {
 			case azurePlan, azureLitePlan, trialPlan, gcpPlan:
@@ -9,7 +9,8 @@ import (
 
 	"github.com/pkg/errors"
 
-	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/keb/orchestration"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/keb/runtime"
 	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
 	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
 	"github.com/spf13/cobra"
@@ -23,6 +24,7 @@ type OrchestrationCommand struct {
 	output     string
 	states     []string
 	operation  string
+	watch      bool
 	listParams orchestration.ListParameters
 }
 
@@ -130,10 +132,12 @@ func NewOrchestrationCmd(log logger.Logger) *cobra.Command {
 The command has two modes:
   - Without specifying an orchestration ID as an argument. In this mode, the command lists all orchestrations, or orchestrations matching the --state option, if provided.
   - When specifying an orchestration ID as an argument. In this mode, the command displays details about the specific orchestration.
-     If the optional --operation flag is provided, it displays details of the specified Runtime operation within the orchestration.`,
+     If the optional --operation flag is provided, it displays details of the specified Runtime operation within the orchestration.
+     If the optional --watch flag is provided, it keeps running and displays the state changes of the Runtime operations within the orchestration.`,
 		Example: `  kcp orchestrations --state inprogress                                   Display all orchestrations which are in progress.
   kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00                  Display details about a specific orchestration.
-  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 --operation OID  Display details of the specified Runtime operation within the orchestration.`,
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 --operation OID  Display details of the specified Runtime operation within the orchestration.
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 --watch          Display details about a specific orchestration and follow the changes of its Runtime operations.`,
 		Args:    cobra.MaximumNArgs(1),
 		PreRunE: func(_ *cobra.Command, args []string) error { return cmd.Validate(args) },
 		RunE:    func(_ *cobra.Command, args []string) error { return cmd.Run(args) },
@@ -143,6 +147,7 @@ The command has two modes:
 	SetOutputOpt(cobraCmd, &cmd.output)
 	cobraCmd.Flags().StringSliceVarP(&cmd.states, "state", "s", nil, fmt.Sprintf("Filter output by state. You can provide multiple values, either separated by a comma (e.g. failed,inprogress), or by specifying the option multiple times. The possible values are: %s.", strings.Join(cliOrchestrationStates(), ", ")))
 	cobraCmd.Flags().StringVar(&cmd.operation, "operation", "", "Option that displays details of the specified Runtime operation when a given orchestration is selected.")
+	cobraCmd.Flags().BoolVarP(&cmd.watch, "watch", "w", false, "Option that keeps the command running after displaying the orchestration, and displays the state changes of its Runtime operations as they happen.")
 	return cobraCmd
 }
 
@@ -174,7 +179,12 @@ func (cmd *OrchestrationCommand) Run(args []string) error {
 	if len(args) == 0 {
 		return cmd.showOrchestrations()
 	} else if cmd.operation == "" {
-		return cmd.showOneOrchestration(args[0])
+		err := cmd.showOneOrchestration(args[0])
+		if err != nil || !cmd.watch {
+			return err
+		}
+		rtClient := runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+		return watchOperations(cmd.cobraCmd.Context(), rtClient, runtime.WatchParameters{OrchestrationIDs: []string{args[0]}}, cmd.output, nil)
 	} else {
 		return cmd.showOperationDetails(args[0])
 	}
@@ -198,6 +208,9 @@ func (cmd *OrchestrationCommand) Validate(args []string) error {
 	if cmd.operation != "" && len(cmd.states) > 0 {
 		return errors.New("--state should not be used together with --operation")
 	}
+	if cmd.watch && (len(args) == 0 || cmd.operation != "") {
+		return errors.New("--watch should only be used when orchestration id is given as an argument, without --operation")
+	}
 
 	return nil
 }
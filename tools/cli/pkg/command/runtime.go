@@ -1,7 +1,9 @@
 package command
 
 import (
-	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"fmt"
+
+	"github.com/kyma-project/control-plane/tools/cli/pkg/keb/runtime"
 	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
 	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
 	"github.com/pkg/errors"
@@ -13,6 +15,9 @@ type RuntimeCommand struct {
 	cobraCmd *cobra.Command
 	log      logger.Logger
 	output   string
+	sort     string
+	labels   []string
+	watch    bool
 	params   runtime.ListParameters
 }
 
@@ -60,19 +65,23 @@ func NewRuntimeCmd(log logger.Logger) *cobra.Command {
 The command supports filtering Runtimes based on various attributes. See the list of options for more details.`,
 		Example: `  kcp runtimes                                           Display table overview about all Runtimes.
   kcp rt -c c-178e034 -o json                            Display all details about one Runtime identified by a Shoot name in the JSON format.
-  kcp runtimes --account CA4836781TID000000000123456789  Display all Runtimes of a given global account.`,
+  kcp runtimes --account CA4836781TID000000000123456789  Display all Runtimes of a given global account.
+  kcp runtimes --plan azure_lite --state failed --operation-type provision  Display all azure_lite Runtimes whose provisioning failed.
+  kcp runtimes --kyma-version 1.17.0 --sort -created_at  Display all Runtimes with Kyma 1.17.0, the newest first.
+  kcp runtimes --label customer-pilot=true               Display all Runtimes labeled as customer pilots.
+  kcp runtimes --account CA4836781TID000000000123456789 --watch  Display all Runtimes of a given global account and follow the changes of their operations.`,
 		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
 		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
 	}
 	cmd.cobraCmd = cobraCmd
 
 	SetOutputOpt(cobraCmd, &cmd.output)
-	cobraCmd.Flags().StringSliceVarP(&cmd.params.Shoots, "shoot", "c", nil, "Filter by Shoot cluster name. You can provide multiple values, either separated by a comma (e.g. shoot1,shoot2), or by specifying the option multiple times.")
-	cobraCmd.Flags().StringSliceVarP(&cmd.params.GlobalAccountIDs, "account", "g", nil, "Filter by global account ID. You can provide multiple values, either separated by a comma (e.g. GAID1,GAID2), or by specifying the option multiple times.")
-	cobraCmd.Flags().StringSliceVarP(&cmd.params.SubAccountIDs, "subaccount", "s", nil, "Filter by subaccount ID. You can provide multiple values, either separated by a comma (e.g. SAID1,SAID2), or by specifying the option multiple times.")
-	cobraCmd.Flags().StringSliceVarP(&cmd.params.RuntimeIDs, "runtime-id", "i", nil, "Filter by Runtime ID. You can provide multiple values, either separated by a comma (e.g. ID1,ID2), or by specifying the option multiple times.")
-	cobraCmd.Flags().StringSliceVarP(&cmd.params.Regions, "region", "r", nil, "Filter by provider region. You can provide multiple values, either separated by a comma (e.g. westeurope,northeurope), or by specifying the option multiple times.")
+	SetRuntimeFilterOpts(cobraCmd, &cmd.params, &cmd.labels, &cmd.sort)
+	cobraCmd.Flags().BoolVarP(&cmd.watch, "watch", "w", false, "Option that keeps the command running after displaying the Runtimes, and displays the state changes of their operations as they happen.")
 
+	cobraCmd.AddCommand(NewRuntimeHistoryCmd(log))
+	cobraCmd.AddCommand(NewRuntimeLabelCmd(log))
+	cobraCmd.AddCommand(NewRuntimeExportCmd(log))
 	return cobraCmd
 }
 
@@ -89,15 +98,79 @@ func (cmd *RuntimeCommand) Run() error {
 		return errors.Wrap(err, "while printing runtimes")
 	}
 
+	if cmd.watch {
+		return watchOperations(cmd.cobraCmd.Context(), client, runtime.WatchParameters{
+			GlobalAccountIDs: cmd.params.GlobalAccountIDs,
+			RuntimeIDs:       cmd.params.RuntimeIDs,
+		}, cmd.output, cmd.listedRuntimesFilter(rp))
+	}
+
 	return nil
 }
 
+// listedRuntimesFilter returns the filter of the events of the listed runtimes, if the runtimes were listed
+// with the options which the operation events stream does not support. Otherwise it returns nil.
+func (cmd *RuntimeCommand) listedRuntimesFilter(runtimes runtime.RuntimesPage) func(runtime.OperationEvent) bool {
+	p := cmd.params
+	if len(p.SubAccountIDs)+len(p.InstanceIDs)+len(p.Regions)+len(p.Shoots)+len(p.Plans)+len(p.States)+len(p.OperationTypes)+len(p.KymaVersions)+len(p.Labels) == 0 {
+		return nil
+	}
+
+	instanceIDs := make(map[string]struct{}, len(runtimes.Data))
+	for _, rt := range runtimes.Data {
+		instanceIDs[rt.InstanceID] = struct{}{}
+	}
+	return func(ev runtime.OperationEvent) bool {
+		_, found := instanceIDs[ev.InstanceID]
+		return found
+	}
+}
+
 // Validate checks the input parameters of the runtimes command
 func (cmd *RuntimeCommand) Validate() error {
 	err := ValidateOutputOpt(cmd.output)
 	if err != nil {
 		return err
 	}
+
+	return ValidateRuntimeFilterOpts(&cmd.params, cmd.labels, cmd.sort)
+}
+
+// SetRuntimeFilterOpts configures the options filtering and sorting the Runtimes on the given command
+func SetRuntimeFilterOpts(cmd *cobra.Command, params *runtime.ListParameters, labels *[]string, sort *string) {
+	cmd.Flags().StringSliceVarP(&params.Shoots, "shoot", "c", nil, "Filter by Shoot cluster name. You can provide multiple values, either separated by a comma (e.g. shoot1,shoot2), or by specifying the option multiple times.")
+	cmd.Flags().StringSliceVarP(&params.GlobalAccountIDs, "account", "g", nil, "Filter by global account ID. You can provide multiple values, either separated by a comma (e.g. GAID1,GAID2), or by specifying the option multiple times.")
+	cmd.Flags().StringSliceVarP(&params.SubAccountIDs, "subaccount", "s", nil, "Filter by subaccount ID. You can provide multiple values, either separated by a comma (e.g. SAID1,SAID2), or by specifying the option multiple times.")
+	cmd.Flags().StringSliceVarP(&params.RuntimeIDs, "runtime-id", "i", nil, "Filter by Runtime ID. You can provide multiple values, either separated by a comma (e.g. ID1,ID2), or by specifying the option multiple times.")
+	cmd.Flags().StringSliceVarP(&params.Regions, "region", "r", nil, "Filter by provider region. You can provide multiple values, either separated by a comma (e.g. westeurope,northeurope), or by specifying the option multiple times.")
+	cmd.Flags().StringSliceVar(&params.Plans, "plan", nil, "Filter by service plan name. You can provide multiple values, either separated by a comma (e.g. azure,azure_lite), or by specifying the option multiple times.")
+	cmd.Flags().StringSliceVar(&params.States, "state", nil, "Filter by the state of the last Runtime operation. The possible values are: succeeded, failed, \"in progress\". You can provide multiple values, either separated by a comma, or by specifying the option multiple times.")
+	cmd.Flags().StringSliceVar(&params.OperationTypes, "operation-type", nil, fmt.Sprintf("Filter by the type of the last Runtime operation. The possible values are: %s, %s, %s. You can provide multiple values, either separated by a comma, or by specifying the option multiple times.", runtime.OperationTypeProvision, runtime.OperationTypeDeprovision, runtime.OperationTypeUpgradeKyma))
+	cmd.Flags().StringSliceVar(&params.KymaVersions, "kyma-version", nil, "Filter by Kyma version of the last succeeded provisioning or upgrade. You can provide multiple values, either separated by a comma (e.g. 1.16.0,1.17.0), or by specifying the option multiple times.")
+	cmd.Flags().StringSliceVar(labels, "label", nil, "Filter by label in the KEY=VALUE format. You can provide multiple labels, either separated by a comma (e.g. tier=gold,customer-pilot=true), or by specifying the option multiple times. The Runtimes must have all of the given labels.")
+	cmd.Flags().StringVar(sort, "sort", "", fmt.Sprintf("Sort Runtimes by the given attribute. The possible values are: %s, %s, %s, %s. The \"-\" prefix reverses the order. Defaults to %s.", runtime.SortByCreatedAt, runtime.SortByCreatedAtDesc, runtime.SortByModifiedAt, runtime.SortByModifiedAtDesc, runtime.SortByCreatedAt))
+}
+
+// ValidateRuntimeFilterOpts checks the sort and label options, and sets them in the params
+func ValidateRuntimeFilterOpts(params *runtime.ListParameters, labels []string, sort string) error {
+	switch sortField := runtime.SortField(sort); sortField {
+	case "", runtime.SortByCreatedAt, runtime.SortByCreatedAtDesc, runtime.SortByModifiedAt, runtime.SortByModifiedAtDesc:
+		params.Sort = sortField
+	default:
+		return fmt.Errorf("invalid value for sort: %s", sort)
+	}
+
+	for _, label := range labels {
+		key, value, err := runtime.ParseLabel(label)
+		if err != nil {
+			return errors.Wrap(err, "invalid value for label")
+		}
+		if params.Labels == nil {
+			params.Labels = map[string]string{}
+		}
+		params.Labels[key] = value
+	}
+
 	return nil
 }
 
@@ -0,0 +1,79 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kyma-project/control-plane/tools/cli/pkg/keb/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RuntimeExportCommand represents an execution of the kcp runtimes export command
+type RuntimeExportCommand struct {
+	cobraCmd *cobra.Command
+	log      logger.Logger
+	format   string
+	file     string
+	sort     string
+	labels   []string
+	params   runtime.ListParameters
+}
+
+// NewRuntimeExportCmd constructs a new instance of RuntimeExportCommand and configures it in terms of a cobra.Command
+func NewRuntimeExportCmd(log logger.Logger) *cobra.Command {
+	cmd := RuntimeExportCommand{log: log}
+	cobraCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports Kyma Runtimes for reporting.",
+		Long: `Exports Kyma Runtimes with their plan, region, Kyma version, creation time, and last operation in the CSV or NDJSON format.
+The export is streamed from Kyma Environment Broker, so it is suitable for the whole fleet. The command supports the same filters as the kcp runtimes command.`,
+		Example: `  kcp runtimes export                                        Export all Runtimes in the CSV format to the standard output.
+  kcp runtimes export --plan azure --file azure-runtimes.csv  Export all azure Runtimes to the given file.
+  kcp runtimes export --format ndjson --state failed         Export all Runtimes whose last operation failed, one JSON object per line.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	cobraCmd.Flags().StringVar(&cmd.format, "format", string(runtime.ExportFormatCSV), fmt.Sprintf("Format of the export. The possible values are: %s, %s.", runtime.ExportFormatCSV, runtime.ExportFormatNDJSON))
+	cobraCmd.Flags().StringVarP(&cmd.file, "file", "f", "", "Path to the file to write the export to. Defaults to the standard output.")
+	SetRuntimeFilterOpts(cobraCmd, &cmd.params, &cmd.labels, &cmd.sort)
+
+	return cobraCmd
+}
+
+// Run executes the runtimes export command
+func (cmd *RuntimeExportCommand) Run() error {
+	client := runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+
+	var out io.Writer = os.Stdout
+	if cmd.file != "" {
+		f, err := os.Create(cmd.file)
+		if err != nil {
+			return errors.Wrap(err, "while creating export file")
+		}
+		defer f.Close()
+		out = f
+	}
+
+	err := client.ExportRuntimes(cmd.params, runtime.ExportFormat(cmd.format), out)
+	if err != nil {
+		return errors.Wrap(err, "while exporting runtimes")
+	}
+
+	return nil
+}
+
+// Validate checks the input parameters of the runtimes export command
+func (cmd *RuntimeExportCommand) Validate() error {
+	switch runtime.ExportFormat(cmd.format) {
+	case runtime.ExportFormatCSV, runtime.ExportFormatNDJSON:
+	default:
+		return fmt.Errorf("invalid value for format: %s", cmd.format)
+	}
+
+	return ValidateRuntimeFilterOpts(&cmd.params, cmd.labels, cmd.sort)
+}
@@ -0,0 +1,181 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyma-project/control-plane/tools/cli/pkg/keb/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RuntimeHistoryCommand represents an execution of the kcp runtimes history command
+type RuntimeHistoryCommand struct {
+	cobraCmd *cobra.Command
+	log      logger.Logger
+	output   string
+	diff     bool
+	from     string
+	to       string
+}
+
+var runtimeStateColumns = []printer.Column{
+	{
+		Header:    "STATE ID",
+		FieldSpec: "{.ID}",
+	},
+	{
+		Header:    "OPERATION ID",
+		FieldSpec: "{.OperationID}",
+	},
+	{
+		Header:         "CREATED AT",
+		FieldFormatter: runtimeStateCreatedAt,
+	},
+	{
+		Header:    "KYMA VERSION",
+		FieldSpec: "{.KymaConfig.Version}",
+	},
+	{
+		Header:    "KUBERNETES VERSION",
+		FieldSpec: "{.ClusterConfig.KubernetesVersion}",
+	},
+}
+
+var changeSymbols = map[runtime.ChangeType]string{
+	runtime.Added:    "+",
+	runtime.Removed:  "-",
+	runtime.Modified: "~",
+}
+
+// NewRuntimeHistoryCmd constructs a new instance of RuntimeHistoryCommand and configures it in terms of a cobra.Command
+func NewRuntimeHistoryCmd(log logger.Logger) *cobra.Command {
+	cmd := RuntimeHistoryCommand{log: log}
+	cobraCmd := &cobra.Command{
+		Use:   "history <runtime-id>",
+		Short: "Displays the configuration history of a Kyma Runtime.",
+		Long: `Displays the Kyma and cluster configuration applied to a Kyma Runtime by each operation, starting from the most recent one.
+If the --diff option is provided, the command displays the changes between two configuration states. By default, the most recent state is compared with the preceding one.
+The values of secret overrides are masked.`,
+		Example: `  kcp runtimes history 0c4357f5-83e0-4b72-9472-49b5cd417c00                               Display the configuration history of the Runtime.
+  kcp runtimes history 0c4357f5-83e0-4b72-9472-49b5cd417c00 --diff                        Display the changes introduced by the last operation.
+  kcp runtimes history 0c4357f5-83e0-4b72-9472-49b5cd417c00 --diff --from SID1 --to SID2  Display the changes between the two given states.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
+		RunE:    func(_ *cobra.Command, args []string) error { return cmd.Run(args[0]) },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().BoolVar(&cmd.diff, "diff", false, "Option that displays the changes between two configuration states instead of the history.")
+	cobraCmd.Flags().StringVar(&cmd.from, "from", "", "ID of the configuration state to compare from. Defaults to the state preceding the one given by --to.")
+	cobraCmd.Flags().StringVar(&cmd.to, "to", "", "ID of the configuration state to compare to. Defaults to the most recent state.")
+
+	return cobraCmd
+}
+
+// Run executes the runtimes history command
+func (cmd *RuntimeHistoryCommand) Run(runtimeID string) error {
+	client := runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+
+	if cmd.diff {
+		diff, err := client.DiffRuntimeStates(runtimeID, cmd.from, cmd.to)
+		if err != nil {
+			return errors.Wrap(err, "while comparing runtime states")
+		}
+		return cmd.printDiff(diff)
+	}
+
+	sp, err := client.ListRuntimeStates(runtimeID)
+	if err != nil {
+		return errors.Wrap(err, "while listing runtime states")
+	}
+	return cmd.printStates(sp)
+}
+
+// Validate checks the input parameters of the runtimes history command
+func (cmd *RuntimeHistoryCommand) Validate() error {
+	err := ValidateOutputOpt(cmd.output)
+	if err != nil {
+		return err
+	}
+
+	if !cmd.diff && (cmd.from != "" || cmd.to != "") {
+		return errors.New("--from and --to should only be used together with --diff")
+	}
+
+	return nil
+}
+
+func (cmd *RuntimeHistoryCommand) printStates(states runtime.RuntimeStatesPage) error {
+	switch cmd.output {
+	case tableOutput:
+		tp, err := printer.NewTablePrinter(runtimeStateColumns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(states.Data)
+	case jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(states)
+	}
+
+	return nil
+}
+
+func (cmd *RuntimeHistoryCommand) printDiff(diff runtime.RuntimeStateDiff) error {
+	switch cmd.output {
+	case tableOutput:
+		fmt.Printf("Changes from state %s to state %s:\n", diff.From, diff.To)
+		if diff.IsEmpty() {
+			fmt.Println("  no changes")
+			return nil
+		}
+		printValueChanges("Kyma", diff.Kyma, "  ")
+		if len(diff.Components) > 0 {
+			fmt.Println("Components:")
+			for _, c := range diff.Components {
+				fmt.Printf("  %s %s\n", changeSymbols[c.Change], c.Name)
+				printValueChanges("", c.Settings, "      ")
+				printValueChanges("", c.Overrides, "      ")
+			}
+		}
+		printValueChanges("Global Overrides", diff.Overrides, "  ")
+		printValueChanges("Cluster", diff.Cluster, "  ")
+	case jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(diff)
+	}
+
+	return nil
+}
+
+func printValueChanges(title string, changes []runtime.ValueChange, indent string) {
+	if len(changes) == 0 {
+		return
+	}
+	if title != "" {
+		fmt.Printf("%s:\n", title)
+	}
+	for _, c := range changes {
+		fmt.Printf("%s%s %s\n", indent, changeSymbols[c.Change], formatValueChange(c))
+	}
+}
+
+func formatValueChange(c runtime.ValueChange) string {
+	switch c.Change {
+	case runtime.Added:
+		return fmt.Sprintf("%s: %s", c.Name, c.To)
+	case runtime.Removed:
+		return fmt.Sprintf("%s: %s", c.Name, c.From)
+	default:
+		return strings.Join([]string{c.Name + ":", c.From, "->", c.To}, " ")
+	}
+}
+
+func runtimeStateCreatedAt(obj interface{}) string {
+	state := obj.(runtime.RuntimeStateDTO)
+	return state.CreatedAt.Format("2006/01/02 15:04:05")
+}
@@ -0,0 +1,138 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyma-project/control-plane/tools/cli/pkg/keb/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RuntimeLabelCommand represents an execution of the kcp runtimes label command
+type RuntimeLabelCommand struct {
+	cobraCmd *cobra.Command
+	log      logger.Logger
+	output   string
+	set      map[string]string
+	remove   []string
+}
+
+type runtimeLabel struct {
+	Key   string
+	Value string
+}
+
+var runtimeLabelColumns = []printer.Column{
+	{
+		Header:    "KEY",
+		FieldSpec: "{.Key}",
+	},
+	{
+		Header:    "VALUE",
+		FieldSpec: "{.Value}",
+	},
+}
+
+// NewRuntimeLabelCmd constructs a new instance of RuntimeLabelCommand and configures it in terms of a cobra.Command
+func NewRuntimeLabelCmd(log logger.Logger) *cobra.Command {
+	cmd := RuntimeLabelCommand{log: log}
+	cobraCmd := &cobra.Command{
+		Use:   "label <runtime-id> [KEY=VALUE ...] [KEY- ...]",
+		Short: "Displays or updates the labels of a Kyma Runtime.",
+		Long: `Displays or updates the labels of a Kyma Runtime stored in Kyma Environment Broker.
+A KEY=VALUE argument sets the label, overwriting its previous value. A KEY- argument removes the label.
+Without the label arguments, the command displays the labels of the Runtime.
+Use the labels to filter Runtimes with the kcp runtimes --label option, and to select the targets of orchestrations with the label={KEY}={VALUE} selector.`,
+		Example: `  kcp runtimes label 0c4357f5-83e0-4b72-9472-49b5cd417c00                                      Display the labels of the Runtime.
+  kcp runtimes label 0c4357f5-83e0-4b72-9472-49b5cd417c00 customer-pilot=true do-not-upgrade=true  Set two labels on the Runtime.
+  kcp runtimes label 0c4357f5-83e0-4b72-9472-49b5cd417c00 do-not-upgrade-                         Remove the label from the Runtime.`,
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: func(_ *cobra.Command, args []string) error { return cmd.Validate(args[1:]) },
+		RunE:    func(_ *cobra.Command, args []string) error { return cmd.Run(args[0]) },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+
+	return cobraCmd
+}
+
+// Run executes the runtimes label command
+func (cmd *RuntimeLabelCommand) Run(runtimeID string) error {
+	client := runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+
+	if len(cmd.set) == 0 && len(cmd.remove) == 0 {
+		labels, err := client.GetLabels(runtimeID)
+		if err != nil {
+			return errors.Wrap(err, "while getting runtime labels")
+		}
+		return cmd.printLabels(labels)
+	}
+
+	var (
+		labels map[string]string
+		err    error
+	)
+	if len(cmd.set) > 0 {
+		labels, err = client.SetLabels(runtimeID, cmd.set)
+		if err != nil {
+			return errors.Wrap(err, "while setting runtime labels")
+		}
+	}
+	for _, key := range cmd.remove {
+		labels, err = client.DeleteLabel(runtimeID, key)
+		if err != nil {
+			return errors.Wrapf(err, "while removing runtime label %s", key)
+		}
+	}
+
+	return cmd.printLabels(labels)
+}
+
+// Validate checks the input parameters of the runtimes label command
+func (cmd *RuntimeLabelCommand) Validate(labelArgs []string) error {
+	err := ValidateOutputOpt(cmd.output)
+	if err != nil {
+		return err
+	}
+
+	cmd.set = map[string]string{}
+	for _, arg := range labelArgs {
+		if strings.HasSuffix(arg, "-") && !strings.Contains(arg, "=") {
+			cmd.remove = append(cmd.remove, strings.TrimSuffix(arg, "-"))
+			continue
+		}
+		key, value, err := runtime.ParseLabel(arg)
+		if err != nil {
+			return fmt.Errorf("invalid label argument %q, expected KEY=VALUE or KEY-", arg)
+		}
+		cmd.set[key] = value
+	}
+
+	return nil
+}
+
+func (cmd *RuntimeLabelCommand) printLabels(labels map[string]string) error {
+	switch cmd.output {
+	case tableOutput:
+		tp, err := printer.NewTablePrinter(runtimeLabelColumns, false)
+		if err != nil {
+			return err
+		}
+		rows := make([]runtimeLabel, 0, len(labels))
+		for key, value := range labels {
+			rows = append(rows, runtimeLabel{Key: key, Value: value})
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Key < rows[j].Key })
+		return tp.PrintObj(rows)
+	case jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(labels)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/control-plane/tools/cli/pkg/keb/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/pkg/errors"
+)
+
+var operationEventColumns = []printer.Column{
+	{
+		Header:         "TIME",
+		FieldFormatter: operationEventUpdatedAt,
+	},
+	{
+		Header:    "OPERATION ID",
+		FieldSpec: "{.OperationID}",
+	},
+	{
+		Header:    "TYPE",
+		FieldSpec: "{.Type}",
+	},
+	{
+		Header:    "RUNTIME ID",
+		FieldSpec: "{.RuntimeID}",
+	},
+	{
+		Header:    "STATE",
+		FieldSpec: "{.State}",
+	},
+	{
+		Header:    "DESCRIPTION",
+		FieldSpec: "{.Description}",
+	},
+}
+
+// watchOperations prints the operation events matching the parameters until the command is interrupted.
+// Events for which accept returns false are skipped. In the JSON output each event is printed as a separate object.
+func watchOperations(ctx context.Context, client runtime.Client, params runtime.WatchParameters, output string, accept func(runtime.OperationEvent) bool) error {
+	tp, err := printer.NewTablePrinter(operationEventColumns, false)
+	if err != nil {
+		return err
+	}
+	jp := printer.NewJSONPrinter("  ")
+
+	if output == tableOutput {
+		fmt.Println("\nWatching operation changes, press Ctrl+C to exit:")
+	}
+	err = client.WatchOperations(ctx, params, func(ev runtime.OperationEvent) error {
+		if accept != nil && !accept(ev) {
+			return nil
+		}
+		switch output {
+		case tableOutput:
+			return tp.PrintObj(ev)
+		case jsonOutput:
+			jp.PrintObj(ev)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "while watching operations")
+	}
+
+	return nil
+}
+
+func operationEventUpdatedAt(obj interface{}) string {
+	ev := obj.(runtime.OperationEvent)
+	return ev.UpdatedAt.Format("2006/01/02 15:04:05")
+}
@@ -0,0 +1,4 @@
+// Package orchestration is the client of the KEB /orchestrations and /upgrade API with its types, copied from the
+// components/kyma-environment-broker/common/orchestration package. KEB is built with dep and is not a Go module,
+// so the CLI keeps its own copy. Change it together with the KEB package.
+package orchestration
@@ -0,0 +1,187 @@
+package orchestration
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+)
+
+// Parameters hold the attributes of orchestration create (upgrade) requests.
+type Parameters struct {
+	Targets  TargetSpec   `json:"targets"`
+	Strategy StrategySpec `json:"strategy,omitempty"`
+	DryRun   bool         `json:"dryRun,omitempty"`
+	// StartTime delays the orchestration until the given time, the orchestration starts immediately if not set
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// Recurrence is a cron expression, e.g. "0 3 * * 0", evaluated in UTC. When the orchestration finishes,
+	// its next run is scheduled as a new orchestration with the same parameters and the targets resolved again.
+	Recurrence string `json:"recurrence,omitempty"`
+	// Actions is the ordered list of actions performed on each runtime by a composite orchestration,
+	// empty for the Kyma upgrade orchestration
+	Actions []ActionType `json:"actions,omitempty"`
+	// KubernetesVersion is the target version of the cluster upgrade action, the default version is used if not set
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+}
+
+// ActionType is the type of the action performed on a runtime by a composite orchestration
+type ActionType string
+
+const (
+	UpgradeClusterAction ActionType = "upgradeCluster"
+	UpgradeKymaAction    ActionType = "upgradeKyma"
+)
+
+// ActionStatus is the progress of a single action of the runtime operation
+type ActionStatus struct {
+	Type        ActionType `json:"type"`
+	State       string     `json:"state"`
+	Description string     `json:"description,omitempty"`
+}
+
+const (
+	// StateParam parameter used in list orchestrations / operations queries to filter by state
+	StateParam = "state"
+)
+
+// Orchestration states
+const (
+	Pending    = "pending"
+	InProgress = "in progress"
+	Succeeded  = "succeeded"
+	Failed     = "failed"
+)
+
+// Canceled is the state of the composite orchestration action skipped due to a failure of the preceding action
+const Canceled = "canceled"
+
+// ListParameters hold attributes of list orchestrations / operations queries.
+type ListParameters struct {
+	Page     int
+	PageSize int
+	States   []string
+}
+
+// TargetAll all SKRs provisioned successfully and not deprovisioning
+const TargetAll = "all"
+
+// RuntimeTarget captures a specification of SKR targets to resolve for an orchestration.
+// When a RuntimeTarget defines multiple fields, all should match to any given runtime to be selected (i.e. the terms are AND-ed).
+type RuntimeTarget struct {
+	// Valid values: "all"
+	Target string `json:"target,omitempty"`
+	// Regex pattern to match against the runtime's GlobalAccount field. E.g. CA50125541TID000000000741207136, CA.*
+	GlobalAccount string `json:"globalAccount,omitempty"`
+	// Regex pattern to match against the runtime's SubAccount field. E.g. 0d20e315-d0b4-48a2-9512-49bc8eb03cd1
+	SubAccount string `json:"subAccount,omitempty"`
+	// Regex pattern to match against the shoot cluster's Region field (not SCP platform-region). E.g. "europe|eu-"
+	Region string `json:"region,omitempty"`
+	// RuntimeID is used to indicate a specific runtime
+	RuntimeID string `json:"runtimeID,omitempty"`
+	// PlanName is used to match runtimes with the same plan
+	PlanName string `json:"planName,omitempty"`
+	// Labels is used to match runtimes having all of the given labels set in KEB, e.g. {"customer-pilot": "true"}
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type StrategyType string
+
+const (
+	ParallelStrategy StrategyType = "parallel"
+)
+
+type ScheduleType string
+
+const (
+	Immediate         ScheduleType = "immediate"
+	MaintenanceWindow ScheduleType = "maintenanceWindow"
+)
+
+// LimitKey is the runtime attribute by which the operations are grouped for a concurrency limit
+type LimitKey string
+
+const (
+	LimitByRegion        LimitKey = "region"
+	LimitByGlobalAccount LimitKey = "globalAccount"
+	LimitBySeed          LimitKey = "seed"
+)
+
+// ConcurrencyLimit defines the maximum number of operations executed at the same time for runtimes sharing the same value of the given key,
+// e.g. at most 2 operations for each region
+type ConcurrencyLimit struct {
+	By  LimitKey `json:"by"`
+	Max int      `json:"max"`
+}
+
+// ParallelStrategySpec defines parameters for the parallel orchestration strategy
+type ParallelStrategySpec struct {
+	Workers int                `json:"workers"`
+	Limits  []ConcurrencyLimit `json:"limits,omitempty"`
+}
+
+// StrategySpec is the strategy part common for all orchestration trigger/status API
+type StrategySpec struct {
+	Type     StrategyType         `json:"type"`
+	Schedule ScheduleType         `json:"schedule,omitempty"`
+	Parallel ParallelStrategySpec `json:"parallel,omitempty"`
+}
+
+// TargetSpec is the targets part common for all orchestration trigger/status API
+type TargetSpec struct {
+	Include []RuntimeTarget `json:"include"`
+	Exclude []RuntimeTarget `json:"exclude,omitempty"`
+}
+
+type StatusResponse struct {
+	OrchestrationID       string     `json:"orchestrationID"`
+	State                 string     `json:"state"`
+	Description           string     `json:"description"`
+	CreatedAt             time.Time  `json:"createdAt"`
+	UpdatedAt             time.Time  `json:"updatedAt"`
+	Parameters            Parameters `json:"parameters"`
+	ParentOrchestrationID string     `json:"parentOrchestrationID,omitempty"`
+}
+
+type OperationResponse struct {
+	OperationID            string    `json:"operationID"`
+	RuntimeID              string    `json:"runtimeID"`
+	GlobalAccountID        string    `json:"globalAccountID"`
+	SubAccountID           string    `json:"subAccountID"`
+	OrchestrationID        string    `json:"orchestrationID"`
+	ServicePlanID          string    `json:"servicePlanID"`
+	ServicePlanName        string    `json:"servicePlanName"`
+	DryRun                 bool      `json:"dryRun"`
+	ShootName              string    `json:"shootName"`
+	MaintenanceWindowBegin time.Time `json:"maintenanceWindowBegin"`
+	MaintenanceWindowEnd   time.Time `json:"maintenanceWindowEnd"`
+	State                  string    `json:"state"`
+	Description            string    `json:"description"`
+	// Actions is the progress of each action of the composite orchestration
+	Actions []ActionStatus `json:"actions,omitempty"`
+}
+
+type OperationResponseList struct {
+	Data       []OperationResponse `json:"data"`
+	Count      int                 `json:"count"`
+	TotalCount int                 `json:"totalCount"`
+	// NextCursor fetches the following page, empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type OperationDetailResponse struct {
+	OperationResponse
+
+	KymaConfig    gqlschema.KymaConfigInput     `json:"kymaConfig"`
+	ClusterConfig gqlschema.GardenerConfigInput `json:"clusterConfig"`
+}
+
+type StatusResponseList struct {
+	Data       []StatusResponse `json:"data"`
+	Count      int              `json:"count"`
+	TotalCount int              `json:"totalCount"`
+	// NextCursor fetches the following page, empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type UpgradeResponse struct {
+	OrchestrationID string `json:"orchestrationID"`
+}
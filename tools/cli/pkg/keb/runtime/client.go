@@ -0,0 +1,406 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+const defaultPageSize = 100
+
+// the query parameters of the paginated KEB APIs
+const (
+	pageParam     = "page"
+	pageSizeParam = "page_size"
+	cursorParam   = "cursor"
+)
+
+// Client is the interface to interact with the KEB /runtimes API as an HTTP client using OIDC ID token in JWT format.
+type Client interface {
+	ListRuntimes(params ListParameters) (RuntimesPage, error)
+	ExportRuntimes(params ListParameters, format ExportFormat, out io.Writer) error
+	ListRuntimeStates(runtimeID string) (RuntimeStatesPage, error)
+	DiffRuntimeStates(runtimeID, fromStateID, toStateID string) (RuntimeStateDiff, error)
+	WatchOperations(ctx context.Context, params WatchParameters, handler func(OperationEvent) error) error
+	GetLabels(runtimeID string) (map[string]string, error)
+	SetLabels(runtimeID string, labels map[string]string) (map[string]string, error)
+	DeleteLabel(runtimeID, key string) (map[string]string, error)
+}
+
+type client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient constructs and returns new Client for KEB /runtimes API
+// It takes the following arguments:
+//   - ctx  : context in which the http request will be executed
+//   - url  : base url of all KEB APIs, e.g. https://kyma-env-broker.kyma.local
+//   - auth : TokenSource object which provides the ID token for the HTTP request
+func NewClient(ctx context.Context, url string, auth oauth2.TokenSource) Client {
+	return &client{
+		url:        url,
+		httpClient: oauth2.NewClient(ctx, auth),
+	}
+}
+
+// ListRuntimes fetches the runtimes from KEB according to the given parameters.
+// If params.Page or params.PageSize is not set (zero), the client will fetch and return all runtimes.
+func (c *client) ListRuntimes(params ListParameters) (RuntimesPage, error) {
+	runtimes := RuntimesPage{}
+	getAll := false
+	fetchedAll := false
+	cursor := ""
+	if params.Page == 0 || params.PageSize == 0 {
+		getAll = true
+		params.Page = 1
+		params.PageSize = defaultPageSize
+	}
+
+	for !fetchedAll {
+		req, err := http.NewRequest("GET", fmt.Sprintf("%s/runtimes", c.url), nil)
+		if err != nil {
+			return runtimes, errors.Wrap(err, "while creating request")
+		}
+		setQuery(req.URL, params, cursor)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return runtimes, errors.Wrapf(err, "while calling %s", req.URL.String())
+		}
+
+		// Drain response body and close, return error to context if there isn't any.
+		defer func() {
+			derr := drainResponseBody(resp.Body)
+			if err == nil {
+				err = derr
+			}
+			cerr := resp.Body.Close()
+			if err == nil {
+				err = cerr
+			}
+		}()
+
+		if resp.StatusCode != http.StatusOK {
+			return runtimes, fmt.Errorf("calling %s returned %d (%s) status", req.URL.String(), resp.StatusCode, resp.Status)
+		}
+
+		var rp RuntimesPage
+		decoder := json.NewDecoder(resp.Body)
+		err = decoder.Decode(&rp)
+		if err != nil {
+			return runtimes, errors.Wrap(err, "while decoding response body")
+		}
+
+		runtimes.TotalCount = rp.TotalCount
+		runtimes.Count += rp.Count
+		runtimes.Data = append(runtimes.Data, rp.Data...)
+		if getAll {
+			params.Page++
+			fetchedAll = nextPageDone(&cursor, rp.NextCursor, runtimes.Count, runtimes.TotalCount)
+		} else {
+			fetchedAll = true
+		}
+	}
+
+	return runtimes, nil
+}
+
+// ExportRuntimes streams the runtimes matching the filters of the given parameters to out in the given format.
+// The paging and expand parameters are ignored, all matching runtimes are exported.
+func (c *client) ExportRuntimes(params ListParameters, format ExportFormat, out io.Writer) error {
+	u, err := url.Parse(fmt.Sprintf("%s/runtimes/export", c.url))
+	if err != nil {
+		return errors.Wrap(err, "while parsing URL")
+	}
+	query := u.Query()
+	setFilterQuery(query, params)
+	query.Add(FormatParam, string(format))
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "while creating request")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "while calling %s", u.String())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("calling %s returned %d (%s) status", u.String(), resp.StatusCode, resp.Status)
+	}
+
+	// the broker aborts the response if the export fails after it started, which results in the copy error
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return errors.Wrap(err, "while reading runtimes export")
+	}
+
+	return nil
+}
+
+// ListRuntimeStates fetches the whole configuration history of the runtime, ordered from the most recent state
+func (c *client) ListRuntimeStates(runtimeID string) (RuntimeStatesPage, error) {
+	states := RuntimeStatesPage{}
+	for page := 1; ; page++ {
+		u, err := url.Parse(fmt.Sprintf("%s/runtimes/%s/states", c.url, url.PathEscape(runtimeID)))
+		if err != nil {
+			return states, errors.Wrap(err, "while parsing URL")
+		}
+		query := u.Query()
+		query.Add(pageParam, strconv.Itoa(page))
+		query.Add(pageSizeParam, strconv.Itoa(defaultPageSize))
+		u.RawQuery = query.Encode()
+
+		var sp RuntimeStatesPage
+		if err := c.get(u, &sp); err != nil {
+			return states, err
+		}
+
+		states.TotalCount = sp.TotalCount
+		states.Count += sp.Count
+		states.Data = append(states.Data, sp.Data...)
+		if sp.Count == 0 || states.Count >= states.TotalCount {
+			return states, nil
+		}
+	}
+}
+
+// DiffRuntimeStates fetches the difference between two states of the runtime.
+// If toStateID is empty, the most recent state is used. If fromStateID is empty, the state preceding toStateID is used.
+func (c *client) DiffRuntimeStates(runtimeID, fromStateID, toStateID string) (RuntimeStateDiff, error) {
+	diff := RuntimeStateDiff{}
+	u, err := url.Parse(fmt.Sprintf("%s/runtimes/%s/states/diff", c.url, url.PathEscape(runtimeID)))
+	if err != nil {
+		return diff, errors.Wrap(err, "while parsing URL")
+	}
+	query := u.Query()
+	if fromStateID != "" {
+		query.Add(FromStateParam, fromStateID)
+	}
+	if toStateID != "" {
+		query.Add(ToStateParam, toStateID)
+	}
+	u.RawQuery = query.Encode()
+
+	err = c.get(u, &diff)
+	return diff, err
+}
+
+// WatchOperations streams the state changes of the operations matching the given parameters and calls the handler for each of them.
+// It blocks until the context is cancelled, the server closes the stream, or the handler returns an error, which is then returned.
+func (c *client) WatchOperations(ctx context.Context, params WatchParameters, handler func(OperationEvent) error) error {
+	u, err := url.Parse(fmt.Sprintf("%s/events/operations", c.url))
+	if err != nil {
+		return errors.Wrap(err, "while parsing URL")
+	}
+	query := u.Query()
+	setParamList(query, GlobalAccountIDParam, params.GlobalAccountIDs)
+	setParamList(query, InstanceIDParam, params.InstanceIDs)
+	setParamList(query, RuntimeIDParam, params.RuntimeIDs)
+	setParamList(query, OrchestrationIDParam, params.OrchestrationIDs)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "while creating request")
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "while calling %s", u.String())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("calling %s returned %d (%s) status", u.String(), resp.StatusCode, resp.Status)
+	}
+
+	// Each event consists of the "event" and "data" fields terminated by an empty line, lines starting with a colon are comments
+	var eventType, data string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		case line == "":
+			if eventType == OperationEventType && data != "" {
+				var ev OperationEvent
+				if err := json.Unmarshal([]byte(data), &ev); err != nil {
+					return errors.Wrap(err, "while decoding operation event")
+				}
+				if err := handler(ev); err != nil {
+					return err
+				}
+			}
+			eventType, data = "", ""
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return errors.Wrap(err, "while reading operation events")
+	}
+
+	return nil
+}
+
+// GetLabels fetches the labels of the given runtime
+func (c *client) GetLabels(runtimeID string) (map[string]string, error) {
+	labels := map[string]string{}
+	u, err := url.Parse(fmt.Sprintf("%s/runtimes/%s/labels", c.url, url.PathEscape(runtimeID)))
+	if err != nil {
+		return labels, errors.Wrap(err, "while parsing URL")
+	}
+
+	err = c.get(u, &labels)
+	return labels, err
+}
+
+// SetLabels adds the labels to the given runtime, overwriting the values of the existing ones, and returns all labels of the runtime
+func (c *client) SetLabels(runtimeID string, labels map[string]string) (map[string]string, error) {
+	result := map[string]string{}
+	u, err := url.Parse(fmt.Sprintf("%s/runtimes/%s/labels", c.url, url.PathEscape(runtimeID)))
+	if err != nil {
+		return result, errors.Wrap(err, "while parsing URL")
+	}
+	body, err := json.Marshal(labels)
+	if err != nil {
+		return result, errors.Wrap(err, "while encoding labels")
+	}
+	req, err := http.NewRequest(http.MethodPut, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return result, errors.Wrap(err, "while creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	err = c.do(req, &result)
+	return result, err
+}
+
+// DeleteLabel removes the label from the given runtime and returns the remaining labels of the runtime
+func (c *client) DeleteLabel(runtimeID, key string) (map[string]string, error) {
+	result := map[string]string{}
+	u, err := url.Parse(fmt.Sprintf("%s/runtimes/%s/labels/%s", c.url, url.PathEscape(runtimeID), key))
+	if err != nil {
+		return result, errors.Wrap(err, "while parsing URL")
+	}
+	req, err := http.NewRequest(http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return result, errors.Wrap(err, "while creating request")
+	}
+
+	err = c.do(req, &result)
+	return result, err
+}
+
+func (c *client) get(u *url.URL, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "while creating request")
+	}
+
+	return c.do(req, out)
+}
+
+func (c *client) do(req *http.Request, out interface{}) (err error) {
+	u := req.URL
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "while calling %s", u.String())
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("calling %s returned %d (%s) status", u.String(), resp.StatusCode, resp.Status)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return errors.Wrap(err, "while decoding response body")
+	}
+
+	return nil
+}
+
+// nextPageDone stores the cursor of the next page and returns true if all items were fetched.
+// Brokers which do not return the cursor are paged by the page number.
+func nextPageDone(cursor *string, nextCursor string, count, totalCount int) bool {
+	if nextCursor != "" {
+		*cursor = nextCursor
+		return false
+	}
+	return *cursor != "" || count >= totalCount
+}
+
+func setQuery(url *url.URL, params ListParameters, cursor string) {
+	query := url.Query()
+	if cursor != "" {
+		query.Add(cursorParam, cursor)
+	} else {
+		query.Add(pageParam, strconv.Itoa(params.Page))
+	}
+	query.Add(pageSizeParam, strconv.Itoa(params.PageSize))
+	setFilterQuery(query, params)
+	setParamList(query, ExpandParam, params.Expand)
+	url.RawQuery = query.Encode()
+}
+
+// setFilterQuery sets the filters and the sort order of the runtimes list
+func setFilterQuery(query url.Values, params ListParameters) {
+	setParamList(query, GlobalAccountIDParam, params.GlobalAccountIDs)
+	setParamList(query, SubAccountIDParam, params.SubAccountIDs)
+	setParamList(query, InstanceIDParam, params.InstanceIDs)
+	setParamList(query, RuntimeIDParam, params.RuntimeIDs)
+	setParamList(query, RegionParam, params.Regions)
+	setParamList(query, ShootParam, params.Shoots)
+	setParamList(query, PlanParam, params.Plans)
+	setParamList(query, StateParam, params.States)
+	setParamList(query, OperationTypeParam, params.OperationTypes)
+	setParamList(query, KymaVersionParam, params.KymaVersions)
+	for key, value := range params.Labels {
+		query.Add(LabelParam, fmt.Sprintf("%s=%s", key, value))
+	}
+	if params.Sort != "" {
+		query.Add(SortParam, string(params.Sort))
+	}
+}
+
+func setParamList(query url.Values, key string, values []string) {
+	for _, value := range values {
+		query.Add(key, value)
+	}
+}
+
+func drainResponseBody(body io.Reader) error {
+	if body == nil {
+		return nil
+	}
+	_, err := io.Copy(ioutil.Discard, io.LimitReader(body, 4096))
+	return err
+}
@@ -0,0 +1,260 @@
+package runtime
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/pkg/errors"
+)
+
+type RuntimeDTO struct {
+	InstanceID       string            `json:"instanceID"`
+	RuntimeID        string            `json:"runtimeID"`
+	GlobalAccountID  string            `json:"globalAccountID"`
+	SubAccountID     string            `json:"subAccountID"`
+	ProviderRegion   string            `json:"region"`
+	SubAccountRegion string            `json:"subAccountRegion"`
+	ShootName        string            `json:"shootName"`
+	ServiceClassID   string            `json:"serviceClassID"`
+	ServiceClassName string            `json:"serviceClassName"`
+	ServicePlanID    string            `json:"servicePlanID"`
+	ServicePlanName  string            `json:"servicePlanName"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	Status           RuntimeStatus     `json:"status"`
+	// Provisioner is set only when the runtimes are listed with the expand=provisioner parameter
+	Provisioner *ProvisionerStatus `json:"provisioner,omitempty"`
+}
+
+// ProvisionerStatus is the current state of the runtime reported by the provisioner
+type ProvisionerStatus struct {
+	RuntimeConnectionStatus string                    `json:"runtimeConnectionStatus,omitempty"`
+	KymaVersion             string                    `json:"kymaVersion,omitempty"`
+	GardenerConfig          *gqlschema.GardenerConfig `json:"gardenerConfig,omitempty"`
+	// Error describes why the status could not be fetched from the provisioner
+	Error string `json:"error,omitempty"`
+}
+
+type RuntimeStatus struct {
+	CreatedAt      time.Time      `json:"createdAt"`
+	ModifiedAt     time.Time      `json:"modifiedAt"`
+	Provisioning   *Operation     `json:"provisioning"`
+	Deprovisioning *Operation     `json:"deprovisioning,omitempty"`
+	UpgradingKyma  OperationsData `json:"upgradingKyma,omitempty"`
+}
+
+type OperationsData struct {
+	Data       []Operation `json:"data"`
+	TotalCount int         `json:"totalCount"`
+	Count      int         `json:"count"`
+}
+
+type Operation struct {
+	State           string    `json:"state"`
+	Description     string    `json:"description"`
+	CreatedAt       time.Time `json:"createdAt"`
+	OperationID     string    `json:"operationID"`
+	OrchestrationID *string   `json:"orchestrationID,omitempty"`
+}
+
+type RuntimesPage struct {
+	Data       []RuntimeDTO `json:"data"`
+	Count      int          `json:"count"`
+	TotalCount int          `json:"totalCount"`
+	// NextCursor fetches the following page, empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// RuntimeStateDTO is the Kyma and cluster configuration applied to the runtime by an operation
+type RuntimeStateDTO struct {
+	ID          string    `json:"id"`
+	RuntimeID   string    `json:"runtimeID"`
+	OperationID string    `json:"operationID"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	KymaConfig gqlschema.KymaConfigInput `json:"kymaConfig"`
+	// ClusterConfig is empty for the states of the operations which did not change the cluster, e.g. Kyma upgrades
+	ClusterConfig gqlschema.GardenerConfigInput `json:"clusterConfig"`
+}
+
+// RuntimeStatesPage is the configuration history of the runtime ordered from the most recent state
+type RuntimeStatesPage struct {
+	Data       []RuntimeStateDTO `json:"data"`
+	Count      int               `json:"count"`
+	TotalCount int               `json:"totalCount"`
+}
+
+// ChangeType describes how a setting differs between two runtime states
+type ChangeType string
+
+const (
+	Added    ChangeType = "added"
+	Removed  ChangeType = "removed"
+	Modified ChangeType = "modified"
+)
+
+// ValueChange is a difference of a single setting, e.g. the Kyma version or an override
+type ValueChange struct {
+	Name   string     `json:"name"`
+	Change ChangeType `json:"change"`
+	From   string     `json:"from,omitempty"`
+	To     string     `json:"to,omitempty"`
+}
+
+// ComponentChange is a difference of a Kyma component. Settings hold the changes of the namespace and the source URL.
+type ComponentChange struct {
+	Name      string        `json:"name"`
+	Change    ChangeType    `json:"change"`
+	Settings  []ValueChange `json:"settings,omitempty"`
+	Overrides []ValueChange `json:"overrides,omitempty"`
+}
+
+// RuntimeStateDiff is the structured difference between two states of the runtime.
+// The values of secret overrides are masked.
+type RuntimeStateDiff struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Kyma holds the changes of the Kyma version and profile
+	Kyma       []ValueChange     `json:"kyma,omitempty"`
+	Components []ComponentChange `json:"components,omitempty"`
+	// Overrides holds the changes of the global overrides
+	Overrides []ValueChange `json:"overrides,omitempty"`
+	// Cluster holds the changes of the Gardener settings, e.g. the Kubernetes version
+	Cluster []ValueChange `json:"cluster,omitempty"`
+}
+
+// IsEmpty returns true if the states do not differ
+func (d RuntimeStateDiff) IsEmpty() bool {
+	return len(d.Kyma) == 0 && len(d.Components) == 0 && len(d.Overrides) == 0 && len(d.Cluster) == 0
+}
+
+const (
+	GlobalAccountIDParam = "account"
+	SubAccountIDParam    = "subaccount"
+	InstanceIDParam      = "instance_id"
+	RuntimeIDParam       = "runtime_id"
+	RegionParam          = "region"
+	ShootParam           = "shoot"
+	PlanParam            = "plan"
+	StateParam           = "state"
+	OperationTypeParam   = "operation_type"
+	KymaVersionParam     = "kyma_version"
+	SortParam            = "sort"
+	FromStateParam       = "from"
+	ToStateParam         = "to"
+	OrchestrationIDParam = "orchestration_id"
+	LabelParam           = "label"
+	ExpandParam          = "expand"
+	FormatParam          = "format"
+)
+
+// ExportFormat is the value of the format query parameter of the runtimes export
+type ExportFormat string
+
+const (
+	ExportFormatCSV    ExportFormat = "csv"
+	ExportFormatNDJSON ExportFormat = "ndjson"
+)
+
+// RuntimeExportRecord is a single runtime in the fleet export
+type RuntimeExportRecord struct {
+	InstanceID       string    `json:"instanceID"`
+	RuntimeID        string    `json:"runtimeID"`
+	GlobalAccountID  string    `json:"globalAccountID"`
+	SubAccountID     string    `json:"subAccountID"`
+	ProviderRegion   string    `json:"region"`
+	SubAccountRegion string    `json:"subAccountRegion"`
+	ShootName        string    `json:"shootName"`
+	ServicePlanName  string    `json:"servicePlanName"`
+	CreatedAt        time.Time `json:"createdAt"`
+	// KymaVersion is the version of the last succeeded provisioning or upgrade, empty if Kyma was not installed yet
+	KymaVersion string `json:"kymaVersion,omitempty"`
+	// LastOperation is empty for the runtimes without operations
+	LastOperation *ExportedOperation `json:"lastOperation,omitempty"`
+}
+
+// ExportedOperation is the most recent operation of the exported runtime
+type ExportedOperation struct {
+	Type        string    `json:"type"`
+	State       string    `json:"state"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// ExpandProvisioner is the value of the expand query parameter which adds the current runtime status from the provisioner
+const ExpandProvisioner = "provisioner"
+
+// OperationEvent is pushed by the operation events stream when the state or the description of an operation changes
+type OperationEvent struct {
+	OperationID     string    `json:"operationID"`
+	Type            string    `json:"type"`
+	State           string    `json:"state"`
+	Description     string    `json:"description"`
+	InstanceID      string    `json:"instanceID"`
+	RuntimeID       string    `json:"runtimeID,omitempty"`
+	GlobalAccountID string    `json:"globalAccountID,omitempty"`
+	SubAccountID    string    `json:"subAccountID,omitempty"`
+	OrchestrationID string    `json:"orchestrationID,omitempty"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// OperationEventType is the SSE event type of the OperationEvent
+const OperationEventType = "operation"
+
+// SortField is the value of the sort query parameter. The "-" prefix reverses the order, e.g. "-created_at" lists the newest runtimes first.
+type SortField string
+
+const (
+	SortByCreatedAt      SortField = "created_at"
+	SortByCreatedAtDesc  SortField = "-created_at"
+	SortByModifiedAt     SortField = "modified_at"
+	SortByModifiedAtDesc SortField = "-modified_at"
+)
+
+const (
+	// OperationTypeProvision is the value of the operation_type query parameter matching runtimes which were provisioned last
+	OperationTypeProvision = "provision"
+	// OperationTypeDeprovision is the value of the operation_type query parameter matching runtimes which were deprovisioned last
+	OperationTypeDeprovision = "deprovision"
+	// OperationTypeUpgradeKyma is the value of the operation_type query parameter matching runtimes which were upgraded last
+	OperationTypeUpgradeKyma = "upgradeKyma"
+)
+
+type ListParameters struct {
+	Page             int
+	PageSize         int
+	GlobalAccountIDs []string
+	SubAccountIDs    []string
+	InstanceIDs      []string
+	RuntimeIDs       []string
+	Regions          []string
+	Shoots           []string
+	Plans            []string
+	// States and OperationTypes match the last operation of the runtime
+	States         []string
+	OperationTypes []string
+	KymaVersions   []string
+	// Labels match the runtimes having all of the given labels
+	Labels map[string]string
+	Sort   SortField
+	// Expand adds the data of other components, e.g. ExpandProvisioner
+	Expand []string
+}
+
+// WatchParameters filter the operation events stream. The event must match all the given filters.
+type WatchParameters struct {
+	GlobalAccountIDs []string
+	InstanceIDs      []string
+	RuntimeIDs       []string
+	OrchestrationIDs []string
+}
+
+// ParseLabel parses the label given in the "key=value" format, used by the label query parameter
+func ParseLabel(label string) (string, string, error) {
+	kv := strings.SplitN(label, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return "", "", errors.Errorf("label %q is not in the key=value format", label)
+	}
+	return kv[0], kv[1], nil
+}